@@ -2267,6 +2267,10 @@ type ResponseCheckTx struct {
 	// mempool_error is set by CometBFT.
 	// ABCI applictions creating a ResponseCheckTX should not set mempool_error.
 	MempoolError string `protobuf:"bytes,11,opt,name=mempool_error,json=mempoolError,proto3" json:"mempool_error,omitempty"`
+	// sequence optionally orders the txs of the same sender: the priority
+	// mempools reap them by increasing sequence, and replace the tx of a sender
+	// and sequence by a tx of higher enough priority. Zero means no sequence.
+	Sequence uint64 `protobuf:"varint,12,opt,name=sequence,proto3" json:"sequence,omitempty"`
 }
 
 func (m *ResponseCheckTx) Reset()         { *m = ResponseCheckTx{} }
@@ -2379,6 +2383,13 @@ func (m *ResponseCheckTx) GetMempoolError() string {
 	return ""
 }
 
+func (m *ResponseCheckTx) GetSequence() uint64 {
+	if m != nil {
+		return m.Sequence
+	}
+	return 0
+}
+
 type ResponseDeliverTx struct {
 	Code      uint32  `protobuf:"varint,1,opt,name=code,proto3" json:"code,omitempty"`
 	Data      []byte  `protobuf:"bytes,2,opt,name=data,proto3" json:"data,omitempty"`
@@ -3630,201 +3641,202 @@ func init() {
 func init() { proto.RegisterFile("tendermint/abci/types.proto", fileDescriptor_252557cfdd89a31a) }
 
 var fileDescriptor_252557cfdd89a31a = []byte{
-	// 3101 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xe4, 0x5a, 0x4b, 0x73, 0xdc, 0xc6,
-	0xf1, 0x5f, 0xec, 0x1b, 0xbd, 0xdc, 0x07, 0x47, 0xb2, 0xb4, 0x82, 0x65, 0x52, 0x86, 0xca, 0x2f,
-	0xd9, 0x26, 0xff, 0xa6, 0x4b, 0xfe, 0xdb, 0xb1, 0x13, 0x9b, 0x5c, 0xad, 0xbc, 0xb4, 0x68, 0x92,
-	0x01, 0x57, 0x72, 0x5e, 0x16, 0x0c, 0x2e, 0x86, 0xbb, 0xb0, 0x76, 0x01, 0x18, 0xc0, 0xd2, 0xa4,
-	0x8e, 0xa9, 0xa4, 0x52, 0xf1, 0xc9, 0x55, 0xb9, 0xf8, 0xe2, 0x7b, 0x2e, 0xf9, 0x08, 0xc9, 0xd9,
-	0x55, 0x49, 0x55, 0x7c, 0xcc, 0x21, 0xe5, 0xa4, 0xec, 0x9c, 0xf2, 0x05, 0x52, 0x39, 0xa4, 0x92,
-	0x9a, 0x07, 0x9e, 0xbb, 0xe0, 0x82, 0x72, 0x6e, 0xb9, 0xcd, 0xf4, 0x74, 0xf7, 0xcc, 0x34, 0x66,
-	0xba, 0xfb, 0xd7, 0x18, 0x78, 0xdc, 0xc3, 0xa6, 0x8e, 0x9d, 0x89, 0x61, 0x7a, 0xeb, 0xda, 0xe1,
-	0xc0, 0x58, 0xf7, 0x4e, 0x6d, 0xec, 0xae, 0xd9, 0x8e, 0xe5, 0x59, 0xa8, 0x19, 0x0e, 0xae, 0x91,
-	0x41, 0xe9, 0x89, 0x08, 0xf7, 0xc0, 0x39, 0xb5, 0x3d, 0x6b, 0xdd, 0x76, 0x2c, 0xeb, 0x88, 0xf1,
-	0x4b, 0x57, 0x23, 0xc3, 0x54, 0x4f, 0x54, 0x5b, 0x6c, 0x94, 0x0b, 0x3f, 0xc0, 0xa7, 0xfe, 0xe8,
-	0x13, 0x33, 0xb2, 0xb6, 0xe6, 0x68, 0x13, 0x7f, 0x78, 0x75, 0x68, 0x59, 0xc3, 0x31, 0x5e, 0xa7,
-	0xbd, 0xc3, 0xe9, 0xd1, 0xba, 0x67, 0x4c, 0xb0, 0xeb, 0x69, 0x13, 0x9b, 0x33, 0x5c, 0x1c, 0x5a,
-	0x43, 0x8b, 0x36, 0xd7, 0x49, 0x8b, 0x53, 0x57, 0x92, 0x62, 0xfa, 0xd4, 0xd1, 0x3c, 0xc3, 0x32,
-	0xd9, 0xb8, 0xfc, 0x5b, 0x11, 0x2a, 0x0a, 0xfe, 0x68, 0x8a, 0x5d, 0x0f, 0x6d, 0x40, 0x11, 0x0f,
-	0x46, 0x56, 0x5b, 0xb8, 0x26, 0x3c, 0x5b, 0xdb, 0xb8, 0xba, 0x96, 0xd8, 0xfc, 0x1a, 0xe7, 0xeb,
-	0x0e, 0x46, 0x56, 0x2f, 0xa7, 0x50, 0x5e, 0x74, 0x13, 0x4a, 0x47, 0xe3, 0xa9, 0x3b, 0x6a, 0xe7,
-	0xa9, 0xd0, 0x13, 0x69, 0x42, 0xb7, 0x09, 0x53, 0x2f, 0xa7, 0x30, 0x6e, 0x32, 0x95, 0x61, 0x1e,
-	0x59, 0xed, 0xc2, 0xd9, 0x53, 0x6d, 0x9b, 0x47, 0x74, 0x2a, 0xc2, 0x8b, 0xb6, 0x00, 0x5c, 0xec,
-	0xa9, 0x96, 0x4d, 0x96, 0xdf, 0x2e, 0x52, 0xc9, 0x27, 0xd3, 0x24, 0x0f, 0xb0, 0xb7, 0x47, 0x19,
-	0x7b, 0x39, 0x45, 0x74, 0xfd, 0x0e, 0xd1, 0x61, 0x98, 0x86, 0xa7, 0x0e, 0x46, 0x9a, 0x61, 0xb6,
-	0x4b, 0x67, 0xeb, 0xd8, 0x36, 0x0d, 0xaf, 0x43, 0x18, 0x89, 0x0e, 0xc3, 0xef, 0x90, 0x2d, 0x7f,
-	0x34, 0xc5, 0xce, 0x69, 0xbb, 0x7c, 0xf6, 0x96, 0xbf, 0x4f, 0x98, 0xc8, 0x96, 0x29, 0x37, 0xea,
-	0x42, 0xed, 0x10, 0x0f, 0x0d, 0x53, 0x3d, 0x1c, 0x5b, 0x83, 0x07, 0xed, 0x0a, 0x15, 0x96, 0xd3,
-	0x84, 0xb7, 0x08, 0xeb, 0x16, 0xe1, 0xec, 0xe5, 0x14, 0x38, 0x0c, 0x7a, 0xe8, 0x0d, 0xa8, 0x0e,
-	0x46, 0x78, 0xf0, 0x40, 0xf5, 0x4e, 0xda, 0x55, 0xaa, 0x63, 0x35, 0x4d, 0x47, 0x87, 0xf0, 0xf5,
-	0x4f, 0x7a, 0x39, 0xa5, 0x32, 0x60, 0x4d, 0xb2, 0x7f, 0x1d, 0x8f, 0x8d, 0x63, 0xec, 0x10, 0x79,
-	0xf1, 0xec, 0xfd, 0xdf, 0x62, 0x9c, 0x54, 0x83, 0xa8, 0xfb, 0x1d, 0xf4, 0x26, 0x88, 0xd8, 0xd4,
-	0xf9, 0x36, 0x80, 0xaa, 0xb8, 0x96, 0x7a, 0x56, 0x4c, 0xdd, 0xdf, 0x44, 0x15, 0xf3, 0x36, 0x7a,
-	0x15, 0xca, 0x03, 0x6b, 0x32, 0x31, 0xbc, 0x76, 0x8d, 0x4a, 0xaf, 0xa4, 0x6e, 0x80, 0x72, 0xf5,
-	0x72, 0x0a, 0xe7, 0x47, 0xbb, 0xd0, 0x18, 0x1b, 0xae, 0xa7, 0xba, 0xa6, 0x66, 0xbb, 0x23, 0xcb,
-	0x73, 0xdb, 0x4b, 0x54, 0xc3, 0x53, 0x69, 0x1a, 0x76, 0x0c, 0xd7, 0x3b, 0xf0, 0x99, 0x7b, 0x39,
-	0xa5, 0x3e, 0x8e, 0x12, 0x88, 0x3e, 0xeb, 0xe8, 0x08, 0x3b, 0x81, 0xc2, 0x76, 0xfd, 0x6c, 0x7d,
-	0x7b, 0x84, 0xdb, 0x97, 0x27, 0xfa, 0xac, 0x28, 0x01, 0xfd, 0x18, 0x2e, 0x8c, 0x2d, 0x4d, 0x0f,
-	0xd4, 0xa9, 0x83, 0xd1, 0xd4, 0x7c, 0xd0, 0x6e, 0x50, 0xa5, 0xcf, 0xa5, 0x2e, 0xd2, 0xd2, 0x74,
-	0x5f, 0x45, 0x87, 0x08, 0xf4, 0x72, 0xca, 0xf2, 0x38, 0x49, 0x44, 0xf7, 0xe1, 0xa2, 0x66, 0xdb,
-	0xe3, 0xd3, 0xa4, 0xf6, 0x26, 0xd5, 0x7e, 0x23, 0x4d, 0xfb, 0x26, 0x91, 0x49, 0xaa, 0x47, 0xda,
-	0x0c, 0x15, 0xf5, 0xa1, 0x65, 0x3b, 0xd8, 0xd6, 0x1c, 0xac, 0xda, 0x8e, 0x65, 0x5b, 0xae, 0x36,
-	0x6e, 0xb7, 0xa8, 0xee, 0x67, 0xd2, 0x74, 0xef, 0x33, 0xfe, 0x7d, 0xce, 0xde, 0xcb, 0x29, 0x4d,
-	0x3b, 0x4e, 0x62, 0x5a, 0xad, 0x01, 0x76, 0xdd, 0x50, 0xeb, 0xf2, 0x22, 0xad, 0x94, 0x3f, 0xae,
-	0x35, 0x46, 0xda, 0xaa, 0x40, 0xe9, 0x58, 0x1b, 0x4f, 0xb1, 0xfc, 0x0c, 0xd4, 0x22, 0x6e, 0x09,
-	0xb5, 0xa1, 0x32, 0xc1, 0xae, 0xab, 0x0d, 0x31, 0xf5, 0x62, 0xa2, 0xe2, 0x77, 0xe5, 0x06, 0x2c,
-	0x45, 0x5d, 0x91, 0xfc, 0x6b, 0x01, 0x96, 0xfa, 0xc6, 0x04, 0x5b, 0x53, 0xcf, 0x25, 0x6e, 0x06,
-	0xed, 0x40, 0xd3, 0x63, 0x7d, 0xbe, 0x50, 0xcc, 0x1d, 0xe1, 0x95, 0x35, 0xe6, 0x43, 0xd7, 0x7c,
-	0x1f, 0xba, 0x76, 0x8b, 0xfb, 0xd0, 0xad, 0xea, 0x17, 0x5f, 0xad, 0xe6, 0x3e, 0xfb, 0xcb, 0xaa,
-	0xa0, 0x34, 0xb8, 0x2c, 0x5b, 0x21, 0x46, 0xef, 0x80, 0x4f, 0x51, 0xf9, 0x59, 0xcf, 0x67, 0x57,
-	0x56, 0xe7, 0xa2, 0xec, 0xfc, 0xcb, 0x93, 0x60, 0x8f, 0x74, 0xa1, 0x6d, 0xa8, 0x1c, 0x63, 0xc7,
-	0x25, 0x4e, 0x90, 0xef, 0x91, 0x77, 0xd1, 0x75, 0xa8, 0xd3, 0x5b, 0xa9, 0xfa, 0xe3, 0x64, 0xce,
-	0xa2, 0xb2, 0x44, 0x89, 0xf7, 0x38, 0xd3, 0x2a, 0xd4, 0xec, 0x0d, 0x3b, 0x60, 0x29, 0x50, 0x16,
-	0xb0, 0x37, 0x6c, 0xce, 0x20, 0x7f, 0x07, 0x5a, 0x49, 0x27, 0x8a, 0x5a, 0x50, 0x78, 0x80, 0x4f,
-	0xf9, 0x7c, 0xa4, 0x89, 0x2e, 0xf2, 0x2f, 0x40, 0xe7, 0x10, 0x15, 0xfe, 0x39, 0x7e, 0x9f, 0x0f,
-	0x84, 0x03, 0xef, 0x89, 0x5e, 0x85, 0x22, 0xd9, 0x10, 0x37, 0xa7, 0x34, 0x63, 0x81, 0xbe, 0x1f,
-	0xc9, 0x98, 0x09, 0x3e, 0x25, 0x26, 0xa0, 0x12, 0xe8, 0x0a, 0x71, 0x76, 0x9a, 0x61, 0xaa, 0x86,
-	0xce, 0xe7, 0xa9, 0xd0, 0xfe, 0xb6, 0x8e, 0xee, 0x40, 0x6b, 0x60, 0x99, 0x2e, 0x36, 0xdd, 0xa9,
-	0xab, 0xb2, 0x48, 0xc9, 0xa3, 0xc9, 0xac, 0x33, 0xea, 0xf8, 0x8c, 0xfb, 0x94, 0x4f, 0x69, 0x0e,
-	0xe2, 0x04, 0x74, 0x1b, 0xe0, 0x58, 0x1b, 0x1b, 0xba, 0xe6, 0x59, 0x8e, 0xdb, 0x2e, 0x5e, 0x2b,
-	0xcc, 0x55, 0x73, 0xcf, 0x67, 0xb9, 0x6b, 0xeb, 0x9a, 0x87, 0xb7, 0x8a, 0x64, 0xb5, 0x4a, 0x44,
-	0x12, 0x3d, 0x0d, 0x4d, 0xcd, 0xb6, 0x55, 0xd7, 0xd3, 0x3c, 0xac, 0x1e, 0x9e, 0x7a, 0xd8, 0xa5,
-	0x31, 0x66, 0x49, 0xa9, 0x6b, 0xb6, 0x7d, 0x40, 0xa8, 0x5b, 0x84, 0x88, 0x9e, 0x82, 0x06, 0x89,
-	0x27, 0x86, 0x36, 0x56, 0x47, 0xd8, 0x18, 0x8e, 0x3c, 0x1a, 0x4b, 0x0a, 0x4a, 0x9d, 0x53, 0x7b,
-	0x94, 0x28, 0xeb, 0xc1, 0x99, 0xa5, 0xb1, 0x04, 0x21, 0x28, 0xea, 0x9a, 0xa7, 0x51, 0x43, 0x2e,
-	0x29, 0xb4, 0x4d, 0x68, 0xb6, 0xe6, 0x8d, 0xb8, 0x79, 0x68, 0x1b, 0x5d, 0x82, 0x32, 0x57, 0x5b,
-	0xa0, 0x6a, 0x79, 0x8f, 0x7c, 0x33, 0xdb, 0xb1, 0x8e, 0x31, 0x0d, 0x9e, 0x55, 0x85, 0x75, 0xe4,
-	0x9f, 0xe5, 0x61, 0x79, 0x26, 0xea, 0x10, 0xbd, 0x23, 0xcd, 0x1d, 0xf9, 0x73, 0x91, 0x36, 0x7a,
-	0x85, 0xe8, 0xd5, 0x74, 0xec, 0xf0, 0xc3, 0xdc, 0x8e, 0x9a, 0x88, 0x65, 0x3a, 0x3d, 0x3a, 0xce,
-	0x4d, 0xc3, 0xb9, 0xd1, 0x1e, 0xb4, 0xc6, 0x9a, 0xeb, 0xdf, 0x04, 0x35, 0x12, 0xf9, 0x67, 0x63,
-	0xd7, 0x8e, 0xe6, 0xfb, 0x7d, 0x72, 0xd8, 0xb9, 0xa2, 0xc6, 0x38, 0x46, 0x45, 0x0a, 0x5c, 0x3c,
-	0x3c, 0x7d, 0xa8, 0x99, 0x9e, 0x61, 0x62, 0x75, 0xe6, 0xcb, 0x5d, 0x99, 0x51, 0xda, 0x3d, 0x36,
-	0x74, 0x6c, 0x0e, 0xfc, 0x4f, 0x76, 0x21, 0x10, 0x0e, 0x3e, 0xa9, 0x2b, 0x2b, 0xd0, 0x88, 0xc7,
-	0x4d, 0xd4, 0x80, 0xbc, 0x77, 0xc2, 0x0d, 0x90, 0xf7, 0x4e, 0xd0, 0xff, 0x41, 0x91, 0x6c, 0x92,
-	0x6e, 0xbe, 0x31, 0x27, 0x69, 0xe1, 0x72, 0xfd, 0x53, 0x1b, 0x2b, 0x94, 0x53, 0x96, 0x83, 0xdb,
-	0x10, 0xc4, 0xd2, 0xa4, 0x56, 0xf9, 0x39, 0x68, 0x26, 0x82, 0x65, 0xe4, 0xfb, 0x09, 0xd1, 0xef,
-	0x27, 0x37, 0xa1, 0x1e, 0x8b, 0x8c, 0xf2, 0x25, 0xb8, 0x38, 0x2f, 0xd0, 0xc9, 0xbf, 0x14, 0x82,
-	0x81, 0x58, 0xc4, 0x42, 0x37, 0xa1, 0x1a, 0x84, 0x3a, 0xdf, 0xbb, 0x25, 0xb7, 0xe1, 0x33, 0x2b,
-	0x01, 0x2b, 0xb9, 0x87, 0xe4, 0x5c, 0xd3, 0x03, 0x91, 0xa7, 0x2b, 0xaf, 0x68, 0xb6, 0xdd, 0x23,
-	0x67, 0x62, 0x15, 0x6a, 0x64, 0x28, 0xe1, 0x4e, 0x34, 0x3b, 0x70, 0x27, 0x1f, 0x40, 0x3b, 0x2d,
-	0xce, 0x25, 0x36, 0x5a, 0x0c, 0x0e, 0xea, 0x25, 0x28, 0x1f, 0x59, 0xce, 0x44, 0x63, 0x5e, 0xb3,
-	0xae, 0xf0, 0x1e, 0x39, 0xc0, 0x2c, 0xe6, 0x15, 0x28, 0x99, 0x75, 0x64, 0x15, 0xae, 0xa4, 0xc6,
-	0x3a, 0x22, 0x62, 0x98, 0x3a, 0x66, 0x16, 0xaf, 0x2b, 0xac, 0x13, 0x2a, 0x62, 0xbb, 0x61, 0x1d,
-	0x32, 0xad, 0x4b, 0x8d, 0x41, 0xf5, 0x8b, 0x0a, 0xef, 0xc9, 0x7f, 0x13, 0xe0, 0xd2, 0xfc, 0x88,
-	0x87, 0x6e, 0x02, 0x30, 0x97, 0x1b, 0x5c, 0xcc, 0xda, 0xc6, 0xa5, 0xd9, 0x6b, 0x71, 0x4b, 0xf3,
-	0x34, 0x45, 0xa4, 0x9c, 0xa4, 0x49, 0x1c, 0x45, 0x28, 0xa6, 0xba, 0xc6, 0x43, 0x76, 0xaa, 0x0a,
-	0x4a, 0x3d, 0xe0, 0x39, 0x30, 0x1e, 0xc6, 0x1d, 0x60, 0x21, 0xee, 0x00, 0x43, 0xdb, 0x15, 0x63,
-	0x97, 0xdc, 0xf7, 0xb6, 0xa5, 0xf3, 0x7a, 0x5b, 0xf9, 0x17, 0xd1, 0x6d, 0xc6, 0xe2, 0x6d, 0xe4,
-	0xe6, 0x0b, 0xe7, 0xba, 0xf9, 0x71, 0xf3, 0xe4, 0x33, 0x9a, 0x47, 0xfe, 0x15, 0x40, 0x55, 0xc1,
-	0xae, 0x4d, 0xdc, 0x34, 0xda, 0x02, 0x11, 0x9f, 0x0c, 0x30, 0x4b, 0xfb, 0x85, 0xd4, 0xb4, 0x99,
-	0x71, 0x77, 0x7d, 0x4e, 0x92, 0xb3, 0x06, 0x62, 0xe8, 0x65, 0x0e, 0x6d, 0xd2, 0x51, 0x0a, 0x17,
-	0x8f, 0x62, 0x9b, 0x57, 0x7c, 0x6c, 0x53, 0x48, 0x4d, 0x53, 0x99, 0x54, 0x02, 0xdc, 0xbc, 0xcc,
-	0xc1, 0x4d, 0x71, 0xc1, 0x64, 0x31, 0x74, 0xd3, 0x89, 0xa1, 0x9b, 0xd2, 0x82, 0x6d, 0xa6, 0xc0,
-	0x9b, 0x4e, 0x0c, 0xde, 0x94, 0x17, 0x28, 0x49, 0xc1, 0x37, 0xaf, 0xf8, 0xf8, 0xa6, 0xb2, 0x60,
-	0xdb, 0x09, 0x80, 0x73, 0x3b, 0x0e, 0x70, 0x18, 0x38, 0xb9, 0x9e, 0x2a, 0x9d, 0x8a, 0x70, 0xbe,
-	0x1b, 0x41, 0x38, 0x62, 0x2a, 0xbc, 0x60, 0x4a, 0xe6, 0x40, 0x9c, 0x4e, 0x0c, 0xe2, 0xc0, 0x02,
-	0x1b, 0xa4, 0x60, 0x9c, 0xb7, 0xa2, 0x18, 0xa7, 0x96, 0x0a, 0x93, 0xf8, 0xa1, 0x99, 0x07, 0x72,
-	0x5e, 0x0b, 0x40, 0xce, 0x52, 0x2a, 0x4a, 0xe3, 0x7b, 0x48, 0xa2, 0x9c, 0xbd, 0x19, 0x94, 0xc3,
-	0x50, 0xc9, 0xd3, 0xa9, 0x2a, 0x16, 0xc0, 0x9c, 0xbd, 0x19, 0x98, 0xd3, 0x58, 0xa0, 0x70, 0x01,
-	0xce, 0xf9, 0xc9, 0x7c, 0x9c, 0x93, 0x8e, 0x44, 0xf8, 0x32, 0xb3, 0x01, 0x1d, 0x35, 0x05, 0xe8,
-	0x30, 0x30, 0xf2, 0x7c, 0xaa, 0xfa, 0xcc, 0x48, 0xe7, 0xee, 0x1c, 0xa4, 0xc3, 0x30, 0xc9, 0xb3,
-	0xa9, 0xca, 0x33, 0x40, 0x9d, 0xbb, 0x73, 0xa0, 0x0e, 0x5a, 0xa8, 0x36, 0x3b, 0xd6, 0x79, 0x8e,
-	0xe4, 0x69, 0x09, 0x37, 0x47, 0x22, 0x19, 0x76, 0x1c, 0xcb, 0xe1, 0xb9, 0x39, 0xeb, 0xc8, 0xcf,
-	0x92, 0xcc, 0x31, 0x74, 0x69, 0x67, 0xe0, 0x22, 0x9a, 0x53, 0x44, 0xdc, 0x98, 0xfc, 0x4f, 0x21,
-	0x94, 0xa5, 0xc9, 0x56, 0x34, 0xeb, 0x14, 0x79, 0xd6, 0x19, 0xc1, 0x20, 0xf9, 0x38, 0x06, 0x59,
-	0x94, 0x0f, 0xa0, 0x1b, 0xb0, 0x4c, 0x93, 0x41, 0x16, 0x17, 0x62, 0x21, 0xac, 0x49, 0x06, 0xd8,
-	0x55, 0x62, 0xb1, 0xec, 0x45, 0xb8, 0x10, 0xe1, 0x0d, 0x52, 0x10, 0x96, 0x53, 0xb7, 0x02, 0xee,
-	0x4d, 0x9e, 0x8b, 0xbc, 0x09, 0x55, 0x8e, 0x9c, 0xdc, 0xd4, 0xe2, 0x4c, 0x14, 0xf3, 0xf1, 0x60,
-	0x15, 0x08, 0xc9, 0xef, 0x86, 0x16, 0x0e, 0xb1, 0x0f, 0x82, 0xe2, 0xc0, 0xd2, 0x31, 0x4f, 0x20,
-	0x68, 0x9b, 0xe0, 0xa1, 0xb1, 0x35, 0xe4, 0x21, 0x99, 0x34, 0x09, 0x57, 0xe0, 0xf4, 0x45, 0xe6,
-	0xd3, 0x79, 0x66, 0x9d, 0x70, 0xb6, 0x73, 0x91, 0x8b, 0xf0, 0xdf, 0x41, 0x2e, 0xf9, 0x47, 0x46,
-	0x2e, 0xd1, 0x0c, 0xaf, 0x10, 0xcf, 0xf0, 0xa2, 0x56, 0x2d, 0x3e, 0x8a, 0x55, 0xff, 0x21, 0x84,
-	0x67, 0x2c, 0x00, 0x32, 0x8f, 0x66, 0xd2, 0x30, 0x9d, 0x2b, 0xd1, 0x13, 0xc3, 0xd3, 0x39, 0x0e,
-	0x4f, 0xcb, 0x74, 0xe1, 0x71, 0x78, 0x5a, 0x61, 0x09, 0x1e, 0xed, 0xa0, 0x57, 0x41, 0xa4, 0xe5,
-	0x5a, 0xd5, 0xb2, 0x5d, 0x1e, 0xa0, 0x1e, 0x8f, 0xee, 0x85, 0x55, 0x65, 0xd7, 0xf6, 0x09, 0xcf,
-	0x9e, 0xed, 0x2a, 0x55, 0x9b, 0xb7, 0x22, 0xd9, 0x96, 0x18, 0xcb, 0xb6, 0xae, 0x82, 0x48, 0x56,
-	0xef, 0xda, 0xda, 0x00, 0xd3, 0x60, 0x23, 0x2a, 0x21, 0x41, 0xbe, 0x0f, 0x68, 0x36, 0xdc, 0xa1,
-	0x1e, 0x94, 0xf1, 0x31, 0x36, 0x3d, 0xf2, 0xd9, 0x0b, 0xc9, 0x84, 0x88, 0xe3, 0x15, 0x6c, 0x7a,
-	0x5b, 0x6d, 0x62, 0xc7, 0xbf, 0x7f, 0xb5, 0xda, 0x62, 0xdc, 0x2f, 0x58, 0x13, 0xc3, 0xc3, 0x13,
-	0xdb, 0x3b, 0x55, 0xb8, 0xbc, 0xfc, 0xe7, 0x3c, 0x01, 0x0f, 0xb1, 0x50, 0x38, 0xd7, 0xb6, 0xfe,
-	0x15, 0xce, 0x47, 0x80, 0x63, 0x36, 0x7b, 0xaf, 0x00, 0x0c, 0x35, 0x57, 0xfd, 0x58, 0x33, 0x3d,
-	0xac, 0x73, 0xa3, 0x47, 0x28, 0x48, 0x82, 0x2a, 0xe9, 0x4d, 0x5d, 0xac, 0x73, 0x0c, 0x1b, 0xf4,
-	0x23, 0xfb, 0xac, 0x7c, 0xbb, 0x7d, 0xc6, 0xad, 0x5c, 0x4d, 0x58, 0x39, 0x92, 0xb6, 0x8b, 0xd1,
-	0xb4, 0x9d, 0xac, 0xcd, 0x76, 0x0c, 0xcb, 0x31, 0xbc, 0x53, 0xfa, 0x69, 0x0a, 0x4a, 0xd0, 0x47,
-	0xd7, 0xa1, 0x3e, 0xc1, 0x13, 0xdb, 0xb2, 0xc6, 0x2a, 0x73, 0x9f, 0x35, 0x2a, 0xba, 0xc4, 0x89,
-	0x5d, 0xea, 0x45, 0x7f, 0x1e, 0xb9, 0xbf, 0x21, 0x80, 0xfb, 0x9f, 0x33, 0xb0, 0xfc, 0x07, 0x5a,
-	0xd5, 0x89, 0x27, 0x3b, 0xe8, 0x00, 0x96, 0x03, 0xff, 0xa1, 0x4e, 0xa9, 0x5f, 0xf1, 0x0f, 0x74,
-	0x56, 0x07, 0xd4, 0x3a, 0x8e, 0x93, 0x5d, 0xf4, 0x03, 0xb8, 0x9c, 0xf0, 0x8d, 0x81, 0xea, 0x7c,
-	0x46, 0x17, 0xf9, 0x58, 0xdc, 0x45, 0xfa, 0x9a, 0x43, 0x5b, 0x15, 0xbe, 0xa5, 0xad, 0xbe, 0xb5,
-	0x3f, 0xdc, 0x86, 0x46, 0x3c, 0xf7, 0x9b, 0x7b, 0x7c, 0xae, 0x43, 0xdd, 0xc1, 0x1e, 0xc1, 0x7e,
-	0xb1, 0x5a, 0xce, 0x12, 0x23, 0xf2, 0x0a, 0xd1, 0x3e, 0x3c, 0x36, 0x37, 0x07, 0x44, 0xff, 0x0f,
-	0x62, 0x98, 0x3e, 0x0a, 0x29, 0x65, 0x91, 0x00, 0xe9, 0x87, 0xbc, 0xf2, 0xef, 0x84, 0x50, 0x65,
-	0xbc, 0x76, 0xd0, 0x85, 0xb2, 0x83, 0xdd, 0xe9, 0x98, 0x81, 0xf5, 0xc6, 0xc6, 0x8b, 0xd9, 0xb2,
-	0x47, 0x42, 0x9d, 0x8e, 0x3d, 0x85, 0x0b, 0xcb, 0xf7, 0xa1, 0xcc, 0x28, 0xa8, 0x06, 0x95, 0xbb,
-	0xbb, 0x77, 0x76, 0xf7, 0xde, 0xdb, 0x6d, 0xe5, 0x10, 0x40, 0x79, 0xb3, 0xd3, 0xe9, 0xee, 0xf7,
-	0x5b, 0x02, 0x12, 0xa1, 0xb4, 0xb9, 0xb5, 0xa7, 0xf4, 0x5b, 0x79, 0x42, 0x56, 0xba, 0xef, 0x74,
-	0x3b, 0xfd, 0x56, 0x01, 0x2d, 0x43, 0x9d, 0xb5, 0xd5, 0xdb, 0x7b, 0xca, 0xbb, 0x9b, 0xfd, 0x56,
-	0x31, 0x42, 0x3a, 0xe8, 0xee, 0xde, 0xea, 0x2a, 0xad, 0x92, 0xfc, 0x12, 0x5c, 0x49, 0xcd, 0x37,
-	0x43, 0xdc, 0x2f, 0x44, 0x70, 0xbf, 0xfc, 0x59, 0x1e, 0xa4, 0xf4, 0x24, 0x12, 0xbd, 0x93, 0xd8,
-	0xf8, 0xc6, 0x39, 0x32, 0xd0, 0xc4, 0xee, 0xd1, 0x53, 0xd0, 0x70, 0xf0, 0x11, 0xf6, 0x06, 0x23,
-	0x96, 0xd4, 0xb2, 0x98, 0x5d, 0x57, 0xea, 0x9c, 0x4a, 0x85, 0x5c, 0xc6, 0xf6, 0x21, 0x1e, 0x78,
-	0x2a, 0xf3, 0x65, 0xec, 0xd4, 0x8a, 0x84, 0x8d, 0x50, 0x0f, 0x18, 0x51, 0xfe, 0xe0, 0x5c, 0xb6,
-	0x14, 0xa1, 0xa4, 0x74, 0xfb, 0xca, 0x0f, 0x5b, 0x05, 0x84, 0xa0, 0x41, 0x9b, 0xea, 0xc1, 0xee,
-	0xe6, 0xfe, 0x41, 0x6f, 0x8f, 0xd8, 0xf2, 0x02, 0x34, 0x7d, 0x5b, 0xfa, 0xc4, 0x92, 0xbc, 0x0f,
-	0x97, 0x53, 0x32, 0xe0, 0x47, 0x2c, 0x7d, 0xc8, 0xbf, 0x11, 0xa2, 0x2a, 0xe3, 0x65, 0x86, 0xb7,
-	0x13, 0x96, 0x5e, 0xcf, 0x9a, 0x37, 0x27, 0xcd, 0x2c, 0x41, 0x15, 0xf3, 0x9a, 0x1f, 0x35, 0xf0,
-	0x92, 0x12, 0xf4, 0xe5, 0x17, 0x17, 0x1b, 0x2d, 0x3c, 0x75, 0x79, 0xf9, 0xdf, 0x02, 0x34, 0x13,
-	0x3e, 0x06, 0x6d, 0x40, 0x89, 0x41, 0xc3, 0xb4, 0x5f, 0xa5, 0xd4, 0x45, 0x72, 0x87, 0xc4, 0x58,
-	0xd1, 0x1b, 0xb1, 0x25, 0xcd, 0xf8, 0x32, 0x66, 0x2c, 0xbf, 0x50, 0xc9, 0x45, 0x03, 0x09, 0xf4,
-	0x26, 0x88, 0x81, 0xb3, 0xe4, 0xf5, 0x88, 0x27, 0x67, 0xc5, 0x03, 0x37, 0xcb, 0xe5, 0x43, 0x19,
-	0xf4, 0x5a, 0x98, 0xb1, 0x17, 0x67, 0x01, 0x29, 0x17, 0x67, 0x0c, 0x5c, 0xd8, 0xe7, 0x97, 0x3b,
-	0x50, 0x8b, 0xec, 0x07, 0x3d, 0x0e, 0xe2, 0x44, 0x3b, 0xe1, 0xe5, 0x6d, 0x56, 0xa0, 0xac, 0x4e,
-	0xb4, 0x13, 0x56, 0xd9, 0xbe, 0x0c, 0x15, 0x32, 0x38, 0xd4, 0x5c, 0x5e, 0xd0, 0x2a, 0x4f, 0xb4,
-	0x93, 0xb7, 0x35, 0x57, 0x7e, 0x1f, 0x1a, 0xf1, 0xd2, 0x2e, 0xb9, 0x8b, 0x8e, 0x35, 0x35, 0x75,
-	0xaa, 0xa3, 0xa4, 0xb0, 0x0e, 0xba, 0x09, 0xa5, 0x63, 0x8b, 0xf9, 0xfb, 0xf9, 0x4e, 0xeb, 0x9e,
-	0xe5, 0xe1, 0x88, 0x5b, 0x65, 0xdc, 0xf2, 0x43, 0x28, 0x51, 0xff, 0x4d, 0x5c, 0x29, 0x2d, 0xd2,
-	0x72, 0xb4, 0x42, 0xda, 0xe8, 0x7d, 0x00, 0xcd, 0xf3, 0x1c, 0xe3, 0x70, 0x1a, 0x2a, 0x5e, 0x9d,
-	0xef, 0xff, 0x37, 0x7d, 0xbe, 0xad, 0xab, 0x3c, 0x10, 0x5c, 0x0c, 0x45, 0x23, 0xc1, 0x20, 0xa2,
-	0x50, 0xde, 0x85, 0x46, 0x5c, 0x36, 0xfa, 0xbb, 0x64, 0x69, 0xce, 0xef, 0x92, 0x20, 0x1f, 0x0d,
-	0xb2, 0xd9, 0x02, 0x2b, 0xc8, 0xd3, 0x8e, 0xfc, 0x89, 0x00, 0xd5, 0xfe, 0x09, 0x3f, 0xa3, 0x29,
-	0xb5, 0xe0, 0x50, 0x34, 0x1f, 0xad, 0x6b, 0xb2, 0xe2, 0x72, 0x21, 0x28, 0x59, 0xbf, 0x15, 0x5c,
-	0xa8, 0x62, 0xd6, 0x42, 0x88, 0x5f, 0xc1, 0xe3, 0xee, 0xfa, 0x75, 0x10, 0x83, 0x53, 0x45, 0x60,
-	0x9f, 0xa6, 0xeb, 0x0e, 0x76, 0x5d, 0xbe, 0x37, 0xbf, 0x4b, 0x7f, 0x2d, 0x58, 0x1f, 0xf3, 0xca,
-	0x69, 0x41, 0x61, 0x1d, 0x59, 0x87, 0x66, 0x22, 0xf2, 0xa3, 0xd7, 0xa1, 0x62, 0x4f, 0x0f, 0x55,
-	0xdf, 0x3c, 0x89, 0xcb, 0xe3, 0x27, 0xe0, 0xd3, 0xc3, 0xb1, 0x31, 0xb8, 0x83, 0x4f, 0xfd, 0xc5,
-	0xd8, 0xd3, 0xc3, 0x3b, 0xcc, 0x8a, 0x6c, 0x96, 0x7c, 0x74, 0x96, 0x63, 0xa8, 0xfa, 0x87, 0x02,
-	0x7d, 0x2f, 0x7a, 0x4f, 0xfc, 0x1f, 0x4e, 0xa9, 0xd9, 0x08, 0x57, 0x1f, 0xb9, 0x26, 0x37, 0x60,
-	0xd9, 0x35, 0x86, 0x26, 0xd6, 0xd5, 0x10, 0x78, 0xd2, 0xd9, 0xaa, 0x4a, 0x93, 0x0d, 0xec, 0xf8,
-	0xa8, 0x53, 0xfe, 0x97, 0x00, 0x55, 0xff, 0xc2, 0xa2, 0x97, 0x22, 0xe7, 0xae, 0x31, 0x27, 0x23,
-	0xf0, 0x19, 0xc3, 0xbf, 0x03, 0xf1, 0xb5, 0xe6, 0xcf, 0xbf, 0xd6, 0xb4, 0xdf, 0x3c, 0x7e, 0x05,
-	0xb8, 0x78, 0xee, 0xff, 0x6d, 0x2f, 0x00, 0xf2, 0x2c, 0x4f, 0x1b, 0xab, 0xc7, 0x96, 0x67, 0x98,
-	0x43, 0x95, 0x19, 0x9b, 0x25, 0xa5, 0x2d, 0x3a, 0x72, 0x8f, 0x0e, 0xec, 0x53, 0xbb, 0xff, 0x54,
-	0x80, 0x6a, 0x90, 0x1d, 0x9c, 0xb7, 0x94, 0x7f, 0x09, 0xca, 0x3c, 0x00, 0xb2, 0x5a, 0x3e, 0xef,
-	0x05, 0xff, 0x9d, 0x8a, 0x91, 0xff, 0x4e, 0x12, 0x54, 0x27, 0xd8, 0xd3, 0x68, 0x9c, 0x61, 0xd8,
-	0x3f, 0xe8, 0xdf, 0x78, 0x0d, 0x6a, 0x91, 0xff, 0x2e, 0xe4, 0xe6, 0xed, 0x76, 0xdf, 0x6b, 0xe5,
-	0xa4, 0xca, 0x27, 0x9f, 0x5f, 0x2b, 0xec, 0xe2, 0x8f, 0xc9, 0x99, 0x55, 0xba, 0x9d, 0x5e, 0xb7,
-	0x73, 0xa7, 0x25, 0x48, 0xb5, 0x4f, 0x3e, 0xbf, 0x56, 0x51, 0x30, 0xad, 0x15, 0xde, 0xe8, 0xc1,
-	0x52, 0xf4, 0xab, 0xc4, 0xc3, 0x01, 0x82, 0xc6, 0xad, 0xbb, 0xfb, 0x3b, 0xdb, 0x9d, 0xcd, 0x7e,
-	0x57, 0xbd, 0xb7, 0xd7, 0xef, 0xb6, 0x04, 0x74, 0x19, 0x2e, 0xec, 0x6c, 0xbf, 0xdd, 0xeb, 0xab,
-	0x9d, 0x9d, 0xed, 0xee, 0x6e, 0x5f, 0xdd, 0xec, 0xf7, 0x37, 0x3b, 0x77, 0x5a, 0xf9, 0x8d, 0x3f,
-	0xd6, 0xa0, 0xb9, 0xb9, 0xd5, 0xd9, 0x26, 0xf1, 0xdf, 0x18, 0x68, 0xbc, 0x16, 0x5b, 0xa4, 0xa5,
-	0x97, 0x33, 0xdf, 0xd1, 0x48, 0x67, 0x97, 0xa2, 0xd1, 0x6d, 0x28, 0xd1, 0xaa, 0x0c, 0x3a, 0xfb,
-	0x61, 0x8d, 0xb4, 0xa0, 0x36, 0x4d, 0x16, 0x43, 0xaf, 0xc7, 0x99, 0x2f, 0x6d, 0xa4, 0xb3, 0x4b,
-	0xd5, 0x48, 0x01, 0x31, 0xac, 0x8a, 0x2c, 0x7e, 0x79, 0x23, 0x65, 0x28, 0x5f, 0x13, 0x9d, 0x21,
-	0xb2, 0x5a, 0xfc, 0x12, 0x45, 0xca, 0xe0, 0xc0, 0xd0, 0x0e, 0x54, 0x7c, 0x30, 0xbc, 0xe8, 0x6d,
-	0x8c, 0xb4, 0xb0, 0xb4, 0x4c, 0x3e, 0x01, 0x2b, 0x5a, 0x9c, 0xfd, 0xd0, 0x47, 0x5a, 0x50, 0x27,
-	0x47, 0xdb, 0x50, 0xe6, 0xd9, 0xfe, 0x82, 0xf7, 0x2e, 0xd2, 0xa2, 0x52, 0x31, 0x31, 0x5a, 0x58,
-	0x4e, 0x5a, 0xfc, 0x7c, 0x49, 0xca, 0xf0, 0x0b, 0x00, 0xdd, 0x05, 0x88, 0x94, 0x28, 0x32, 0xbc,
-	0x4b, 0x92, 0xb2, 0x94, 0xf6, 0xd1, 0x1e, 0x54, 0x03, 0xc4, 0xb8, 0xf0, 0x95, 0x90, 0xb4, 0xb8,
-	0xc6, 0x8e, 0xee, 0x43, 0x3d, 0x8e, 0x74, 0xb2, 0xbd, 0xfd, 0x91, 0x32, 0x16, 0xcf, 0x89, 0xfe,
-	0x38, 0xec, 0xc9, 0xf6, 0x16, 0x48, 0xca, 0x58, 0x4b, 0x47, 0x1f, 0xc2, 0xf2, 0x2c, 0x2c, 0xc9,
-	0xfe, 0x34, 0x48, 0x3a, 0x47, 0x75, 0x1d, 0x4d, 0x00, 0xcd, 0x81, 0x33, 0xe7, 0x78, 0x29, 0x24,
-	0x9d, 0xa7, 0xd8, 0x8e, 0x74, 0x68, 0x26, 0x31, 0x42, 0xd6, 0x97, 0x43, 0x52, 0xe6, 0xc2, 0x3b,
-	0x9b, 0x25, 0x0e, 0x1b, 0xb2, 0xbe, 0x24, 0x92, 0x32, 0xd7, 0xe1, 0xb7, 0xba, 0x5f, 0x7c, 0xbd,
-	0x22, 0x7c, 0xf9, 0xf5, 0x8a, 0xf0, 0xd7, 0xaf, 0x57, 0x84, 0x4f, 0xbf, 0x59, 0xc9, 0x7d, 0xf9,
-	0xcd, 0x4a, 0xee, 0x4f, 0xdf, 0xac, 0xe4, 0x7e, 0xf4, 0xfc, 0xd0, 0xf0, 0x46, 0xd3, 0xc3, 0xb5,
-	0x81, 0x35, 0x59, 0x8f, 0x3e, 0xd9, 0x9c, 0xf7, 0x8c, 0xf4, 0xb0, 0x4c, 0x83, 0xee, 0xcb, 0xff,
-	0x09, 0x00, 0x00, 0xff, 0xff, 0x01, 0xd0, 0x52, 0xd6, 0x66, 0x2a, 0x00, 0x00,
+	// 3108 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xe4, 0x5a, 0x4b, 0x73, 0xe3, 0xc6,
+	0xf1, 0xe7, 0xfb, 0xd1, 0x7c, 0x6a, 0x56, 0xde, 0xe5, 0xc2, 0x6b, 0x69, 0xff, 0x70, 0xf9, 0xb5,
+	0xb6, 0xa5, 0xbf, 0xe5, 0xb2, 0x63, 0xc7, 0x49, 0x6c, 0x89, 0xcb, 0x35, 0xe5, 0x95, 0x25, 0x65,
+	0xc4, 0x5d, 0xe7, 0xe5, 0x85, 0x41, 0x72, 0x44, 0xc2, 0x4b, 0x02, 0x30, 0x00, 0xca, 0xd2, 0x1e,
+	0x53, 0x49, 0xa5, 0xe2, 0x93, 0xab, 0x72, 0xf1, 0xc5, 0xf7, 0x5c, 0xf2, 0x11, 0x92, 0xb3, 0xab,
+	0x92, 0xaa, 0xf8, 0x98, 0x93, 0x93, 0xb2, 0x73, 0xca, 0x17, 0x48, 0x72, 0x48, 0x25, 0x35, 0x2f,
+	0x10, 0x00, 0x09, 0x91, 0xda, 0xcd, 0x2d, 0x37, 0x74, 0xa3, 0xbb, 0x67, 0xa6, 0x31, 0xd3, 0xdd,
+	0xbf, 0xc6, 0xc0, 0xe3, 0x1e, 0x31, 0xfb, 0xc4, 0x19, 0x1b, 0xa6, 0xb7, 0xa9, 0x77, 0x7b, 0xc6,
+	0xa6, 0x77, 0x66, 0x13, 0x77, 0xc3, 0x76, 0x2c, 0xcf, 0x42, 0xb5, 0xe9, 0xcb, 0x0d, 0xfa, 0x52,
+	0x79, 0x22, 0x20, 0xdd, 0x73, 0xce, 0x6c, 0xcf, 0xda, 0xb4, 0x1d, 0xcb, 0x3a, 0xe6, 0xf2, 0xca,
+	0xb5, 0xc0, 0x6b, 0x66, 0x27, 0x68, 0x4d, 0xb9, 0x36, 0xab, 0x7c, 0x9f, 0x9c, 0xc9, 0xb7, 0x4f,
+	0xcc, 0xe8, 0xda, 0xba, 0xa3, 0x8f, 0xe5, 0xeb, 0xf5, 0x81, 0x65, 0x0d, 0x46, 0x64, 0x93, 0x51,
+	0xdd, 0xc9, 0xf1, 0xa6, 0x67, 0x8c, 0x89, 0xeb, 0xe9, 0x63, 0x5b, 0x08, 0xac, 0x0e, 0xac, 0x81,
+	0xc5, 0x1e, 0x37, 0xe9, 0x93, 0xe0, 0xae, 0x45, 0xd5, 0xfa, 0x13, 0x47, 0xf7, 0x0c, 0xcb, 0xe4,
+	0xef, 0xd5, 0xdf, 0x16, 0x21, 0x8f, 0xc9, 0x47, 0x13, 0xe2, 0x7a, 0x68, 0x0b, 0x32, 0xa4, 0x37,
+	0xb4, 0x1a, 0xc9, 0xeb, 0xc9, 0x67, 0x4b, 0x5b, 0xd7, 0x36, 0x22, 0x8b, 0xdf, 0x10, 0x72, 0xad,
+	0xde, 0xd0, 0x6a, 0x27, 0x30, 0x93, 0x45, 0xaf, 0x40, 0xf6, 0x78, 0x34, 0x71, 0x87, 0x8d, 0x14,
+	0x53, 0x7a, 0x22, 0x4e, 0xe9, 0x16, 0x15, 0x6a, 0x27, 0x30, 0x97, 0xa6, 0x43, 0x19, 0xe6, 0xb1,
+	0xd5, 0x48, 0x9f, 0x3f, 0xd4, 0xae, 0x79, 0xcc, 0x86, 0xa2, 0xb2, 0x68, 0x07, 0xc0, 0x25, 0x9e,
+	0x66, 0xd9, 0x74, 0xfa, 0x8d, 0x0c, 0xd3, 0xfc, 0xbf, 0x38, 0xcd, 0x23, 0xe2, 0x1d, 0x30, 0xc1,
+	0x76, 0x02, 0x17, 0x5d, 0x49, 0x50, 0x1b, 0x86, 0x69, 0x78, 0x5a, 0x6f, 0xa8, 0x1b, 0x66, 0x23,
+	0x7b, 0xbe, 0x8d, 0x5d, 0xd3, 0xf0, 0x9a, 0x54, 0x90, 0xda, 0x30, 0x24, 0x41, 0x97, 0xfc, 0xd1,
+	0x84, 0x38, 0x67, 0x8d, 0xdc, 0xf9, 0x4b, 0xfe, 0x3e, 0x15, 0xa2, 0x4b, 0x66, 0xd2, 0xa8, 0x05,
+	0xa5, 0x2e, 0x19, 0x18, 0xa6, 0xd6, 0x1d, 0x59, 0xbd, 0xfb, 0x8d, 0x3c, 0x53, 0x56, 0xe3, 0x94,
+	0x77, 0xa8, 0xe8, 0x0e, 0x95, 0x6c, 0x27, 0x30, 0x74, 0x7d, 0x0a, 0x7d, 0x07, 0x0a, 0xbd, 0x21,
+	0xe9, 0xdd, 0xd7, 0xbc, 0xd3, 0x46, 0x81, 0xd9, 0x58, 0x8f, 0xb3, 0xd1, 0xa4, 0x72, 0x9d, 0xd3,
+	0x76, 0x02, 0xe7, 0x7b, 0xfc, 0x91, 0xae, 0xbf, 0x4f, 0x46, 0xc6, 0x09, 0x71, 0xa8, 0x7e, 0xf1,
+	0xfc, 0xf5, 0xdf, 0xe4, 0x92, 0xcc, 0x42, 0xb1, 0x2f, 0x09, 0xf4, 0x26, 0x14, 0x89, 0xd9, 0x17,
+	0xcb, 0x00, 0x66, 0xe2, 0x7a, 0xec, 0x5e, 0x31, 0xfb, 0x72, 0x11, 0x05, 0x22, 0x9e, 0xd1, 0x6b,
+	0x90, 0xeb, 0x59, 0xe3, 0xb1, 0xe1, 0x35, 0x4a, 0x4c, 0x7b, 0x2d, 0x76, 0x01, 0x4c, 0xaa, 0x9d,
+	0xc0, 0x42, 0x1e, 0xed, 0x43, 0x75, 0x64, 0xb8, 0x9e, 0xe6, 0x9a, 0xba, 0xed, 0x0e, 0x2d, 0xcf,
+	0x6d, 0x94, 0x99, 0x85, 0xa7, 0xe2, 0x2c, 0xec, 0x19, 0xae, 0x77, 0x24, 0x85, 0xdb, 0x09, 0x5c,
+	0x19, 0x05, 0x19, 0xd4, 0x9e, 0x75, 0x7c, 0x4c, 0x1c, 0xdf, 0x60, 0xa3, 0x72, 0xbe, 0xbd, 0x03,
+	0x2a, 0x2d, 0xf5, 0xa9, 0x3d, 0x2b, 0xc8, 0x40, 0x3f, 0x86, 0x4b, 0x23, 0x4b, 0xef, 0xfb, 0xe6,
+	0xb4, 0xde, 0x70, 0x62, 0xde, 0x6f, 0x54, 0x99, 0xd1, 0xe7, 0x62, 0x27, 0x69, 0xe9, 0x7d, 0x69,
+	0xa2, 0x49, 0x15, 0xda, 0x09, 0xbc, 0x32, 0x8a, 0x32, 0xd1, 0x3d, 0x58, 0xd5, 0x6d, 0x7b, 0x74,
+	0x16, 0xb5, 0x5e, 0x63, 0xd6, 0x6f, 0xc4, 0x59, 0xdf, 0xa6, 0x3a, 0x51, 0xf3, 0x48, 0x9f, 0xe1,
+	0xa2, 0x0e, 0xd4, 0x6d, 0x87, 0xd8, 0xba, 0x43, 0x34, 0xdb, 0xb1, 0x6c, 0xcb, 0xd5, 0x47, 0x8d,
+	0x3a, 0xb3, 0xfd, 0x4c, 0x9c, 0xed, 0x43, 0x2e, 0x7f, 0x28, 0xc4, 0xdb, 0x09, 0x5c, 0xb3, 0xc3,
+	0x2c, 0x6e, 0xd5, 0xea, 0x11, 0xd7, 0x9d, 0x5a, 0x5d, 0x59, 0x64, 0x95, 0xc9, 0x87, 0xad, 0x86,
+	0x58, 0x3b, 0x79, 0xc8, 0x9e, 0xe8, 0xa3, 0x09, 0x51, 0x9f, 0x81, 0x52, 0x20, 0x2c, 0xa1, 0x06,
+	0xe4, 0xc7, 0xc4, 0x75, 0xf5, 0x01, 0x61, 0x51, 0xac, 0x88, 0x25, 0xa9, 0x56, 0xa1, 0x1c, 0x0c,
+	0x45, 0xea, 0xaf, 0x93, 0x50, 0xee, 0x18, 0x63, 0x62, 0x4d, 0x3c, 0x97, 0x86, 0x19, 0xb4, 0x07,
+	0x35, 0x8f, 0xd3, 0x62, 0xa2, 0x44, 0x04, 0xc2, 0xab, 0x1b, 0x3c, 0x86, 0x6e, 0xc8, 0x18, 0xba,
+	0x71, 0x53, 0xc4, 0xd0, 0x9d, 0xc2, 0x17, 0x5f, 0xad, 0x27, 0x3e, 0xfb, 0xf3, 0x7a, 0x12, 0x57,
+	0x85, 0x2e, 0x9f, 0x21, 0x41, 0xef, 0x80, 0xe4, 0x68, 0x62, 0xaf, 0xa7, 0x96, 0x37, 0x56, 0x11,
+	0xaa, 0x7c, 0xff, 0xab, 0x63, 0x7f, 0x8d, 0x6c, 0xa2, 0x0d, 0xc8, 0x9f, 0x10, 0xc7, 0xa5, 0x41,
+	0x50, 0xac, 0x51, 0x90, 0xe8, 0x49, 0xa8, 0xb0, 0x53, 0xa9, 0xc9, 0xf7, 0x74, 0xcc, 0x0c, 0x2e,
+	0x33, 0xe6, 0x5d, 0x21, 0xb4, 0x0e, 0x25, 0x7b, 0xcb, 0xf6, 0x45, 0xd2, 0x4c, 0x04, 0xec, 0x2d,
+	0x5b, 0x08, 0xa8, 0xdf, 0x86, 0x7a, 0x34, 0x88, 0xa2, 0x3a, 0xa4, 0xef, 0x93, 0x33, 0x31, 0x1e,
+	0x7d, 0x44, 0xab, 0xe2, 0x0b, 0xb0, 0x31, 0x8a, 0x58, 0x7c, 0x8e, 0xdf, 0xa7, 0xa0, 0x1e, 0x8d,
+	0x9e, 0xe8, 0x35, 0xc8, 0xd0, 0x05, 0x09, 0x77, 0x2a, 0x33, 0x1e, 0xe8, 0xc8, 0x4c, 0xc6, 0x5d,
+	0xf0, 0x29, 0x75, 0x01, 0xd3, 0x40, 0x57, 0x69, 0xb0, 0xd3, 0x0d, 0x53, 0x33, 0xfa, 0x62, 0x9c,
+	0x3c, 0xa3, 0x77, 0xfb, 0xe8, 0x36, 0xd4, 0x7b, 0x96, 0xe9, 0x12, 0xd3, 0x9d, 0xb8, 0x1a, 0xcf,
+	0x94, 0x8d, 0x74, 0x4c, 0x30, 0x6a, 0x4a, 0xc1, 0x43, 0x26, 0x87, 0x6b, 0xbd, 0x30, 0x03, 0xdd,
+	0x02, 0x38, 0xd1, 0x47, 0x46, 0x5f, 0xf7, 0x2c, 0xc7, 0x6d, 0x64, 0xae, 0xa7, 0xe7, 0x9a, 0xb9,
+	0x2b, 0x45, 0xee, 0xd8, 0x7d, 0xdd, 0x23, 0x3b, 0x19, 0x3a, 0x5b, 0x1c, 0xd0, 0x44, 0x4f, 0x43,
+	0x4d, 0xb7, 0x6d, 0xcd, 0xf5, 0x74, 0x8f, 0x68, 0xdd, 0x33, 0x8f, 0xb8, 0x2c, 0xc7, 0x94, 0x71,
+	0x45, 0xb7, 0xed, 0x23, 0xca, 0xdd, 0xa1, 0x4c, 0xf4, 0x14, 0x54, 0x69, 0x3e, 0x31, 0xf4, 0x91,
+	0x36, 0x24, 0xc6, 0x60, 0xe8, 0xb1, 0x5c, 0x92, 0xc6, 0x15, 0xc1, 0x6d, 0x33, 0xa6, 0xda, 0x87,
+	0x72, 0x30, 0x97, 0x20, 0x04, 0x99, 0xbe, 0xee, 0xe9, 0xcc, 0x91, 0x65, 0xcc, 0x9e, 0x29, 0xcf,
+	0xd6, 0xbd, 0xa1, 0x70, 0x0f, 0x7b, 0x46, 0x97, 0x21, 0x27, 0xcc, 0xa6, 0x99, 0x59, 0x41, 0xd1,
+	0x6f, 0x66, 0x3b, 0xd6, 0x09, 0x61, 0xc9, 0xb3, 0x80, 0x39, 0xa1, 0xfe, 0x2c, 0x05, 0x2b, 0x33,
+	0x59, 0x87, 0xda, 0x1d, 0xea, 0xee, 0x50, 0x8e, 0x45, 0x9f, 0xd1, 0xab, 0xd4, 0xae, 0xde, 0x27,
+	0x8e, 0xd8, 0xcc, 0x8d, 0xa0, 0x8b, 0x78, 0xa5, 0xd3, 0x66, 0xef, 0x85, 0x6b, 0x84, 0x34, 0x3a,
+	0x80, 0xfa, 0x48, 0x77, 0xe5, 0x49, 0xd0, 0x02, 0x99, 0x7f, 0x36, 0x77, 0xed, 0xe9, 0x32, 0xee,
+	0xd3, 0xcd, 0x2e, 0x0c, 0x55, 0x47, 0x21, 0x2e, 0xc2, 0xb0, 0xda, 0x3d, 0x7b, 0xa0, 0x9b, 0x9e,
+	0x61, 0x12, 0x6d, 0xe6, 0xcb, 0x5d, 0x9d, 0x31, 0xda, 0x3a, 0x31, 0xfa, 0xc4, 0xec, 0xc9, 0x4f,
+	0x76, 0xc9, 0x57, 0xf6, 0x3f, 0xa9, 0xab, 0x62, 0xa8, 0x86, 0xf3, 0x26, 0xaa, 0x42, 0xca, 0x3b,
+	0x15, 0x0e, 0x48, 0x79, 0xa7, 0xe8, 0xff, 0x21, 0x43, 0x17, 0xc9, 0x16, 0x5f, 0x9d, 0x53, 0xb4,
+	0x08, 0xbd, 0xce, 0x99, 0x4d, 0x30, 0x93, 0x54, 0x55, 0xa8, 0x47, 0x73, 0x69, 0xd4, 0xaa, 0xfa,
+	0x1c, 0xd4, 0x22, 0xc9, 0x32, 0xf0, 0xfd, 0x92, 0xc1, 0xef, 0xa7, 0xd6, 0xa0, 0x12, 0xca, 0x8c,
+	0xea, 0x65, 0x58, 0x9d, 0x97, 0xe8, 0xd4, 0x5f, 0x26, 0x61, 0x75, 0x5e, 0xc6, 0x42, 0xaf, 0x40,
+	0xc1, 0x4f, 0x75, 0x32, 0xba, 0x45, 0x97, 0x21, 0x85, 0xb1, 0x2f, 0x4a, 0xcf, 0x21, 0xdd, 0xd7,
+	0x6c, 0x43, 0xa4, 0xd8, 0xcc, 0xf3, 0xba, 0x6d, 0xb7, 0xe9, 0x9e, 0x58, 0x87, 0x92, 0x6e, 0xcf,
+	0x84, 0x13, 0xdd, 0xf6, 0xc3, 0xc9, 0x07, 0xd0, 0x88, 0xcb, 0x73, 0x91, 0x85, 0x66, 0xfc, 0x8d,
+	0x7a, 0x19, 0x72, 0xc7, 0x96, 0x33, 0xd6, 0x79, 0xd4, 0xac, 0x60, 0x41, 0xd1, 0x0d, 0xcc, 0x73,
+	0x5e, 0x9a, 0xb1, 0x39, 0xa1, 0x6a, 0x70, 0x35, 0x36, 0xd7, 0x51, 0x15, 0xc3, 0xec, 0x13, 0xee,
+	0xf1, 0x0a, 0xe6, 0xc4, 0xd4, 0x10, 0x5f, 0x0d, 0x27, 0xe8, 0xb0, 0x2e, 0x73, 0x06, 0xb3, 0x5f,
+	0xc4, 0x82, 0x52, 0xff, 0x9a, 0x84, 0xcb, 0xf3, 0x33, 0x1e, 0x7a, 0x05, 0x80, 0x87, 0x5c, 0xff,
+	0x60, 0x96, 0xb6, 0x2e, 0xcf, 0x1e, 0x8b, 0x9b, 0xba, 0xa7, 0xe3, 0x22, 0x93, 0xa4, 0x8f, 0x34,
+	0x50, 0x4c, 0xd5, 0x34, 0xd7, 0x78, 0xc0, 0x77, 0x55, 0x1a, 0x57, 0x7c, 0x99, 0x23, 0xe3, 0x41,
+	0x38, 0x00, 0xa6, 0xc3, 0x01, 0x70, 0xea, 0xbb, 0x4c, 0xe8, 0x90, 0xcb, 0x68, 0x9b, 0xbd, 0x68,
+	0xb4, 0x55, 0x7f, 0x11, 0x5c, 0x66, 0x28, 0xdf, 0x06, 0x4e, 0x7e, 0xf2, 0x42, 0x27, 0x3f, 0xec,
+	0x9e, 0xd4, 0x92, 0xee, 0x51, 0x7f, 0x05, 0x50, 0xc0, 0xc4, 0xb5, 0x2d, 0xd3, 0x25, 0x68, 0x07,
+	0x8a, 0xe4, 0xb4, 0x47, 0x78, 0xd9, 0x9f, 0x8c, 0x2d, 0x9b, 0xb9, 0x74, 0x4b, 0x4a, 0xd2, 0x9a,
+	0xd5, 0x57, 0x43, 0x2f, 0x0b, 0x68, 0x13, 0x8f, 0x52, 0x84, 0x7a, 0x10, 0xdb, 0xbc, 0x2a, 0xb1,
+	0x4d, 0x3a, 0xb6, 0x4c, 0xe5, 0x5a, 0x11, 0x70, 0xf3, 0xb2, 0x00, 0x37, 0x99, 0x05, 0x83, 0x85,
+	0xd0, 0x4d, 0x33, 0x84, 0x6e, 0xb2, 0x0b, 0x96, 0x19, 0x03, 0x6f, 0x9a, 0x21, 0x78, 0x93, 0x5b,
+	0x60, 0x24, 0x06, 0xdf, 0xbc, 0x2a, 0xf1, 0x4d, 0x7e, 0xc1, 0xb2, 0x23, 0x00, 0xe7, 0x56, 0x18,
+	0xe0, 0x70, 0x70, 0xf2, 0x64, 0xac, 0x76, 0x2c, 0xc2, 0xf9, 0x6e, 0x00, 0xe1, 0x14, 0x63, 0xe1,
+	0x05, 0x37, 0x32, 0x07, 0xe2, 0x34, 0x43, 0x10, 0x07, 0x16, 0xf8, 0x20, 0x06, 0xe3, 0xbc, 0x15,
+	0xc4, 0x38, 0xa5, 0x58, 0x98, 0x24, 0x36, 0xcd, 0x3c, 0x90, 0xf3, 0xba, 0x0f, 0x72, 0xca, 0xb1,
+	0x28, 0x4d, 0xac, 0x21, 0x8a, 0x72, 0x0e, 0x66, 0x50, 0x0e, 0x47, 0x25, 0x4f, 0xc7, 0x9a, 0x58,
+	0x00, 0x73, 0x0e, 0x66, 0x60, 0x4e, 0x75, 0x81, 0xc1, 0x05, 0x38, 0xe7, 0x27, 0xf3, 0x71, 0x4e,
+	0x3c, 0x12, 0x11, 0xd3, 0x5c, 0x0e, 0xe8, 0x68, 0x31, 0x40, 0x87, 0x83, 0x91, 0xe7, 0x63, 0xcd,
+	0x2f, 0x8d, 0x74, 0xee, 0xcc, 0x41, 0x3a, 0x1c, 0x93, 0x3c, 0x1b, 0x6b, 0x7c, 0x09, 0xa8, 0x73,
+	0x67, 0x0e, 0xd4, 0x41, 0x0b, 0xcd, 0x2e, 0x8f, 0x75, 0x9e, 0x83, 0x15, 0xa9, 0xe6, 0x87, 0x39,
+	0x9a, 0xc9, 0x88, 0xe3, 0x58, 0x8e, 0xa8, 0xcd, 0x39, 0xa1, 0x3e, 0x0b, 0x65, 0x5f, 0xf4, 0x7c,
+	0x5c, 0xc4, 0x6a, 0x8a, 0x40, 0x18, 0x53, 0xff, 0x99, 0x84, 0x72, 0x30, 0x42, 0x85, 0xaa, 0xce,
+	0xa2, 0xa8, 0x3a, 0x03, 0x18, 0x24, 0x15, 0xc6, 0x20, 0x8b, 0xea, 0x01, 0x74, 0x03, 0x56, 0x58,
+	0x31, 0xc8, 0xf3, 0x42, 0x28, 0x85, 0xd5, 0xe8, 0x0b, 0x7e, 0x94, 0x18, 0x1b, 0xbd, 0x08, 0x97,
+	0x02, 0xb2, 0x7e, 0x09, 0xc2, 0x6b, 0xea, 0xba, 0x2f, 0xbd, 0x2d, 0x6a, 0x91, 0x37, 0xa1, 0x20,
+	0x90, 0x93, 0x1b, 0xdb, 0x9c, 0x09, 0x62, 0x3e, 0x91, 0xac, 0x7c, 0x25, 0xf5, 0x5d, 0x58, 0x99,
+	0x89, 0xb0, 0x74, 0xfd, 0x3d, 0xab, 0x4f, 0x44, 0x01, 0xc1, 0x9e, 0x29, 0x1e, 0x1a, 0x59, 0x03,
+	0x91, 0x92, 0xe9, 0x23, 0x95, 0xf2, 0x83, 0x7e, 0x91, 0xc7, 0x74, 0x51, 0x59, 0x47, 0x82, 0xed,
+	0x5c, 0xe4, 0x92, 0xfc, 0xef, 0x20, 0x97, 0xd4, 0x43, 0x23, 0x97, 0x60, 0x85, 0x97, 0x0e, 0x57,
+	0x78, 0x41, 0xaf, 0x66, 0x1e, 0xc6, 0xab, 0x7f, 0x4f, 0x42, 0x25, 0x94, 0x33, 0x1e, 0xde, 0xa5,
+	0xd3, 0x72, 0x2e, 0xcb, 0x76, 0x0c, 0x27, 0x24, 0x3c, 0xcd, 0xb1, 0x89, 0x87, 0xe1, 0x69, 0x9e,
+	0xf1, 0x38, 0x81, 0x5e, 0x83, 0x22, 0x6b, 0xd7, 0x6a, 0x96, 0xed, 0x8a, 0x04, 0xf5, 0x78, 0x70,
+	0x2d, 0xbc, 0x2b, 0xbb, 0x71, 0x48, 0x65, 0x0e, 0x6c, 0x17, 0x17, 0x6c, 0xf1, 0x14, 0xa8, 0xb6,
+	0x8a, 0xa1, 0x6a, 0xeb, 0x1a, 0x14, 0xe9, 0xec, 0x5d, 0x5b, 0xef, 0x11, 0x96, 0x6c, 0x8a, 0x78,
+	0xca, 0x50, 0xef, 0x01, 0x9a, 0x4d, 0x77, 0xa8, 0x0d, 0x39, 0x72, 0x42, 0x4c, 0x8f, 0x7e, 0xf6,
+	0x74, 0xb4, 0x20, 0x12, 0x78, 0x85, 0x98, 0xde, 0x4e, 0x83, 0xfa, 0xf1, 0x6f, 0x5f, 0xad, 0xd7,
+	0xb9, 0xf4, 0x0b, 0xd6, 0xd8, 0xf0, 0xc8, 0xd8, 0xf6, 0xce, 0xb0, 0xd0, 0x57, 0xff, 0x91, 0x82,
+	0x9a, 0x1c, 0x40, 0xa2, 0x96, 0x79, 0xbe, 0x95, 0x47, 0x38, 0x15, 0x00, 0x8e, 0xcb, 0xf9, 0x7b,
+	0x0d, 0x60, 0xa0, 0xbb, 0xda, 0xc7, 0xba, 0xe9, 0x91, 0xbe, 0x70, 0x7a, 0x80, 0x83, 0x14, 0x28,
+	0x50, 0x6a, 0xe2, 0x92, 0xbe, 0xc0, 0xb0, 0x3e, 0x1d, 0x58, 0x67, 0xfe, 0xd1, 0xd6, 0x19, 0xf6,
+	0x72, 0x21, 0xe2, 0xe5, 0x40, 0xd9, 0x5e, 0x0c, 0x96, 0xed, 0x74, 0x6e, 0xb6, 0x63, 0x58, 0x8e,
+	0xe1, 0x9d, 0xb1, 0x4f, 0x93, 0xc6, 0x3e, 0x4d, 0x5b, 0x25, 0x63, 0x32, 0xb6, 0x2d, 0x6b, 0xa4,
+	0xf1, 0xf0, 0x59, 0x62, 0xaa, 0x65, 0xc1, 0x6c, 0x51, 0x1e, 0x35, 0xe0, 0xd2, 0x7a, 0xd8, 0xec,
+	0x11, 0x96, 0xc5, 0x33, 0xd8, 0xa7, 0xd5, 0x9f, 0x07, 0xce, 0xf6, 0x14, 0xdc, 0xfd, 0xcf, 0x39,
+	0x5f, 0xfd, 0x03, 0xeb, 0xf8, 0x84, 0x0b, 0x21, 0x74, 0x04, 0x2b, 0x7e, 0x6c, 0xd1, 0x26, 0x2c,
+	0xe6, 0xc8, 0xcd, 0xbe, 0x6c, 0x70, 0xaa, 0x9f, 0x84, 0xd9, 0x2e, 0xfa, 0x01, 0x5c, 0x89, 0xc4,
+	0x4d, 0xdf, 0x74, 0x6a, 0xc9, 0xf0, 0xf9, 0x58, 0x38, 0x7c, 0x4a, 0xcb, 0x53, 0x5f, 0xa5, 0x1f,
+	0xd1, 0x57, 0x8f, 0x1c, 0x2b, 0x77, 0xa1, 0x2a, 0xbd, 0xc9, 0xeb, 0xc2, 0xb9, 0xdb, 0xe7, 0x49,
+	0xa8, 0x38, 0xc4, 0xa3, 0xb8, 0x30, 0xd4, 0xe7, 0x29, 0x73, 0xa6, 0xe8, 0x1e, 0x1d, 0xc2, 0x63,
+	0x73, 0xeb, 0x43, 0xf4, 0x2d, 0x28, 0x4e, 0x4b, 0xcb, 0x64, 0x4c, 0xcb, 0x44, 0x8a, 0xe3, 0xa9,
+	0xac, 0xfa, 0xbb, 0x24, 0x3c, 0x36, 0xb7, 0x42, 0x44, 0x2d, 0xc8, 0x39, 0xc4, 0x9d, 0x8c, 0x38,
+	0x90, 0xaf, 0x6e, 0xbd, 0xb8, 0x5c, 0x65, 0x49, 0xb9, 0x93, 0x91, 0x87, 0x85, 0xb2, 0x7a, 0x0f,
+	0x72, 0x9c, 0x83, 0x4a, 0x90, 0xbf, 0xb3, 0x7f, 0x7b, 0xff, 0xe0, 0xbd, 0xfd, 0x7a, 0x02, 0x01,
+	0xe4, 0xb6, 0x9b, 0xcd, 0xd6, 0x61, 0xa7, 0x9e, 0x44, 0x45, 0xc8, 0x6e, 0xef, 0x1c, 0xe0, 0x4e,
+	0x3d, 0x45, 0xd9, 0xb8, 0xf5, 0x4e, 0xab, 0xd9, 0xa9, 0xa7, 0xd1, 0x0a, 0x54, 0xf8, 0xb3, 0x76,
+	0xeb, 0x00, 0xbf, 0xbb, 0xdd, 0xa9, 0x67, 0x02, 0xac, 0xa3, 0xd6, 0xfe, 0xcd, 0x16, 0xae, 0x67,
+	0xd5, 0x97, 0xe0, 0xaa, 0x9c, 0xc7, 0x6c, 0x33, 0xc2, 0xef, 0x09, 0x24, 0x03, 0x3d, 0x01, 0xf5,
+	0xb3, 0x14, 0x28, 0xf1, 0x05, 0x26, 0x7a, 0x27, 0xb2, 0xf0, 0xad, 0x0b, 0x54, 0xa7, 0x91, 0xd5,
+	0xd3, 0xae, 0xa0, 0x43, 0x8e, 0x89, 0xd7, 0x1b, 0xf2, 0x82, 0x97, 0xe7, 0xf3, 0x0a, 0xae, 0x08,
+	0x2e, 0x53, 0x72, 0xb9, 0xd8, 0x87, 0xa4, 0xe7, 0x69, 0x3c, 0xce, 0xf1, 0x5d, 0x5b, 0xc4, 0x15,
+	0xce, 0x3d, 0xe2, 0x4c, 0xf5, 0x83, 0x0b, 0xf9, 0xb2, 0x08, 0x59, 0xdc, 0xea, 0xe0, 0x1f, 0xd6,
+	0xd3, 0x08, 0x41, 0x95, 0x3d, 0x6a, 0x47, 0xfb, 0xdb, 0x87, 0x47, 0xed, 0x03, 0xea, 0xcb, 0x4b,
+	0x50, 0x93, 0xbe, 0x94, 0xcc, 0xac, 0x7a, 0x08, 0x57, 0x62, 0xaa, 0xe3, 0x87, 0x6c, 0x8b, 0xa8,
+	0xbf, 0x49, 0x06, 0x4d, 0x86, 0x5b, 0x10, 0x6f, 0x47, 0x3c, 0xbd, 0xb9, 0x6c, 0x4d, 0x1d, 0x75,
+	0xb3, 0x02, 0x05, 0x22, 0xfa, 0x81, 0xcc, 0xc1, 0x65, 0xec, 0xd3, 0xea, 0x8b, 0x8b, 0x9d, 0x36,
+	0xdd, 0x75, 0x29, 0xf5, 0xdf, 0x49, 0xa8, 0x45, 0x62, 0x0c, 0xda, 0x82, 0x2c, 0x87, 0x8d, 0x71,
+	0xbf, 0x51, 0x59, 0x88, 0xe4, 0xc2, 0x38, 0xdb, 0x95, 0x3f, 0xf5, 0x02, 0x53, 0x9a, 0x89, 0x65,
+	0xdc, 0x59, 0xb2, 0x89, 0x29, 0x54, 0x7d, 0x0d, 0xfa, 0x43, 0xce, 0x0f, 0x96, 0x8d, 0xf4, 0x2c,
+	0x58, 0xe5, 0xea, 0x7e, 0x98, 0x15, 0xfa, 0x53, 0x1d, 0xf4, 0xfa, 0xb4, 0x9a, 0xcf, 0xcc, 0x82,
+	0x55, 0xa1, 0xce, 0x05, 0x84, 0xb2, 0x94, 0x57, 0x9b, 0x50, 0x0a, 0xac, 0x07, 0x3d, 0x0e, 0xc5,
+	0xb1, 0x7e, 0x2a, 0x5a, 0xdf, 0xbc, 0x79, 0x59, 0x18, 0xeb, 0xa7, 0xbc, 0xeb, 0x7d, 0x05, 0xf2,
+	0xf4, 0xe5, 0x40, 0x77, 0x45, 0xb3, 0x2b, 0x37, 0xd6, 0x4f, 0xdf, 0xd6, 0x5d, 0xf5, 0x7d, 0xa8,
+	0x86, 0xdb, 0xbe, 0xf4, 0x2c, 0x3a, 0xd6, 0xc4, 0xec, 0x33, 0x1b, 0x59, 0xcc, 0x09, 0xfa, 0xe7,
+	0xf5, 0xc4, 0xe2, 0xf1, 0x7e, 0x7e, 0xd0, 0xba, 0x6b, 0x79, 0x24, 0x10, 0x56, 0xb9, 0xb4, 0xfa,
+	0x00, 0xb2, 0x2c, 0x7e, 0xd3, 0x50, 0xca, 0x1a, 0xb8, 0x02, 0xc9, 0xd0, 0x67, 0xf4, 0x3e, 0x80,
+	0xee, 0x79, 0x8e, 0xd1, 0x9d, 0x4c, 0x0d, 0xaf, 0xcf, 0x8f, 0xff, 0xdb, 0x52, 0x6e, 0xe7, 0x9a,
+	0x48, 0x04, 0xab, 0x53, 0xd5, 0x40, 0x32, 0x08, 0x18, 0x54, 0xf7, 0xa1, 0x1a, 0xd6, 0x0d, 0xfe,
+	0x4a, 0x29, 0xcf, 0xf9, 0x95, 0xe2, 0xd7, 0xaa, 0x7e, 0xa5, 0x9b, 0xe6, 0xcd, 0x7a, 0x46, 0xa8,
+	0x9f, 0x24, 0xa1, 0xd0, 0x39, 0x15, 0x7b, 0x34, 0xa6, 0x4f, 0x3c, 0x55, 0x4d, 0x05, 0x7b, 0x9e,
+	0xbc, 0xf1, 0x9c, 0xf6, 0xdb, 0xd9, 0x6f, 0xf9, 0x07, 0x2a, 0xb3, 0x6c, 0x93, 0x44, 0x76, 0xf7,
+	0x44, 0xb8, 0x7e, 0x03, 0x8a, 0xfe, 0xae, 0xa2, 0x90, 0x50, 0xef, 0xf7, 0x1d, 0xe2, 0xba, 0x62,
+	0x6d, 0x92, 0xa4, 0xd3, 0xb1, 0xad, 0x8f, 0x45, 0x57, 0x35, 0x8d, 0x39, 0xa1, 0xf6, 0xa1, 0x16,
+	0xc9, 0xfc, 0xe8, 0x0d, 0xc8, 0xdb, 0x93, 0xae, 0x26, 0xdd, 0x13, 0x39, 0x3c, 0xb2, 0x38, 0x9f,
+	0x74, 0x47, 0x46, 0xef, 0x36, 0x39, 0x93, 0x93, 0xb1, 0x27, 0xdd, 0xdb, 0xdc, 0x8b, 0x7c, 0x94,
+	0x54, 0x70, 0x94, 0x13, 0x28, 0xc8, 0x4d, 0x81, 0xbe, 0x17, 0x3c, 0x27, 0xf2, 0x67, 0x54, 0x6c,
+	0x35, 0x22, 0xcc, 0x07, 0x8e, 0xc9, 0x0d, 0x58, 0x71, 0x8d, 0x81, 0x49, 0xfa, 0xda, 0x14, 0x94,
+	0xb2, 0xd1, 0x0a, 0xb8, 0xc6, 0x5f, 0xec, 0x49, 0x44, 0xaa, 0xfe, 0x2b, 0x09, 0x05, 0x79, 0x60,
+	0xd1, 0x4b, 0x81, 0x7d, 0x57, 0x9d, 0x53, 0x11, 0x48, 0xc1, 0xe9, 0x9f, 0x83, 0xf0, 0x5c, 0x53,
+	0x17, 0x9f, 0x6b, 0xdc, 0x2f, 0x20, 0xd9, 0x1d, 0xce, 0x5c, 0xf8, 0x5f, 0xdc, 0x0b, 0x80, 0x3c,
+	0xcb, 0xd3, 0x47, 0xda, 0x89, 0xe5, 0x19, 0xe6, 0x40, 0xe3, 0xce, 0xe6, 0x45, 0x69, 0x9d, 0xbd,
+	0xb9, 0xcb, 0x5e, 0x1c, 0x32, 0xbf, 0xff, 0x34, 0x09, 0x05, 0xbf, 0x3a, 0xb8, 0x68, 0x9b, 0xff,
+	0x32, 0xe4, 0x44, 0x02, 0xe4, 0x7d, 0x7e, 0x41, 0xf9, 0xff, 0xa4, 0x32, 0x81, 0x7f, 0x52, 0x0a,
+	0x14, 0xc6, 0xc4, 0xd3, 0x59, 0x9e, 0xe1, 0x7d, 0x01, 0x9f, 0xbe, 0xf1, 0x3a, 0x94, 0x02, 0xff,
+	0x64, 0xe8, 0xc9, 0xdb, 0x6f, 0xbd, 0x57, 0x4f, 0x28, 0xf9, 0x4f, 0x3e, 0xbf, 0x9e, 0xde, 0x27,
+	0x1f, 0xd3, 0x3d, 0x8b, 0x5b, 0xcd, 0x76, 0xab, 0x79, 0xbb, 0x9e, 0x54, 0x4a, 0x9f, 0x7c, 0x7e,
+	0x3d, 0x8f, 0x09, 0xeb, 0x23, 0xde, 0x68, 0x43, 0x39, 0xf8, 0x55, 0xc2, 0xe9, 0x00, 0x41, 0xf5,
+	0xe6, 0x9d, 0xc3, 0xbd, 0xdd, 0xe6, 0x76, 0xa7, 0xa5, 0xdd, 0x3d, 0xe8, 0xb4, 0xea, 0x49, 0x74,
+	0x05, 0x2e, 0xed, 0xed, 0xbe, 0xdd, 0xee, 0x68, 0xcd, 0xbd, 0xdd, 0xd6, 0x7e, 0x47, 0xdb, 0xee,
+	0x74, 0xb6, 0x9b, 0xb7, 0xeb, 0xa9, 0xad, 0x3f, 0x96, 0xa0, 0xb6, 0xbd, 0xd3, 0xdc, 0xa5, 0xf9,
+	0xdf, 0xe8, 0xe9, 0xa2, 0x4f, 0x9b, 0x61, 0x6d, 0x99, 0x73, 0xef, 0xd8, 0x28, 0xe7, 0xb7, 0xa9,
+	0xd1, 0x2d, 0xc8, 0xb2, 0x8e, 0x0d, 0x3a, 0xff, 0xd2, 0x8d, 0xb2, 0xa0, 0x6f, 0x4d, 0x27, 0xc3,
+	0x8e, 0xc7, 0xb9, 0xb7, 0x70, 0x94, 0xf3, 0xdb, 0xd8, 0x08, 0x43, 0x71, 0xda, 0x31, 0x59, 0x7c,
+	0x2b, 0x47, 0x59, 0xa2, 0xb5, 0x4d, 0x6d, 0x4e, 0x91, 0xd5, 0xe2, 0x5b, 0x2a, 0xca, 0x12, 0x01,
+	0x0c, 0xed, 0x41, 0x5e, 0x02, 0xe5, 0x45, 0xf7, 0x66, 0x94, 0x85, 0x6d, 0x67, 0xfa, 0x09, 0x78,
+	0x43, 0xe3, 0xfc, 0x4b, 0x40, 0xca, 0x82, 0x1e, 0x3a, 0xda, 0x85, 0x9c, 0xa8, 0xf6, 0x17, 0xdc,
+	0x85, 0x51, 0x16, 0xb5, 0x91, 0xa9, 0xd3, 0xa6, 0xad, 0xa6, 0xc5, 0x57, 0x9b, 0x94, 0x25, 0x7e,
+	0x0f, 0xa0, 0x3b, 0x00, 0x81, 0xf6, 0xc5, 0x12, 0x77, 0x96, 0x94, 0x65, 0xda, 0xfe, 0xe8, 0x00,
+	0x0a, 0x3e, 0x62, 0x5c, 0x78, 0x83, 0x48, 0x59, 0xdc, 0x7f, 0x47, 0xf7, 0xa0, 0x12, 0x46, 0x3a,
+	0xcb, 0xdd, 0x0b, 0x52, 0x96, 0x6c, 0xac, 0x53, 0xfb, 0x61, 0xd8, 0xb3, 0xdc, 0x3d, 0x21, 0x65,
+	0xc9, 0x3e, 0x3b, 0xfa, 0x10, 0x56, 0x66, 0x61, 0xc9, 0xf2, 0xd7, 0x86, 0x94, 0x0b, 0x74, 0xde,
+	0xd1, 0x18, 0xd0, 0x1c, 0x38, 0x73, 0x81, 0x5b, 0x44, 0xca, 0x45, 0x1a, 0xf1, 0xa8, 0x0f, 0xb5,
+	0x28, 0x46, 0x58, 0xf6, 0x56, 0x91, 0xb2, 0x74, 0x53, 0x9e, 0x8f, 0x12, 0x86, 0x0d, 0xcb, 0xde,
+	0x32, 0x52, 0x96, 0xee, 0xd1, 0xef, 0xb4, 0xbe, 0xf8, 0x7a, 0x2d, 0xf9, 0xe5, 0xd7, 0x6b, 0xc9,
+	0xbf, 0x7c, 0xbd, 0x96, 0xfc, 0xf4, 0x9b, 0xb5, 0xc4, 0x97, 0xdf, 0xac, 0x25, 0xfe, 0xf4, 0xcd,
+	0x5a, 0xe2, 0x47, 0xcf, 0x0f, 0x0c, 0x6f, 0x38, 0xe9, 0x6e, 0xf4, 0xac, 0xf1, 0x66, 0xf0, 0x3a,
+	0xe7, 0xbc, 0x2b, 0xa6, 0xdd, 0x1c, 0x4b, 0xba, 0x2f, 0xff, 0x67, 0x00, 0x9d, 0x02, 0x24, 0x48,
+	0x82, 0x2a, 0x00, 0x00,
 }
 
 // Reference imports to suppress errors if they are not otherwise used.
@@ -6421,6 +6433,11 @@ func (m *ResponseCheckTx) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	_ = i
 	var l int
 	_ = l
+	if m.Sequence != 0 {
+		i = encodeVarintTypes(dAtA, i, uint64(m.Sequence))
+		i--
+		dAtA[i] = 0x60
+	}
 	if len(m.MempoolError) > 0 {
 		i -= len(m.MempoolError)
 		copy(dAtA[i:], m.MempoolError)
@@ -8396,6 +8413,9 @@ func (m *ResponseCheckTx) Size() (n int) {
 	if l > 0 {
 		n += 1 + l + sovTypes(uint64(l))
 	}
+	if m.Sequence != 0 {
+		n += 1 + sovTypes(uint64(m.Sequence))
+	}
 	return n
 }
 
@@ -13700,6 +13720,25 @@ func (m *ResponseCheckTx) Unmarshal(dAtA []byte) error {
 			}
 			m.MempoolError = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
+		case 12:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Sequence", wireType)
+			}
+			m.Sequence = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Sequence |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
 		default:
 			iNdEx = preIndex
 			skippy, err := skipTypes(dAtA[iNdEx:])
@@ -4,7 +4,7 @@ Package Behaviour provides a mechanism for reactors to report behaviour of peers
 Instead of a reactor calling the switch directly it will call the behaviour module which will
 handle the stoping and marking peer as good on behalf of the reactor.
 
-There are four different behaviours a reactor can report.
+There are five different behaviours a reactor can report.
 
 1. bad message
 
@@ -36,6 +36,14 @@ There are four different behaviours a reactor can report.
 		explanation string
 	}
 
-This message will request the peer be marked as good
+This message will request the peer be marked as good.
+
+5. slow response
+
+	type slowResponse struct {
+		explanation string
+	}
+
+This message will request the peer be marked as bad, lowering its trust score.
 */
 package behaviour
@@ -47,3 +47,12 @@ type blockPart struct {
 func BlockPart(peerID p2p.ID, explanation string) PeerBehaviour {
 	return PeerBehaviour{peerID: peerID, reason: blockPart{explanation}}
 }
+
+type slowResponse struct {
+	explanation string
+}
+
+// SlowResponse returns slowResponse PeerBehaviour.
+func SlowResponse(peerID p2p.ID, explanation string) PeerBehaviour {
+	return PeerBehaviour{peerID: peerID, reason: slowResponse{explanation}}
+}
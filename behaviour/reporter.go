@@ -39,6 +39,8 @@ func (spbr *SwitchReporter) Report(behaviour PeerBehaviour) error {
 		spbr.sw.StopPeerForError(peer, reason.explanation)
 	case messageOutOfOrder:
 		spbr.sw.StopPeerForError(peer, reason.explanation)
+	case slowResponse:
+		spbr.sw.MarkPeerAsBad(peer, reason.explanation)
 	default:
 		return errors.New("unknown reason reported")
 	}
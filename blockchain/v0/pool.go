@@ -203,9 +203,10 @@ func (pool *BlockPool) PeekTwoBlocks() (first *types.Block, second *types.Block)
 	return
 }
 
-// PopRequest pops the first block at pool.height.
+// PopRequest pops the first block at pool.height, and returns the ID of the
+// peer which sent it.
 // It must have been validated by 'second'.Commit from PeekTwoBlocks().
-func (pool *BlockPool) PopRequest() {
+func (pool *BlockPool) PopRequest() p2p.ID {
 	pool.mtx.Lock()
 	defer pool.mtx.Unlock()
 
@@ -220,9 +221,9 @@ func (pool *BlockPool) PopRequest() {
 		}
 		delete(pool.requesters, pool.height)
 		pool.height++
-	} else {
-		panic(fmt.Sprintf("Expected requester to pop, got nothing at height %v", pool.height))
+		return r.getPeerID()
 	}
+	panic(fmt.Sprintf("Expected requester to pop, got nothing at height %v", pool.height))
 }
 
 // RedoRequest invalidates the block at pool.height,
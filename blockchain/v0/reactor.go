@@ -10,6 +10,8 @@ import (
 	bc "github.com/tendermint/tendermint/blockchain"
 	"github.com/tendermint/tendermint/libs/log"
 	"github.com/tendermint/tendermint/p2p"
+	"github.com/tendermint/tendermint/pkg/trace"
+	"github.com/tendermint/tendermint/pkg/trace/schema"
 	bcproto "github.com/tendermint/tendermint/proto/tendermint/blockchain"
 	sm "github.com/tendermint/tendermint/state"
 	"github.com/tendermint/tendermint/store"
@@ -61,11 +63,22 @@ type BlockchainReactor struct {
 
 	requestsCh <-chan BlockRequest
 	errorsCh   <-chan peerError
+
+	traceClient trace.Tracer
+}
+
+// ReactorOption sets an optional parameter on the BlockchainReactor.
+type ReactorOption func(*BlockchainReactor)
+
+// WithTraceClient sets the tracer used to trace the block requests and
+// responses.
+func WithTraceClient(traceClient trace.Tracer) ReactorOption {
+	return func(bcR *BlockchainReactor) { bcR.traceClient = traceClient }
 }
 
 // NewBlockchainReactor returns new reactor instance.
 func NewBlockchainReactor(state sm.State, blockExec *sm.BlockExecutor, store *store.BlockStore,
-	fastSync bool) *BlockchainReactor {
+	fastSync bool, options ...ReactorOption) *BlockchainReactor {
 
 	if state.LastBlockHeight != store.Height() {
 		panic(fmt.Sprintf("state (%v) and store (%v) height mismatch", state.LastBlockHeight,
@@ -91,6 +104,10 @@ func NewBlockchainReactor(state sm.State, blockExec *sm.BlockExecutor, store *st
 		fastSync:     fastSync,
 		requestsCh:   requestsCh,
 		errorsCh:     errorsCh,
+		traceClient:  trace.NoOpTracer(),
+	}
+	for _, option := range options {
+		option(bcR)
 	}
 	bcR.BaseReactor = *p2p.NewBaseReactor("BlockchainReactor", bcR)
 	return bcR
@@ -183,16 +200,26 @@ func (bcR *BlockchainReactor) respondToPeer(msg *bcproto.BlockRequest,
 			bcR.Logger.Error("could not convert msg to protobuf", "err", err)
 			return false
 		}
-		return p2p.TrySendEnvelopeShim(src, p2p.Envelope{ //nolint: staticcheck
+		queued = p2p.TrySendEnvelopeShim(src, p2p.Envelope{ //nolint: staticcheck
 			ChannelID: BlockchainChannel,
 			Message:   &bcproto.BlockResponse{Block: bl},
 		}, bcR.Logger)
+		if queued {
+			schema.WriteBlockSync(bcR.traceClient, msg.Height, string(src.ID()),
+				schema.BlockSyncResponse, bl.Size(), schema.Upload)
+		}
+		return queued
 	}
 
-	return p2p.TrySendEnvelopeShim(src, p2p.Envelope{ //nolint: staticcheck
+	queued = p2p.TrySendEnvelopeShim(src, p2p.Envelope{ //nolint: staticcheck
 		ChannelID: BlockchainChannel,
 		Message:   &bcproto.NoBlockResponse{Height: msg.Height},
 	}, bcR.Logger)
+	if queued {
+		schema.WriteBlockSync(bcR.traceClient, msg.Height, string(src.ID()),
+			schema.BlockSyncNoBlockResponse, 0, schema.Upload)
+	}
+	return queued
 }
 
 func (bcR *BlockchainReactor) ReceiveEnvelope(e p2p.Envelope) {
@@ -206,8 +233,12 @@ func (bcR *BlockchainReactor) ReceiveEnvelope(e p2p.Envelope) {
 
 	switch msg := e.Message.(type) {
 	case *bcproto.BlockRequest:
+		schema.WriteBlockSync(bcR.traceClient, msg.Height, string(e.Src.ID()),
+			schema.BlockSyncRequest, 0, schema.Download)
 		bcR.respondToPeer(msg, e.Src)
 	case *bcproto.BlockResponse:
+		schema.WriteBlockSync(bcR.traceClient, msg.Block.Header.Height, string(e.Src.ID()),
+			schema.BlockSyncResponse, msg.Block.Size(), schema.Download)
 		bi, err := types.BlockFromProto(msg.Block)
 		if err != nil {
 			bcR.Logger.Error("Block content is invalid", "err", err)
@@ -227,6 +258,8 @@ func (bcR *BlockchainReactor) ReceiveEnvelope(e p2p.Envelope) {
 		// Got a peer status. Unverified.
 		bcR.pool.SetPeerRange(e.Src.ID(), msg.Base, msg.Height)
 	case *bcproto.NoBlockResponse:
+		schema.WriteBlockSync(bcR.traceClient, msg.Height, string(e.Src.ID()),
+			schema.BlockSyncNoBlockResponse, 0, schema.Download)
 		bcR.Logger.Debug("Peer does not have requested block", "peer", e.Src, "height", msg.Height)
 	default:
 		bcR.Logger.Error(fmt.Sprintf("Unknown message type %v", reflect.TypeOf(msg)))
@@ -291,7 +324,10 @@ func (bcR *BlockchainReactor) poolRoutine(stateSynced bool) {
 				}, bcR.Logger)
 				if !queued {
 					bcR.Logger.Debug("Send queue is full, drop block request", "peer", peer.ID(), "height", request.Height)
+					continue
 				}
+				schema.WriteBlockSync(bcR.traceClient, request.Height, string(peer.ID()),
+					schema.BlockSyncRequest, 0, schema.Upload)
 			case err := <-bcR.errorsCh:
 				peer := bcR.Switch.Peers().Get(err.peerID)
 				if peer != nil {
@@ -405,7 +441,10 @@ FOR_LOOP:
 				continue FOR_LOOP
 			}
 
-			bcR.pool.PopRequest()
+			// the peer sent us a valid block
+			if peer := bcR.Switch.Peers().Get(bcR.pool.PopRequest()); peer != nil {
+				bcR.Switch.MarkPeerAsUseful(peer)
+			}
 
 			// TODO: batch saves so we dont persist to disk every block
 			bcR.store.SaveBlock(first, firstParts, second.LastCommit)
@@ -0,0 +1,85 @@
+package commands
+
+import (
+	"fmt"
+	"path/filepath"
+
+	"github.com/spf13/cobra"
+
+	cfg "github.com/tendermint/tendermint/config"
+	mempl "github.com/tendermint/tendermint/mempool"
+)
+
+var mempoolVersion string
+
+// MempoolCmd contains the tools for the mempool of a node.
+var MempoolCmd = &cobra.Command{
+	Use:   "mempool",
+	Short: "Tools for the mempool of a node",
+}
+
+var mempoolMigrateCmd = &cobra.Command{
+	Use:   "migrate",
+	Short: "Switch the mempool of a stopped node to another version, keeping its txs",
+	Long: `Switch the mempool of a stopped node to another version, keeping its txs.
+
+The mempool version of the config file is set to the one given. The pending
+txs are carried over to the new mempool as follows:
+
+  1. While the node runs, call the unsafe_export_mempool RPC endpoint. It
+     writes the txs of the mempool, in order of arrival, to data/mempool.export.
+  2. Stop the node and run this command.
+  3. Start the node. The exported txs are checked into the new mempool in order
+     of arrival before the node joins consensus, and the export is removed.
+
+If the mempool WAL is enabled (wal_dir), its txs are replayed into the new
+mempool on startup as well, including the ones received after the export.`,
+	Example: `
+	cometbft mempool migrate --version v2
+	`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		exported, err := MigrateMempool(config, mempoolVersion)
+		if err != nil {
+			return fmt.Errorf("failed to migrate mempool: %w", err)
+		}
+
+		fmt.Printf("Set the mempool version to %s, %d exported txs to import on startup\n",
+			mempoolVersion, exported)
+		if exported == 0 && !config.Mempool.WalEnabled() {
+			fmt.Println("No pending txs are carried over: neither an export nor the mempool WAL was found")
+		}
+		return nil
+	},
+}
+
+func init() {
+	mempoolMigrateCmd.Flags().StringVar(&mempoolVersion, "version", "",
+		fmt.Sprintf("mempool version to migrate to: %s, %s or %s", cfg.MempoolV0, cfg.MempoolV1, cfg.MempoolV2))
+	_ = mempoolMigrateCmd.MarkFlagRequired("version")
+	MempoolCmd.AddCommand(mempoolMigrateCmd)
+}
+
+// MigrateMempool sets the mempool version of the config file of the node to
+// version, and returns the number of txs exported from the mempool that will
+// be imported into the new one on startup.
+func MigrateMempool(config *cfg.Config, version string) (int, error) {
+	switch version {
+	case cfg.MempoolV0, cfg.MempoolV1, cfg.MempoolV2:
+	default:
+		return 0, fmt.Errorf("unknown mempool version %q, options are %s, %s and %s",
+			version, cfg.MempoolV0, cfg.MempoolV1, cfg.MempoolV2)
+	}
+
+	txs, err := mempl.ReadWAL(config.Mempool.ExportDir())
+	if err != nil {
+		return 0, err
+	}
+
+	config.Mempool.Version = version
+	if err := config.ValidateBasic(); err != nil {
+		return 0, fmt.Errorf("error in config file: %w", err)
+	}
+	cfg.WriteConfigFile(filepath.Join(config.RootDir, "config", "config.toml"), config)
+	return len(txs), nil
+}
@@ -0,0 +1,124 @@
+package commands
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	abcitypes "github.com/tendermint/tendermint/abci/types"
+	"github.com/tendermint/tendermint/libs/progressbar"
+	"github.com/tendermint/tendermint/state"
+)
+
+const (
+	rebuildTxInfoFailed = "tx info rebuild failed: "
+)
+
+// RebuildTxInfoCmd constructs a command to rebuild the tx info returned by
+// the tx_status RPC endpoint in a block height interval.
+var RebuildTxInfoCmd = &cobra.Command{
+	Use:     "rebuild-tx-info",
+	Aliases: []string{"rebuild_tx_info"},
+	Short:   "Rebuild the tx info of the tx_status endpoint from the stored blocks",
+	Long: `
+rebuild-tx-info is an offline tooling to rebuild the tx info returned by the tx_status
+RPC endpoint from the blocks of the blockstore and their ABCI responses. You can run
+this command to backfill the tx info of blocks committed before it was saved, or to
+index tx info saved by older versions so that it is pruned by tx_info_retain_blocks.
+The default start-height is 0, meaning the tooling will start from the base block
+height (inclusive); and the default end-height is 0, meaning the tooling will rebuild
+until the latest block height (inclusive). User can omit either or both arguments.
+
+Note: This operation requires ABCIResponses. Do not set DiscardABCIResponses to true if you
+want to use this command.
+	`,
+	Example: `
+	cometbft rebuild-tx-info
+	cometbft rebuild-tx-info --start-height 2
+	cometbft rebuild-tx-info --end-height 10
+	cometbft rebuild-tx-info --start-height 2 --end-height 10
+	`,
+	Run: func(cmd *cobra.Command, args []string) {
+		bs, ss, err := loadStateAndBlockStore(config)
+		if err != nil {
+			fmt.Println(rebuildTxInfoFailed, err)
+			return
+		}
+		defer bs.Close()
+		defer ss.Close()
+
+		if err := checkValidHeight(bs); err != nil {
+			fmt.Println(rebuildTxInfoFailed, err)
+			return
+		}
+
+		rArgs := txInfoRebuildArgs{
+			startHeight: startHeight,
+			endHeight:   endHeight,
+			blockStore:  bs,
+			stateStore:  ss,
+		}
+		if err := txInfoRebuild(cmd, rArgs); err != nil {
+			panic(fmt.Errorf("%s: %w", rebuildTxInfoFailed, err))
+		}
+
+		fmt.Println("tx info rebuild finished")
+	},
+}
+
+func init() {
+	RebuildTxInfoCmd.Flags().Int64Var(&startHeight, "start-height", 0, "the block height would like to start for rebuild")
+	RebuildTxInfoCmd.Flags().Int64Var(&endHeight, "end-height", 0, "the block height would like to finish for rebuild")
+}
+
+type txInfoRebuildArgs struct {
+	startHeight int64
+	endHeight   int64
+	blockStore  state.BlockStore
+	stateStore  state.Store
+}
+
+func txInfoRebuild(cmd *cobra.Command, args txInfoRebuildArgs) error {
+	var bar progressbar.Bar
+	bar.NewOption(args.startHeight-1, args.endHeight)
+
+	fmt.Println("start rebuilding tx info:")
+	defer bar.Finish()
+	for i := args.startHeight; i <= args.endHeight; i++ {
+		select {
+		case <-cmd.Context().Done():
+			return fmt.Errorf("tx info rebuild terminated at height %d: %w", i, cmd.Context().Err())
+		default:
+			b := args.blockStore.LoadBlock(i)
+			if b == nil {
+				return fmt.Errorf("not able to load block at height %d from the blockstore", i)
+			}
+
+			r, err := args.stateStore.LoadABCIResponses(i)
+			if err != nil {
+				return fmt.Errorf("not able to load ABCI Response at height %d from the statestore", i)
+			}
+			if len(r.DeliverTxs) != len(b.Txs) {
+				return fmt.Errorf("ABCI Response at height %d has %d tx results for %d txs",
+					i, len(r.DeliverTxs), len(b.Txs))
+			}
+
+			codes := make([]uint32, len(r.DeliverTxs))
+			logs := make([]string, len(r.DeliverTxs))
+			for j, res := range r.DeliverTxs {
+				codes[j] = res.Code
+				if res.Code != abcitypes.CodeTypeOK {
+					logs[j] = res.Log
+				}
+			}
+
+			if err := args.blockStore.SaveTxInfo(b, codes, logs); err != nil {
+				return fmt.Errorf("tx info rebuild at height %d failed: %w", i, err)
+			}
+		}
+
+		bar.Play(i)
+	}
+
+	return nil
+}
@@ -0,0 +1,80 @@
+package commands
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	abcitypes "github.com/tendermint/tendermint/abci/types"
+	protocmtstate "github.com/tendermint/tendermint/proto/tendermint/state"
+	"github.com/tendermint/tendermint/state/mocks"
+	"github.com/tendermint/tendermint/types"
+)
+
+func TestRebuildTxInfo(t *testing.T) {
+	mockBlockStore := &mocks.BlockStore{}
+	mockStateStore := &mocks.Store{}
+
+	block := &types.Block{Data: types.Data{Txs: types.Txs{make(types.Tx, 1), make(types.Tx, 2)}}}
+	mockBlockStore.
+		On("LoadBlock", base).Return(nil).Once().
+		On("LoadBlock", base).Return(block).
+		On("LoadBlock", height).Return(block)
+
+	abciResp := &protocmtstate.ABCIResponses{
+		DeliverTxs: []*abcitypes.ResponseDeliverTx{
+			{Code: abcitypes.CodeTypeOK, Log: "ok"},
+			{Code: 1, Log: "failure"},
+		},
+		EndBlock:   &abcitypes.ResponseEndBlock{},
+		BeginBlock: &abcitypes.ResponseBeginBlock{},
+	}
+	shortResp := &protocmtstate.ABCIResponses{
+		DeliverTxs: abciResp.DeliverTxs[:1],
+		EndBlock:   &abcitypes.ResponseEndBlock{},
+		BeginBlock: &abcitypes.ResponseBeginBlock{},
+	}
+
+	mockStateStore.
+		On("LoadABCIResponses", base).Return(nil, errors.New("")).Once().
+		On("LoadABCIResponses", base).Return(shortResp, nil).Once().
+		On("LoadABCIResponses", base).Return(abciResp, nil).
+		On("LoadABCIResponses", height).Return(abciResp, nil)
+
+	// logs are only kept for failed txs
+	mockBlockStore.
+		On("SaveTxInfo", block, []uint32{0, 1}, []string{"", "failure"}).Return(errors.New("")).Once().
+		On("SaveTxInfo", block, []uint32{0, 1}, []string{"", "failure"}).Return(nil)
+
+	testCases := []struct {
+		startHeight int64
+		endHeight   int64
+		rebuildErr  bool
+	}{
+		{base, height, true}, // LoadBlock error
+		{base, height, true}, // LoadABCIResponses error
+		{base, height, true}, // ABCIResponses mismatch
+		{base, height, true}, // SaveTxInfo error
+		{base, base, false},
+		{height, height, false},
+	}
+
+	for _, tc := range testCases {
+		args := txInfoRebuildArgs{
+			startHeight: tc.startHeight,
+			endHeight:   tc.endHeight,
+			blockStore:  mockBlockStore,
+			stateStore:  mockStateStore,
+		}
+
+		err := txInfoRebuild(setupReIndexEventCmd(), args)
+		if tc.rebuildErr {
+			require.Error(t, err)
+		} else {
+			require.NoError(t, err)
+		}
+	}
+	mockBlockStore.AssertCalled(t, "SaveTxInfo", block, mock.Anything, mock.Anything)
+}
@@ -8,7 +8,6 @@ import (
 	dbm "github.com/cometbft/cometbft-db"
 	"github.com/spf13/cobra"
 
-	abcitypes "github.com/tendermint/tendermint/abci/types"
 	cmtcfg "github.com/tendermint/tendermint/config"
 	"github.com/tendermint/tendermint/libs/progressbar"
 	"github.com/tendermint/tendermint/state"
@@ -17,7 +16,6 @@ import (
 	"github.com/tendermint/tendermint/state/indexer/sink/psql"
 	"github.com/tendermint/tendermint/state/txindex"
 	"github.com/tendermint/tendermint/state/txindex/kv"
-	"github.com/tendermint/tendermint/types"
 )
 
 const (
@@ -42,6 +40,10 @@ reindex from the base block height(inclusive); and the default end-height is 0,
 the tooling will reindex until the latest block height(inclusive). User can omit
 either or both arguments.
 
+The events are indexed as filtered by the allow and deny event patterns of each
+indexer, and the event attributes the patterns exclude are removed from the
+blocks and txs indexed already, so that an existing index can be slimmed down.
+
 Note: This operation requires ABCIResponses. Do not set DiscardABCIResponses to true if you
 want to use this command.
 	`,
@@ -63,22 +65,24 @@ want to use this command.
 			return
 		}
 
-		bi, ti, err := loadEventSinks(config)
+		bis, tis, err := loadEventSinks(config)
 		if err != nil {
 			fmt.Println(reindexFailed, err)
 			return
 		}
 
-		riArgs := eventReIndexArgs{
-			startHeight:  startHeight,
-			endHeight:    endHeight,
-			blockIndexer: bi,
-			txIndexer:    ti,
-			blockStore:   bs,
-			stateStore:   ss,
-		}
-		if err := eventReIndex(cmd, riArgs); err != nil {
-			panic(fmt.Errorf("%s: %w", reindexFailed, err))
+		for i := range bis {
+			riArgs := eventReIndexArgs{
+				startHeight:  startHeight,
+				endHeight:    endHeight,
+				blockIndexer: bis[i],
+				txIndexer:    tis[i],
+				blockStore:   bs,
+				stateStore:   ss,
+			}
+			if err := eventReIndex(cmd, riArgs); err != nil {
+				panic(fmt.Errorf("%s: %w", reindexFailed, err))
+			}
 		}
 
 		fmt.Println("event re-index finished")
@@ -95,8 +99,31 @@ func init() {
 	ReIndexEventCmd.Flags().Int64Var(&endHeight, "end-height", 0, "the block height would like to finish for re-index")
 }
 
-func loadEventSinks(cfg *cmtcfg.Config) (indexer.BlockIndexer, txindex.TxIndexer, error) {
-	switch strings.ToLower(cfg.TxIndex.Indexer) {
+// loadEventSinks returns the block and tx indexers of each of the indexers
+// set in cfg.
+func loadEventSinks(cfg *cmtcfg.Config) ([]indexer.BlockIndexer, []txindex.TxIndexer, error) {
+	var (
+		bis []indexer.BlockIndexer
+		tis []txindex.TxIndexer
+	)
+	for _, sink := range cfg.TxIndex.Indexers() {
+		bi, ti, err := loadEventSink(cfg, sink)
+		if err != nil {
+			return nil, nil, err
+		}
+		bis = append(bis, bi)
+		tis = append(tis, ti)
+	}
+	return bis, tis, nil
+}
+
+func loadEventSink(cfg *cmtcfg.Config, sink string) (indexer.BlockIndexer, txindex.TxIndexer, error) {
+	filter, err := indexer.NewEventFilter(cfg.TxIndex.EventPatterns(sink))
+	if err != nil {
+		return nil, nil, err
+	}
+
+	switch strings.ToLower(sink) {
 	case "null":
 		return nil, nil, errors.New("found null event sink, please check the tx-index section in the config.toml")
 	case "psql":
@@ -104,7 +131,7 @@ func loadEventSinks(cfg *cmtcfg.Config) (indexer.BlockIndexer, txindex.TxIndexer
 		if conn == "" {
 			return nil, nil, errors.New("the psql connection settings cannot be empty")
 		}
-		es, err := psql.NewEventSink(conn, cfg.ChainID())
+		es, err := psql.NewEventSink(conn, cfg.ChainID(), psql.WithEventFilter(filter), psql.WithPruning())
 		if err != nil {
 			return nil, nil, err
 		}
@@ -115,11 +142,12 @@ func loadEventSinks(cfg *cmtcfg.Config) (indexer.BlockIndexer, txindex.TxIndexer
 			return nil, nil, err
 		}
 
-		txIndexer := kv.NewTxIndex(store)
-		blockIndexer := blockidxkv.New(dbm.NewPrefixDB(store, []byte("block_events")))
+		txIndexer := kv.NewTxIndex(store, kv.WithEventFilter(filter), kv.WithPruning())
+		blockIndexer := blockidxkv.New(dbm.NewPrefixDB(store, []byte("block_events")),
+			blockidxkv.WithEventFilter(filter), blockidxkv.WithPruning())
 		return blockIndexer, txIndexer, nil
 	default:
-		return nil, nil, fmt.Errorf("unsupported event sink type: %s", cfg.TxIndex.Indexer)
+		return nil, nil, fmt.Errorf("unsupported event sink type: %s", sink)
 	}
 }
 
@@ -143,41 +171,12 @@ func eventReIndex(cmd *cobra.Command, args eventReIndexArgs) error {
 		case <-cmd.Context().Done():
 			return fmt.Errorf("event re-index terminated at height %d: %w", i, cmd.Context().Err())
 		default:
-			b := args.blockStore.LoadBlock(i)
-			if b == nil {
-				return fmt.Errorf("not able to load block at height %d from the blockstore", i)
-			}
-
-			r, err := args.stateStore.LoadABCIResponses(i)
+			e, batch, err := txindex.LoadBlockEvents(args.blockStore, args.stateStore, i)
 			if err != nil {
-				return fmt.Errorf("not able to load ABCI Response at height %d from the statestore", i)
+				return err
 			}
 
-			e := types.EventDataNewBlockHeader{
-				Header:           b.Header,
-				NumTxs:           int64(len(b.Txs)),
-				ResultBeginBlock: *r.BeginBlock,
-				ResultEndBlock:   *r.EndBlock,
-			}
-
-			var batch *txindex.Batch
 			if e.NumTxs > 0 {
-				batch = txindex.NewBatch(e.NumTxs)
-
-				for i := range b.Data.Txs {
-					tr := abcitypes.TxResult{
-						Height: b.Height,
-						//nolint:gosec
-						Index:  uint32(i),
-						Tx:     b.Data.Txs[i],
-						Result: *(r.DeliverTxs[i]),
-					}
-
-					if err = batch.Add(&tr); err != nil {
-						return fmt.Errorf("adding tx to batch: %w", err)
-					}
-				}
-
 				if err := args.txIndexer.AddBatch(batch); err != nil {
 					return fmt.Errorf("tx event re-index at height %d failed: %w", i, err)
 				}
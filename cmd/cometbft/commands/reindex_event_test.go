@@ -90,6 +90,7 @@ func TestLoadEventSink(t *testing.T) {
 		{"NULL", "", true},
 		{"KV", "", false},
 		{"PSQL", "", true}, // true because empty connect url
+		{"kv,psql", "", true},
 		// skip to test PSQL connect with correct url
 		{"UnsupportedSinkType", "wrongUrl", true},
 	}
@@ -0,0 +1,62 @@
+package commands
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+
+	"github.com/tendermint/tendermint/pkg/trace/analysis"
+)
+
+var traceOutput string
+
+// TraceCmd contains the tools for the data collected by the tracers.
+var TraceCmd = &cobra.Command{
+	Use:   "trace",
+	Short: "Tools for the data collected by the tracers",
+}
+
+var traceAnalyzeCmd = &cobra.Command{
+	Use:   "analyze [trace-dir...]",
+	Short: "Analyze the consensus and mempool traces of several nodes",
+	Long: `Analyze the consensus and mempool traces of several nodes.
+
+The trace directories are searched recursively for the tables written by the
+local tracer, e.g. the data/traces directories of the nodes or the directory
+the tables were downloaded to. Nodes are told apart by the node ID of their
+events. The analysis reports per height block propagation latencies, vote
+arrival distributions, the causes of round changes and the duplication of the
+gossiped transactions.
+
+It uses the following tables: ` + fmt.Sprint(analysis.Tables()),
+	Example: `
+	cometbft trace analyze ./node0/data/traces ./node1/data/traces
+	cometbft trace analyze --output json ./traces > report.json
+	`,
+	Args: cobra.MinimumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if traceOutput != "text" && traceOutput != "json" {
+			return fmt.Errorf("unknown output %q, options are text and json", traceOutput)
+		}
+
+		traces, err := analysis.Load(args...)
+		if err != nil {
+			return err
+		}
+		report := analysis.Analyze(traces)
+
+		if traceOutput == "json" {
+			enc := json.NewEncoder(os.Stdout)
+			enc.SetIndent("", "  ")
+			return enc.Encode(report)
+		}
+		return report.WriteText(os.Stdout)
+	},
+}
+
+func init() {
+	traceAnalyzeCmd.Flags().StringVarP(&traceOutput, "output", "o", "text", "output format: text or json")
+	TraceCmd.AddCommand(traceAnalyzeCmd)
+}
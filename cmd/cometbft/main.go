@@ -19,6 +19,7 @@ func main() {
 		cmd.ProbeUpnpCmd,
 		cmd.LightCmd,
 		cmd.ReIndexEventCmd,
+		cmd.RebuildTxInfoCmd,
 		cmd.ReplayCmd,
 		cmd.ReplayConsoleCmd,
 		cmd.ResetAllCmd,
@@ -31,6 +32,8 @@ func main() {
 		cmd.VersionCmd,
 		cmd.RollbackStateCmd,
 		cmd.CompactGoLevelDBCmd,
+		cmd.TraceCmd,
+		cmd.MempoolCmd,
 		debug.DebugCmd,
 		cli.NewCompletionCmd(rootCmd, true),
 	)
@@ -6,7 +6,9 @@ import (
 	"fmt"
 	"net/http"
 	"os"
+	"path"
 	"path/filepath"
+	"strings"
 	"time"
 )
 
@@ -29,6 +31,12 @@ const (
 	MempoolV0 = "v0"
 	MempoolV1 = "v1"
 	MempoolV2 = "v2"
+
+	// Mempool reap modes. By default, the transactions are reaped for a block
+	// up to its max data bytes. In the square mode, they are also reaped up to
+	// the shares they are estimated to take up in the data square.
+	MempoolReapModeBytes  = "bytes"
+	MempoolReapModeSquare = "square"
 )
 
 // NOTE: Most of the structs & relevant comments + the
@@ -56,6 +64,8 @@ var (
 	defaultPrivValKeyPath   = filepath.Join(defaultConfigDir, defaultPrivValKeyName)
 	defaultPrivValStatePath = filepath.Join(defaultDataDir, defaultPrivValStateName)
 
+	defaultMempoolExportDir = filepath.Join(defaultDataDir, "mempool.export")
+
 	defaultNodeKeyPath  = filepath.Join(defaultConfigDir, defaultNodeKeyName)
 	defaultAddrBookPath = filepath.Join(defaultConfigDir, defaultAddrBookName)
 
@@ -152,6 +162,12 @@ func (cfg *Config) ValidateBasic() error {
 	if err := cfg.Consensus.ValidateBasic(); err != nil {
 		return fmt.Errorf("error in [consensus] section: %w", err)
 	}
+	if err := cfg.Storage.ValidateBasic(); err != nil {
+		return fmt.Errorf("error in [storage] section: %w", err)
+	}
+	if err := cfg.TxIndex.ValidateBasic(); err != nil {
+		return fmt.Errorf("error in [tx_index] section: %w", err)
+	}
 	if err := cfg.Instrumentation.ValidateBasic(); err != nil {
 		return fmt.Errorf("error in [instrumentation] section: %w", err)
 	}
@@ -395,6 +411,12 @@ type RPCConfig struct {
 	// Maximum size of request header, in bytes
 	MaxHeaderBytes int `mapstructure:"max_header_bytes"`
 
+	// Maximum number of index entries a /tx_search_cursor, /block_search_cursor,
+	// /tx_search_stream or /block_search_stream request examines. A request
+	// reaching it returns the results found so far, along with the cursor to
+	// resume the search from. 0 means unlimited.
+	SearchScanLimit int `mapstructure:"search_scan_limit"`
+
 	// The path to a file containing certificate that is used to create the HTTPS server.
 	// Might be either absolute path or path related to CometBFT's config directory.
 	//
@@ -439,6 +461,8 @@ func DefaultRPCConfig() *RPCConfig {
 		MaxBodyBytes:   int64(1000000), // 1MB
 		MaxHeaderBytes: 1 << 20,        // same as the net/http default
 
+		SearchScanLimit: 100000,
+
 		TLSCertFile: "",
 		TLSKeyFile:  "",
 	}
@@ -489,6 +513,9 @@ func (cfg *RPCConfig) ValidateBasic() error {
 	if cfg.MaxHeaderBytes < 0 {
 		return errors.New("max_header_bytes can't be negative")
 	}
+	if cfg.SearchScanLimit < 0 {
+		return errors.New("search_scan_limit can't be negative")
+	}
 	return nil
 }
 
@@ -587,6 +614,11 @@ type P2PConfig struct { //nolint: maligned
 	// Toggle to disable guard against peers connecting from the same ip.
 	AllowDuplicateIP bool `mapstructure:"allow_duplicate_ip"`
 
+	// Set true to score peers by their trust history, persisted across
+	// restarts, to evict inbound peers of low trust scores for peers of higher
+	// ones and to prefer the addresses of trusted peers when dialing
+	TrustMetrics bool `mapstructure:"trust_metrics"`
+
 	// Peer connection configuration.
 	HandshakeTimeout time.Duration `mapstructure:"handshake_timeout"`
 	DialTimeout      time.Duration `mapstructure:"dial_timeout"`
@@ -617,6 +649,7 @@ func DefaultP2PConfig() *P2PConfig {
 		PexReactor:                   true,
 		SeedMode:                     false,
 		AllowDuplicateIP:             false,
+		TrustMetrics:                 false,
 		HandshakeTimeout:             20 * time.Second,
 		DialTimeout:                  3 * time.Second,
 		TestDialFail:                 false,
@@ -715,7 +748,8 @@ type MempoolConfig struct {
 	// WalPath (default: "") configures the location of the Write Ahead Log
 	// (WAL) for the mempool. The WAL is disabled by default. To enable, set
 	// WalPath to where you want the WAL to be written (e.g.
-	// "data/mempool.wal").
+	// "data/mempool.wal"). The txs of the WAL are checked back into the
+	// mempool on startup, before the node joins consensus.
 	WalPath string `mapstructure:"wal_dir"`
 	// Maximum number of transactions in the mempool
 	Size int `mapstructure:"size"`
@@ -773,6 +807,67 @@ type MempoolConfig struct {
 	// Only applicable to the v2 / CAT mempool
 	// Default is 200ms
 	MaxGossipDelay time.Duration `mapstructure:"max-gossip-delay"`
+
+	// PullOnly (default: false) defines whether the reactor only announces
+	// the transactions it receives to its peers, which request the ones they
+	// need, instead of pushing the new ones to them.
+	// Only applicable to the v2 / CAT mempool
+	PullOnly bool `mapstructure:"pull-only"`
+
+	// MaxOutboundBytesPerSecond, if non-zero, is the maximum number of bytes
+	// of transactions the reactor sends to all its peers per second.
+	// Only applicable to the v2 / CAT mempool
+	MaxOutboundBytesPerSecond int64 `mapstructure:"max-outbound-bytes-per-second"`
+
+	// MaxPeerOutboundBytesPerSecond, if non-zero, is the maximum number of
+	// bytes of transactions the reactor sends to each peer per second.
+	// Only applicable to the v2 / CAT mempool
+	MaxPeerOutboundBytesPerSecond int64 `mapstructure:"max-peer-outbound-bytes-per-second"`
+
+	// ReplacementPriorityBump is the percentage by which the priority of a
+	// transaction must exceed the priority of the transaction of the same
+	// sender and sequence it replaces in the mempool.
+	// Only applicable to the v1 and v2 / CAT mempools
+	// Default is 10
+	ReplacementPriorityBump int64 `mapstructure:"replacement-priority-bump"`
+
+	// PeerQuotaWindow is the window over which the transactions received from
+	// a peer are limited by MaxPeerTxsPerWindow and MaxPeerBytesPerWindow. A
+	// peer exceeding them is disconnected.
+	// Only applicable to the v1 and v2 / CAT mempools
+	// Default is 10s
+	PeerQuotaWindow time.Duration `mapstructure:"peer-quota-window"`
+
+	// MaxPeerTxsPerWindow, if non-zero, is the maximum number of transactions
+	// a peer can send to the mempool per PeerQuotaWindow.
+	MaxPeerTxsPerWindow int `mapstructure:"max-peer-txs-per-window"`
+
+	// MaxPeerBytesPerWindow, if non-zero, is the maximum total size of the
+	// transactions a peer can send to the mempool per PeerQuotaWindow.
+	MaxPeerBytesPerWindow int64 `mapstructure:"max-peer-bytes-per-window"`
+
+	// MaxSenderShare, if non-zero, is the maximum percentage of Size and
+	// MaxTxsBytes the transactions of a sender assigned by the application can
+	// take up in the mempool.
+	// Only applicable to the v1 and v2 / CAT mempools
+	MaxSenderShare int64 `mapstructure:"max-sender-share"`
+
+	// MaxBlobBytes, if non-zero, limits the total size of the blobs of the
+	// BlobTxs in the mempool, in addition to MaxTxsBytes. It keeps room for
+	// the regular transactions when the mempool is flooded with blobs.
+	MaxBlobBytes int64 `mapstructure:"max-blob-bytes"`
+
+	// ReapMode is how the transactions are reaped for a block:
+	//  1) "bytes" - (default) up to the max data bytes of the block.
+	//  2) "square" - also up to the shares they are estimated to take up in a
+	//     data square of MaxSquareSize.
+	ReapMode string `mapstructure:"reap-mode"`
+
+	// MaxSquareSize is the width of the largest data square the transactions
+	// are reaped for in the square ReapMode. It should match the max square
+	// size of the application.
+	// Default is 64
+	MaxSquareSize int `mapstructure:"max-square-size"`
 }
 
 // DefaultMempoolConfig returns a default configuration for the CometBFT mempool
@@ -790,8 +885,12 @@ func DefaultMempoolConfig() *MempoolConfig {
 		MaxTxBytes:  1024 * 1024, // 1MB
 		ExperimentalMaxGossipConnectionsToNonPersistentPeers: 0,
 		ExperimentalMaxGossipConnectionsToPersistentPeers:    0,
-		TTLDuration:  0 * time.Second,
-		TTLNumBlocks: 0,
+		TTLDuration:             0 * time.Second,
+		TTLNumBlocks:            0,
+		ReplacementPriorityBump: 10,
+		PeerQuotaWindow:         10 * time.Second,
+		ReapMode:                MempoolReapModeBytes,
+		MaxSquareSize:           64,
 	}
 }
 
@@ -812,6 +911,13 @@ func (cfg *MempoolConfig) WalEnabled() bool {
 	return cfg.WalPath != ""
 }
 
+// ExportDir returns the full path to the dir the txs of the mempool are
+// exported to by the unsafe_export_mempool RPC endpoint, to be imported into
+// the mempool on the next startup.
+func (cfg *MempoolConfig) ExportDir() string {
+	return rootify(defaultMempoolExportDir, cfg.RootDir)
+}
+
 // ValidateBasic performs basic validation (checking param bounds, etc.) and
 // returns an error if any check fails.
 func (cfg *MempoolConfig) ValidateBasic() error {
@@ -833,6 +939,45 @@ func (cfg *MempoolConfig) ValidateBasic() error {
 	if cfg.ExperimentalMaxGossipConnectionsToNonPersistentPeers < 0 {
 		return errors.New("experimental_max_gossip_connections_to_non_persistent_peers can't be negative")
 	}
+	if cfg.ReplacementPriorityBump < 0 {
+		return errors.New("replacement-priority-bump can't be negative")
+	}
+	if cfg.PeerQuotaWindow < 0 {
+		return errors.New("peer-quota-window can't be negative")
+	}
+	if cfg.MaxPeerTxsPerWindow < 0 {
+		return errors.New("max-peer-txs-per-window can't be negative")
+	}
+	if cfg.MaxPeerBytesPerWindow < 0 {
+		return errors.New("max-peer-bytes-per-window can't be negative")
+	}
+	if (cfg.MaxPeerTxsPerWindow > 0 || cfg.MaxPeerBytesPerWindow > 0) && cfg.PeerQuotaWindow == 0 {
+		return errors.New("peer-quota-window must be positive when peer quotas are set")
+	}
+	if cfg.MaxSenderShare < 0 || cfg.MaxSenderShare > 100 {
+		return errors.New("max-sender-share must be between 0 and 100")
+	}
+	if cfg.MaxOutboundBytesPerSecond < 0 {
+		return errors.New("max-outbound-bytes-per-second can't be negative")
+	}
+	if cfg.MaxPeerOutboundBytesPerSecond < 0 {
+		return errors.New("max-peer-outbound-bytes-per-second can't be negative")
+	}
+	if cfg.MaxBlobBytes < 0 {
+		return errors.New("max-blob-bytes can't be negative")
+	}
+	switch cfg.ReapMode {
+	case MempoolReapModeBytes, MempoolReapModeSquare:
+	default:
+		return fmt.Errorf("unknown reap-mode %q, must be %q or %q",
+			cfg.ReapMode, MempoolReapModeBytes, MempoolReapModeSquare)
+	}
+	if cfg.MaxSquareSize < 0 {
+		return errors.New("max-square-size can't be negative")
+	}
+	if cfg.ReapMode == MempoolReapModeSquare && cfg.MaxSquareSize == 0 {
+		return errors.New("max-square-size must be positive in the square reap-mode")
+	}
 	return nil
 }
 
@@ -1002,6 +1147,26 @@ type ConsensusConfig struct {
 	PeerQueryMaj23SleepDuration time.Duration `mapstructure:"peer_query_maj23_sleep_duration"`
 
 	DoubleSignCheckHeight int64 `mapstructure:"double_sign_check_height"`
+
+	// Gossip proposal blocks as compact blocks to the peers supporting it,
+	// which rebuild them from the txs of their mempool
+	CompactBlocks bool `mapstructure:"compact_blocks"`
+	// How long we wait for a peer to rebuild a compact block before falling
+	// back to sending it the block parts
+	CompactBlockTimeout time.Duration `mapstructure:"compact_block_timeout"`
+
+	// Scale the propose and prevote timeouts by the number of parts of the
+	// proposal block and the time between the arrival of the block parts of
+	// recent heights, instead of using the static timeouts above
+	AdaptiveTimeouts bool `mapstructure:"adaptive_timeouts"`
+	// Bounds of the adaptive propose timeout of round 0. The floor is the
+	// time allowed for the proposal to be created and start arriving, to
+	// which the time to receive its parts is added
+	TimeoutProposeFloor   time.Duration `mapstructure:"timeout_propose_floor"`
+	TimeoutProposeCeiling time.Duration `mapstructure:"timeout_propose_ceiling"`
+	// Bounds of the adaptive prevote timeout of round 0
+	TimeoutPrevoteFloor   time.Duration `mapstructure:"timeout_prevote_floor"`
+	TimeoutPrevoteCeiling time.Duration `mapstructure:"timeout_prevote_ceiling"`
 }
 
 // DefaultConsensusConfig returns a default configuration for the consensus service
@@ -1022,6 +1187,13 @@ func DefaultConsensusConfig() *ConsensusConfig {
 		PeerGossipSleepDuration:     100 * time.Millisecond,
 		PeerQueryMaj23SleepDuration: 2000 * time.Millisecond,
 		DoubleSignCheckHeight:       int64(0),
+		CompactBlocks:               false,
+		CompactBlockTimeout:         1000 * time.Millisecond,
+		AdaptiveTimeouts:            false,
+		TimeoutProposeFloor:         1000 * time.Millisecond,
+		TimeoutProposeCeiling:       30 * time.Second,
+		TimeoutPrevoteFloor:         500 * time.Millisecond,
+		TimeoutPrevoteCeiling:       10 * time.Second,
 	}
 }
 
@@ -1040,6 +1212,7 @@ func TestConsensusConfig() *ConsensusConfig {
 	cfg.PeerGossipSleepDuration = 5 * time.Millisecond
 	cfg.PeerQueryMaj23SleepDuration = 250 * time.Millisecond
 	cfg.DoubleSignCheckHeight = int64(0)
+	cfg.CompactBlockTimeout = 100 * time.Millisecond
 	return cfg
 }
 
@@ -1154,6 +1327,21 @@ func (cfg *ConsensusConfig) ValidateBasic() error {
 	if cfg.DoubleSignCheckHeight < 0 {
 		return errors.New("double_sign_check_height can't be negative")
 	}
+	if cfg.CompactBlockTimeout < 0 {
+		return errors.New("compact_block_timeout can't be negative")
+	}
+	if cfg.TimeoutProposeFloor < 0 {
+		return errors.New("timeout_propose_floor can't be negative")
+	}
+	if cfg.TimeoutProposeCeiling < cfg.TimeoutProposeFloor {
+		return errors.New("timeout_propose_ceiling can't be less than timeout_propose_floor")
+	}
+	if cfg.TimeoutPrevoteFloor < 0 {
+		return errors.New("timeout_prevote_floor can't be negative")
+	}
+	if cfg.TimeoutPrevoteCeiling < cfg.TimeoutPrevoteFloor {
+		return errors.New("timeout_prevote_ceiling can't be less than timeout_prevote_floor")
+	}
 	return nil
 }
 
@@ -1167,6 +1355,15 @@ type StorageConfig struct {
 	// required for `/block_results` RPC queries, and to reindex events in the
 	// command-line tool.
 	DiscardABCIResponses bool `mapstructure:"discard_abci_responses"`
+
+	// The number of most recent heights for which the TxInfo index, used by
+	// the /tx_status RPC endpoint, is kept. If 0, TxInfo are pruned together
+	// with the blocks. Otherwise they are pruned in the background every
+	// TxInfoPruneInterval, independently of the blocks retained.
+	TxInfoRetainBlocks int64 `mapstructure:"tx_info_retain_blocks"`
+
+	// How often TxInfo older than TxInfoRetainBlocks are pruned.
+	TxInfoPruneInterval time.Duration `mapstructure:"tx_info_prune_interval"`
 }
 
 // DefaultStorageConfig returns the default configuration options relating to
@@ -1174,6 +1371,8 @@ type StorageConfig struct {
 func DefaultStorageConfig() *StorageConfig {
 	return &StorageConfig{
 		DiscardABCIResponses: false,
+		TxInfoRetainBlocks:   0,
+		TxInfoPruneInterval:  time.Minute,
 	}
 }
 
@@ -1182,7 +1381,21 @@ func DefaultStorageConfig() *StorageConfig {
 func TestStorageConfig() *StorageConfig {
 	return &StorageConfig{
 		DiscardABCIResponses: false,
+		TxInfoRetainBlocks:   0,
+		TxInfoPruneInterval:  time.Second,
+	}
+}
+
+// ValidateBasic performs basic validation (checking param bounds, etc.) and
+// returns an error if any check fails.
+func (cfg *StorageConfig) ValidateBasic() error {
+	if cfg.TxInfoRetainBlocks < 0 {
+		return errors.New("tx_info_retain_blocks can't be negative")
 	}
+	if cfg.TxInfoRetainBlocks > 0 && cfg.TxInfoPruneInterval <= 0 {
+		return errors.New("tx_info_prune_interval must be positive")
+	}
+	return nil
 }
 
 // -----------------------------------------------------------------------------
@@ -1206,11 +1419,29 @@ type TxIndexConfig struct {
 	//   2) "kv" (default) - the simplest possible indexer,
 	//      backed by key-value storage (defaults to levelDB; see DBBackend).
 	//   3) "psql" - the indexer services backed by PostgreSQL.
+	//
+	// Several of "kv" and "psql" can be set, separated by commas, to index
+	// into all of them: the first one serves the RPC queries. Each tracks the
+	// last height it indexed, and is backfilled from the block store if it
+	// falls behind or is added later.
 	Indexer string `mapstructure:"indexer"`
 
 	// The PostgreSQL connection configuration, the connection format:
 	// postgresql://<user>:<password>@<host>:<port>/<db>?<opts>
 	PsqlConn string `mapstructure:"psql-conn"`
+
+	// Patterns of the composite keys "<event type>.<attribute key>" of the
+	// event attributes the "kv" indexer indexes, among the ones the
+	// application marks to index, as matched by path.Match (e.g. "transfer.*").
+	// An attribute is indexed if it matches one of the allow patterns, or
+	// there are none, and none of the deny patterns.
+	KVAllowEvents []string `mapstructure:"kv-allow-events"`
+	KVDenyEvents  []string `mapstructure:"kv-deny-events"`
+
+	// Patterns of the event attributes the "psql" indexer indexes, as the ones
+	// of the "kv" indexer.
+	PsqlAllowEvents []string `mapstructure:"psql-allow-events"`
+	PsqlDenyEvents  []string `mapstructure:"psql-deny-events"`
 }
 
 // DefaultTxIndexConfig returns a default configuration for the transaction indexer.
@@ -1220,6 +1451,57 @@ func DefaultTxIndexConfig() *TxIndexConfig {
 	}
 }
 
+// ValidateBasic performs basic validation (checking param bounds, etc.) and
+// returns an error if any check fails.
+func (cfg *TxIndexConfig) ValidateBasic() error {
+	for _, indexer := range []string{"kv", "psql"} {
+		allow, deny := cfg.EventPatterns(indexer)
+		for _, pattern := range append(append([]string{}, allow...), deny...) {
+			if _, err := path.Match(pattern, ""); err != nil {
+				return fmt.Errorf("invalid %s event pattern %q: %w", indexer, pattern, err)
+			}
+		}
+	}
+
+	indexers := cfg.Indexers()
+	if len(indexers) < 2 {
+		return nil
+	}
+	seen := make(map[string]bool, len(indexers))
+	for _, indexer := range indexers {
+		switch {
+		case indexer != "kv" && indexer != "psql":
+			return fmt.Errorf("unsupported indexer %q among several indexers", indexer)
+		case seen[indexer]:
+			return fmt.Errorf("indexer %q is set more than once", indexer)
+		}
+		seen[indexer] = true
+	}
+	return nil
+}
+
+// Indexers returns the indexers set, the first one serving the RPC queries.
+func (cfg *TxIndexConfig) Indexers() []string {
+	indexers := strings.Split(cfg.Indexer, ",")
+	for i, indexer := range indexers {
+		indexers[i] = strings.TrimSpace(indexer)
+	}
+	return indexers
+}
+
+// EventPatterns returns the allow and deny patterns of the event attributes
+// the indexer indexes.
+func (cfg *TxIndexConfig) EventPatterns(indexer string) (allow, deny []string) {
+	switch strings.ToLower(indexer) {
+	case "kv":
+		return cfg.KVAllowEvents, cfg.KVDenyEvents
+	case "psql":
+		return cfg.PsqlAllowEvents, cfg.PsqlDenyEvents
+	default:
+		return nil, nil
+	}
+}
+
 // TestTxIndexConfig returns a default configuration for the transaction indexer.
 func TestTxIndexConfig() *TxIndexConfig {
 	return DefaultTxIndexConfig()
@@ -1255,9 +1537,17 @@ type InstrumentationConfig struct {
 	// pulling data.
 	TracePullAddress string `mapstructure:"trace_pull_address"`
 
-	// TraceType is the type of tracer used. Options are "local" and "noop".
+	// TraceType is the type of tracer used. Options are "local", "http",
+	// "otlp" and "noop". Additional tracers can be registered with
+	// trace.RegisterTracer.
 	TraceType string `mapstructure:"trace_type"`
 
+	// TraceRemoteURL is the endpoint that remote tracers push trace data to.
+	// For the "http" tracer it is the URL of a collector accepting newline
+	// delimited JSON, and for the "otlp" tracer the OTLP/HTTP traces
+	// endpoint, e.g. "http://localhost:4318/v1/traces".
+	TraceRemoteURL string `mapstructure:"trace_remote_url"`
+
 	// TraceBufferSize is the number of traces to write in a single batch.
 	TraceBufferSize int `mapstructure:"trace_push_batch_size"`
 
@@ -1266,6 +1556,23 @@ type InstrumentationConfig struct {
 	// comma separate string. For example: "consensus_round_state,mempool_tx".
 	TracingTables string `mapstructure:"tracing_tables"`
 
+	// TraceRotateSize is the size in bytes after which the local tracer
+	// rotates a table file into a new segment. 0 disables rotation by size.
+	TraceRotateSize int64 `mapstructure:"trace_rotate_size"`
+
+	// TraceRotateAge is the age after which the local tracer rotates a table
+	// file into a new segment. 0 disables rotation by age.
+	TraceRotateAge time.Duration `mapstructure:"trace_rotate_age"`
+
+	// TraceMaxTotalSize is the maximum number of bytes used by the local
+	// tracer on disk across all tables. The oldest rotated segments are
+	// deleted once it is exceeded. 0 means no limit.
+	TraceMaxTotalSize int64 `mapstructure:"trace_max_total_size"`
+
+	// TraceCompression is the compression of rotated segments. Options are
+	// "none", "gzip" and "zstd".
+	TraceCompression string `mapstructure:"trace_compression"`
+
 	// PyroscopeURL is the pyroscope url used to establish a connection with a
 	// pyroscope continuous profiling server.
 	PyroscopeURL string `mapstructure:"pyroscope_url"`
@@ -1291,8 +1598,13 @@ func DefaultInstrumentationConfig() *InstrumentationConfig {
 		TracePushConfig:      "",
 		TracePullAddress:     "",
 		TraceType:            "noop",
+		TraceRemoteURL:       "",
 		TraceBufferSize:      1000,
 		TracingTables:        DefaultTracingTables,
+		TraceRotateSize:      0,
+		TraceRotateAge:       0,
+		TraceMaxTotalSize:    0,
+		TraceCompression:     "gzip",
 		PyroscopeURL:         "",
 		PyroscopeTrace:       false,
 		PyroscopeProfileTypes: []string{
@@ -1323,6 +1635,23 @@ func (cfg *InstrumentationConfig) ValidateBasic() error {
 	if cfg.PyroscopeTrace && cfg.PyroscopeURL == "" {
 		return errors.New("pyroscope_trace can't be enabled if profiling is disabled")
 	}
+	if (cfg.TraceType == "http" || cfg.TraceType == "otlp") && cfg.TraceRemoteURL == "" {
+		return fmt.Errorf("trace_remote_url is required by the %s tracer", cfg.TraceType)
+	}
+	if cfg.TraceRotateSize < 0 {
+		return errors.New("trace_rotate_size can't be negative")
+	}
+	if cfg.TraceRotateAge < 0 {
+		return errors.New("trace_rotate_age can't be negative")
+	}
+	if cfg.TraceMaxTotalSize < 0 {
+		return errors.New("trace_max_total_size can't be negative")
+	}
+	switch cfg.TraceCompression {
+	case "", "none", "gzip", "zstd":
+	default:
+		return fmt.Errorf("unknown trace_compression %q, options are none, gzip and zstd", cfg.TraceCompression)
+	}
 	// if there is not TracePushConfig configured, then we do not need to validate the rest
 	// of the config because we are not connecting.
 	if cfg.TracePushConfig == "" {
@@ -75,6 +75,7 @@ func TestRPCConfigValidateBasic(t *testing.T) {
 		"TimeoutBroadcastTxCommit",
 		"MaxBodyBytes",
 		"MaxHeaderBytes",
+		"SearchScanLimit",
 	}
 
 	for _, fieldName := range fieldsToTest {
@@ -113,6 +114,15 @@ func TestMempoolConfigValidateBasic(t *testing.T) {
 		"MaxTxsBytes",
 		"CacheSize",
 		"MaxTxBytes",
+		"ReplacementPriorityBump",
+		"PeerQuotaWindow",
+		"MaxPeerTxsPerWindow",
+		"MaxPeerBytesPerWindow",
+		"MaxSenderShare",
+		"MaxOutboundBytesPerSecond",
+		"MaxPeerOutboundBytesPerSecond",
+		"MaxBlobBytes",
+		"MaxSquareSize",
 	}
 
 	for _, fieldName := range fieldsToTest {
@@ -120,6 +130,26 @@ func TestMempoolConfigValidateBasic(t *testing.T) {
 		assert.Error(t, cfg.ValidateBasic())
 		reflect.ValueOf(cfg).Elem().FieldByName(fieldName).SetInt(0)
 	}
+
+	cfg = TestMempoolConfig()
+	cfg.MaxSenderShare = 101
+	assert.Error(t, cfg.ValidateBasic())
+
+	// peer quotas need a window
+	cfg = TestMempoolConfig()
+	cfg.MaxPeerTxsPerWindow = 100
+	assert.NoError(t, cfg.ValidateBasic())
+	cfg.PeerQuotaWindow = 0
+	assert.Error(t, cfg.ValidateBasic())
+
+	// the square reap mode needs a square size
+	cfg = TestMempoolConfig()
+	cfg.ReapMode = MempoolReapModeSquare
+	assert.NoError(t, cfg.ValidateBasic())
+	cfg.MaxSquareSize = 0
+	assert.Error(t, cfg.ValidateBasic())
+	cfg.ReapMode = "shares"
+	assert.Error(t, cfg.ValidateBasic())
 }
 
 func TestStateSyncConfigValidateBasic(t *testing.T) {
@@ -139,6 +169,44 @@ func TestFastSyncConfigValidateBasic(t *testing.T) {
 	assert.Error(t, cfg.ValidateBasic())
 }
 
+func TestStorageConfigValidateBasic(t *testing.T) {
+	cfg := TestStorageConfig()
+	assert.NoError(t, cfg.ValidateBasic())
+
+	cfg.TxInfoRetainBlocks = -1
+	assert.Error(t, cfg.ValidateBasic())
+
+	cfg.TxInfoRetainBlocks = 100
+	assert.NoError(t, cfg.ValidateBasic())
+	cfg.TxInfoPruneInterval = 0
+	assert.Error(t, cfg.ValidateBasic())
+}
+
+func TestTxIndexConfigValidateBasic(t *testing.T) {
+	cfg := TestTxIndexConfig()
+	assert.NoError(t, cfg.ValidateBasic())
+	assert.Equal(t, []string{"kv"}, cfg.Indexers())
+
+	cfg.Indexer = "kv, psql"
+	assert.NoError(t, cfg.ValidateBasic())
+	assert.Equal(t, []string{"kv", "psql"}, cfg.Indexers())
+
+	cfg.Indexer = "kv,null"
+	assert.Error(t, cfg.ValidateBasic())
+	cfg.Indexer = "psql,psql"
+	assert.Error(t, cfg.ValidateBasic())
+
+	cfg.Indexer = "kv"
+	cfg.KVAllowEvents = []string{"transfer.*", "message.action"}
+	cfg.PsqlDenyEvents = []string{"*.sender"}
+	assert.NoError(t, cfg.ValidateBasic())
+	allow, deny := cfg.EventPatterns("kv")
+	assert.Equal(t, cfg.KVAllowEvents, allow)
+	assert.Empty(t, deny)
+	cfg.KVDenyEvents = []string{"transfer.[a"}
+	assert.Error(t, cfg.ValidateBasic())
+}
+
 //nolint:lll
 func TestConsensusConfig_ValidateBasic(t *testing.T) {
 	testcases := map[string]struct {
@@ -164,6 +232,11 @@ func TestConsensusConfig_ValidateBasic(t *testing.T) {
 		"PeerQueryMaj23SleepDuration":          {func(c *ConsensusConfig) { c.PeerQueryMaj23SleepDuration = time.Second }, false},
 		"PeerQueryMaj23SleepDuration negative": {func(c *ConsensusConfig) { c.PeerQueryMaj23SleepDuration = -1 }, true},
 		"DoubleSignCheckHeight negative":       {func(c *ConsensusConfig) { c.DoubleSignCheckHeight = -1 }, true},
+		"CompactBlockTimeout negative":         {func(c *ConsensusConfig) { c.CompactBlockTimeout = -1 }, true},
+		"TimeoutProposeFloor negative":         {func(c *ConsensusConfig) { c.TimeoutProposeFloor = -1 }, true},
+		"TimeoutProposeCeiling below floor":    {func(c *ConsensusConfig) { c.TimeoutProposeCeiling = c.TimeoutProposeFloor - 1 }, true},
+		"TimeoutPrevoteFloor negative":         {func(c *ConsensusConfig) { c.TimeoutPrevoteFloor = -1 }, true},
+		"TimeoutPrevoteCeiling below floor":    {func(c *ConsensusConfig) { c.TimeoutPrevoteCeiling = c.TimeoutPrevoteFloor - 1 }, true},
 	}
 
 	for desc, tc := range testcases {
@@ -245,6 +245,12 @@ max_body_bytes = {{ .RPC.MaxBodyBytes }}
 # Maximum size of request header, in bytes
 max_header_bytes = {{ .RPC.MaxHeaderBytes }}
 
+# Maximum number of index entries a /tx_search_cursor, /block_search_cursor,
+# /tx_search_stream or /block_search_stream request examines. A request reaching
+# it returns the results found so far, along with the cursor to resume the
+# search from. 0 means unlimited.
+search_scan_limit = {{ .RPC.SearchScanLimit }}
+
 # The path to a file containing certificate that is used to create the HTTPS server.
 # Might be either absolute path or path related to CometBFT's config directory.
 # If the certificate is signed by a certificate authority,
@@ -333,6 +339,11 @@ private_peer_ids = "{{ .P2P.PrivatePeerIDs }}"
 # Toggle to disable guard against peers connecting from the same ip.
 allow_duplicate_ip = {{ .P2P.AllowDuplicateIP }}
 
+# Set true to score peers by their trust history, persisted across restarts,
+# to evict inbound peers of low trust scores for peers of higher ones and to
+# prefer the addresses of trusted peers when dialing
+trust_metrics = {{ .P2P.TrustMetrics }}
+
 # Peer connection configuration.
 handshake_timeout = "{{ .P2P.HandshakeTimeout }}"
 dial_timeout = "{{ .P2P.DialTimeout }}"
@@ -346,6 +357,8 @@ dial_timeout = "{{ .P2P.DialTimeout }}"
 #   1) "v0" - FIFO mempool.
 #   2) "v1" - (default) prioritized mempool.
 #   3) "v2" - content addressable transaction pool
+# To switch versions without dropping the pending txs, see
+# "cometbft mempool migrate --help".
 version = "{{ .Mempool.Version }}"
 
 # Recheck (default: true) defines whether CometBFT should recheck the
@@ -355,6 +368,10 @@ version = "{{ .Mempool.Version }}"
 # you can disable rechecking.
 recheck = {{ .Mempool.Recheck }}
 broadcast = {{ .Mempool.Broadcast }}
+
+# WalPath (default: "") is the directory of the write-ahead log journaling the
+# transactions admitted to the mempool. They are checked back into the mempool
+# on startup, before the node joins consensus. Leave empty to disable it.
 wal_dir = "{{ js .Mempool.WalPath }}"
 
 # Maximum number of transactions in the mempool
@@ -404,6 +421,66 @@ ttl-num-blocks = {{ .Mempool.TTLNumBlocks }}
 # Default is 200ms
 max-gossip-delay = "{{ .Mempool.MaxGossipDelay }}"
 
+# pull-only defines whether the reactor only announces the transactions it
+# receives to its peers, which request the ones they need, instead of pushing
+# the new ones to them. It saves bandwidth on constrained links.
+# Only applicable to the v2 / CAT mempool
+pull-only = {{ .Mempool.PullOnly }}
+
+# max-outbound-bytes-per-second and max-peer-outbound-bytes-per-second, if
+# non-zero, limit the bytes of transactions the reactor sends to all its peers
+# and to each peer per second. Over budget, the new transactions are announced
+# instead of pushed, and the requests for transactions are served in order of
+# priority.
+# Only applicable to the v2 / CAT mempool
+max-outbound-bytes-per-second = {{ .Mempool.MaxOutboundBytesPerSecond }}
+max-peer-outbound-bytes-per-second = {{ .Mempool.MaxPeerOutboundBytesPerSecond }}
+
+# replacement-priority-bump is the percentage by which the priority of a
+# transaction must exceed the priority of the transaction of the same sender
+# and sequence it replaces in the mempool
+# Only applicable to the v1 and v2 / CAT mempools
+# Default is 10
+replacement-priority-bump = {{ .Mempool.ReplacementPriorityBump }}
+
+# peer-quota-window is the window over which the transactions received from a
+# peer are limited by max-peer-txs-per-window and max-peer-bytes-per-window.
+# A peer exceeding them is disconnected.
+# Only applicable to the v1 and v2 / CAT mempools
+# Default is 10s
+peer-quota-window = "{{ .Mempool.PeerQuotaWindow }}"
+
+# max-peer-txs-per-window, if non-zero, is the maximum number of transactions
+# a peer can send to the mempool per peer-quota-window.
+max-peer-txs-per-window = {{ .Mempool.MaxPeerTxsPerWindow }}
+
+# max-peer-bytes-per-window, if non-zero, is the maximum total size of the
+# transactions a peer can send to the mempool per peer-quota-window.
+max-peer-bytes-per-window = {{ .Mempool.MaxPeerBytesPerWindow }}
+
+# max-sender-share, if non-zero, is the maximum percentage of size and
+# max_txs_bytes the transactions of a sender assigned by the application can
+# take up in the mempool. The transactions exceeding it are rejected with the
+# code 1 of the "mempool" codespace.
+# Only applicable to the v1 and v2 / CAT mempools
+max-sender-share = {{ .Mempool.MaxSenderShare }}
+
+# max-blob-bytes, if non-zero, limits the total size of the blobs of the
+# BlobTxs in the mempool, in addition to max_txs_bytes, to keep room for the
+# regular transactions.
+max-blob-bytes = {{ .Mempool.MaxBlobBytes }}
+
+# reap-mode is how the transactions are reaped for a block:
+#   1) "bytes" - (default) up to the max data bytes of the block.
+#   2) "square" - also up to the shares they are estimated to take up in a
+#   data square of max-square-size, blobs included.
+reap-mode = "{{ .Mempool.ReapMode }}"
+
+# max-square-size is the width of the largest data square the transactions are
+# reaped for in the "square" reap-mode. It should match the max square size of
+# the application.
+max-square-size = {{ .Mempool.MaxSquareSize }}
+
 # Experimental parameters to limit gossiping txs to up to the specified number of peers.
 # This feature is only available for the default mempool (version config set to "v0").
 # We use two independent upper values for persistent and non-persistent peers.
@@ -517,6 +594,32 @@ create_empty_blocks_interval = "{{ .Consensus.CreateEmptyBlocksInterval }}"
 peer_gossip_sleep_duration = "{{ .Consensus.PeerGossipSleepDuration }}"
 peer_query_maj23_sleep_duration = "{{ .Consensus.PeerQueryMaj23SleepDuration }}"
 
+# Gossip proposal blocks as compact blocks to the peers supporting it: the keys of the
+# block txs are sent instead of the block parts, and the peers rebuild the block from
+# the txs of their mempool, fetching the missing ones.
+compact_blocks = {{ .Consensus.CompactBlocks }}
+
+# How long to wait for a peer to rebuild a compact block before falling back to
+# gossiping the block parts.
+compact_block_timeout = "{{ .Consensus.CompactBlockTimeout }}"
+
+# Scale the propose and prevote timeouts by the number of parts of the proposal block
+# and by the time between the arrival of the block parts of recent heights, instead of
+# using the static timeouts above. Large blocks then get more time to propagate to slow
+# peers, while small blocks time out sooner.
+adaptive_timeouts = {{ .Consensus.AdaptiveTimeouts }}
+
+# Bounds of the adaptive propose timeout of round 0, which grows by timeout_propose_delta
+# with each round. The floor is the time allowed for the proposal to be created and to
+# start arriving, to which the time to receive its parts is added.
+timeout_propose_floor = "{{ .Consensus.TimeoutProposeFloor }}"
+timeout_propose_ceiling = "{{ .Consensus.TimeoutProposeCeiling }}"
+
+# Bounds of the adaptive prevote timeout of round 0, which grows by timeout_prevote_delta
+# with each round.
+timeout_prevote_floor = "{{ .Consensus.TimeoutPrevoteFloor }}"
+timeout_prevote_ceiling = "{{ .Consensus.TimeoutPrevoteCeiling }}"
+
 #######################################################
 ###         Storage Configuration Options           ###
 #######################################################
@@ -528,6 +631,16 @@ peer_query_maj23_sleep_duration = "{{ .Consensus.PeerQueryMaj23SleepDuration }}"
 # reindex events in the command-line tool.
 discard_abci_responses = {{ .Storage.DiscardABCIResponses}}
 
+# The number of most recent heights for which the tx info returned by the
+# /tx_status RPC endpoint is kept. If 0, tx info is pruned together with the
+# blocks. Otherwise it is pruned in the background, independently of the blocks
+# retained: it can be kept for longer, or shorter, than the blocks. Tx info can
+# be rebuilt from the stored blocks with the rebuild-tx-info command.
+tx_info_retain_blocks = {{ .Storage.TxInfoRetainBlocks }}
+
+# How often tx info older than tx_info_retain_blocks is pruned.
+tx_info_prune_interval = "{{ .Storage.TxInfoPruneInterval }}"
+
 #######################################################
 ###   Transaction Indexer Configuration Options     ###
 #######################################################
@@ -544,12 +657,32 @@ discard_abci_responses = {{ .Storage.DiscardABCIResponses}}
 # 		- When "kv" is chosen "tx.height" and "tx.hash" will always be indexed.
 #   3) "psql" - the indexer services backed by PostgreSQL.
 # When "kv" or "psql" is chosen "tx.height" and "tx.hash" will always be indexed.
+#
+# Several of "kv" and "psql" can be set, separated by commas (e.g. "kv,psql"),
+# to index into all of them: the first one serves the RPC queries. Each tracks
+# the last height it indexed, and is backfilled from the block store and the
+# ABCI responses if it falls behind or is added later (see discard_abci_responses).
 indexer = "{{ .TxIndex.Indexer }}"
 
 # The PostgreSQL connection configuration, the connection format:
 #   postgresql://<user>:<password>@<host>:<port>/<db>?<opts>
 psql-conn = "{{ .TxIndex.PsqlConn }}"
 
+# Patterns of the event attributes the "kv" indexer indexes, among the ones the
+# application marks to index. The patterns match the composite key
+# "<event type>.<attribute key>" of the attributes, with "*" matching any
+# characters (e.g. "transfer.*" or "*.sender"). An attribute is indexed if it
+# matches one of the allow patterns, or there are none, and none of the deny
+# patterns. "tx.height", "tx.hash" and "block.height" are always indexed.
+#
+# Running reindex-event removes the attributes denied from an existing index.
+kv-allow-events = [{{ range .TxIndex.KVAllowEvents }}{{ printf "%q, " . }}{{end}}]
+kv-deny-events = [{{ range .TxIndex.KVDenyEvents }}{{ printf "%q, " . }}{{end}}]
+
+# Patterns of the event attributes the "psql" indexer indexes, as above.
+psql-allow-events = [{{ range .TxIndex.PsqlAllowEvents }}{{ printf "%q, " . }}{{end}}]
+psql-deny-events = [{{ range .TxIndex.PsqlDenyEvents }}{{ printf "%q, " . }}{{end}}]
+
 #######################################################
 ###       Instrumentation Configuration Options     ###
 #######################################################
@@ -582,9 +715,15 @@ trace_push_config = "{{ .Instrumentation.TracePushConfig }}"
 # event collection. If empty, the pull based server will not be started.
 trace_pull_address = "{{ .Instrumentation.TracePullAddress }}"
 
-# The tracer to use for collecting trace data.
+# The tracer to use for collecting trace data. Options are "local", "http",
+# "otlp" and "noop".
 trace_type = "{{ .Instrumentation.TraceType }}"
 
+# The endpoint remote tracers push trace data to. The "http" tracer pushes
+# newline delimited JSON to it, and the "otlp" tracer expects an OTLP/HTTP
+# traces endpoint, e.g. "http://localhost:4318/v1/traces".
+trace_remote_url = "{{ .Instrumentation.TraceRemoteURL }}"
+
 # The size of the batches that are sent to the database.
 trace_push_batch_size = {{ .Instrumentation.TraceBufferSize }}
 
@@ -593,6 +732,19 @@ trace_push_batch_size = {{ .Instrumentation.TraceBufferSize }}
 # comma separate string. For example: "consensus_round_state,mempool_tx".
 tracing_tables = "{{ .Instrumentation.TracingTables }}"
 
+# The local tracer rotates a table file into a new segment once it reaches
+# trace_rotate_size bytes or is older than trace_rotate_age. 0 disables the
+# respective rotation.
+trace_rotate_size = {{ .Instrumentation.TraceRotateSize }}
+trace_rotate_age = "{{ .Instrumentation.TraceRotateAge }}"
+
+# The maximum number of bytes the local tracer keeps on disk across all tables.
+# The oldest rotated segments are deleted first. 0 means no limit.
+trace_max_total_size = {{ .Instrumentation.TraceMaxTotalSize }}
+
+# The compression of rotated segments. Options are "none", "gzip" and "zstd".
+trace_compression = "{{ .Instrumentation.TraceCompression }}"
+
 # The URL of the pyroscope instance to use for continuous profiling.
 # If empty, continuous profiling is disabled.
 pyroscope_url = "{{ .Instrumentation.PyroscopeURL }}"
@@ -0,0 +1,216 @@
+package consensus
+
+import (
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/gogo/protobuf/proto"
+	"google.golang.org/protobuf/encoding/protowire"
+
+	cmtproto "github.com/tendermint/tendermint/proto/tendermint/types"
+	"github.com/tendermint/tendermint/types"
+)
+
+// Field numbers of the Block and Data protobuf messages.
+const (
+	blockHeaderField     = 1
+	blockDataField       = 2
+	blockEvidenceField   = 3
+	blockLastCommitField = 4
+
+	dataTxsField        = 1
+	dataSquareSizeField = 5
+	dataHashField       = 6
+)
+
+var errUnexpectedBlockField = errors.New("unexpected block field")
+
+// blockDecoder decodes the protobuf of a proposal block incrementally, as the
+// parts from the first one are received in order, so that only the end of the
+// block is left to decode once its last part is received. The txs are decoded
+// one by one as their bytes arrive, the other fields of the block once all of
+// their bytes did.
+//
+// The block is decoded at once, as before, if its encoding isn't the one of
+// types.Block.ToProto, e.g. because it holds unknown or repeated fields.
+type blockDecoder struct {
+	partSet *types.PartSet
+	next    int // index of the next part to decode
+
+	buf   []byte // bytes of the parts received not decoded yet
+	block cmtproto.Block
+	err   error // why the block can't be decoded incrementally
+
+	// fields of the block and of its data decoded
+	fields, dataFields map[protowire.Number]bool
+	inData             bool // whether decoding the fields of the data
+	dataLeft           int  // bytes of the data not decoded yet
+}
+
+// partAdded decodes the parts of partSet received in order since the last
+// call. Passing another part set starts decoding its block.
+func (d *blockDecoder) partAdded(partSet *types.PartSet) {
+	if d.partSet != partSet {
+		*d = blockDecoder{
+			partSet:    partSet,
+			fields:     make(map[protowire.Number]bool),
+			dataFields: make(map[protowire.Number]bool),
+		}
+	}
+	if d.err != nil {
+		return
+	}
+	for d.next < int(partSet.Total()) {
+		part := partSet.GetPart(d.next)
+		if part == nil {
+			return
+		}
+		d.next++
+		d.buf = append(d.buf, part.Bytes...)
+		d.decode()
+		if d.err != nil {
+			d.buf = nil
+			return
+		}
+	}
+}
+
+// decodedBlock returns the block of partSet, which must be complete, decoding
+// what wasn't yet.
+func (d *blockDecoder) decodedBlock(partSet *types.PartSet) (*types.Block, error) {
+	d.partAdded(partSet)
+	if d.err == nil && (len(d.buf) > 0 || d.inData) {
+		d.err = io.ErrUnexpectedEOF
+	}
+	pbb := &d.block
+	if d.err != nil {
+		// decode the block at once
+		bz, err := io.ReadAll(partSet.GetReader())
+		if err != nil {
+			return nil, err
+		}
+		pbb = new(cmtproto.Block)
+		if err := proto.Unmarshal(bz, pbb); err != nil {
+			return nil, err
+		}
+	}
+	return types.BlockFromProto(pbb)
+}
+
+// decode decodes the fields of the block held in whole by d.buf, and the
+// txs of its data.
+func (d *blockDecoder) decode() {
+	for len(d.buf) > 0 {
+		var (
+			inData = d.inData
+			n      int
+		)
+		if inData {
+			n = d.decodeDataField()
+		} else {
+			n = d.decodeBlockField()
+		}
+		if n <= 0 {
+			// wait for more bytes, or give up
+			return
+		}
+		d.buf = d.buf[n:]
+		if inData {
+			d.dataLeft -= n
+			if d.dataLeft < 0 {
+				d.err = fmt.Errorf("data field overrun by %d bytes", -d.dataLeft)
+				return
+			}
+			d.inData = d.dataLeft > 0
+		}
+	}
+}
+
+// decodeBlockField decodes the field of the block at the start of d.buf and
+// returns its size, or the size of its tag and length for the data. It
+// returns 0 if d.buf doesn't hold it yet, or -1 setting d.err.
+func (d *blockDecoder) decodeBlockField() int {
+	num, typ, tagLen := protowire.ConsumeTag(d.buf)
+	if tagLen < 0 {
+		return d.needMore(tagLen)
+	}
+	if typ != protowire.BytesType || d.fields[num] {
+		d.err = fmt.Errorf("%w: %d", errUnexpectedBlockField, num)
+		return -1
+	}
+	size, sizeLen := protowire.ConsumeVarint(d.buf[tagLen:])
+	if sizeLen < 0 {
+		return d.needMore(sizeLen)
+	}
+	header := tagLen + sizeLen
+
+	if num == blockDataField {
+		d.fields[num] = true
+		d.inData = size > 0
+		d.dataLeft = int(size)
+		return header
+	}
+	if uint64(len(d.buf)-header) < size {
+		return 0
+	}
+	bz := d.buf[header : header+int(size)]
+
+	var err error
+	switch num {
+	case blockHeaderField:
+		err = d.block.Header.Unmarshal(bz)
+	case blockEvidenceField:
+		err = d.block.Evidence.Unmarshal(bz)
+	case blockLastCommitField:
+		d.block.LastCommit = new(cmtproto.Commit)
+		err = d.block.LastCommit.Unmarshal(bz)
+	default:
+		err = fmt.Errorf("%w: %d", errUnexpectedBlockField, num)
+	}
+	if err != nil {
+		d.err = err
+		return -1
+	}
+	d.fields[num] = true
+	return header + int(size)
+}
+
+// decodeDataField decodes the field of the data at the start of d.buf as
+// decodeBlockField.
+func (d *blockDecoder) decodeDataField() int {
+	num, typ, n := protowire.ConsumeField(d.buf)
+	if n < 0 {
+		return d.needMore(n)
+	}
+	bz := d.buf[:n]
+	_, _, tagLen := protowire.ConsumeTag(bz)
+	bz = bz[tagLen:]
+
+	switch {
+	case num == dataTxsField && typ == protowire.BytesType:
+		tx, _ := protowire.ConsumeBytes(bz)
+		d.block.Data.Txs = append(d.block.Data.Txs, append(make([]byte, 0, len(tx)), tx...))
+	case num == dataSquareSizeField && typ == protowire.VarintType && !d.dataFields[num]:
+		d.block.Data.SquareSize, _ = protowire.ConsumeVarint(bz)
+	case num == dataHashField && typ == protowire.BytesType && !d.dataFields[num]:
+		hash, _ := protowire.ConsumeBytes(bz)
+		d.block.Data.Hash = append([]byte{}, hash...)
+	default:
+		d.err = fmt.Errorf("%w: data %d", errUnexpectedBlockField, num)
+		return -1
+	}
+	d.dataFields[num] = true
+	return n
+}
+
+// needMore returns 0 if the error code n of protowire is due to d.buf not
+// holding a whole field yet, or -1 setting d.err otherwise.
+func (d *blockDecoder) needMore(n int) int {
+	err := protowire.ParseError(n)
+	if errors.Is(err, io.ErrUnexpectedEOF) {
+		return 0
+	}
+	d.err = err
+	return -1
+}
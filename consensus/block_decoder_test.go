@@ -0,0 +1,79 @@
+package consensus
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	cmtrand "github.com/tendermint/tendermint/libs/rand"
+	"github.com/tendermint/tendermint/types"
+)
+
+func TestBlockDecoder(t *testing.T) {
+	cs, _ := randState(1)
+	block, _ := cs.createProposalBlock()
+	require.NotNil(t, block)
+
+	txs := make([]types.Tx, 0, 200)
+	for i := 0; i < 200; i++ {
+		txs = append(txs, cmtrand.Bytes(cmtrand.Intn(500)))
+	}
+	txs = append(txs, types.Tx{})
+	bigBlock := types.MakeBlock(block.Height, types.Data{Txs: txs, SquareSize: 16},
+		block.LastCommit, nil)
+	bigBlock.Header = block.Header
+
+	for name, block := range map[string]*types.Block{"proposal": block, "txs": bigBlock} {
+		for _, partSize := range []uint32{3, 100, types.BlockPartSizeBytes} {
+			partSet := block.MakePartSet(partSize)
+			want := partSetBlock(t, partSet)
+
+			// the parts are decoded as they arrive in order, or out of order
+			for _, reverse := range []bool{false, true} {
+				var (
+					d        blockDecoder
+					received = types.NewPartSetFromHeader(partSet.Header())
+				)
+				for i := 0; i < int(partSet.Total()); i++ {
+					index := i
+					if reverse {
+						index = int(partSet.Total()) - 1 - i
+					}
+					added, err := received.AddPart(partSet.GetPart(index))
+					require.NoError(t, err)
+					require.True(t, added)
+					d.partAdded(received)
+				}
+				require.NoError(t, d.err, name)
+
+				decoded, err := d.decodedBlock(received)
+				require.NoError(t, err)
+				require.Equal(t, want, decoded, "%s: part size %d", name, partSize)
+			}
+		}
+	}
+}
+
+func TestBlockDecoderFallback(t *testing.T) {
+	cs, _ := randState(1)
+	block, _ := cs.createProposalBlock()
+	partSet := block.MakePartSet(types.BlockPartSizeBytes)
+	want := partSetBlock(t, partSet)
+
+	// a block that isn't decoded incrementally is decoded at once
+	d := blockDecoder{}
+	d.partAdded(partSet)
+	d.err = errUnexpectedBlockField
+	decoded, err := d.decodedBlock(partSet)
+	require.NoError(t, err)
+	require.Equal(t, want, decoded)
+}
+
+func partSetBlock(t *testing.T, partSet *types.PartSet) *types.Block {
+	var d blockDecoder
+	d.partSet = partSet
+	d.err = errUnexpectedBlockField
+	block, err := d.decodedBlock(partSet)
+	require.NoError(t, err)
+	return block
+}
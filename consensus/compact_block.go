@@ -0,0 +1,438 @@
+package consensus
+
+import (
+	"bytes"
+	"errors"
+	"time"
+
+	"github.com/gogo/protobuf/proto"
+
+	cstypes "github.com/tendermint/tendermint/consensus/types"
+	"github.com/tendermint/tendermint/libs/log"
+	cmtsync "github.com/tendermint/tendermint/libs/sync"
+	"github.com/tendermint/tendermint/p2p"
+	cmtproto "github.com/tendermint/tendermint/proto/tendermint/types"
+	"github.com/tendermint/tendermint/types"
+	cmttime "github.com/tendermint/tendermint/types/time"
+)
+
+// Compact blocks
+//
+// Peers most likely hold most of the txs of a proposal block in their
+// mempool already, so instead of its parts, the proposal block is sent to the
+// peers advertising the CompactBlockChannel as the keys of its txs, along with
+// the txs they are unlikely to hold. Peers rebuild the block from their
+// mempool, request the txs they are still missing, and hand the parts of the
+// rebuilt block to the consensus state.
+//
+// Once done, peers report whether the block could be rebuilt. Until then, or
+// until the CompactBlockTimeout elapses, the block parts aren't gossiped to
+// them. If the block couldn't be rebuilt, the parts are gossiped as usual.
+
+// TxFetcher is the part of the mempool compact blocks are rebuilt from.
+type TxFetcher interface {
+	GetTxByKey(key types.TxKey) (types.Tx, bool)
+}
+
+// PeerTxTracker is implemented by mempool reactors tracking which txs their
+// peers hold, such as the CAT reactor.
+type PeerTxTracker interface {
+	PeerHasTx(peer p2p.ID, key types.TxKey) bool
+}
+
+// compactBlockCache caches the compact form of the last proposal block
+// gossiped, which is the same for all peers.
+type compactBlockCache struct {
+	mtx    cmtsync.Mutex
+	hash   []byte
+	block  *cmtproto.Block
+	txKeys []types.TxKey
+}
+
+// get returns block without its txs, and the keys of its txs.
+func (c *compactBlockCache) get(block *types.Block) (*cmtproto.Block, []types.TxKey, error) {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+
+	hash := block.Hash()
+	if c.block != nil && bytes.Equal(c.hash, hash) {
+		return c.block, c.txKeys, nil
+	}
+
+	pbb, err := block.ToProto()
+	if err != nil {
+		return nil, nil, err
+	}
+	pbb.Data.Txs = nil
+	txKeys := make([]types.TxKey, len(block.Txs))
+	for i, tx := range block.Txs {
+		txKeys[i] = tx.Key()
+	}
+
+	c.hash, c.block, c.txKeys = hash, pbb, txKeys
+	return pbb, txKeys, nil
+}
+
+// sentCompactBlock is a compact block sent to a peer.
+type sentCompactBlock struct {
+	height int64
+	round  int32
+	block  *types.Block
+	sentAt time.Time
+	// set once the peer rebuilt the block, or failed to.
+	done bool
+}
+
+// pendingCompactBlock is a compact block received from a peer, being rebuilt.
+type pendingCompactBlock struct {
+	proposal *types.Proposal
+	block    *cmtproto.Block
+	txs      []types.Tx
+	// indexes of the txs missing from the mempool, in increasing order.
+	missing []uint32
+}
+
+func newPendingCompactBlock(msg *CompactBlockMessage, mempool TxFetcher) *pendingCompactBlock {
+	cb := &pendingCompactBlock{
+		proposal: msg.Proposal,
+		block:    msg.Block,
+		txs:      make([]types.Tx, len(msg.TxKeys)),
+	}
+	for i, key := range msg.TxKeys {
+		if tx, ok := msg.Txs[uint32(i)]; ok {
+			cb.txs[i] = tx
+		} else if tx, ok := mempool.GetTxByKey(key); ok {
+			cb.txs[i] = tx
+		} else {
+			cb.missing = append(cb.missing, uint32(i))
+		}
+	}
+	return cb
+}
+
+// partSet returns the parts of the rebuilt block, making sure they are the
+// parts of the proposal block.
+func (cb *pendingCompactBlock) partSet() (*types.PartSet, error) {
+	pbb := *cb.block
+	pbb.Data.Txs = make([][]byte, len(cb.txs))
+	for i, tx := range cb.txs {
+		pbb.Data.Txs[i] = tx
+	}
+	bz, err := proto.Marshal(&pbb)
+	if err != nil {
+		return nil, err
+	}
+
+	parts := types.NewPartSetFromData(bz, types.BlockPartSizeBytes)
+	if !parts.HasHeader(cb.proposal.BlockID.PartSetHeader) {
+		return nil, errors.New("rebuilt block does not match the proposal")
+	}
+	return parts, nil
+}
+
+//-----------------------------------------------------------------------------
+
+// supportsCompactBlocks returns whether compact blocks are gossiped with the
+// peer.
+func (conR *Reactor) supportsCompactBlocks(peer p2p.Peer) bool {
+	if conR.txFetcher == nil {
+		return false
+	}
+	ni, ok := peer.NodeInfo().(p2p.DefaultNodeInfo)
+	return ok && ni.HasChannel(CompactBlockChannel)
+}
+
+// peerHasTx returns whether the peer likely holds the tx. Txs missing from our
+// mempool are assumed to be missing from the peer's as well.
+func (conR *Reactor) peerHasTx(peer p2p.ID, key types.TxKey) bool {
+	if _, ok := conR.txFetcher.GetTxByKey(key); !ok {
+		return false
+	}
+	return conR.peerTxs == nil || conR.peerTxs.PeerHasTx(peer, key)
+}
+
+// gossipCompactBlock sends the proposal block to the peer as a compact block,
+// if the peer has the proposal but was sent none of its parts yet. It returns
+// whether the compact block was sent.
+func (conR *Reactor) gossipCompactBlock(logger log.Logger, rs *cstypes.RoundState,
+	prs *cstypes.PeerRoundState, ps *PeerState, peer p2p.Peer) bool {
+
+	if rs.Height != prs.Height || rs.Round != prs.Round || !prs.Proposal || !prs.ProposalBlockParts.IsEmpty() {
+		return false
+	}
+	if rs.Proposal == nil || rs.ProposalBlock == nil || !rs.ProposalBlockParts.IsComplete() ||
+		!rs.ProposalBlockParts.HasHeader(rs.Proposal.BlockID.PartSetHeader) ||
+		!prs.ProposalBlockPartSetHeader.Equals(rs.Proposal.BlockID.PartSetHeader) {
+		return false
+	}
+	if ps.hasSentCompactBlock(rs.Height, rs.Round) {
+		return false
+	}
+
+	block, txKeys, err := conR.compactBlock.get(rs.ProposalBlock)
+	if err != nil {
+		logger.Error("Failed to make compact block", "height", rs.Height, "round", rs.Round, "err", err)
+		return false
+	}
+	msg := &CompactBlockMessage{
+		Proposal: rs.Proposal,
+		Block:    block,
+		TxKeys:   txKeys,
+		Txs:      make(map[uint32]types.Tx),
+	}
+	for i, key := range txKeys {
+		if !conR.peerHasTx(peer.ID(), key) {
+			msg.Txs[uint32(i)] = rs.ProposalBlock.Txs[i]
+		}
+	}
+
+	ps.setCompactBlockSent(rs.Height, rs.Round, rs.ProposalBlock)
+	logger.Debug("Sending compact block", "height", rs.Height, "round", rs.Round,
+		"txs", len(txKeys), "sent_txs", len(msg.Txs))
+	e, err := compactBlockEnvelope(msg)
+	if err != nil || !p2p.SendEnvelopeShim(peer, e, logger) { //nolint: staticcheck
+		logger.Debug("Failed to send compact block, falling back to block parts", "err", err)
+		ps.ApplyCompactBlockStatusMessage(&CompactBlockStatusMessage{Height: rs.Height, Round: rs.Round})
+		return false
+	}
+	return true
+}
+
+// receiveCompactBlock rebuilds the proposal block of a compact block from the
+// mempool, requesting the txs missing from it to the peer.
+func (conR *Reactor) receiveCompactBlock(msg *CompactBlockMessage, ps *PeerState, peer p2p.Peer) {
+	height, round := msg.Proposal.Height, msg.Proposal.Round
+	ps.SetHasProposal(msg.Proposal)
+
+	rs := conR.getRoundState()
+	if rs.Height == height && rs.ProposalBlockParts.HasHeader(msg.Proposal.BlockID.PartSetHeader) &&
+		rs.ProposalBlockParts.IsComplete() {
+		// we got the block from other peers already
+		ps.SetHasAllProposalBlockParts(height, round)
+		conR.sendCompactBlockStatus(peer, height, round, true)
+		return
+	}
+
+	cb := newPendingCompactBlock(msg, conR.txFetcher)
+	if len(cb.missing) == 0 {
+		conR.rebuildCompactBlock(cb, ps, peer, "rebuilt")
+		return
+	}
+
+	ps.setPendingCompactBlock(cb)
+	e, err := compactBlockEnvelope(&CompactBlockTxsRequestMessage{
+		Height:  height,
+		Round:   round,
+		Indexes: cb.missing,
+	})
+	if err != nil || !p2p.TrySendEnvelopeShim(peer, e, conR.Logger) { //nolint: staticcheck
+		ps.takePendingCompactBlock(height, round)
+		conR.failCompactBlock(peer, height, round, errors.New("failed to request missing txs"))
+	}
+}
+
+// receiveCompactBlockTxsRequest sends the peer the txs it requested of the
+// compact block last sent to it.
+func (conR *Reactor) receiveCompactBlockTxsRequest(msg *CompactBlockTxsRequestMessage, ps *PeerState, peer p2p.Peer) {
+	block := ps.getSentCompactBlock(msg.Height, msg.Round)
+	if block == nil {
+		return
+	}
+
+	txs := make(map[uint32]types.Tx, len(msg.Indexes))
+	for _, index := range msg.Indexes {
+		if int(index) < len(block.Txs) {
+			txs[index] = block.Txs[index]
+		}
+	}
+	e, err := compactBlockEnvelope(&CompactBlockTxsMessage{
+		Height: msg.Height,
+		Round:  msg.Round,
+		Txs:    txs,
+	})
+	if err != nil || !p2p.TrySendEnvelopeShim(peer, e, conR.Logger) { //nolint: staticcheck
+		// the peer fails to rebuild the block, fall back to block parts
+		ps.ApplyCompactBlockStatusMessage(&CompactBlockStatusMessage{Height: msg.Height, Round: msg.Round})
+	}
+}
+
+// receiveCompactBlockTxs completes the compact block waiting for the given
+// txs, and rebuilds it.
+func (conR *Reactor) receiveCompactBlockTxs(msg *CompactBlockTxsMessage, ps *PeerState, peer p2p.Peer) {
+	cb := ps.takePendingCompactBlock(msg.Height, msg.Round)
+	if cb == nil {
+		return
+	}
+
+	for _, index := range cb.missing {
+		tx, ok := msg.Txs[index]
+		if !ok {
+			conR.failCompactBlock(peer, msg.Height, msg.Round, errors.New("peer did not send all missing txs"))
+			return
+		}
+		cb.txs[index] = tx
+	}
+	conR.rebuildCompactBlock(cb, ps, peer, "fetched")
+}
+
+// rebuildCompactBlock hands the parts of the rebuilt block to the consensus
+// state, as if they were received from the peer, and reports to the peer
+// whether the block could be rebuilt.
+func (conR *Reactor) rebuildCompactBlock(cb *pendingCompactBlock, ps *PeerState, peer p2p.Peer, status string) {
+	height, round := cb.proposal.Height, cb.proposal.Round
+	parts, err := cb.partSet()
+	if err != nil {
+		conR.failCompactBlock(peer, height, round, err)
+		return
+	}
+
+	conR.conS.peerMsgQueue <- msgInfo{&ProposalMessage{Proposal: cb.proposal}, peer.ID()}
+	for i := 0; i < int(parts.Total()); i++ {
+		conR.conS.peerMsgQueue <- msgInfo{&BlockPartMessage{
+			Height: height,
+			Round:  round,
+			Part:   parts.GetPart(i),
+		}, peer.ID()}
+	}
+	ps.SetHasAllProposalBlockParts(height, round)
+	conR.Metrics.CompactBlocks.With("status", status).Add(1)
+	conR.sendCompactBlockStatus(peer, height, round, true)
+}
+
+// failCompactBlock reports to the peer that its compact block could not be
+// rebuilt, for it to fall back to gossiping the block parts.
+func (conR *Reactor) failCompactBlock(peer p2p.Peer, height int64, round int32, err error) {
+	conR.Logger.Info("Failed to rebuild compact block", "peer", peer, "height", height, "round", round, "err", err)
+	conR.Metrics.CompactBlocks.With("status", "failed").Add(1)
+	conR.sendCompactBlockStatus(peer, height, round, false)
+}
+
+func (conR *Reactor) sendCompactBlockStatus(peer p2p.Peer, height int64, round int32, rebuilt bool) {
+	e, err := compactBlockEnvelope(&CompactBlockStatusMessage{
+		Height:  height,
+		Round:   round,
+		Rebuilt: rebuilt,
+	})
+	if err != nil {
+		conR.Logger.Error("Failed to make compact block status", "err", err)
+		return
+	}
+	p2p.TrySendEnvelopeShim(peer, e, conR.Logger) //nolint: staticcheck
+}
+
+// compactBlockEnvelope returns the envelope for sending msg on the
+// CompactBlockChannel, as long as it isn't too big to be received.
+func compactBlockEnvelope(msg Message) (p2p.Envelope, error) {
+	pb, err := MsgToProto(msg)
+	if err != nil {
+		return p2p.Envelope{}, err
+	}
+	if size := pb.Size(); size > maxCompactBlockMsgSize {
+		return p2p.Envelope{}, errors.New("compact block message is too big")
+	}
+	m, err := pb.Unwrap()
+	if err != nil {
+		return p2p.Envelope{}, err
+	}
+	return p2p.Envelope{ChannelID: CompactBlockChannel, Message: m}, nil
+}
+
+//-----------------------------------------------------------------------------
+
+// SetHasAllProposalBlockParts sets all the parts of the proposal block as known
+// for the peer.
+func (ps *PeerState) SetHasAllProposalBlockParts(height int64, round int32) {
+	ps.mtx.Lock()
+	defer ps.mtx.Unlock()
+
+	ps.setHasAllProposalBlockParts(height, round)
+}
+
+func (ps *PeerState) setHasAllProposalBlockParts(height int64, round int32) {
+	if ps.PRS.Height != height || ps.PRS.Round != round || ps.PRS.ProposalBlockParts == nil {
+		return
+	}
+
+	for i := 0; i < ps.PRS.ProposalBlockParts.Size(); i++ {
+		ps.PRS.ProposalBlockParts.SetIndex(i, true)
+	}
+}
+
+// ApplyCompactBlockStatusMessage updates the peer state for the compact block
+// it reports on.
+func (ps *PeerState) ApplyCompactBlockStatusMessage(msg *CompactBlockStatusMessage) {
+	ps.mtx.Lock()
+	defer ps.mtx.Unlock()
+
+	sent := ps.sentCompactBlock
+	if sent == nil || sent.height != msg.Height || sent.round != msg.Round {
+		return
+	}
+
+	sent.done = true
+	if msg.Rebuilt {
+		ps.setHasAllProposalBlockParts(msg.Height, msg.Round)
+	}
+}
+
+func (ps *PeerState) setCompactBlockSent(height int64, round int32, block *types.Block) {
+	ps.mtx.Lock()
+	defer ps.mtx.Unlock()
+
+	ps.sentCompactBlock = &sentCompactBlock{
+		height: height,
+		round:  round,
+		block:  block,
+		sentAt: cmttime.Now(),
+	}
+}
+
+func (ps *PeerState) hasSentCompactBlock(height int64, round int32) bool {
+	return ps.getSentCompactBlock(height, round) != nil
+}
+
+// getSentCompactBlock returns the block sent to the peer as a compact block
+// at the given height and round, if any.
+func (ps *PeerState) getSentCompactBlock(height int64, round int32) *types.Block {
+	ps.mtx.Lock()
+	defer ps.mtx.Unlock()
+
+	sent := ps.sentCompactBlock
+	if sent == nil || sent.height != height || sent.round != round {
+		return nil
+	}
+	return sent.block
+}
+
+// awaitingCompactBlock returns whether the peer has been rebuilding the
+// compact block sent to it at the given height and round for less than
+// timeout.
+func (ps *PeerState) awaitingCompactBlock(height int64, round int32, timeout time.Duration) bool {
+	ps.mtx.Lock()
+	defer ps.mtx.Unlock()
+
+	sent := ps.sentCompactBlock
+	return sent != nil && sent.height == height && sent.round == round && !sent.done &&
+		cmttime.Now().Sub(sent.sentAt) < timeout
+}
+
+func (ps *PeerState) setPendingCompactBlock(cb *pendingCompactBlock) {
+	ps.mtx.Lock()
+	defer ps.mtx.Unlock()
+
+	ps.pendingCompactBlock = cb
+}
+
+// takePendingCompactBlock returns and forgets the compact block received from
+// the peer at the given height and round, if it is still being rebuilt.
+func (ps *PeerState) takePendingCompactBlock(height int64, round int32) *pendingCompactBlock {
+	ps.mtx.Lock()
+	defer ps.mtx.Unlock()
+
+	cb := ps.pendingCompactBlock
+	if cb == nil || cb.proposal.Height != height || cb.proposal.Round != round {
+		return nil
+	}
+	ps.pendingCompactBlock = nil
+	return cb
+}
@@ -69,6 +69,11 @@ type Metrics struct {
 	// Number of blockparts transmitted by peer.
 	BlockParts metrics.Counter
 
+	// Number of compact blocks received, labeled by whether they were rebuilt
+	// from the mempool, rebuilt after fetching the missing txs, or failed to
+	// be rebuilt.
+	CompactBlocks metrics.Counter
+
 	// Histogram of step duration.
 	StepDuration metrics.Histogram
 	stepStart    time.Time
@@ -235,6 +240,13 @@ func PrometheusMetrics(namespace string, labelsAndValues ...string) *Metrics {
 			Name:      "block_parts",
 			Help:      "Number of blockparts transmitted by peer.",
 		}, append(labels, "peer_id")).With(labelsAndValues...),
+		CompactBlocks: prometheus.NewCounterFrom(stdprometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: MetricsSubsystem,
+			Name:      "compact_blocks",
+			Help: "Number of compact blocks received, labeled by whether they were rebuilt " +
+				"from the mempool, rebuilt after fetching the missing txs, or failed to be rebuilt.",
+		}, append(labels, "status")).With(labelsAndValues...),
 		BlockGossipPartsReceived: prometheus.NewCounterFrom(stdprometheus.CounterOpts{
 			Namespace: namespace,
 			Subsystem: MetricsSubsystem,
@@ -308,6 +320,7 @@ func NopMetrics() *Metrics {
 		FastSyncing:                  discard.NewGauge(),
 		StateSyncing:                 discard.NewGauge(),
 		BlockParts:                   discard.NewCounter(),
+		CompactBlocks:                discard.NewCounter(),
 		BlockGossipPartsReceived:     discard.NewCounter(),
 		QuorumPrevoteMessageDelay:    discard.NewGauge(),
 		FullPrevoteMessageDelay:      discard.NewGauge(),
@@ -3,6 +3,7 @@ package consensus
 import (
 	"errors"
 	"fmt"
+	"sort"
 
 	"github.com/gogo/protobuf/proto"
 	cstypes "github.com/tendermint/tendermint/consensus/types"
@@ -115,6 +116,43 @@ func MsgToProto(msg Message) (*cmtcons.Message, error) {
 
 		return m.Wrap().(*cmtcons.Message), nil
 
+	case *CompactBlockMessage:
+		txKeys := make([][]byte, len(msg.TxKeys))
+		for i := range msg.TxKeys {
+			txKeys[i] = msg.TxKeys[i][:]
+		}
+		m := &cmtcons.CompactBlock{
+			Proposal: *msg.Proposal.ToProto(),
+			Block:    msg.Block,
+			TxKeys:   txKeys,
+			Txs:      compactBlockTxsToProto(msg.Txs),
+		}
+		return m.Wrap().(*cmtcons.Message), nil
+
+	case *CompactBlockTxsRequestMessage:
+		m := &cmtcons.CompactBlockTxsRequest{
+			Height:  msg.Height,
+			Round:   msg.Round,
+			Indexes: msg.Indexes,
+		}
+		return m.Wrap().(*cmtcons.Message), nil
+
+	case *CompactBlockTxsMessage:
+		m := &cmtcons.CompactBlockTxs{
+			Height: msg.Height,
+			Round:  msg.Round,
+			Txs:    compactBlockTxsToProto(msg.Txs),
+		}
+		return m.Wrap().(*cmtcons.Message), nil
+
+	case *CompactBlockStatusMessage:
+		m := &cmtcons.CompactBlockStatus{
+			Height:  msg.Height,
+			Round:   msg.Round,
+			Rebuilt: msg.Rebuilt,
+		}
+		return m.Wrap().(*cmtcons.Message), nil
+
 	default:
 		return nil, fmt.Errorf("consensus: message not recognized: %T", msg)
 	}
@@ -230,6 +268,41 @@ func MsgFromProto(p *cmtcons.Message) (Message, error) {
 			BlockID: *bi,
 			Votes:   bits,
 		}
+	case *cmtcons.CompactBlock:
+		proposal, err := types.ProposalFromProto(&msg.Proposal)
+		if err != nil {
+			return nil, fmt.Errorf("compactBlock msg to proto error: %w", err)
+		}
+		txKeys := make([]types.TxKey, len(msg.TxKeys))
+		for i, key := range msg.TxKeys {
+			if txKeys[i], err = types.TxKeyFromBytes(key); err != nil {
+				return nil, fmt.Errorf("compactBlock msg to proto error: %w", err)
+			}
+		}
+		pb = &CompactBlockMessage{
+			Proposal: proposal,
+			Block:    msg.Block,
+			TxKeys:   txKeys,
+			Txs:      compactBlockTxsFromProto(msg.Txs),
+		}
+	case *cmtcons.CompactBlockTxsRequest:
+		pb = &CompactBlockTxsRequestMessage{
+			Height:  msg.Height,
+			Round:   msg.Round,
+			Indexes: msg.Indexes,
+		}
+	case *cmtcons.CompactBlockTxs:
+		pb = &CompactBlockTxsMessage{
+			Height: msg.Height,
+			Round:  msg.Round,
+			Txs:    compactBlockTxsFromProto(msg.Txs),
+		}
+	case *cmtcons.CompactBlockStatus:
+		pb = &CompactBlockStatusMessage{
+			Height:  msg.Height,
+			Round:   msg.Round,
+			Rebuilt: msg.Rebuilt,
+		}
 	default:
 		return nil, fmt.Errorf("consensus: message not recognized: %T", msg)
 	}
@@ -241,6 +314,28 @@ func MsgFromProto(p *cmtcons.Message) (Message, error) {
 	return pb, nil
 }
 
+// compactBlockTxsToProto returns the txs of a compact block, by index in the
+// block, ordered by index.
+func compactBlockTxsToProto(txs map[uint32]types.Tx) []cmtcons.CompactBlockTx {
+	if len(txs) == 0 {
+		return nil
+	}
+	pbTxs := make([]cmtcons.CompactBlockTx, 0, len(txs))
+	for index, tx := range txs {
+		pbTxs = append(pbTxs, cmtcons.CompactBlockTx{Index: index, Tx: tx})
+	}
+	sort.Slice(pbTxs, func(i, j int) bool { return pbTxs[i].Index < pbTxs[j].Index })
+	return pbTxs
+}
+
+func compactBlockTxsFromProto(pbTxs []cmtcons.CompactBlockTx) map[uint32]types.Tx {
+	txs := make(map[uint32]types.Tx, len(pbTxs))
+	for _, pbTx := range pbTxs {
+		txs[pbTx.Index] = pbTx.Tx
+	}
+	return txs
+}
+
 // MustEncode takes the reactors msg, makes it proto and marshals it
 // this mimics `MustMarshalBinaryBare` in that is panics on error
 //
@@ -68,6 +68,10 @@ func TestMsgToProto(t *testing.T) {
 	require.NoError(t, err)
 	pbVote := vote.ToProto()
 
+	pbBlock := &cmtproto.Block{Header: cmtproto.Header{Height: 1}}
+	tx := types.Tx("tx")
+	txKey := tx.Key()
+
 	testsCases := []struct {
 		testName string
 		msg      Message
@@ -167,6 +171,52 @@ func TestMsgToProto(t *testing.T) {
 			Votes:   *pbBits,
 		}).Wrap().(*cmtcons.Message),
 
+			false},
+		{"successful CompactBlockMessage", &CompactBlockMessage{
+			Proposal: &proposal,
+			Block:    pbBlock,
+			TxKeys:   []types.TxKey{txKey, txKey},
+			Txs:      map[uint32]types.Tx{1: tx},
+		}, (&cmtcons.CompactBlock{
+			Proposal: *pbProposal,
+			Block:    pbBlock,
+			TxKeys:   [][]byte{txKey[:], txKey[:]},
+			Txs:      []cmtcons.CompactBlockTx{{Index: 1, Tx: tx}},
+		}).Wrap().(*cmtcons.Message),
+
+			false},
+		{"successful CompactBlockTxsRequestMessage", &CompactBlockTxsRequestMessage{
+			Height:  1,
+			Round:   1,
+			Indexes: []uint32{0, 2},
+		}, (&cmtcons.CompactBlockTxsRequest{
+			Height:  1,
+			Round:   1,
+			Indexes: []uint32{0, 2},
+		}).Wrap().(*cmtcons.Message),
+
+			false},
+		{"successful CompactBlockTxsMessage", &CompactBlockTxsMessage{
+			Height: 1,
+			Round:  1,
+			Txs:    map[uint32]types.Tx{0: tx, 2: tx},
+		}, (&cmtcons.CompactBlockTxs{
+			Height: 1,
+			Round:  1,
+			Txs:    []cmtcons.CompactBlockTx{{Index: 0, Tx: tx}, {Index: 2, Tx: tx}},
+		}).Wrap().(*cmtcons.Message),
+
+			false},
+		{"successful CompactBlockStatusMessage", &CompactBlockStatusMessage{
+			Height:  1,
+			Round:   1,
+			Rebuilt: true,
+		}, (&cmtcons.CompactBlockStatus{
+			Height:  1,
+			Round:   1,
+			Rebuilt: true,
+		}).Wrap().(*cmtcons.Message),
+
 			false},
 		{"failure", nil, &cmtcons.Message{}, true},
 	}
@@ -0,0 +1,87 @@
+package consensus
+
+import (
+	"runtime"
+
+	cmtsync "github.com/tendermint/tendermint/libs/sync"
+	"github.com/tendermint/tendermint/types"
+)
+
+// partVerifiers is the number of routines verifying the proofs of the block
+// parts received from peers, off the consensus mutex.
+var partVerifiers = runtime.NumCPU()
+
+// startPartVerifiers starts the routines verifying the proofs of the block
+// parts received from peers.
+func (cs *State) startPartVerifiers() {
+	for i := 0; i < partVerifiers; i++ {
+		go cs.partVerifyRoutine()
+	}
+}
+
+// partVerifyRoutine verifies the proofs of the block parts of the
+// partVerifyQueue against the proposal of their height, and sends them to the
+// peerMsgQueue. The parts received before the proposal are sent as is, to be
+// verified when they are added to the proposal block.
+func (cs *State) partVerifyRoutine() {
+	for {
+		select {
+		case mi := <-cs.partVerifyQueue:
+			cs.verifyBlockPart(mi.Msg.(*BlockPartMessage))
+			select {
+			case cs.peerMsgQueue <- mi:
+			case <-cs.Quit():
+				return
+			}
+		case <-cs.Quit():
+			return
+		}
+	}
+}
+
+// verifyBlockPart verifies the proof of the part against the header of the
+// proposal block parts of its height, if known, and records it as verified
+// if valid.
+func (cs *State) verifyBlockPart(msg *BlockPartMessage) {
+	cs.mtx.RLock()
+	if cs.Height != msg.Height || cs.ProposalBlockParts == nil {
+		cs.mtx.RUnlock()
+		return
+	}
+	header := cs.ProposalBlockParts.Header()
+	cs.mtx.RUnlock()
+
+	if header.VerifyPart(msg.Part) == nil {
+		cs.verifiedParts.push(msg.Part, header)
+	}
+}
+
+// verifiedParts holds the headers of the part sets the block parts verified
+// by the part verifiers were verified against, until they are added.
+//
+// verifiedParts is safe for concurrent use.
+type verifiedParts struct {
+	mtx     cmtsync.Mutex
+	headers map[*types.Part]types.PartSetHeader
+}
+
+func (v *verifiedParts) push(part *types.Part, header types.PartSetHeader) {
+	v.mtx.Lock()
+	defer v.mtx.Unlock()
+
+	if v.headers == nil {
+		v.headers = make(map[*types.Part]types.PartSetHeader)
+	}
+	v.headers[part] = header
+}
+
+// pop returns the header the part was verified against, if it was, and
+// forgets it.
+func (v *verifiedParts) pop(part *types.Part) (types.PartSetHeader, bool) {
+	v.mtx.Lock()
+	defer v.mtx.Unlock()
+
+	header, ok := v.headers[part]
+	delete(v.headers, part)
+	return header, ok
+}
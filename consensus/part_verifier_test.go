@@ -0,0 +1,56 @@
+package consensus
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	cmtrand "github.com/tendermint/tendermint/libs/rand"
+	"github.com/tendermint/tendermint/types"
+)
+
+func TestVerifyBlockPart(t *testing.T) {
+	cs, _ := randState(1)
+	partSet := types.NewPartSetFromData(cmtrand.Bytes(3*int(types.BlockPartSizeBytes)), types.BlockPartSizeBytes)
+	part := func(i int) *BlockPartMessage {
+		return &BlockPartMessage{Height: cs.Height, Round: cs.Round, Part: partSet.GetPart(i)}
+	}
+
+	// the parts received before the proposal aren't verified
+	early := part(0)
+	cs.verifyBlockPart(early)
+	_, verified := cs.verifiedParts.pop(early.Part)
+	require.False(t, verified)
+
+	cs.ProposalBlockParts = types.NewPartSetFromHeader(partSet.Header())
+
+	// the parts of other heights aren't verified
+	other := part(0)
+	other.Height++
+	cs.verifyBlockPart(other)
+	_, verified = cs.verifiedParts.pop(other.Part)
+	require.False(t, verified)
+
+	valid := part(1)
+	cs.verifyBlockPart(valid)
+	header, verified := cs.verifiedParts.pop(valid.Part)
+	require.True(t, verified)
+	require.Equal(t, partSet.Header(), header)
+	// the verification is forgotten once the part is added
+	_, verified = cs.verifiedParts.pop(valid.Part)
+	require.False(t, verified)
+
+	invalid := part(2)
+	invalid.Part.Bytes[0]++
+	cs.verifyBlockPart(invalid)
+	_, verified = cs.verifiedParts.pop(invalid.Part)
+	require.False(t, verified)
+
+	// the parts verified are added, the others verified when added
+	cs.verifyBlockPart(valid)
+	added, err := cs.addProposalBlockPart(valid, "peer")
+	require.NoError(t, err)
+	require.True(t, added)
+	_, err = cs.addProposalBlockPart(invalid, "peer")
+	require.ErrorIs(t, err, types.ErrPartSetInvalidProof)
+}
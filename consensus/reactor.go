@@ -29,11 +29,22 @@ const (
 	DataChannel        = byte(0x21)
 	VoteChannel        = byte(0x22)
 	VoteSetBitsChannel = byte(0x23)
+	// CompactBlockChannel is only advertised by the nodes gossiping compact
+	// blocks, making it negotiated per peer.
+	CompactBlockChannel = byte(0x24)
 
 	maxMsgSize = 1048576 // 1MB; NOTE/TODO: keep in sync with types.PartSet sizes.
 
+	// compact blocks and their txs carry up to a whole block.
+	maxCompactBlockMsgSize = types.MaxBlockSizeBytes
+
 	blocksToContributeToBecomeGoodPeer = 10000
 	votesToContributeToBecomeGoodPeer  = 10000
+
+	// the peers raise their trust score once per this many useful block parts
+	// or votes, not to contend for the trust metrics on every message
+	blockPartsToContributeToBecomeUseful = 100
+	votesToContributeToBecomeUseful      = 100
 )
 
 //-----------------------------------------------------------------------------
@@ -51,6 +62,11 @@ type Reactor struct {
 
 	Metrics     *Metrics
 	traceClient trace.Tracer
+
+	// set if compact blocks are enabled, see ReactorCompactBlocks.
+	txFetcher    TxFetcher
+	peerTxs      PeerTxTracker
+	compactBlock compactBlockCache
 }
 
 type ReactorOption func(*Reactor)
@@ -150,7 +166,7 @@ conR:
 // GetChannels implements Reactor
 func (conR *Reactor) GetChannels() []*p2p.ChannelDescriptor {
 	// TODO optimize
-	return []*p2p.ChannelDescriptor{
+	channels := []*p2p.ChannelDescriptor{
 		{
 			ID:                  StateChannel,
 			Priority:            6,
@@ -184,6 +200,17 @@ func (conR *Reactor) GetChannels() []*p2p.ChannelDescriptor {
 			MessageType:         &cmtcons.Message{},
 		},
 	}
+	if conR.txFetcher != nil {
+		channels = append(channels, &p2p.ChannelDescriptor{
+			ID:                  CompactBlockChannel,
+			Priority:            10,
+			SendQueueCapacity:   10,
+			RecvBufferCapacity:  50 * 4096,
+			RecvMessageCapacity: maxCompactBlockMsgSize,
+			MessageType:         &cmtcons.Message{},
+		})
+	}
+	return channels
 }
 
 // InitPeer implements Reactor by creating a state for the peer.
@@ -398,7 +425,7 @@ func (conR *Reactor) ReceiveEnvelope(e p2p.Envelope) {
 			ps.SetHasProposalBlockPart(msg.Height, msg.Round, int(msg.Part.Index))
 			conR.Metrics.BlockParts.With("peer_id", string(e.Src.ID())).Add(1)
 			schema.WriteBlockPart(conR.traceClient, msg.Height, msg.Round, msg.Part.Index, false, string(e.Src.ID()), schema.Download)
-			conR.conS.peerMsgQueue <- msgInfo{msg, e.Src.ID()}
+			conR.conS.partVerifyQueue <- msgInfo{msg, e.Src.ID()}
 		default:
 			conR.Logger.Error(fmt.Sprintf("Unknown message type %v", reflect.TypeOf(msg)))
 		}
@@ -429,6 +456,28 @@ func (conR *Reactor) ReceiveEnvelope(e p2p.Envelope) {
 			conR.Logger.Error(fmt.Sprintf("Unknown message type %v", reflect.TypeOf(msg)))
 		}
 
+	case CompactBlockChannel:
+		if conR.WaitSync() {
+			conR.Logger.Info("Ignoring message received during sync", "msg", msg)
+			return
+		}
+		if conR.txFetcher == nil {
+			conR.Logger.Error("Received compact block message while compact blocks are disabled", "src", e.Src)
+			return
+		}
+		switch msg := msg.(type) {
+		case *CompactBlockMessage:
+			conR.receiveCompactBlock(msg, ps, e.Src)
+		case *CompactBlockTxsRequestMessage:
+			conR.receiveCompactBlockTxsRequest(msg, ps, e.Src)
+		case *CompactBlockTxsMessage:
+			conR.receiveCompactBlockTxs(msg, ps, e.Src)
+		case *CompactBlockStatusMessage:
+			ps.ApplyCompactBlockStatusMessage(msg)
+		default:
+			conR.Logger.Error(fmt.Sprintf("Unknown message type %v", reflect.TypeOf(msg)))
+		}
+
 	case VoteSetBitsChannel:
 		if conR.WaitSync() {
 			conR.Logger.Info("Ignoring message received during sync", "msg", msg)
@@ -677,8 +726,15 @@ OUTER_LOOP:
 		rs := conR.getRoundState()
 		prs := ps.GetRoundState()
 
-		// Send proposal Block parts?
-		if rs.ProposalBlockParts.HasHeader(prs.ProposalBlockPartSetHeader) {
+		// Send the proposal block as a compact block?
+		if conR.supportsCompactBlocks(peer) && conR.gossipCompactBlock(logger, rs, prs, ps, peer) {
+			continue OUTER_LOOP
+		}
+
+		// Send proposal Block parts, unless the peer is rebuilding it from a
+		// compact block?
+		if rs.ProposalBlockParts.HasHeader(prs.ProposalBlockPartSetHeader) &&
+			!ps.awaitingCompactBlock(rs.Height, rs.Round, conR.conS.config.CompactBlockTimeout) {
 			if index, ok := rs.ProposalBlockParts.BitArray().Sub(prs.ProposalBlockParts.Copy()).PickRandom(); ok {
 				part := rs.ProposalBlockParts.GetPart(index)
 				parts, err := part.ToProto()
@@ -1158,12 +1214,18 @@ func (conR *Reactor) peerStatsRoutine() {
 			}
 			switch msg.Msg.(type) {
 			case *VoteMessage:
-				if numVotes := ps.RecordVote(); numVotes%votesToContributeToBecomeGoodPeer == 0 {
+				switch numVotes := ps.RecordVote(); {
+				case numVotes%votesToContributeToBecomeGoodPeer == 0:
 					conR.Switch.MarkPeerAsGood(peer)
+				case numVotes%votesToContributeToBecomeUseful == 0:
+					conR.Switch.MarkPeerAsUseful(peer)
 				}
 			case *BlockPartMessage:
-				if numParts := ps.RecordBlockPart(); numParts%blocksToContributeToBecomeGoodPeer == 0 {
+				switch numParts := ps.RecordBlockPart(); {
+				case numParts%blocksToContributeToBecomeGoodPeer == 0:
 					conR.Switch.MarkPeerAsGood(peer)
+				case numParts%blockPartsToContributeToBecomeUseful == 0:
+					conR.Switch.MarkPeerAsUseful(peer)
 				}
 			}
 		case <-conR.conS.Quit():
@@ -1207,6 +1269,17 @@ func ReactorTracing(traceClient trace.Tracer) ReactorOption {
 	return func(conR *Reactor) { conR.traceClient = traceClient }
 }
 
+// ReactorCompactBlocks enables gossiping proposal blocks as compact blocks with
+// the peers supporting it, rebuilding the ones received from the txs of the
+// mempool. peerTxs is optional and used to send along the txs peers are
+// unlikely to hold.
+func ReactorCompactBlocks(txs TxFetcher, peerTxs PeerTxTracker) ReactorOption {
+	return func(conR *Reactor) {
+		conR.txFetcher = txs
+		conR.peerTxs = peerTxs
+	}
+}
+
 //-----------------------------------------------------------------------------
 
 var (
@@ -1225,6 +1298,11 @@ type PeerState struct {
 	mtx   sync.Mutex             // NOTE: Modify below using setters, never directly.
 	PRS   cstypes.PeerRoundState `json:"round_state"` // Exposed.
 	Stats *peerStateStats        `json:"stats"`       // Exposed.
+
+	// the last compact block sent to the peer, and the last one received from
+	// it while its missing txs are fetched.
+	sentCompactBlock    *sentCompactBlock
+	pendingCompactBlock *pendingCompactBlock
 }
 
 // peerStateStats holds internal statistics for a peer.
@@ -1714,6 +1792,10 @@ func init() {
 	cmtjson.RegisterType(&HasVoteMessage{}, "tendermint/HasVote")
 	cmtjson.RegisterType(&VoteSetMaj23Message{}, "tendermint/VoteSetMaj23")
 	cmtjson.RegisterType(&VoteSetBitsMessage{}, "tendermint/VoteSetBits")
+	cmtjson.RegisterType(&CompactBlockMessage{}, "tendermint/CompactBlock")
+	cmtjson.RegisterType(&CompactBlockTxsRequestMessage{}, "tendermint/CompactBlockTxsRequest")
+	cmtjson.RegisterType(&CompactBlockTxsMessage{}, "tendermint/CompactBlockTxs")
+	cmtjson.RegisterType(&CompactBlockStatusMessage{}, "tendermint/CompactBlockStatus")
 }
 
 //-------------------------------------
@@ -2011,3 +2093,130 @@ func (m *VoteSetBitsMessage) String() string {
 }
 
 //-------------------------------------
+
+// CompactBlockMessage is sent for gossiping a proposal block to a peer
+// supporting compact blocks. The txs of the block are replaced by their keys,
+// but for the ones the peer is unlikely to hold, for it to rebuild the block
+// from its mempool.
+type CompactBlockMessage struct {
+	Proposal *types.Proposal
+	// Block is the proposal block without its txs.
+	Block  *cmtproto.Block
+	TxKeys []types.TxKey
+	// Txs are the txs sent along, by index in the block.
+	Txs map[uint32]types.Tx
+}
+
+// ValidateBasic performs basic validation.
+func (m *CompactBlockMessage) ValidateBasic() error {
+	if m.Proposal == nil {
+		return errors.New("nil Proposal")
+	}
+	if err := m.Proposal.ValidateBasic(); err != nil {
+		return fmt.Errorf("wrong Proposal: %v", err)
+	}
+	if m.Block == nil {
+		return errors.New("nil Block")
+	}
+	if m.Block.Header.Height != m.Proposal.Height {
+		return fmt.Errorf("block height %d does not match the proposal height %d",
+			m.Block.Header.Height, m.Proposal.Height)
+	}
+	if len(m.Block.Data.Txs) != 0 {
+		return errors.New("block has txs")
+	}
+	for index := range m.Txs {
+		if int(index) >= len(m.TxKeys) {
+			return fmt.Errorf("tx index %d out of range [0, %d)", index, len(m.TxKeys))
+		}
+	}
+	return nil
+}
+
+// String returns a string representation.
+func (m *CompactBlockMessage) String() string {
+	return fmt.Sprintf("[CompactBlock H:%v R:%v Txs:%v/%v]",
+		m.Proposal.Height, m.Proposal.Round, len(m.Txs), len(m.TxKeys))
+}
+
+//-------------------------------------
+
+// CompactBlockTxsRequestMessage is sent for requesting the txs of a compact
+// block missing from the mempool.
+type CompactBlockTxsRequestMessage struct {
+	Height  int64
+	Round   int32
+	Indexes []uint32
+}
+
+// ValidateBasic performs basic validation.
+func (m *CompactBlockTxsRequestMessage) ValidateBasic() error {
+	if m.Height < 0 {
+		return errors.New("negative Height")
+	}
+	if m.Round < 0 {
+		return errors.New("negative Round")
+	}
+	if len(m.Indexes) == 0 {
+		return errors.New("no tx requested")
+	}
+	return nil
+}
+
+// String returns a string representation.
+func (m *CompactBlockTxsRequestMessage) String() string {
+	return fmt.Sprintf("[CompactBlockTxsRequest H:%v R:%v Txs:%v]", m.Height, m.Round, len(m.Indexes))
+}
+
+//-------------------------------------
+
+// CompactBlockTxsMessage is sent in response to a
+// CompactBlockTxsRequestMessage.
+type CompactBlockTxsMessage struct {
+	Height int64
+	Round  int32
+	// Txs are the requested txs, by index in the block.
+	Txs map[uint32]types.Tx
+}
+
+// ValidateBasic performs basic validation.
+func (m *CompactBlockTxsMessage) ValidateBasic() error {
+	if m.Height < 0 {
+		return errors.New("negative Height")
+	}
+	if m.Round < 0 {
+		return errors.New("negative Round")
+	}
+	return nil
+}
+
+// String returns a string representation.
+func (m *CompactBlockTxsMessage) String() string {
+	return fmt.Sprintf("[CompactBlockTxs H:%v R:%v Txs:%v]", m.Height, m.Round, len(m.Txs))
+}
+
+//-------------------------------------
+
+// CompactBlockStatusMessage is sent once a compact block was rebuilt, or
+// failed to be, for the sender to fall back to gossiping the block parts.
+type CompactBlockStatusMessage struct {
+	Height  int64
+	Round   int32
+	Rebuilt bool
+}
+
+// ValidateBasic performs basic validation.
+func (m *CompactBlockStatusMessage) ValidateBasic() error {
+	if m.Height < 0 {
+		return errors.New("negative Height")
+	}
+	if m.Round < 0 {
+		return errors.New("negative Round")
+	}
+	return nil
+}
+
+// String returns a string representation.
+func (m *CompactBlockStatusMessage) String() string {
+	return fmt.Sprintf("[CompactBlockStatus H:%v R:%v Rebuilt:%v]", m.Height, m.Round, m.Rebuilt)
+}
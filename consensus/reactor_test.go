@@ -53,6 +53,16 @@ func startConsensusNet(t *testing.T, css []*State, n int) (
 	[]*Reactor,
 	[]types.Subscription,
 	[]*types.EventBus,
+) {
+	return startConsensusNetWithOptions(t, css, n, func(int) []ReactorOption { return nil })
+}
+
+// startConsensusNetWithOptions starts a testnet whose i-th reactor is created
+// with the options returned by opts(i).
+func startConsensusNetWithOptions(t *testing.T, css []*State, n int, opts func(i int) []ReactorOption) (
+	[]*Reactor,
+	[]types.Subscription,
+	[]*types.EventBus,
 ) {
 	reactors := make([]*Reactor, n)
 	blocksSubs := make([]types.Subscription, 0)
@@ -60,7 +70,7 @@ func startConsensusNet(t *testing.T, css []*State, n int) (
 	for i := 0; i < n; i++ {
 		/*logger, err := cmtflags.ParseLogLevel("consensus:info,*:error", logger, "info")
 		if err != nil {	t.Fatal(err)}*/
-		reactors[i] = NewReactor(css[i], true) // so we dont start the consensus states
+		reactors[i] = NewReactor(css[i], true, opts(i)...) // so we dont start the consensus states
 		reactors[i].SetLogger(css[i].Logger)
 
 		// eventBus is already started with the cs
@@ -126,6 +136,52 @@ func TestReactorBasic(t *testing.T) {
 	}, css)
 }
 
+// Ensure a testnet gossiping compact blocks commits txs, whether all the
+// validators have them in their mempool or only the proposer does.
+func TestReactorCompactBlocks(t *testing.T) {
+	N := 4
+	css, cleanup := randConsensusNet(N, "consensus_reactor_compact_blocks_test", newMockTickerFunc(true),
+		newPersistentKVStore)
+	defer cleanup()
+	reactors, blocksSubs, eventBuses := startConsensusNetWithOptions(t, css, N, func(i int) []ReactorOption {
+		return []ReactorOption{ReactorCompactBlocks(assertMempool(css[i].txNotifier), nil)}
+	})
+	defer stopConsensusNet(log.TestingLogger(), reactors, eventBuses)
+
+	activeVals := make(map[string]struct{})
+	for i := 0; i < N; i++ {
+		pubKey, err := css[i].privValidator.GetPubKey()
+		require.NoError(t, err)
+		activeVals[string(pubKey.Address())] = struct{}{}
+	}
+
+	// wait till everyone makes block 1
+	timeoutWaitGroup(t, N, func(j int) {
+		<-blocksSubs[j].Out()
+	}, css)
+
+	sharedTx := kvstore.NewTx("shared", "tx")
+	for i := 0; i < N; i++ {
+		require.NoError(t, assertMempool(css[i].txNotifier).CheckTx(sharedTx, nil, mempl.TxInfo{}))
+	}
+	// the other validators fetch this one from the first one once it proposes
+	ownTx := kvstore.NewTx("own", "tx")
+	require.NoError(t, assertMempool(css[0].txNotifier).CheckTx(ownTx, nil, mempl.TxInfo{}))
+
+	waitForAndValidateBlockWithTx(t, N, activeVals, blocksSubs, css, sharedTx, ownTx)
+
+	sent := false
+	for _, r := range reactors {
+		for _, peer := range r.Switch.Peers().List() {
+			ps := peer.Get(types.PeerStateKey).(*PeerState)
+			ps.mtx.Lock()
+			sent = sent || ps.sentCompactBlock != nil
+			ps.mtx.Unlock()
+		}
+	}
+	assert.True(t, sent, "no compact block was sent")
+}
+
 // Ensure we can process blocks with evidence
 func TestReactorWithEvidence(t *testing.T) {
 	nValidators := 4
@@ -1045,6 +1101,76 @@ func TestVoteSetBitsMessageValidateBasic(t *testing.T) {
 	}
 }
 
+func TestCompactBlockMessageValidateBasic(t *testing.T) {
+	tx := types.Tx("tx")
+	testCases := []struct {
+		malleateFn func(*CompactBlockMessage)
+		expErr     string
+	}{
+		{func(msg *CompactBlockMessage) {}, ""},
+		{func(msg *CompactBlockMessage) { msg.Proposal = nil }, "nil Proposal"},
+		{func(msg *CompactBlockMessage) { msg.Proposal.Signature = nil }, "wrong Proposal"},
+		{func(msg *CompactBlockMessage) { msg.Block = nil }, "nil Block"},
+		{func(msg *CompactBlockMessage) { msg.Block.Header.Height = 2 },
+			"block height 2 does not match the proposal height 1"},
+		{func(msg *CompactBlockMessage) { msg.Block.Data.Txs = [][]byte{tx} }, "block has txs"},
+		{func(msg *CompactBlockMessage) { msg.Txs[1] = tx }, "tx index 1 out of range [0, 1)"},
+	}
+
+	for i, tc := range testCases {
+		tc := tc
+		t.Run(fmt.Sprintf("#%d", i), func(t *testing.T) {
+			proposal := types.NewProposal(1, 0, -1, types.BlockID{
+				Hash:          tmhash.Sum([]byte("block")),
+				PartSetHeader: types.PartSetHeader{Total: 1, Hash: tmhash.Sum([]byte("parts"))},
+			})
+			proposal.Signature = []byte("signature")
+			msg := &CompactBlockMessage{
+				Proposal: proposal,
+				Block:    &cmtproto.Block{Header: cmtproto.Header{Height: 1}},
+				TxKeys:   []types.TxKey{tx.Key()},
+				Txs:      map[uint32]types.Tx{0: tx},
+			}
+
+			tc.malleateFn(msg)
+			err := msg.ValidateBasic()
+			if tc.expErr == "" {
+				assert.NoError(t, err)
+			} else if assert.Error(t, err) {
+				assert.Contains(t, err.Error(), tc.expErr)
+			}
+		})
+	}
+}
+
+func TestCompactBlockTxsRequestMessageValidateBasic(t *testing.T) {
+	testCases := []struct {
+		testName       string
+		messageHeight  int64
+		messageRound   int32
+		messageIndexes []uint32
+		expectErr      bool
+	}{
+		{"Valid Message", 0, 0, []uint32{0}, false},
+		{"Invalid Message", -1, 0, []uint32{0}, true},
+		{"Invalid Message", 0, -1, []uint32{0}, true},
+		{"Invalid Message", 0, 0, nil, true},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.testName, func(t *testing.T) {
+			message := CompactBlockTxsRequestMessage{
+				Height:  tc.messageHeight,
+				Round:   tc.messageRound,
+				Indexes: tc.messageIndexes,
+			}
+
+			assert.Equal(t, tc.expectErr, message.ValidateBasic() != nil, "Validate Basic had an unexpected result")
+		})
+	}
+}
+
 func TestMarshalJSONPeerState(t *testing.T) {
 	ps := NewPeerState(nil)
 	data, err := json.Marshal(ps)
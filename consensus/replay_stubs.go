@@ -47,10 +47,14 @@ func (emptyMempool) TxsBytes() int64               { return 0 }
 func (emptyMempool) TxsFront() *clist.CElement    { return nil }
 func (emptyMempool) TxsWaitChan() <-chan struct{} { return nil }
 
-func (emptyMempool) InitWAL() error                          { return nil }
-func (emptyMempool) CloseWAL()                               {}
-func (emptyMempool) GetTxByKey(types.TxKey) (types.Tx, bool) { return nil, false }
-func (emptyMempool) WasRecentlyEvicted(types.TxKey) bool     { return false }
+func (emptyMempool) InitWAL() error                                           { return nil }
+func (emptyMempool) CloseWAL()                                                {}
+func (emptyMempool) GetTxByKey(types.TxKey) (types.Tx, bool)                  { return nil, false }
+func (emptyMempool) WasRecentlyEvicted(types.TxKey) bool                      { return false }
+func (emptyMempool) GetPendingTx(types.TxKey) (*mempl.PendingTx, bool)        { return nil, false }
+func (emptyMempool) GetRemovalReason(types.TxKey) (mempl.RemovalReason, bool) { return "", false }
+func (emptyMempool) SetTxListener(mempl.TxListener)                           {}
+func (emptyMempool) Snapshot() *mempl.Snapshot                                { return &mempl.Snapshot{} }
 
 //-----------------------------------------------------------------------------
 // mockProxyApp uses ABCIResponses to give the right results.
@@ -4,14 +4,11 @@ import (
 	"bytes"
 	"errors"
 	"fmt"
-	"io"
 	"os"
 	"runtime/debug"
 	"sort"
 	"time"
 
-	"github.com/gogo/protobuf/proto"
-
 	cfg "github.com/tendermint/tendermint/config"
 	cstypes "github.com/tendermint/tendermint/consensus/types"
 	"github.com/tendermint/tendermint/crypto"
@@ -145,6 +142,17 @@ type State struct {
 	metrics *Metrics
 
 	traceClient trace.Tracer
+
+	// for the adaptive timeouts, see timeouts.go
+	partTimes    partTimes
+	proposeStart time.Time // when the propose step of the round was entered
+
+	// block parts received from peers, which proofs are verified by the part
+	// verifiers before they are sent to the peerMsgQueue
+	partVerifyQueue chan msgInfo
+	verifiedParts   verifiedParts
+	// decodes the proposal block as its parts are received
+	blockDecoder blockDecoder
 }
 
 // StateOption sets an optional parameter on the State.
@@ -166,6 +174,7 @@ func NewState(
 		blockStore:       blockStore,
 		txNotifier:       txNotifier,
 		peerMsgQueue:     make(chan msgInfo, msgQueueSize),
+		partVerifyQueue:  make(chan msgInfo, msgQueueSize),
 		internalMsgQueue: make(chan msgInfo, msgQueueSize),
 		timeoutTicker:    NewTimeoutTicker(),
 		statsMsgQueue:    make(chan msgInfo, msgQueueSize),
@@ -388,7 +397,8 @@ func (cs *State) OnStart() error {
 		return err
 	}
 
-	// now start the receiveRoutine
+	// now start the part verifiers and the receiveRoutine
+	cs.startPartVerifiers()
 	go cs.receiveRoutine(0)
 
 	// schedule the first round!
@@ -407,6 +417,7 @@ func (cs *State) startRoutines(maxSteps int) {
 		return
 	}
 
+	cs.startPartVerifiers()
 	go cs.receiveRoutine(maxSteps)
 }
 
@@ -946,6 +957,14 @@ func (cs *State) handleTimeout(ti timeoutInfo, rs cstypes.RoundState) {
 		cs.enterPropose(ti.Height, 0)
 
 	case cstypes.RoundStepPropose:
+		// wait for the rest of a proposal block larger than expected
+		if extension := cs.proposeTimeoutExtension(ti); extension > 0 {
+			cs.Logger.Debug("extending propose timeout", "height", ti.Height, "round", ti.Round,
+				"extension", extension)
+			cs.scheduleTimeout(extension, ti.Height, ti.Round, cstypes.RoundStepPropose)
+			return
+		}
+
 		if err := cs.eventBus.PublishEventTimeoutPropose(cs.RoundStateEvent()); err != nil {
 			cs.Logger.Error("failed publishing timeout propose", "err", err)
 		}
@@ -1125,7 +1144,8 @@ func (cs *State) enterPropose(height int64, round int32) {
 	}()
 
 	// If we don't get the proposal and all block parts quick enough, enterPrevote
-	cs.scheduleTimeout(cs.config.ProposeWithCustomTimeout(round, cs.state.TimeoutPropose), height, round, cstypes.RoundStepPropose)
+	cs.proposeStart = time.Now()
+	cs.scheduleTimeout(cs.proposeTimeout(height, round), height, round, cstypes.RoundStepPropose)
 
 	// Nothing more to do if we're not a validator
 	if cs.privValidator == nil {
@@ -1373,7 +1393,7 @@ func (cs *State) enterPrevoteWait(height int64, round int32) {
 	}()
 
 	// Wait for some more prevotes; enterPrecommit
-	cs.scheduleTimeout(cs.config.Prevote(round), height, round, cstypes.RoundStepPrevoteWait)
+	cs.scheduleTimeout(cs.prevoteTimeout(height, round), height, round, cstypes.RoundStepPrevoteWait)
 }
 
 // Enter: `timeoutPrevote` after any +2/3 prevotes.
@@ -1934,6 +1954,7 @@ func (cs *State) defaultSetProposal(proposal *types.Proposal) error {
 // once we have the full block.
 func (cs *State) addProposalBlockPart(msg *BlockPartMessage, peerID p2p.ID) (added bool, err error) {
 	height, round, part := msg.Height, msg.Round, msg.Part
+	header, verified := cs.verifiedParts.pop(part)
 
 	// Blocks might be reused, so round mismatch is OK
 	if cs.Height != height {
@@ -1957,7 +1978,11 @@ func (cs *State) addProposalBlockPart(msg *BlockPartMessage, peerID p2p.ID) (add
 		return false, nil
 	}
 
-	added, err = cs.ProposalBlockParts.AddPart(part)
+	if verified && cs.ProposalBlockParts.HasHeader(header) {
+		added, err = cs.ProposalBlockParts.AddVerifiedPart(part)
+	} else {
+		added, err = cs.ProposalBlockParts.AddPart(part)
+	}
 	if err != nil {
 		if errors.Is(err, types.ErrPartSetInvalidProof) || errors.Is(err, types.ErrPartSetUnexpectedIndex) {
 			cs.metrics.BlockGossipPartsReceived.With("matches_current", "false").Add(1)
@@ -1966,6 +1991,10 @@ func (cs *State) addProposalBlockPart(msg *BlockPartMessage, peerID p2p.ID) (add
 	}
 
 	cs.metrics.BlockGossipPartsReceived.With("matches_current", "true").Add(1)
+	if added {
+		cs.partTimes.partAdded(cs.ProposalBlockParts, peerID != "", time.Now())
+		cs.blockDecoder.partAdded(cs.ProposalBlockParts)
+	}
 
 	if cs.ProposalBlockParts.ByteSize() > cs.state.ConsensusParams.Block.MaxBytes {
 		return added, fmt.Errorf("total size of proposal block parts exceeds maximum block bytes (%d > %d)",
@@ -1973,18 +2002,7 @@ func (cs *State) addProposalBlockPart(msg *BlockPartMessage, peerID p2p.ID) (add
 		)
 	}
 	if added && cs.ProposalBlockParts.IsComplete() {
-		bz, err := io.ReadAll(cs.ProposalBlockParts.GetReader())
-		if err != nil {
-			return added, err
-		}
-
-		pbb := new(cmtproto.Block)
-		err = proto.Unmarshal(bz, pbb)
-		if err != nil {
-			return added, err
-		}
-
-		block, err := types.BlockFromProto(pbb)
+		block, err := cs.blockDecoder.decodedBlock(cs.ProposalBlockParts)
 		if err != nil {
 			return added, err
 		}
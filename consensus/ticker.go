@@ -90,10 +90,14 @@ func (t *timeoutTicker) stopTimer() {
 
 // send on tickChan to start a new timer.
 // timers are interupted and replaced by new ticks from later steps
+// a tick for the step of a timer that fired extends its timeout
 // timeouts of 0 on the tickChan will be immediately relayed to the tockChan
 func (t *timeoutTicker) timeoutRoutine() {
 	t.Logger.Debug("Starting timeout routine")
-	var ti timeoutInfo
+	var (
+		ti    timeoutInfo
+		fired bool
+	)
 	for {
 		select {
 		case newti := <-t.tickChan:
@@ -106,7 +110,7 @@ func (t *timeoutTicker) timeoutRoutine() {
 				if newti.Round < ti.Round {
 					continue
 				} else if newti.Round == ti.Round {
-					if ti.Step > 0 && newti.Step <= ti.Step {
+					if ti.Step > 0 && (newti.Step < ti.Step || (newti.Step == ti.Step && !fired)) {
 						continue
 					}
 				}
@@ -118,10 +122,12 @@ func (t *timeoutTicker) timeoutRoutine() {
 			// update timeoutInfo and reset timer
 			// NOTE time.Timer allows duration to be non-positive
 			ti = newti
+			fired = false
 			t.timer.Reset(ti.Duration)
 			t.Logger.Debug("Scheduled timeout", "dur", ti.Duration, "height", ti.Height, "round", ti.Round, "step", ti.Step)
 		case <-t.timer.C:
 			t.Logger.Info("Timed out", "dur", ti.Duration, "height", ti.Height, "round", ti.Round, "step", ti.Step)
+			fired = true
 			// go routine here guarantees timeoutRoutine doesn't block.
 			// Determinism comes from playback in the receiveRoutine.
 			// We can eliminate it by merging the timeoutRoutine into receiveRoutine
@@ -0,0 +1,170 @@
+package consensus
+
+import (
+	"time"
+
+	cstypes "github.com/tendermint/tendermint/consensus/types"
+	"github.com/tendermint/tendermint/pkg/trace/schema"
+	"github.com/tendermint/tendermint/types"
+)
+
+const (
+	// partTimeHeights is the number of recent heights the time between the
+	// arrival of the parts of their proposal block is averaged over.
+	partTimeHeights = 20
+
+	// partTimeMargin multiplies the expected time to receive the parts of a
+	// proposal block, to leave time to the peers slower than the node.
+	partTimeMargin = 2
+)
+
+// partTimes tracks the time between the arrival of the parts of the proposal
+// blocks of recent heights, which the adaptive timeouts are scaled by.
+type partTimes struct {
+	// parts and time between parts of the proposal blocks of recent heights
+	samples []partTime
+	next    int
+
+	// proposal block parts being received, and when the first of them was
+	// received from a peer
+	partSet *types.PartSet
+	start   time.Time
+}
+
+type partTime struct {
+	parts   uint32
+	perPart time.Duration
+}
+
+// partAdded records that a part of partSet was added at now, received from
+// a peer or not. Once partSet is complete, the time between the arrival of
+// its parts is sampled, unless the parts weren't received from peers.
+func (p *partTimes) partAdded(partSet *types.PartSet, fromPeer bool, now time.Time) {
+	if p.partSet != partSet {
+		p.partSet = partSet
+		p.start = time.Time{}
+	}
+	if p.start.IsZero() {
+		if fromPeer && partSet.Count() == 1 {
+			p.start = now
+		}
+		return
+	}
+	if !partSet.IsComplete() {
+		return
+	}
+
+	total := partSet.Total()
+	sample := partTime{parts: total, perPart: now.Sub(p.start) / time.Duration(total-1)}
+	if len(p.samples) < partTimeHeights {
+		p.samples = append(p.samples, sample)
+	} else {
+		p.samples[p.next] = sample
+		p.next = (p.next + 1) % partTimeHeights
+	}
+	p.start = time.Time{}
+}
+
+// mean returns the mean number of parts of the proposal blocks sampled and
+// the mean time between the arrival of their parts, zero if none were.
+func (p *partTimes) mean() (parts uint32, perPart time.Duration) {
+	if len(p.samples) == 0 {
+		return 0, 0
+	}
+	var totalParts, totalPerPart int64
+	for _, sample := range p.samples {
+		totalParts += int64(sample.parts)
+		totalPerPart += int64(sample.perPart)
+	}
+	n := int64(len(p.samples))
+	//nolint:gosec
+	return uint32(totalParts / n), time.Duration(totalPerPart / n)
+}
+
+// adaptiveTimeout returns the timeout of the round for receiving a proposal
+// block of the given parts arriving perPart apart: floor plus the time to
+// receive the parts with a margin, at most ceiling, increased by delta for
+// each round.
+func adaptiveTimeout(
+	parts uint32,
+	perPart time.Duration,
+	round int32,
+	delta, floor, ceiling time.Duration,
+) time.Duration {
+	timeout := floor + partTimeMargin*time.Duration(parts)*perPart
+	if timeout > ceiling {
+		timeout = ceiling
+	}
+	return timeout + time.Duration(round)*delta
+}
+
+// proposeTimeout returns the propose timeout of the round. With adaptive
+// timeouts, it is scaled by the size of the proposal block once part
+// arrival times were observed, and the decision is traced.
+func (cs *State) proposeTimeout(height int64, round int32) time.Duration {
+	static := cs.config.ProposeWithCustomTimeout(round, cs.state.TimeoutPropose)
+	if !cs.config.AdaptiveTimeouts {
+		return static
+	}
+
+	timeout, parts, perPart := cs.scaledTimeout(static, round, cs.config.TimeoutProposeDelta,
+		cs.config.TimeoutProposeFloor, cs.config.TimeoutProposeCeiling)
+	schema.WriteTimeout(cs.traceClient, height, round, schema.TimeoutPropose, parts, perPart, static, timeout)
+	return timeout
+}
+
+// prevoteTimeout returns the prevote timeout of the round, scaled by the size
+// of the proposal block as the propose timeout.
+func (cs *State) prevoteTimeout(height int64, round int32) time.Duration {
+	static := cs.config.Prevote(round)
+	if !cs.config.AdaptiveTimeouts {
+		return static
+	}
+
+	timeout, parts, perPart := cs.scaledTimeout(static, round, cs.config.TimeoutPrevoteDelta,
+		cs.config.TimeoutPrevoteFloor, cs.config.TimeoutPrevoteCeiling)
+	schema.WriteTimeout(cs.traceClient, height, round, schema.TimeoutPrevote, parts, perPart, static, timeout)
+	return timeout
+}
+
+// proposeTimeoutExtension returns how much longer to wait for the proposal
+// block on the propose timeout ti, zero to prevote now. With adaptive
+// timeouts, the propose timeout scheduled before the proposal was received
+// is extended to the one of the size of the proposal block, if larger.
+func (cs *State) proposeTimeoutExtension(ti timeoutInfo) time.Duration {
+	if !cs.config.AdaptiveTimeouts ||
+		ti.Height != cs.Height || ti.Round != cs.Round || cs.Step != cstypes.RoundStepPropose ||
+		cs.Proposal == nil || cs.isProposalComplete() || cs.proposeStart.IsZero() {
+		return 0
+	}
+
+	static := cs.config.ProposeWithCustomTimeout(ti.Round, cs.state.TimeoutPropose)
+	timeout, parts, perPart := cs.scaledTimeout(static, ti.Round, cs.config.TimeoutProposeDelta,
+		cs.config.TimeoutProposeFloor, cs.config.TimeoutProposeCeiling)
+	extension := timeout - time.Since(cs.proposeStart)
+	if extension <= 0 {
+		return 0
+	}
+	schema.WriteTimeout(cs.traceClient, ti.Height, ti.Round, schema.TimeoutProposeExtended,
+		parts, perPart, static, timeout)
+	return extension
+}
+
+// scaledTimeout returns the adaptive timeout of the round for the proposal
+// block, see adaptiveTimeout, along with the parts and time between parts it
+// is scaled by. It returns the static timeout until part arrival times are
+// observed.
+func (cs *State) scaledTimeout(
+	static time.Duration,
+	round int32,
+	delta, floor, ceiling time.Duration,
+) (timeout time.Duration, parts uint32, perPart time.Duration) {
+	parts, perPart = cs.partTimes.mean()
+	if cs.Proposal != nil {
+		parts = cs.Proposal.BlockID.PartSetHeader.Total
+	}
+	if perPart == 0 {
+		return static, parts, perPart
+	}
+	return adaptiveTimeout(parts, perPart, round, delta, floor, ceiling), parts, perPart
+}
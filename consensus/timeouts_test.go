@@ -0,0 +1,117 @@
+package consensus
+
+import (
+	"os"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/tendermint/tendermint/abci/example/counter"
+	"github.com/tendermint/tendermint/crypto/tmhash"
+	"github.com/tendermint/tendermint/types"
+)
+
+func TestPartTimes(t *testing.T) {
+	var (
+		p   partTimes
+		now = time.Now()
+	)
+	parts, perPart := p.mean()
+	require.Zero(t, parts)
+	require.Zero(t, perPart)
+
+	addParts := func(partSet *types.PartSet, fromPeer bool, interval time.Duration) {
+		for i := 0; i < int(partSet.Total()); i++ {
+			part := partSet.GetPart(i)
+			_, err := p.partSet.AddPart(part)
+			require.NoError(t, err)
+			p.partAdded(p.partSet, fromPeer, now)
+			now = now.Add(interval)
+		}
+	}
+	newPartSet := func(parts int) *types.PartSet {
+		full := types.NewPartSetFromData(make([]byte, parts*int(types.BlockPartSizeBytes)), types.BlockPartSizeBytes)
+		p.partSet = types.NewPartSetFromHeader(full.Header())
+		p.start = time.Time{}
+		return full
+	}
+
+	// the parts of a block of 5 parts arrive 100ms apart
+	addParts(newPartSet(5), true, 100*time.Millisecond)
+	parts, perPart = p.mean()
+	require.EqualValues(t, 5, parts)
+	require.Equal(t, 100*time.Millisecond, perPart)
+
+	// the parts of a block of 3 parts arrive 300ms apart
+	addParts(newPartSet(3), true, 300*time.Millisecond)
+	parts, perPart = p.mean()
+	require.EqualValues(t, 4, parts)
+	require.Equal(t, 200*time.Millisecond, perPart)
+
+	// the blocks of a single part and the blocks of the node aren't sampled
+	addParts(newPartSet(1), true, time.Second)
+	addParts(newPartSet(5), false, 0)
+	require.Len(t, p.samples, 2)
+
+	// the samples of the oldest heights are dropped
+	for i := 0; i < partTimeHeights; i++ {
+		addParts(newPartSet(2), true, 10*time.Millisecond)
+	}
+	parts, perPart = p.mean()
+	require.EqualValues(t, 2, parts)
+	require.Equal(t, 10*time.Millisecond, perPart)
+}
+
+func TestAdaptiveTimeout(t *testing.T) {
+	floor, ceiling, delta := time.Second, 5*time.Second, 500*time.Millisecond
+
+	// the time to receive the parts is added to the floor, with a margin
+	require.Equal(t, 3*time.Second, adaptiveTimeout(10, 100*time.Millisecond, 0, delta, floor, ceiling))
+	require.Equal(t, floor+20*time.Millisecond, adaptiveTimeout(1, 10*time.Millisecond, 0, delta, floor, ceiling))
+	// up to the ceiling
+	require.Equal(t, ceiling, adaptiveTimeout(100, 100*time.Millisecond, 0, delta, floor, ceiling))
+	// increased with each round
+	require.Equal(t, ceiling+2*delta, adaptiveTimeout(100, 100*time.Millisecond, 2, delta, floor, ceiling))
+}
+
+// The propose timeout scheduled before the proposal is received is extended
+// to the time to receive the parts of a proposal block larger than expected.
+func TestStateAdaptiveProposeTimeout(t *testing.T) {
+	config := ResetConfig("consensus_adaptive_timeouts_test")
+	defer os.RemoveAll(config.RootDir)
+	config.Consensus.AdaptiveTimeouts = true
+	config.Consensus.TimeoutProposeFloor = 50 * time.Millisecond
+	config.Consensus.TimeoutProposeCeiling = 5 * time.Second
+
+	state, privVals := randGenesisState(2, false, 10)
+	cs1 := newStateWithConfig(config, state, privVals[0], counter.NewApplication(true))
+	vs2 := newValidatorStub(privVals[1], 1)
+	incrementHeight(vs2)
+	height, round := cs1.Height, cs1.Round
+
+	// blocks of a single part arrived 50ms apart from their proposal: the
+	// propose timeout is 150ms
+	cs1.partTimes.samples = []partTime{{parts: 1, perPart: 50 * time.Millisecond}}
+
+	// make the second validator the proposer of a block of 10 parts: the
+	// propose timeout is extended to 1050ms
+	round++
+	incrementRound(vs2)
+	blockID := types.BlockID{
+		Hash:          tmhash.Sum([]byte("block")),
+		PartSetHeader: types.PartSetHeader{Total: 10, Hash: tmhash.Sum([]byte("parts"))},
+	}
+	proposal := types.NewProposal(vs2.Height, round, -1, blockID)
+	p := proposal.ToProto()
+	require.NoError(t, vs2.SignProposal(config.ChainID(), p))
+	proposal.Signature = p.Signature
+	require.NoError(t, cs1.SetProposal(proposal, "peer"))
+
+	timeoutProposeCh := subscribe(cs1.eventBus, types.EventQueryTimeoutPropose)
+	start := time.Now()
+	startTestRound(cs1, height, round)
+
+	ensureNewTimeout(timeoutProposeCh, height, round, (2 * time.Second).Nanoseconds())
+	require.GreaterOrEqual(t, time.Since(start), time.Second)
+}
@@ -0,0 +1,90 @@
+package light
+
+import (
+	"container/list"
+
+	cmtsync "github.com/tendermint/tendermint/libs/sync"
+	"github.com/tendermint/tendermint/types"
+)
+
+// verifiedCache is a thread-safe LRU cache of light blocks that have already
+// been verified, keyed by height. It complements the trusted store, which is
+// pruned down to the latest pruningSize light blocks, so that repeatedly
+// serving data for older heights (e.g. search results) doesn't require
+// verifying the same headers again.
+type verifiedCache struct {
+	mtx      cmtsync.Mutex
+	size     int
+	cacheMap map[int64]*list.Element
+	list     *list.List
+}
+
+func newVerifiedCache(size int) *verifiedCache {
+	return &verifiedCache{
+		size:     size,
+		cacheMap: make(map[int64]*list.Element, size),
+		list:     list.New(),
+	}
+}
+
+// Get returns the verified light block at the given height, if cached.
+func (c *verifiedCache) Get(height int64) (*types.LightBlock, bool) {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+
+	e, ok := c.cacheMap[height]
+	if !ok {
+		return nil, false
+	}
+	c.list.MoveToBack(e)
+	return e.Value.(*types.LightBlock), true
+}
+
+// Add caches a verified light block, evicting the least recently used one if
+// the cache is full.
+func (c *verifiedCache) Add(l *types.LightBlock) {
+	if c.size <= 0 {
+		return
+	}
+
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+
+	if e, ok := c.cacheMap[l.Height]; ok {
+		e.Value = l
+		c.list.MoveToBack(e)
+		return
+	}
+
+	if c.list.Len() >= c.size {
+		front := c.list.Front()
+		if front != nil {
+			delete(c.cacheMap, front.Value.(*types.LightBlock).Height)
+			c.list.Remove(front)
+		}
+	}
+
+	c.cacheMap[l.Height] = c.list.PushBack(l)
+}
+
+// RemoveAfter drops all cached light blocks above the given height.
+func (c *verifiedCache) RemoveAfter(height int64) {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+
+	for h, e := range c.cacheMap {
+		if h > height {
+			delete(c.cacheMap, h)
+			c.list.Remove(e)
+		}
+	}
+}
+
+// Reset empties the cache.
+func (c *verifiedCache) Reset() {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+
+	c.cacheMap = make(map[int64]*list.Element, c.size)
+	c.list.Init()
+}
@@ -0,0 +1,43 @@
+package light
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/tendermint/tendermint/types"
+)
+
+func TestVerifiedCache(t *testing.T) {
+	lb := func(height int64) *types.LightBlock {
+		return &types.LightBlock{SignedHeader: &types.SignedHeader{Header: &types.Header{Height: height}}}
+	}
+
+	c := newVerifiedCache(2)
+	c.Add(lb(1))
+	c.Add(lb(2))
+
+	// accessing 1 makes 2 the least recently used
+	_, ok := c.Get(1)
+	assert.True(t, ok)
+	c.Add(lb(3))
+	_, ok = c.Get(2)
+	assert.False(t, ok)
+
+	c.RemoveAfter(1)
+	_, ok = c.Get(3)
+	assert.False(t, ok)
+	l, ok := c.Get(1)
+	assert.True(t, ok)
+	assert.EqualValues(t, 1, l.Height)
+
+	c.Reset()
+	_, ok = c.Get(1)
+	assert.False(t, ok)
+
+	// a zero sized cache never stores anything
+	c = newVerifiedCache(0)
+	c.Add(lb(1))
+	_, ok = c.Get(1)
+	assert.False(t, ok)
+}
@@ -23,8 +23,9 @@ const (
 	sequential mode = iota + 1
 	skipping
 
-	defaultPruningSize      = 1000
-	defaultMaxRetryAttempts = 10
+	defaultPruningSize       = 1000
+	defaultVerifiedCacheSize = 1000
+	defaultMaxRetryAttempts  = 10
 	// For verifySkipping, when using the cache of headers from the previous batch,
 	// they will always be at a height greater than 1/2 (normal verifySkipping) so to
 	// find something in between the range, 9/16 is used.
@@ -79,6 +80,18 @@ func PruningSize(h uint16) Option {
 	}
 }
 
+// VerifiedCacheSize option sets the maximum amount of verified light blocks
+// that the light client keeps in memory, in addition to the trusted store.
+// Unlike the trusted store, the cache is not pruned by height, so light blocks
+// which were verified on demand (e.g. to check a proof for an old height) are
+// not verified again while they remain cached.
+// Default: 1000. A size of 0 disables the cache.
+func VerifiedCacheSize(size uint16) Option {
+	return func(c *Client) {
+		c.verifiedCache = newVerifiedCache(int(size))
+	}
+}
+
 // ConfirmationFunction option can be used to prompt to confirm an action. For
 // example, remove newer headers if the light client is being reset with an
 // older header. No confirmation is required by default!
@@ -153,6 +166,8 @@ type Client struct {
 
 	// See RemoveNoLongerTrustedHeadersPeriod option
 	pruningSize uint16
+	// Light blocks verified on demand. See VerifiedCacheSize option
+	verifiedCache *verifiedCache
 	// See ConfirmationFunction option
 	confirmationFn func(action string) bool
 
@@ -229,6 +244,7 @@ func NewClientFromTrustedStore(
 		witnesses:        witnesses,
 		trustedStore:     trustedStore,
 		pruningSize:      defaultPruningSize,
+		verifiedCache:    newVerifiedCache(defaultVerifiedCacheSize),
 		confirmationFn:   func(action string) bool { return true },
 		quit:             make(chan struct{}),
 		logger:           log.NewNopLogger(),
@@ -463,7 +479,7 @@ func (c *Client) Update(ctx context.Context, now time.Time) (*types.LightBlock,
 
 // VerifyLightBlockAtHeight fetches the light block at the given height
 // and verifies it. It returns the block immediately if it exists in
-// the trustedStore (no verification is needed).
+// the trustedStore or was verified recently (no verification is needed).
 //
 // height must be > 0.
 //
@@ -484,6 +500,12 @@ func (c *Client) VerifyLightBlockAtHeight(ctx context.Context, height int64, now
 		return h, nil
 	}
 
+	// Check if the light block was verified before, but has since been pruned.
+	if l, ok := c.verifiedCache.Get(height); ok {
+		c.logger.Debug("Header has already been verified (cached)", "height", height, "hash", l.Hash())
+		return l, nil
+	}
+
 	// Request the light block from primary
 	l, err := c.lightBlockFromPrimary(ctx, height)
 	if err != nil {
@@ -873,6 +895,7 @@ func (c *Client) Witnesses() []provider.Provider {
 func (c *Client) Cleanup() error {
 	c.logger.Info("Removing all the data")
 	c.latestTrustedBlock = nil
+	c.verifiedCache.Reset()
 	return c.trustedStore.Prune(0)
 }
 
@@ -880,6 +903,7 @@ func (c *Client) Cleanup() error {
 // resets latestTrustedBlock to the latest header.
 func (c *Client) cleanupAfter(height int64) error {
 	prevHeight := c.latestTrustedBlock.Height
+	c.verifiedCache.RemoveAfter(height)
 
 	for {
 		h, err := c.trustedStore.LightBlockBefore(prevHeight)
@@ -913,6 +937,7 @@ func (c *Client) updateTrustedLightBlock(l *types.LightBlock) error {
 	if err := c.trustedStore.SaveLightBlock(l); err != nil {
 		return fmt.Errorf("failed to save trusted header: %w", err)
 	}
+	c.verifiedCache.Add(l)
 
 	if c.pruningSize > 0 {
 		if err := c.trustedStore.Prune(c.pruningSize); err != nil {
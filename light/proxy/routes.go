@@ -33,12 +33,15 @@ func RPCRoutes(c *lrpc.Client) map[string]*rpcserver.RPCFunc {
 		"tx":                   rpcserver.NewRPCFunc(makeTxFunc(c), "hash,prove", rpcserver.Cacheable()),
 		"tx_search":            rpcserver.NewRPCFunc(makeTxSearchFuncMatchEvents(c), "query,prove,page,per_page,order_by,match_events"),
 		"block_search":         rpcserver.NewRPCFunc(makeBlockSearchFuncMatchEvents(c), "query,page,per_page,order_by,match_events"),
+		"tx_search_cursor":     rpcserver.NewRPCFunc(makeTxSearchCursorFunc(c), "query,prove,cursor,per_page,order_by"),
+		"block_search_cursor":  rpcserver.NewRPCFunc(makeBlockSearchCursorFunc(c), "query,cursor,per_page,order_by"),
 		"validators":           rpcserver.NewRPCFunc(makeValidatorsFunc(c), "height,page,per_page", rpcserver.Cacheable("height")),
 		"dump_consensus_state": rpcserver.NewRPCFunc(makeDumpConsensusStateFunc(c), ""),
 		"consensus_state":      rpcserver.NewRPCFunc(makeConsensusStateFunc(c), ""),
 		"consensus_params":     rpcserver.NewRPCFunc(makeConsensusParamsFunc(c), "height", rpcserver.Cacheable("height")),
 		"unconfirmed_txs":      rpcserver.NewRPCFunc(makeUnconfirmedTxsFunc(c), "limit"),
 		"num_unconfirmed_txs":  rpcserver.NewRPCFunc(makeNumUnconfirmedTxsFunc(c), ""),
+		"mempool_snapshot":     rpcserver.NewRPCFunc(makeMempoolSnapshotFunc(c), ""),
 		"tx_status":            rpcserver.NewRPCFunc(makeTxStatusFunc(c), "hash"),
 
 		// tx broadcast API
@@ -222,6 +225,48 @@ func makeBlockSearchFuncMatchEvents(c *lrpc.Client) rpcBlockSearchFuncMatchEvent
 	}
 }
 
+type rpcTxSearchCursorFunc func(
+	ctx *rpctypes.Context,
+	query string,
+	prove bool,
+	cursor string,
+	perPage *int,
+	orderBy string,
+) (*ctypes.ResultTxSearchCursor, error)
+
+func makeTxSearchCursorFunc(c *lrpc.Client) rpcTxSearchCursorFunc {
+	return func(
+		ctx *rpctypes.Context,
+		query string,
+		prove bool,
+		cursor string,
+		perPage *int,
+		orderBy string,
+	) (*ctypes.ResultTxSearchCursor, error) {
+		return c.TxSearchCursor(ctx.Context(), query, prove, cursor, perPage, orderBy)
+	}
+}
+
+type rpcBlockSearchCursorFunc func(
+	ctx *rpctypes.Context,
+	query string,
+	cursor string,
+	perPage *int,
+	orderBy string,
+) (*ctypes.ResultBlockSearchCursor, error)
+
+func makeBlockSearchCursorFunc(c *lrpc.Client) rpcBlockSearchCursorFunc {
+	return func(
+		ctx *rpctypes.Context,
+		query string,
+		cursor string,
+		perPage *int,
+		orderBy string,
+	) (*ctypes.ResultBlockSearchCursor, error) {
+		return c.BlockSearchCursor(ctx.Context(), query, cursor, perPage, orderBy)
+	}
+}
+
 type rpcValidatorsFunc func(ctx *rpctypes.Context, height *int64,
 	page, perPage *int) (*ctypes.ResultValidators, error)
 
@@ -271,6 +316,14 @@ func makeNumUnconfirmedTxsFunc(c *lrpc.Client) rpcNumUnconfirmedTxsFunc {
 	}
 }
 
+type rpcMempoolSnapshotFunc func(ctx *rpctypes.Context) (*ctypes.ResultMempoolSnapshot, error)
+
+func makeMempoolSnapshotFunc(c *lrpc.Client) rpcMempoolSnapshotFunc {
+	return func(ctx *rpctypes.Context) (*ctypes.ResultMempoolSnapshot, error) {
+		return c.MempoolSnapshot(ctx.Context())
+	}
+}
+
 type rpcBroadcastTxCommitFunc func(ctx *rpctypes.Context, tx types.Tx) (*ctypes.ResultBroadcastTxCommit, error)
 
 func makeBroadcastTxCommitFunc(c *lrpc.Client) rpcBroadcastTxCommitFunc {
@@ -5,6 +5,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"math"
 	"regexp"
 	"time"
 
@@ -15,6 +16,8 @@ import (
 	cmtbytes "github.com/tendermint/tendermint/libs/bytes"
 	cmtmath "github.com/tendermint/tendermint/libs/math"
 	service "github.com/tendermint/tendermint/libs/service"
+	"github.com/tendermint/tendermint/pkg/consts"
+	"github.com/tendermint/tendermint/pkg/da"
 	rpcclient "github.com/tendermint/tendermint/rpc/client"
 	ctypes "github.com/tendermint/tendermint/rpc/core/types"
 	rpctypes "github.com/tendermint/tendermint/rpc/jsonrpc/types"
@@ -211,6 +214,10 @@ func (c *Client) NumUnconfirmedTxs(ctx context.Context) (*ctypes.ResultUnconfirm
 	return c.next.NumUnconfirmedTxs(ctx)
 }
 
+func (c *Client) MempoolSnapshot(ctx context.Context) (*ctypes.ResultMempoolSnapshot, error) {
+	return c.next.MempoolSnapshot(ctx)
+}
+
 func (c *Client) CheckTx(ctx context.Context, tx types.Tx) (*ctypes.ResultCheckTx, error) {
 	return c.next.CheckTx(ctx, tx)
 }
@@ -316,30 +323,10 @@ func (c *Client) Block(ctx context.Context, height *int64) (*ctypes.ResultBlock,
 		return nil, err
 	}
 
-	// Validate res.
-	if err := res.BlockID.ValidateBasic(); err != nil {
-		return nil, err
-	}
-	if err := res.Block.ValidateBasic(); err != nil {
-		return nil, err
-	}
-	if bmH, bH := res.BlockID.Hash, res.Block.Hash(); !bytes.Equal(bmH, bH) {
-		return nil, fmt.Errorf("blockID %X does not match with block %X",
-			bmH, bH)
-	}
-
-	// Update the light client if we're behind.
-	l, err := c.updateLightClientIfNeededTo(ctx, &res.Block.Height)
-	if err != nil {
+	if err := c.verifyBlock(ctx, res); err != nil {
 		return nil, err
 	}
 
-	// Verify block.
-	if bH, tH := res.Block.Hash(), l.Hash(); !bytes.Equal(bH, tH) {
-		return nil, fmt.Errorf("block header %X does not match with trusted header %X",
-			bH, tH)
-	}
-
 	return res, nil
 }
 
@@ -396,31 +383,43 @@ func (c *Client) BlockByHash(ctx context.Context, hash []byte) (*ctypes.ResultBl
 		return nil, err
 	}
 
+	if err := c.verifyBlock(ctx, res); err != nil {
+		return nil, err
+	}
+
+	return res, nil
+}
+
+// verifyBlock validates the block and checks its header against the trusted
+// header at the same height.
+func (c *Client) verifyBlock(ctx context.Context, res *ctypes.ResultBlock) error {
 	// Validate res.
 	if err := res.BlockID.ValidateBasic(); err != nil {
-		return nil, err
+		return err
 	}
 	if err := res.Block.ValidateBasic(); err != nil {
-		return nil, err
+		return err
 	}
 	if bmH, bH := res.BlockID.Hash, res.Block.Hash(); !bytes.Equal(bmH, bH) {
-		return nil, fmt.Errorf("blockID %X does not match with block %X",
+		return fmt.Errorf("blockID %X does not match with block %X",
 			bmH, bH)
 	}
 
 	// Update the light client if we're behind.
 	l, err := c.updateLightClientIfNeededTo(ctx, &res.Block.Height)
 	if err != nil {
-		return nil, err
+		return err
 	}
 
 	// Verify block.
 	if bH, tH := res.Block.Hash(), l.Hash(); !bytes.Equal(bH, tH) {
-		return nil, fmt.Errorf("block header %X does not match with trusted header %X",
-			bH, tH)
+		return ErrProofVerification{
+			Height: res.Block.Height,
+			Reason: fmt.Errorf("block header %X does not match with trusted header %X", bH, tH),
+		}
 	}
 
-	return res, nil
+	return nil
 }
 
 // BlockResults returns the block results for the given height. If no height is
@@ -487,9 +486,90 @@ func (c *Client) BlockResults(ctx context.Context, height *int64) (*ctypes.Resul
 	return res, nil
 }
 
-// TxStatus retrieves the status of the transaction given its hash.
+// TxStatus retrieves the status of the transaction given its hash. If the tx
+// is reported as committed, its inclusion proof is fetched and verified
+// against the trusted header at the reported height, and the shares of the
+// block up to the tx are proven to find its index. Other statuses can't be
+// proven and are returned as is.
+//
+// The txs of a block are stored in the compact shares of the tx namespace,
+// followed by those of the PayForBlob namespace. Only the index of the former
+// can be proven, by counting the txs preceding it, so PayForBlobs txs are
+// returned unverified once their inclusion has been proven.
 func (c *Client) TxStatus(ctx context.Context, hash []byte) (*ctypes.ResultTxStatus, error) {
-	return c.next.TxStatus(ctx, hash)
+	res, err := c.next.TxStatus(ctx, hash)
+	if err != nil || res.Status != ctypes.TxStatusCommitted {
+		return res, err
+	}
+
+	// Validate res.
+	if res.Height <= 0 {
+		return nil, errNegOrZeroHeight
+	}
+
+	// Fetch the tx along with its inclusion proof.
+	tx, err := c.next.Tx(ctx, hash, true)
+	if err != nil {
+		return nil, fmt.Errorf("can't get inclusion proof of committed tx %X: %w", hash, err)
+	}
+	if tx.Height != res.Height {
+		return nil, ErrProofVerification{
+			Height: res.Height,
+			Reason: fmt.Errorf("tx %X is proven at height %d, but status reports height %d", hash, tx.Height, res.Height),
+		}
+	}
+	if err := c.verifyTx(ctx, tx); err != nil {
+		return nil, err
+	}
+
+	//nolint:gosec
+	namespace := append([]byte{uint8(tx.Proof.NamespaceVersion)}, tx.Proof.NamespaceID...)
+	if !bytes.Equal(namespace, da.TxNamespace) {
+		return res, nil
+	}
+	if err := c.verifyTxIndex(ctx, tx.Tx, res.Height, res.Index, tx.Proof); err != nil {
+		return nil, err
+	}
+
+	res.Verified = true
+	return res, nil
+}
+
+// verifyTxIndex checks that the tx, whose proof of shares in the tx namespace
+// has been verified, is the one at the index of the block. The shares from the
+// start of the namespace up to the last share of the tx are proven, and the
+// txs stored in them counted.
+func (c *Client) verifyTxIndex(
+	ctx context.Context,
+	tx types.Tx,
+	height int64,
+	index uint32,
+	proof types.ShareProof,
+) error {
+	rowProof, shareProof := proof.RowProof.Proofs[len(proof.RowProof.Proofs)-1], proof.ShareProofs[len(proof.ShareProofs)-1]
+	//nolint:gosec
+	end := uint64(rowProof.Index*(rowProof.Total/4) + int64(shareProof.End))
+
+	//nolint:gosec
+	res, err := c.ProveSharesV2(ctx, uint64(height), 0, end)
+	if err != nil {
+		return fmt.Errorf("can't prove the shares preceding tx %X: %w", tx.Hash(), err)
+	}
+	shares := res.ShareProof.Data
+	if !da.IsSequenceStart(shares[0]) {
+		return ErrProofVerification{Height: height, Reason: errors.New("tx namespace doesn't start the data square")}
+	}
+	units, err := da.ParseCompactShares(shares)
+	if err != nil {
+		return ErrProofVerification{Height: height, Reason: err}
+	}
+	if int(index) >= len(units) || !isTxUnit(tx, units[index]) {
+		return ErrProofVerification{
+			Height: height,
+			Reason: fmt.Errorf("tx %X is not at index %d among the %d txs up to its shares", tx.Hash(), index, len(units)),
+		}
+	}
+	return nil
 }
 
 // Header fetches and verifies the header directly via the light client
@@ -540,24 +620,112 @@ func (c *Client) Commit(ctx context.Context, height *int64) (*ctypes.ResultCommi
 	}, nil
 }
 
+// DataCommitment calls rpcclient#DataCommitment and then verifies the result
+// by recomputing the commitment over the data roots of the trusted headers in
+// the end exclusive range [start, end).
 func (c *Client) DataCommitment(
 	ctx context.Context,
 	start uint64,
 	end uint64,
 ) (*ctypes.ResultDataCommitment, error) {
-	return c.next.DataCommitment(ctx, start, end)
+	res, err := c.next.DataCommitment(ctx, start, end)
+	if err != nil {
+		return nil, err
+	}
+
+	tuples, err := c.trustedDataRootTuples(ctx, start, end)
+	if err != nil {
+		return nil, err
+	}
+
+	// Verify the commitment.
+	if cH, tH := res.DataCommitment, merkle.HashFromByteSlices(tuples); !bytes.Equal(cH, tH) {
+		return nil, ErrProofVerification{
+			//nolint:gosec
+			Height: int64(end - 1),
+			Reason: fmt.Errorf("data commitment %X does not match trusted data commitment %X", cH, tH),
+		}
+	}
+
+	return res, nil
 }
 
 // DataRootInclusionProof calls rpcclient#DataRootInclusionProof method and returns
 // a merkle proof for the data root of block height `height` to the set of blocks
-// defined by `start` and `end`.
+// defined by `start` and `end`. The proof is verified against the data
+// commitment recomputed from the trusted headers in that range.
 func (c *Client) DataRootInclusionProof(
 	ctx context.Context,
 	height uint64,
 	start uint64,
 	end uint64,
 ) (*ctypes.ResultDataRootInclusionProof, error) {
-	return c.next.DataRootInclusionProof(ctx, height, start, end)
+	res, err := c.next.DataRootInclusionProof(ctx, height, start, end)
+	if err != nil {
+		return nil, err
+	}
+
+	// Validate res.
+	if height < start || height >= end {
+		return nil, fmt.Errorf("height %d is not within the end exclusive range [%d, %d)", height, start, end)
+	}
+
+	tuples, err := c.trustedDataRootTuples(ctx, start, end)
+	if err != nil {
+		return nil, err
+	}
+
+	// Verify the proof.
+	err = res.Proof.Verify(merkle.HashFromByteSlices(tuples), tuples[height-start])
+	if err != nil {
+		//nolint:gosec
+		return nil, ErrProofVerification{Height: int64(height), Reason: err}
+	}
+
+	return res, nil
+}
+
+// trustedDataRootTuples verifies the light blocks in the end exclusive range
+// [start, end) and returns their encoded data root tuples, in the order they
+// are committed to by a data commitment.
+func (c *Client) trustedDataRootTuples(ctx context.Context, start, end uint64) ([][]byte, error) {
+	if start == 0 {
+		return nil, errNegOrZeroHeight
+	}
+	if start >= end {
+		return nil, fmt.Errorf("start %d must be smaller than end %d", start, end)
+	}
+	// Bound the number of headers to verify, whatever the primary accepted.
+	if end-start > da.DataCommitmentBlocksLimit {
+		return nil, fmt.Errorf("range [%d, %d) exceeds the limit of %d blocks", start, end, da.DataCommitmentBlocksLimit)
+	}
+
+	// Update the light client to the last height first, so that the lower
+	// heights can be verified backwards from it.
+	//nolint:gosec
+	lastHeight := int64(end - 1)
+	if _, err := c.updateLightClientIfNeededTo(ctx, &lastHeight); err != nil {
+		return nil, err
+	}
+
+	tuples := make([][]byte, 0, end-start)
+	for height := start; height < end; height++ {
+		//nolint:gosec
+		h := int64(height)
+		l, err := c.updateLightClientIfNeededTo(ctx, &h)
+		if err != nil {
+			return nil, err
+		}
+		if len(l.DataHash) != 32 {
+			return nil, fmt.Errorf("trusted header %d has a data hash of %d bytes, expected 32", h, len(l.DataHash))
+		}
+		tuple, err := da.EncodeDataRootTuple(height, *(*[32]byte)(l.DataHash))
+		if err != nil {
+			return nil, err
+		}
+		tuples = append(tuples, tuple)
+	}
+	return tuples, nil
 }
 
 // Tx calls rpcclient#Tx method and then verifies the proof if such was
@@ -568,19 +736,67 @@ func (c *Client) Tx(ctx context.Context, hash []byte, prove bool) (*ctypes.Resul
 		return res, err
 	}
 
+	return res, c.verifyTx(ctx, res)
+}
+
+// verifyTx validates the share proof of the tx against the trusted header at
+// the tx's height, and checks that the tx is stored in the proven shares.
+func (c *Client) verifyTx(ctx context.Context, res *ctypes.ResultTx) error {
 	// Validate res.
 	if res.Height <= 0 {
-		return nil, errNegOrZeroHeight
+		return errNegOrZeroHeight
+	}
+	if tH := res.Tx.Hash(); !bytes.Equal(res.Hash, tH) {
+		return fmt.Errorf("tx hash %X does not match with tx %X", res.Hash, tH)
 	}
 
 	// Update the light client if we're behind.
 	l, err := c.updateLightClientIfNeededTo(ctx, &res.Height)
 	if err != nil {
-		return nil, err
+		return err
 	}
 
 	// Validate the proof.
-	return res, res.Proof.Validate(l.DataHash)
+	if err := res.Proof.Validate(l.DataHash); err != nil {
+		return ErrProofVerification{Height: res.Height, Reason: err}
+	}
+	if err := verifyTxInShares(res.Tx, res.Proof); err != nil {
+		return ErrProofVerification{Height: res.Height, Reason: err}
+	}
+	return nil
+}
+
+// verifyTxInShares checks that the tx is one of the txs stored in the compact
+// shares proven by the proof, so that a valid proof of other shares of the
+// block can't be attached to a made up tx.
+func verifyTxInShares(tx types.Tx, proof types.ShareProof) error {
+	//nolint:gosec
+	namespace := append([]byte{uint8(proof.NamespaceVersion)}, proof.NamespaceID...)
+	if !da.IsCompactNamespace(namespace) {
+		return fmt.Errorf("proven shares of namespace %X don't hold txs", namespace)
+	}
+	units, err := da.ParseCompactShares(proof.Data)
+	if err != nil {
+		return err
+	}
+	for _, unit := range units {
+		if isTxUnit(tx, unit) {
+			return nil
+		}
+	}
+	return fmt.Errorf("tx %X is not stored in the proven shares", tx.Hash())
+}
+
+// isTxUnit returns true if the unit of compact shares stores the tx. The blobs
+// of a BlobTx are stored apart, only its tx is stored, wrapped in an
+// IndexWrapper.
+func isTxUnit(tx types.Tx, unit []byte) bool {
+	blobTx, isBlobTx := types.UnmarshalBlobTx(tx)
+	if !isBlobTx {
+		return bytes.Equal(tx, unit)
+	}
+	wrapper, isIndexWrapper := types.UnmarshalIndexWrapper(unit)
+	return isIndexWrapper && bytes.Equal(blobTx.Tx, wrapper.Tx)
 }
 
 // ProveShares calls rpcclient#ProveShares method and returns an NMT proof for a set
@@ -594,7 +810,15 @@ func (c *Client) ProveShares(
 	endShare uint64,
 ) (types.ShareProof, error) {
 	res, err := c.next.ProveShares(ctx, height, startShare, endShare)
-	return res, err
+	if err != nil {
+		return res, err
+	}
+
+	if err := c.verifyShareProof(ctx, height, startShare, endShare, res); err != nil {
+		return types.ShareProof{}, err
+	}
+
+	return res, nil
 }
 
 // ProveSharesV2 returns a proof of inclusion for a share range to the data root
@@ -608,9 +832,111 @@ func (c *Client) ProveSharesV2(
 	endShare uint64,
 ) (*ctypes.ResultShareProof, error) {
 	res, err := c.next.ProveSharesV2(ctx, height, startShare, endShare)
-	return res, err
+	if err != nil {
+		return nil, err
+	}
+
+	if err := c.verifyShareProof(ctx, height, startShare, endShare, res.ShareProof); err != nil {
+		return nil, err
+	}
+
+	return res, nil
+}
+
+// verifyShareProof updates the light client to the given height and validates
+// the share proof, including its row proof, against the trusted data hash. The
+// proof must prove the shares in the end exclusive range [start, end).
+func (c *Client) verifyShareProof(
+	ctx context.Context,
+	height uint64,
+	start uint64,
+	end uint64,
+	proof types.ShareProof,
+) error {
+	//nolint:gosec
+	h := int64(height)
+	if h <= 0 {
+		return errNegOrZeroHeight
+	}
+
+	// Update the light client if we're behind.
+	l, err := c.updateLightClientIfNeededTo(ctx, &h)
+	if err != nil {
+		return err
+	}
+
+	if err := proof.Validate(l.DataHash); err != nil {
+		return ErrProofVerification{Height: h, Reason: err}
+	}
+	if err := verifyShareRange(proof, start, end); err != nil {
+		return ErrProofVerification{Height: h, Reason: err}
+	}
+	return nil
+}
+
+// verifyShareRange checks that a share proof proves the shares in the end
+// exclusive range [start, end) of the original data square, and that all of
+// them belong to the namespace of the proof. The position of every row is
+// taken from its row proof, which has been validated against the data hash,
+// rather than from the start and end rows reported along with the proof.
+func verifyShareRange(proof types.ShareProof, start, end uint64) error {
+	rowProofs := proof.RowProof.Proofs
+	if len(rowProofs) == 0 || len(rowProofs) != len(proof.ShareProofs) {
+		return fmt.Errorf("proof of %d rows has %d share proofs", len(rowProofs), len(proof.ShareProofs))
+	}
+
+	// The data root commits to the row and column roots of the extended data
+	// square, which is twice as wide as the original one.
+	total := rowProofs[0].Total
+	if total <= 0 || total%4 != 0 {
+		return fmt.Errorf("row proof of %d roots doesn't belong to a data square", total)
+	}
+	width := total / 4
+
+	var first, last int64
+	for i, rp := range rowProofs {
+		sp := proof.ShareProofs[i]
+		switch {
+		case rp.Total != total:
+			return fmt.Errorf("row proof %d is of %d roots, expected %d", i, rp.Total, total)
+		case rp.Index != int64(proof.RowProof.StartRow)+int64(i):
+			return fmt.Errorf("row proof %d proves row %d, expected %d", i, rp.Index, int64(proof.RowProof.StartRow)+int64(i))
+		case rp.Index >= width:
+			return fmt.Errorf("row %d is not part of the original data square of width %d", rp.Index, width)
+		case int64(sp.End) > width:
+			return fmt.Errorf("share proof %d ends at %d, beyond the original data square of width %d", i, sp.End, width)
+		case i > 0 && sp.Start != 0:
+			return fmt.Errorf("share proof %d starts at %d, leaving a gap in the proven shares", i, sp.Start)
+		case i < len(rowProofs)-1 && int64(sp.End) != width:
+			return fmt.Errorf("share proof %d ends at %d, leaving a gap in the proven shares", i, sp.End)
+		}
+		if i == 0 {
+			first = rp.Index*width + int64(sp.Start)
+		}
+		last = rp.Index*width + int64(sp.End)
+	}
+	//nolint:gosec
+	if first != int64(start) || last != int64(end) {
+		return fmt.Errorf("proof is of shares [%d, %d), expected [%d, %d)", first, last, start, end)
+	}
+
+	if len(proof.NamespaceID) != consts.NamespaceIDSize || proof.NamespaceVersion > math.MaxUint8 {
+		return fmt.Errorf("invalid namespace %d/%X", proof.NamespaceVersion, proof.NamespaceID)
+	}
+	//nolint:gosec
+	namespace := append([]byte{uint8(proof.NamespaceVersion)}, proof.NamespaceID...)
+	for i, share := range proof.Data {
+		if !bytes.HasPrefix(share, namespace) {
+			return fmt.Errorf("share %d doesn't belong to namespace %X", i, namespace)
+		}
+	}
+	return nil
 }
 
+// TxSearch calls rpcclient#TxSearch and, if proofs were requested, verifies
+// the proof of every tx returned against the trusted header at its height.
+// Txs which fail verification are dropped from the result. Note that the
+// primary can still omit matching txs, which can't be detected.
 func (c *Client) TxSearch(
 	ctx context.Context,
 	query string,
@@ -618,16 +944,131 @@ func (c *Client) TxSearch(
 	page, perPage *int,
 	orderBy string,
 ) (*ctypes.ResultTxSearch, error) {
-	return c.next.TxSearch(ctx, query, prove, page, perPage, orderBy)
+	res, err := c.next.TxSearch(ctx, query, prove, page, perPage, orderBy)
+	if err != nil || !prove {
+		return res, err
+	}
+
+	if res.Txs, err = c.verifyTxs(ctx, res.Txs); err != nil {
+		return nil, err
+	}
+	return res, nil
 }
 
+// BlockSearch calls rpcclient#BlockSearch and then verifies every block
+// returned against the trusted header at its height. Blocks which fail
+// verification are dropped from the result. Note that the primary can still
+// omit matching blocks, which can't be detected.
 func (c *Client) BlockSearch(
 	ctx context.Context,
 	query string,
 	page, perPage *int,
 	orderBy string,
 ) (*ctypes.ResultBlockSearch, error) {
-	return c.next.BlockSearch(ctx, query, page, perPage, orderBy)
+	res, err := c.next.BlockSearch(ctx, query, page, perPage, orderBy)
+	if err != nil {
+		return nil, err
+	}
+
+	if res.Blocks, err = c.verifyBlocks(ctx, res.Blocks); err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+// TxSearchCursor calls rpcclient#TxSearchCursor and, if proofs were requested,
+// verifies the proof of every tx returned against the trusted header at its
+// height. Txs which fail verification are dropped from the result, which
+// keeps the cursor of the primary. Note that the primary can still omit
+// matching txs, which can't be detected.
+func (c *Client) TxSearchCursor(
+	ctx context.Context,
+	query string,
+	prove bool,
+	cursor string,
+	perPage *int,
+	orderBy string,
+) (*ctypes.ResultTxSearchCursor, error) {
+	res, err := c.next.TxSearchCursor(ctx, query, prove, cursor, perPage, orderBy)
+	if err != nil || !prove {
+		return res, err
+	}
+
+	if res.Txs, err = c.verifyTxs(ctx, res.Txs); err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+// BlockSearchCursor calls rpcclient#BlockSearchCursor and then verifies every
+// block returned against the trusted header at its height. Blocks which fail
+// verification are dropped from the result, which keeps the cursor of the
+// primary. Note that the primary can still omit matching blocks, which can't
+// be detected.
+func (c *Client) BlockSearchCursor(
+	ctx context.Context,
+	query string,
+	cursor string,
+	perPage *int,
+	orderBy string,
+) (*ctypes.ResultBlockSearchCursor, error) {
+	res, err := c.next.BlockSearchCursor(ctx, query, cursor, perPage, orderBy)
+	if err != nil {
+		return nil, err
+	}
+
+	if res.Blocks, err = c.verifyBlocks(ctx, res.Blocks); err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+// verifyTxs verifies the proof of every tx of a search result against the
+// trusted header at its height, and returns the txs which passed verification.
+func (c *Client) verifyTxs(ctx context.Context, txs []*ctypes.ResultTx) ([]*ctypes.ResultTx, error) {
+	verified := make([]*ctypes.ResultTx, 0, len(txs))
+	for _, tx := range txs {
+		if err := c.verifyTx(ctx, tx); err != nil {
+			if !isVerificationFailure(err) {
+				return nil, err
+			}
+			c.Logger.Error("Dropping unverified tx from search result",
+				"hash", tx.Hash, "height", tx.Height, "err", err)
+			continue
+		}
+		verified = append(verified, tx)
+	}
+	return verified, nil
+}
+
+// verifyBlocks verifies every block of a search result against the trusted
+// header at its height, and returns the blocks which passed verification.
+func (c *Client) verifyBlocks(ctx context.Context, blocks []*ctypes.ResultBlock) ([]*ctypes.ResultBlock, error) {
+	verified := make([]*ctypes.ResultBlock, 0, len(blocks))
+	for _, block := range blocks {
+		if block == nil || block.Block == nil {
+			c.Logger.Error("Dropping empty block from search result")
+			continue
+		}
+		if err := c.verifyBlock(ctx, block); err != nil {
+			if !isVerificationFailure(err) {
+				return nil, err
+			}
+			c.Logger.Error("Dropping unverified block from search result",
+				"height", block.Block.Height, "err", err)
+			continue
+		}
+		verified = append(verified, block)
+	}
+	return verified, nil
+}
+
+// isVerificationFailure returns true if the error means the data returned by
+// the primary is invalid, as opposed to the light client being unable to
+// verify it (e.g. because the primary can't be reached).
+func isVerificationFailure(err error) bool {
+	var updateErr errLightClientUpdate
+	return !errors.As(err, &updateErr)
 }
 
 // Validators fetches and verifies validators.
@@ -689,7 +1130,7 @@ func (c *Client) updateLightClientIfNeededTo(ctx context.Context, height *int64)
 		l, err = c.lc.VerifyLightBlockAtHeight(ctx, *height, time.Now())
 	}
 	if err != nil {
-		return nil, fmt.Errorf("failed to update light client to %d: %w", *height, err)
+		return nil, errLightClientUpdate{height: height, err: err}
 	}
 	return l, nil
 }
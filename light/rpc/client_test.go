@@ -0,0 +1,374 @@
+package rpc
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/celestiaorg/nmt"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/tendermint/tendermint/crypto/merkle"
+	"github.com/tendermint/tendermint/crypto/tmhash"
+	cmtbytes "github.com/tendermint/tendermint/libs/bytes"
+	lcmock "github.com/tendermint/tendermint/light/rpc/mocks"
+	"github.com/tendermint/tendermint/pkg/consts"
+	"github.com/tendermint/tendermint/pkg/da"
+	tmproto "github.com/tendermint/tendermint/proto/tendermint/types"
+	rpcclient "github.com/tendermint/tendermint/rpc/client"
+	ctypes "github.com/tendermint/tendermint/rpc/core/types"
+	"github.com/tendermint/tendermint/types"
+)
+
+// dataRootClient is a primary that only serves data commitments and data root
+// inclusion proofs.
+type dataRootClient struct {
+	rpcclient.Client
+
+	commitment []byte
+	proof      merkle.Proof
+	txs        []*ctypes.ResultTx
+	status     *ctypes.ResultTxStatus
+	shareProof types.ShareProof
+}
+
+func (c *dataRootClient) TxStatus(context.Context, []byte) (*ctypes.ResultTxStatus, error) {
+	return c.status, nil
+}
+
+func (c *dataRootClient) Tx(context.Context, []byte, bool) (*ctypes.ResultTx, error) {
+	return c.txs[0], nil
+}
+
+func (c *dataRootClient) DataCommitment(context.Context, uint64, uint64) (*ctypes.ResultDataCommitment, error) {
+	return &ctypes.ResultDataCommitment{DataCommitment: c.commitment}, nil
+}
+
+func (c *dataRootClient) DataRootInclusionProof(
+	context.Context, uint64, uint64, uint64,
+) (*ctypes.ResultDataRootInclusionProof, error) {
+	return &ctypes.ResultDataRootInclusionProof{Proof: c.proof}, nil
+}
+
+func (c *dataRootClient) TxSearch(
+	context.Context, string, bool, *int, *int, string,
+) (*ctypes.ResultTxSearch, error) {
+	return &ctypes.ResultTxSearch{Txs: c.txs, TotalCount: len(c.txs)}, nil
+}
+
+func (c *dataRootClient) ProveSharesV2(context.Context, uint64, uint64, uint64) (*ctypes.ResultShareProof, error) {
+	return &ctypes.ResultShareProof{ShareProof: c.shareProof}, nil
+}
+
+// trustedDataRoots returns a light client mock trusting a header with a random
+// data hash at every height in [start, end), along with the encoded tuples.
+func trustedDataRoots(t *testing.T, start, end uint64) (*lcmock.LightClient, [][]byte) {
+	lc := &lcmock.LightClient{}
+	tuples := make([][]byte, 0, end-start)
+	for h := start; h < end; h++ {
+		dataHash := tmhash.Sum([]byte{byte(h)})
+		lc.On("VerifyLightBlockAtHeight", mock.Anything, int64(h), mock.Anything).Return(
+			&types.LightBlock{SignedHeader: &types.SignedHeader{
+				Header: &types.Header{Height: int64(h), DataHash: dataHash},
+			}}, nil)
+		tuple, err := da.EncodeDataRootTuple(h, *(*[32]byte)(dataHash))
+		require.NoError(t, err)
+		tuples = append(tuples, tuple)
+	}
+	return lc, tuples
+}
+
+// trustedDataHash returns a light client mock trusting a header with the given
+// data hash at the given height.
+func trustedDataHash(height int64, dataHash []byte) *lcmock.LightClient {
+	lc := &lcmock.LightClient{}
+	lc.On("VerifyLightBlockAtHeight", mock.Anything, height, mock.Anything).Return(
+		&types.LightBlock{SignedHeader: &types.SignedHeader{
+			Header: &types.Header{Height: height, DataHash: dataHash},
+		}}, nil)
+	return lc
+}
+
+// testSquare is an original data square committed to by its data hash. Only
+// its rows are actual NMTs, the roots of the parity rows and of the columns
+// are made up, which is enough to prove shares of the original data square.
+type testSquare struct {
+	width     int
+	shares    [][]byte
+	rowRoots  [][]byte
+	rowProofs []*merkle.Proof
+	dataHash  []byte
+}
+
+// newTestSquare lays out the shares in the smallest square fitting them, and
+// fills the rest of the square with tail padding shares.
+func newTestSquare(t *testing.T, shares [][]byte) *testSquare {
+	width := 1
+	for width*width < len(shares) {
+		width *= 2
+	}
+	tailPadding := bytes.Repeat([]byte{0xFF}, consts.ShareSize)
+	tailPadding[consts.NamespaceSize-1] = 0xFE
+
+	sq := &testSquare{width: width, shares: shares}
+	for len(sq.shares) < width*width {
+		sq.shares = append(sq.shares, tailPadding)
+	}
+
+	roots := make([][]byte, 0, 4*width)
+	for row := 0; row < width; row++ {
+		root, err := sq.rowTree(t, row).Root()
+		require.NoError(t, err)
+		roots = append(roots, root)
+	}
+	for i := width; i < 4*width; i++ {
+		roots = append(roots, tmhash.Sum([]byte{byte(i)}))
+	}
+	sq.rowRoots = roots[:width]
+	sq.dataHash, sq.rowProofs = merkle.ProofsFromByteSlices(roots)
+	return sq
+}
+
+// rowTree returns the NMT of the row, extended by parity shares.
+func (sq *testSquare) rowTree(t *testing.T, row int) *nmt.NamespacedMerkleTree {
+	tree := nmt.New(consts.NewBaseHashFunc(), nmt.NamespaceIDSize(consts.NamespaceSize), nmt.IgnoreMaxNamespace(true))
+	for _, share := range sq.shares[row*sq.width : (row+1)*sq.width] {
+		require.NoError(t, tree.Push(append(share[:consts.NamespaceSize:consts.NamespaceSize], share...)))
+	}
+	parity := bytes.Repeat([]byte{0xFF}, consts.ShareSize)
+	for i := 0; i < sq.width; i++ {
+		require.NoError(t, tree.Push(append(parity[:consts.NamespaceSize:consts.NamespaceSize], parity...)))
+	}
+	return tree
+}
+
+// testBlobShares returns n distinct shares of a blob namespace.
+func testBlobShares(n int) [][]byte {
+	namespace := append([]byte{0}, bytes.Repeat([]byte{0x0A}, consts.NamespaceIDSize)...)
+	shares := make([][]byte, n)
+	for i := range shares {
+		shares[i] = append(append([]byte{}, namespace...), bytes.Repeat([]byte{byte(i)}, consts.ShareSize-len(namespace))...)
+	}
+	return shares
+}
+
+// prove returns the proof of the shares in the end exclusive range [start, end).
+func (sq *testSquare) prove(t *testing.T, start, end int) types.ShareProof {
+	startRow, endRow := start/sq.width, (end-1)/sq.width
+	proof := types.ShareProof{
+		Data:             sq.shares[start:end],
+		NamespaceID:      sq.shares[start][consts.NamespaceVersionSize:consts.NamespaceSize],
+		NamespaceVersion: uint32(sq.shares[start][0]),
+		RowProof: types.RowProof{
+			Proofs:   sq.rowProofs[startRow : endRow+1],
+			StartRow: uint32(startRow),
+			EndRow:   uint32(endRow),
+		},
+	}
+	for row := startRow; row <= endRow; row++ {
+		from, to := max(start-row*sq.width, 0), min(end-row*sq.width, sq.width)
+		nmtProof, err := sq.rowTree(t, row).ProveRange(from, to)
+		require.NoError(t, err)
+		proof.ShareProofs = append(proof.ShareProofs, &tmproto.NMTProof{
+			Start: int32(from),
+			End:   int32(to),
+			Nodes: nmtProof.Nodes(),
+		})
+		proof.RowProof.RowRoots = append(proof.RowProof.RowRoots, cmtbytes.HexBytes(sq.rowRoots[row]))
+	}
+	return proof
+}
+
+func TestDataCommitment(t *testing.T) {
+	lc, tuples := trustedDataRoots(t, 1, 5)
+
+	next := &dataRootClient{commitment: merkle.HashFromByteSlices(tuples)}
+	res, err := NewClient(next, lc).DataCommitment(context.Background(), 1, 5)
+	require.NoError(t, err)
+	assert.EqualValues(t, next.commitment, res.DataCommitment)
+
+	next.commitment = tmhash.Sum([]byte("forged"))
+	_, err = NewClient(next, lc).DataCommitment(context.Background(), 1, 5)
+	var verr ErrProofVerification
+	require.ErrorAs(t, err, &verr)
+	assert.EqualValues(t, 4, verr.Height)
+}
+
+func TestDataCommitmentRejectsOversizedRange(t *testing.T) {
+	lc := &lcmock.LightClient{}
+	next := &dataRootClient{commitment: tmhash.Sum([]byte("oversized"))}
+	c := NewClient(next, lc)
+
+	end := uint64(1 + da.DataCommitmentBlocksLimit + 1)
+	_, err := c.DataCommitment(context.Background(), 1, end)
+	require.Error(t, err)
+	_, err = c.DataRootInclusionProof(context.Background(), 1, 1, end)
+	require.Error(t, err)
+	lc.AssertNotCalled(t, "VerifyLightBlockAtHeight", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestDataRootInclusionProof(t *testing.T) {
+	lc, tuples := trustedDataRoots(t, 1, 5)
+	_, proofs := merkle.ProofsFromByteSlices(tuples)
+
+	next := &dataRootClient{proof: *proofs[2]}
+	_, err := NewClient(next, lc).DataRootInclusionProof(context.Background(), 3, 1, 5)
+	require.NoError(t, err)
+
+	// a valid proof for another height must not verify
+	_, err = NewClient(next, lc).DataRootInclusionProof(context.Background(), 2, 1, 5)
+	var verr ErrProofVerification
+	require.ErrorAs(t, err, &verr)
+	assert.EqualValues(t, 2, verr.Height)
+}
+
+func TestProveSharesV2(t *testing.T) {
+	lc, _ := trustedDataRoots(t, 1, 2)
+
+	// an empty proof never verifies against a non-empty data hash
+	_, err := NewClient(&dataRootClient{}, lc).ProveSharesV2(context.Background(), 1, 0, 1)
+	var verr ErrProofVerification
+	require.ErrorAs(t, err, &verr)
+	assert.EqualValues(t, 1, verr.Height)
+
+	shares := testBlobShares(16)
+	sq := newTestSquare(t, shares)
+	lc = trustedDataHash(1, sq.dataHash)
+
+	// the proof spans two rows
+	next := &dataRootClient{shareProof: sq.prove(t, 2, 6)}
+	res, err := NewClient(next, lc).ProveSharesV2(context.Background(), 1, 2, 6)
+	require.NoError(t, err)
+	assert.Equal(t, shares[2:6], res.ShareProof.Data)
+
+	// a valid proof of other shares must not verify
+	for _, r := range [][2]uint64{{3, 6}, {2, 5}, {6, 10}} {
+		_, err = NewClient(next, lc).ProveSharesV2(context.Background(), 1, r[0], r[1])
+		require.ErrorAs(t, err, &verr, r)
+	}
+	next.shareProof = sq.prove(t, 6, 10)
+	_, err = NewClient(next, lc).ProveSharesV2(context.Background(), 1, 2, 6)
+	require.ErrorAs(t, err, &verr)
+
+	// the reported rows must match the proven ones
+	next.shareProof.RowProof.StartRow, next.shareProof.RowProof.EndRow = 0, 1
+	_, err = NewClient(next, lc).ProveSharesV2(context.Background(), 1, 2, 6)
+	require.ErrorAs(t, err, &verr)
+}
+
+// newTestTxSquare returns a square holding the txs and the PayForBlobs txs,
+// along with the number of shares of the txs.
+func newTestTxSquare(t *testing.T, txs, pfbs [][]byte) (*testSquare, int) {
+	txShares := da.SplitCompactShares(da.TxNamespace, txs)
+	return newTestSquare(t, append(txShares, da.SplitCompactShares(da.PayForBlobNamespace, pfbs)...)), len(txShares)
+}
+
+func TestTx(t *testing.T) {
+	tx := types.Tx("tx")
+	blobTx, err := types.MarshalBlobTx([]byte("pfb"), &tmproto.Blob{
+		NamespaceId: bytes.Repeat([]byte{0x0A}, consts.NamespaceIDSize),
+		Data:        []byte("blob"),
+	})
+	require.NoError(t, err)
+	wrapped, err := types.MarshalIndexWrapper([]byte("pfb"), 2)
+	require.NoError(t, err)
+
+	sq, txShares := newTestTxSquare(t, [][]byte{[]byte("other"), tx}, [][]byte{wrapped})
+	lc := trustedDataHash(1, sq.dataHash)
+	txProof := sq.prove(t, 0, txShares)
+
+	next := &dataRootClient{txs: []*ctypes.ResultTx{{Hash: tx.Hash(), Height: 1, Tx: tx, Proof: txProof}}}
+	_, err = NewClient(next, lc).Tx(context.Background(), tx.Hash(), true)
+	require.NoError(t, err)
+
+	// the blobs of a BlobTx are not stored along with its tx
+	next.txs[0] = &ctypes.ResultTx{Hash: types.Tx(blobTx).Hash(), Height: 1, Tx: blobTx, Proof: sq.prove(t, txShares, txShares+1)}
+	_, err = NewClient(next, lc).Tx(context.Background(), next.txs[0].Hash, true)
+	require.NoError(t, err)
+
+	// a made up tx along with a valid proof of the shares of other txs
+	forged := types.Tx("forged")
+	next.txs[0] = &ctypes.ResultTx{Hash: forged.Hash(), Height: 1, Tx: forged, Proof: txProof}
+	_, err = NewClient(next, lc).Tx(context.Background(), forged.Hash(), true)
+	var verr ErrProofVerification
+	require.ErrorAs(t, err, &verr)
+
+	// a valid proof of shares which don't hold txs
+	sq = newTestSquare(t, testBlobShares(1))
+	next.txs[0] = &ctypes.ResultTx{Hash: tx.Hash(), Height: 1, Tx: tx, Proof: sq.prove(t, 0, 1)}
+	_, err = NewClient(next, trustedDataHash(1, sq.dataHash)).Tx(context.Background(), tx.Hash(), true)
+	require.ErrorAs(t, err, &verr)
+}
+
+func TestTxSearchDropsUnverifiedTxs(t *testing.T) {
+	lc, _ := trustedDataRoots(t, 1, 2)
+	lc.On("VerifyLightBlockAtHeight", mock.Anything, int64(2), mock.Anything).
+		Return(nil, errors.New("primary unavailable"))
+
+	tx := types.Tx("tx")
+	next := &dataRootClient{txs: []*ctypes.ResultTx{
+		// empty proof
+		{Hash: tx.Hash(), Height: 1, Tx: tx},
+		// hash doesn't match the tx
+		{Hash: tmhash.Sum([]byte("other")), Height: 1, Tx: tx},
+	}}
+	res, err := NewClient(next, lc).TxSearch(context.Background(), "tx.height=1", true, nil, nil, "")
+	require.NoError(t, err)
+	assert.Empty(t, res.Txs)
+
+	// without proofs there is nothing to verify
+	res, err = NewClient(next, lc).TxSearch(context.Background(), "tx.height=1", false, nil, nil, "")
+	require.NoError(t, err)
+	assert.Len(t, res.Txs, 2)
+
+	// failing to verify the header is an error rather than an invalid tx
+	next.txs = []*ctypes.ResultTx{{Hash: tx.Hash(), Height: 2, Tx: tx}}
+	_, err = NewClient(next, lc).TxSearch(context.Background(), "tx.height=2", true, nil, nil, "")
+	require.Error(t, err)
+}
+
+func TestTxStatus(t *testing.T) {
+	lc, _ := trustedDataRoots(t, 1, 2)
+	tx := types.Tx("tx")
+
+	// pending txs can't be verified
+	next := &dataRootClient{status: &ctypes.ResultTxStatus{Status: ctypes.TxStatusPending}}
+	res, err := NewClient(next, lc).TxStatus(context.Background(), tx.Hash())
+	require.NoError(t, err)
+	assert.False(t, res.Verified)
+
+	// the proof doesn't verify
+	next.status = &ctypes.ResultTxStatus{Status: ctypes.TxStatusCommitted, Height: 1, Index: 1}
+	next.txs = []*ctypes.ResultTx{{Hash: tx.Hash(), Height: 1, Index: 1, Tx: tx}}
+	_, err = NewClient(next, lc).TxStatus(context.Background(), tx.Hash())
+	var verr ErrProofVerification
+	require.ErrorAs(t, err, &verr)
+
+	wrapped, err := types.MarshalIndexWrapper([]byte("pfb"), 2)
+	require.NoError(t, err)
+	sq, txShares := newTestTxSquare(t, [][]byte{[]byte("other"), tx}, [][]byte{wrapped})
+	lc = trustedDataHash(1, sq.dataHash)
+	next.txs[0].Proof = sq.prove(t, 0, txShares)
+	next.shareProof = sq.prove(t, 0, txShares)
+
+	res, err = NewClient(next, lc).TxStatus(context.Background(), tx.Hash())
+	require.NoError(t, err)
+	assert.True(t, res.Verified)
+
+	// the tx is not at the reported index
+	for _, index := range []uint32{0, 2} {
+		next.status.Index = index
+		_, err = NewClient(next, lc).TxStatus(context.Background(), tx.Hash())
+		require.ErrorAs(t, err, &verr, index)
+	}
+
+	// the index of PayForBlobs txs can't be proven
+	next.status = &ctypes.ResultTxStatus{Status: ctypes.TxStatusCommitted, Height: 1, Index: 2}
+	next.txs[0] = &ctypes.ResultTx{Hash: types.Tx(wrapped).Hash(), Height: 1, Tx: wrapped, Proof: sq.prove(t, txShares, txShares+1)}
+	res, err = NewClient(next, lc).TxStatus(context.Background(), next.txs[0].Hash)
+	require.NoError(t, err)
+	assert.False(t, res.Verified)
+}
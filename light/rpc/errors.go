@@ -0,0 +1,38 @@
+package rpc
+
+import "fmt"
+
+// ErrProofVerification is returned when a proof or commitment returned by the
+// primary does not match the data committed to by the light client's trusted
+// headers.
+type ErrProofVerification struct {
+	Height int64
+	Reason error
+}
+
+// Unwrap returns the underlying reason.
+func (e ErrProofVerification) Unwrap() error {
+	return e.Reason
+}
+
+func (e ErrProofVerification) Error() string {
+	return fmt.Sprintf("proof verification against trusted header #%d failed: %v", e.Height, e.Reason)
+}
+
+// errLightClientUpdate is returned when the light client can't be updated to
+// (or verify) the requested height.
+type errLightClientUpdate struct {
+	height *int64
+	err    error
+}
+
+func (e errLightClientUpdate) Unwrap() error {
+	return e.err
+}
+
+func (e errLightClientUpdate) Error() string {
+	if e.height == nil {
+		return fmt.Sprintf("failed to update light client to latest height: %v", e.err)
+	}
+	return fmt.Sprintf("failed to update light client to %d: %v", *e.height, e.err)
+}
@@ -0,0 +1,207 @@
+package mempool
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"math"
+	"math/bits"
+	"sort"
+
+	"github.com/tendermint/tendermint/pkg/consts"
+	"github.com/tendermint/tendermint/types"
+)
+
+// BlobInfo describes the blobs of a BlobTx, see types.UnmarshalBlobTx. It is
+// the zero value for the other transactions.
+type BlobInfo struct {
+	// Count is the number of blobs.
+	Count int
+	// Bytes is the total size of the data of the blobs.
+	Bytes int64
+	// Namespaces are the distinct namespaces (version and ID) of the blobs,
+	// in increasing order.
+	Namespaces [][]byte
+
+	sizes  []int // sizes of the data of the blobs
+	txSize int   // size of the tx wrapped by the BlobTx
+}
+
+// NewBlobInfo returns the blobs of tx, if it is a BlobTx.
+func NewBlobInfo(tx types.Tx) BlobInfo {
+	bTx, isBlob := types.UnmarshalBlobTx(tx)
+	if !isBlob {
+		return BlobInfo{}
+	}
+
+	info := BlobInfo{
+		Count:  len(bTx.Blobs),
+		sizes:  make([]int, len(bTx.Blobs)),
+		txSize: len(bTx.Tx),
+	}
+	for i, b := range bTx.Blobs {
+		info.sizes[i] = len(b.Data)
+		info.Bytes += int64(len(b.Data))
+
+		ns := append([]byte{byte(b.NamespaceVersion)}, b.NamespaceId...)
+		j := sort.Search(len(info.Namespaces), func(j int) bool {
+			return bytes.Compare(info.Namespaces[j], ns) >= 0
+		})
+		if j < len(info.Namespaces) && bytes.Equal(info.Namespaces[j], ns) {
+			continue
+		}
+		info.Namespaces = append(info.Namespaces, nil)
+		copy(info.Namespaces[j+1:], info.Namespaces[j:])
+		info.Namespaces[j] = ns
+	}
+	return info
+}
+
+// IsBlobTx returns whether the transaction is a BlobTx.
+func (b BlobInfo) IsBlobTx() bool { return b.Count > 0 }
+
+// ErrBlobBytesExceeded defines an error where the blobs of a transaction
+// don't fit within the blob bytes the mempool is allowed to hold.
+type ErrBlobBytesExceeded struct {
+	BlobBytes    int64
+	MaxBlobBytes int64
+}
+
+func (e ErrBlobBytesExceeded) Error() string {
+	return fmt.Sprintf(
+		"mempool is full of blobs: total blob bytes %d (max: %d)",
+		e.BlobBytes,
+		e.MaxBlobBytes,
+	)
+}
+
+// CheckBlobBytes returns ErrBlobBytesExceeded if adding the blobs to a mempool
+// holding blobBytes bytes of blobs exceeds maxBlobBytes. A zero maxBlobBytes
+// is not enforced.
+func CheckBlobBytes(blobs BlobInfo, blobBytes, maxBlobBytes int64) error {
+	if maxBlobBytes > 0 && blobs.Bytes > 0 && blobBytes+blobs.Bytes > maxBlobBytes {
+		return ErrBlobBytesExceeded{
+			BlobBytes:    blobBytes,
+			MaxBlobBytes: maxBlobBytes,
+		}
+	}
+	return nil
+}
+
+// SquareEstimator estimates the number of shares the transactions reaped for
+// a block take up in its data square, so that they are reaped up to what
+// fits in a square of a maximum size rather than up to a number of bytes.
+//
+// The estimate is an upper bound: the regular transactions and the
+// transactions wrapped by BlobTxs are packed into compact shares, and each
+// blob into sparse shares preceded by as much padding as its alignment can
+// require.
+//
+// A nil *SquareEstimator doesn't limit the transactions.
+type SquareEstimator struct {
+	maxShares int
+
+	txBytes    int // bytes of the compact shares of the regular transactions
+	pfbBytes   int // bytes of the compact shares of the wrapped transactions
+	blobShares int // sparse shares of the blobs, including padding
+}
+
+// NewSquareEstimator returns an estimator of the shares of a square of
+// maxSquareSize x maxSquareSize shares.
+func NewSquareEstimator(maxSquareSize int) *SquareEstimator {
+	return &SquareEstimator{maxShares: maxSquareSize * maxSquareSize}
+}
+
+// Add adds a transaction of txSize bytes with the given blobs to the
+// estimate and returns true if it fits in the square. Otherwise, it returns
+// false and leaves the estimate as is.
+func (e *SquareEstimator) Add(txSize int, blobs BlobInfo) bool {
+	if e == nil {
+		return true
+	}
+
+	txBytes, pfbBytes, blobShares := e.txBytes, e.pfbBytes, e.blobShares
+	if blobs.IsBlobTx() {
+		pfbBytes += delimitedSize(indexWrapperSize(blobs.txSize, blobs.Count))
+		for _, size := range blobs.sizes {
+			shares := sparseShares(size)
+			blobShares += shares + subtreeWidth(shares) - 1
+		}
+	} else {
+		txBytes += delimitedSize(txSize)
+	}
+
+	if compactShares(txBytes)+compactShares(pfbBytes)+blobShares > e.maxShares {
+		return false
+	}
+	e.txBytes, e.pfbBytes, e.blobShares = txBytes, pfbBytes, blobShares
+	return true
+}
+
+// Shares returns the estimated number of shares of the transactions added.
+func (e *SquareEstimator) Shares() int {
+	return compactShares(e.txBytes) + compactShares(e.pfbBytes) + e.blobShares
+}
+
+// delimitedSize returns the size of a unit of n bytes prefixed by its length
+// in a compact share sequence.
+func delimitedSize(n int) int {
+	return uvarintSize(uint64(n)) + n
+}
+
+// indexWrapperSize returns an upper bound of the size of the IndexWrapper the
+// tx of txSize bytes of a BlobTx of count blobs is put in the square as.
+func indexWrapperSize(txSize, count int) int {
+	indexes := count * binary.MaxVarintLen32
+	return 1 + delimitedSize(txSize) +
+		1 + delimitedSize(indexes) +
+		1 + delimitedSize(len(consts.ProtoIndexWrapperTypeID))
+}
+
+// compactShares returns the number of compact shares of a sequence of n
+// bytes.
+func compactShares(n int) int {
+	if n == 0 {
+		return 0
+	}
+	if n <= consts.FirstCompactShareContentSize {
+		return 1
+	}
+	return 1 + ceilDiv(n-consts.FirstCompactShareContentSize, consts.ContinuationCompactShareContentSize)
+}
+
+// sparseShares returns the number of sparse shares of a blob of n bytes.
+func sparseShares(n int) int {
+	if n <= consts.FirstSparseShareContentSize {
+		return 1
+	}
+	return 1 + ceilDiv(n-consts.FirstSparseShareContentSize, consts.ContinuationSparseShareContentSize)
+}
+
+// subtreeWidth returns the width of the subtrees of the share commitment of
+// a blob of the given number of shares, which the index of its first share
+// is a multiple of.
+func subtreeWidth(shares int) int {
+	width := roundUpPowerOfTwo(ceilDiv(shares, consts.SubtreeRootThreshold))
+	minSquareSize := roundUpPowerOfTwo(int(math.Ceil(math.Sqrt(float64(shares)))))
+	if minSquareSize < width {
+		return minSquareSize
+	}
+	return width
+}
+
+func ceilDiv(a, b int) int {
+	return (a + b - 1) / b
+}
+
+func roundUpPowerOfTwo(n int) int {
+	if n <= 1 {
+		return 1
+	}
+	return 1 << bits.Len(uint(n-1))
+}
+
+func uvarintSize(n uint64) int {
+	var buf [binary.MaxVarintLen64]byte
+	return binary.PutUvarint(buf[:], n)
+}
@@ -0,0 +1,86 @@
+package mempool
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/tendermint/tendermint/pkg/consts"
+	tmproto "github.com/tendermint/tendermint/proto/tendermint/types"
+	"github.com/tendermint/tendermint/types"
+)
+
+func TestNewBlobInfo(t *testing.T) {
+	nsOne := bytes.Repeat([]byte{1}, consts.NamespaceIDSize)
+	nsTwo := bytes.Repeat([]byte{2}, consts.NamespaceIDSize)
+	bTx, err := types.MarshalBlobTx([]byte("tx"),
+		&tmproto.Blob{NamespaceId: nsTwo, Data: make([]byte, 100)},
+		&tmproto.Blob{NamespaceId: nsOne, Data: make([]byte, 200)},
+		&tmproto.Blob{NamespaceId: nsTwo, Data: make([]byte, 300)},
+	)
+	require.NoError(t, err)
+
+	info := NewBlobInfo(bTx)
+	require.True(t, info.IsBlobTx())
+	require.Equal(t, 3, info.Count)
+	require.EqualValues(t, 600, info.Bytes)
+	// the namespaces are deduplicated and sorted, with their version
+	require.Equal(t, [][]byte{append([]byte{0}, nsOne...), append([]byte{0}, nsTwo...)}, info.Namespaces)
+
+	require.False(t, NewBlobInfo(types.Tx("sender=key=1")).IsBlobTx())
+	require.Equal(t, BlobInfo{}, NewBlobInfo(types.Tx("sender=key=1")))
+}
+
+func TestCheckBlobBytes(t *testing.T) {
+	blobs := BlobInfo{Count: 1, Bytes: 100}
+
+	require.NoError(t, CheckBlobBytes(blobs, 900, 1000))
+	err := CheckBlobBytes(blobs, 901, 1000)
+	var blobErr ErrBlobBytesExceeded
+	require.True(t, errors.As(err, &blobErr))
+	require.EqualValues(t, 901, blobErr.BlobBytes)
+
+	// the regular txs and a zero max aren't limited
+	require.NoError(t, CheckBlobBytes(BlobInfo{}, 2000, 1000))
+	require.NoError(t, CheckBlobBytes(blobs, 2000, 0))
+}
+
+func TestSquareEstimator(t *testing.T) {
+	blobTx := func(size int) BlobInfo {
+		bTx, err := types.MarshalBlobTx(make([]byte, 100), &tmproto.Blob{
+			NamespaceId: bytes.Repeat([]byte{1}, consts.NamespaceIDSize),
+			Data:        make([]byte, size),
+		})
+		require.NoError(t, err)
+		return NewBlobInfo(bTx)
+	}
+
+	// a 2x2 square holds 4 shares
+	e := NewSquareEstimator(2)
+	require.True(t, e.Add(400, BlobInfo{}))
+	require.Equal(t, 1, e.Shares())
+
+	// a blob of 3 shares and its tx don't fit along with the regular tx
+	require.False(t, e.Add(100, blobTx(1000)))
+	require.Equal(t, 1, e.Shares())
+
+	// a blob of 2 shares does
+	require.True(t, e.Add(100, blobTx(500)))
+	require.Equal(t, 4, e.Shares())
+
+	// regular txs are packed in the compact shares used
+	require.True(t, e.Add(10, BlobInfo{}))
+	require.False(t, e.Add(100, BlobInfo{}))
+	require.Equal(t, 4, e.Shares())
+
+	// the padding of a blob aligned to its subtree width is counted:
+	// a blob of 65 shares has a subtree width of 2
+	e = NewSquareEstimator(16)
+	require.True(t, e.Add(100, blobTx(consts.FirstSparseShareContentSize+64*consts.ContinuationSparseShareContentSize)))
+	require.Equal(t, 1+65+1, e.Shares())
+
+	var nilEstimator *SquareEstimator
+	require.True(t, nilEstimator.Add(1<<30, BlobInfo{}))
+}
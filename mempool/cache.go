@@ -134,3 +134,90 @@ func (NopTxCache) Push(types.Tx) bool      { return true }
 func (NopTxCache) Remove(types.Tx)         {}
 func (NopTxCache) Has(types.Tx) bool       { return false }
 func (NopTxCache) HasKey(types.TxKey) bool { return false }
+
+// RemovedTxCache maintains a thread-safe LRU cache of the keys of the
+// transactions removed from, or rejected by, the mempool, along with the
+// reason why. It is used to report the status of transactions that are no
+// longer in the mempool.
+type RemovedTxCache struct {
+	mtx      cmtsync.Mutex
+	size     int
+	cacheMap map[types.TxKey]*list.Element
+	list     *list.List
+}
+
+type removedTx struct {
+	key    types.TxKey
+	reason RemovalReason
+}
+
+// NewRemovedTxCache returns a cache holding up to cacheSize transactions. A
+// cache of size zero holds no transactions.
+func NewRemovedTxCache(cacheSize int) *RemovedTxCache {
+	return &RemovedTxCache{
+		size:     cacheSize,
+		cacheMap: make(map[types.TxKey]*list.Element, cacheSize),
+		list:     list.New(),
+	}
+}
+
+func (c *RemovedTxCache) Reset() {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+
+	c.cacheMap = make(map[types.TxKey]*list.Element, c.size)
+	c.list.Init()
+}
+
+// Push records that the transaction with the given key was removed for the
+// given reason, replacing any previous reason.
+func (c *RemovedTxCache) Push(key types.TxKey, reason RemovalReason) {
+	if c.size == 0 {
+		return
+	}
+
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+
+	if e, ok := c.cacheMap[key]; ok {
+		e.Value.(*removedTx).reason = reason
+		c.list.MoveToBack(e)
+		return
+	}
+
+	if c.list.Len() >= c.size {
+		if front := c.list.Front(); front != nil {
+			delete(c.cacheMap, front.Value.(*removedTx).key)
+			c.list.Remove(front)
+		}
+	}
+
+	c.cacheMap[key] = c.list.PushBack(&removedTx{key: key, reason: reason})
+}
+
+func (c *RemovedTxCache) Remove(key types.TxKey) {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+
+	if e, ok := c.cacheMap[key]; ok {
+		delete(c.cacheMap, key)
+		c.list.Remove(e)
+	}
+}
+
+// Get returns the reason the transaction with the given key was removed, if
+// it is in the cache.
+func (c *RemovedTxCache) Get(key types.TxKey) (RemovalReason, bool) {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+
+	if e, ok := c.cacheMap[key]; ok {
+		return e.Value.(*removedTx).reason, true
+	}
+	return "", false
+}
+
+func (c *RemovedTxCache) Has(key types.TxKey) bool {
+	_, ok := c.Get(key)
+	return ok
+}
@@ -0,0 +1,205 @@
+package cat
+
+import (
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/tendermint/tendermint/p2p"
+	"github.com/tendermint/tendermint/types"
+)
+
+const (
+	// budgetWindow is the window over which the outbound bytes are limited.
+	budgetWindow = time.Second
+
+	// maxPendingWants is the maximum number of WantTx requests deferred until
+	// the outbound budget allows serving them. Past it, the requests of the
+	// lowest priority transactions are dropped.
+	maxPendingWants = 5000
+)
+
+// outboundBudget limits the bytes of transactions the reactor sends per
+// window, to all the peers and to each peer. A zero limit is not enforced.
+//
+// outboundBudget is safe for concurrent use.
+type outboundBudget struct {
+	window     time.Duration
+	max        int64
+	maxPerPeer int64
+
+	mtx        sync.Mutex
+	start      time.Time
+	used       int64
+	usedByPeer map[uint16]int64
+}
+
+func newOutboundBudget(window time.Duration, max, maxPerPeer int64) *outboundBudget {
+	return &outboundBudget{
+		window:     window,
+		max:        max,
+		maxPerPeer: maxPerPeer,
+		usedByPeer: make(map[uint16]int64),
+	}
+}
+
+// enabled returns whether any limit is enforced.
+func (b *outboundBudget) enabled() bool {
+	return b.max > 0 || b.maxPerPeer > 0
+}
+
+// consume counts n bytes sent to the peer at now against the budget and
+// returns true, or returns false without counting them if they exceed it.
+// The first transaction of a window is always within the budget, so that
+// transactions larger than it are eventually sent.
+func (b *outboundBudget) consume(peer uint16, n int64, now time.Time) bool {
+	if !b.enabled() {
+		return true
+	}
+
+	b.mtx.Lock()
+	defer b.mtx.Unlock()
+
+	b.roll(now)
+	used, usedByPeer := b.used+n, b.usedByPeer[peer]+n
+	if (b.max > 0 && b.used > 0 && used > b.max) ||
+		(b.maxPerPeer > 0 && b.usedByPeer[peer] > 0 && usedByPeer > b.maxPerPeer) {
+		return false
+	}
+	b.used, b.usedByPeer[peer] = used, usedByPeer
+	return true
+}
+
+// exhausted returns whether the global budget of the window of now is used
+// up.
+func (b *outboundBudget) exhausted(now time.Time) bool {
+	b.mtx.Lock()
+	defer b.mtx.Unlock()
+
+	b.roll(now)
+	return b.max > 0 && b.used >= b.max
+}
+
+// utilization returns the fractions of the global budget and of the budget
+// of the most demanding peer used in the current window.
+func (b *outboundBudget) utilization() (global, peer float64) {
+	b.mtx.Lock()
+	defer b.mtx.Unlock()
+
+	if b.max > 0 {
+		global = float64(b.used) / float64(b.max)
+	}
+	if b.maxPerPeer > 0 {
+		for _, used := range b.usedByPeer {
+			if u := float64(used) / float64(b.maxPerPeer); u > peer {
+				peer = u
+			}
+		}
+	}
+	return global, peer
+}
+
+// removePeer forgets the bytes sent to the peer.
+func (b *outboundBudget) removePeer(peer uint16) {
+	b.mtx.Lock()
+	defer b.mtx.Unlock()
+
+	delete(b.usedByPeer, peer)
+}
+
+// roll starts a new window if the current one is over at now. The caller must
+// hold b.mtx.
+func (b *outboundBudget) roll(now time.Time) {
+	if now.Sub(b.start) < b.window {
+		return
+	}
+	b.start = now
+	b.used = 0
+	for peer := range b.usedByPeer {
+		delete(b.usedByPeer, peer)
+	}
+}
+
+// pendingWant is a WantTx request deferred until the outbound budget allows
+// serving it.
+type pendingWant struct {
+	peer     p2p.Peer
+	peerID   uint16
+	key      types.TxKey
+	priority int64
+}
+
+// wantQueue holds the deferred WantTx requests, to serve them in order of
+// nonincreasing priority of their transactions.
+//
+// wantQueue is safe for concurrent use.
+type wantQueue struct {
+	mtx   sync.Mutex
+	wants []pendingWant
+}
+
+// push defers a request. If the queue is full, the request of the lowest
+// priority transaction is dropped and returned.
+func (q *wantQueue) push(want pendingWant) (dropped *pendingWant) {
+	q.mtx.Lock()
+	defer q.mtx.Unlock()
+
+	q.wants = append(q.wants, want)
+	if len(q.wants) <= maxPendingWants {
+		return nil
+	}
+	q.sort()
+	last := q.wants[len(q.wants)-1]
+	q.wants = q.wants[:len(q.wants)-1]
+	return &last
+}
+
+// len returns the number of deferred requests.
+func (q *wantQueue) len() int {
+	q.mtx.Lock()
+	defer q.mtx.Unlock()
+	return len(q.wants)
+}
+
+// drain calls serve with the deferred requests in order of nonincreasing
+// priority. The requests serve returns true for are removed from the queue,
+// the others kept. drain stops early when done returns true.
+func (q *wantQueue) drain(serve func(pendingWant) bool, done func() bool) {
+	q.mtx.Lock()
+	defer q.mtx.Unlock()
+
+	q.sort()
+	kept := q.wants[:0]
+	for i, want := range q.wants {
+		if done() {
+			kept = append(kept, q.wants[i:]...)
+			break
+		}
+		if !serve(want) {
+			kept = append(kept, want)
+		}
+	}
+	q.wants = kept
+}
+
+// removePeer drops the requests of the peer.
+func (q *wantQueue) removePeer(peerID uint16) {
+	q.mtx.Lock()
+	defer q.mtx.Unlock()
+
+	kept := q.wants[:0]
+	for _, want := range q.wants {
+		if want.peerID != peerID {
+			kept = append(kept, want)
+		}
+	}
+	q.wants = kept
+}
+
+// sort orders the requests by nonincreasing priority. The caller must hold
+// q.mtx.
+func (q *wantQueue) sort() {
+	sort.SliceStable(q.wants, func(i, j int) bool {
+		return q.wants[i].priority > q.wants[j].priority
+	})
+}
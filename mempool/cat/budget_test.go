@@ -0,0 +1,84 @@
+package cat
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/tendermint/tendermint/types"
+)
+
+func TestOutboundBudget(t *testing.T) {
+	var (
+		b   = newOutboundBudget(time.Second, 100, 60)
+		now = time.Now()
+	)
+	require.True(t, b.enabled())
+
+	require.True(t, b.consume(1, 50, now))
+	// over the budget of the peer
+	require.False(t, b.consume(1, 20, now))
+	require.True(t, b.consume(2, 50, now))
+	global, peer := b.utilization()
+	require.Equal(t, 1.0, global)
+	require.InDelta(t, 50.0/60, peer, 0.001)
+	require.True(t, b.exhausted(now))
+	// over the global budget
+	require.False(t, b.consume(3, 1, now))
+
+	// the budget is reset at the end of the window
+	require.False(t, b.exhausted(now.Add(time.Second)))
+	require.True(t, b.consume(3, 10, now.Add(time.Second)))
+
+	// the first tx of a window is sent even if it exceeds the budget
+	require.True(t, b.consume(1, 1000, now.Add(2*time.Second)))
+	require.False(t, b.consume(2, 1, now.Add(2*time.Second)))
+
+	disabled := newOutboundBudget(time.Second, 0, 0)
+	require.False(t, disabled.enabled())
+	for i := 0; i < 10; i++ {
+		require.True(t, disabled.consume(1, 1000, now))
+	}
+}
+
+func TestWantQueue(t *testing.T) {
+	var q wantQueue
+	for i, priority := range []int64{2, 5, 1, 5, 3} {
+		q.push(pendingWant{peerID: uint16(i), key: types.TxKey{byte(i)}, priority: priority})
+	}
+	require.Equal(t, 5, q.len())
+
+	// the wants are served by nonincreasing priority, in order of arrival
+	var served []uint16
+	q.drain(func(want pendingWant) bool {
+		served = append(served, want.peerID)
+		return want.peerID != 4 // keep the want of priority 3
+	}, func() bool { return len(served) == 3 })
+	require.Equal(t, []uint16{1, 3, 4}, served)
+	require.Equal(t, 3, q.len())
+
+	served = nil
+	q.drain(func(want pendingWant) bool {
+		served = append(served, want.peerID)
+		return true
+	}, func() bool { return false })
+	require.Equal(t, []uint16{4, 0, 2}, served)
+	require.Zero(t, q.len())
+
+	// the wants of a removed peer are dropped
+	q.push(pendingWant{peerID: 1})
+	q.push(pendingWant{peerID: 2})
+	q.removePeer(1)
+	require.Equal(t, 1, q.len())
+
+	// past the limit, the want of the lowest priority is dropped
+	q = wantQueue{}
+	for i := 0; i < maxPendingWants; i++ {
+		require.Nil(t, q.push(pendingWant{priority: 2}))
+	}
+	dropped := q.push(pendingWant{priority: 1})
+	require.NotNil(t, dropped)
+	require.EqualValues(t, 1, dropped.priority)
+	require.Equal(t, maxPendingWants, q.len())
+}
@@ -3,7 +3,6 @@ package cat
 import (
 	"errors"
 	"fmt"
-	"sort"
 	"sync"
 	"time"
 
@@ -62,13 +61,20 @@ type TxPool struct {
 	// Thread-safe cache of rejected transactions for quick look-up
 	rejectedTxCache *LRUTxCache
 	// Thread-safe cache of evicted transactions for quick look-up
-	evictedTxCache *LRUTxCache
+	evictedTxCache *mempool.RemovedTxCache
+	// Thread-safe cache of why transactions were rejected, reported by
+	// TxStatus. Unlike rejectedTxCache, it doesn't prevent them from being
+	// checked again.
+	rejectionReasons *mempool.RemovedTxCache
 	// Thread-safe list of transactions peers have seen that we have not yet seen
 	seenByPeersSet *SeenTxSet
 
 	// Store of wrapped transactions
 	store *store
 
+	// Journal of the transactions, if enabled
+	wal *mempool.WAL
+
 	// broadcastCh is an unbuffered channel of new transactions that need to
 	// be broadcasted to peers. Only populated if `broadcast` in the config is enabled
 	broadcastCh      chan *wrappedTx
@@ -91,7 +97,8 @@ func NewTxPool(
 		proxyAppConn:     proxyAppConn,
 		metrics:          mempool.NopMetrics(),
 		rejectedTxCache:  NewLRUTxCache(cfg.CacheSize),
-		evictedTxCache:   NewLRUTxCache(cfg.CacheSize / 5),
+		evictedTxCache:   mempool.NewRemovedTxCache(cfg.CacheSize / 5),
+		rejectionReasons: mempool.NewRemovedTxCache(cfg.CacheSize / 5),
 		seenByPeersSet:   NewSeenTxSet(),
 		height:           height,
 		preCheckFn:       func(_ types.Tx) error { return nil },
@@ -101,6 +108,8 @@ func NewTxPool(
 		txsToBeBroadcast: make([]types.TxKey, 0),
 	}
 
+	txmp.store.senderQuotas = mempool.NewSenderQuotas(cfg.MaxSenderShare, cfg.Size, cfg.MaxTxsBytes)
+
 	for _, opt := range options {
 		opt(txmp)
 	}
@@ -127,6 +136,11 @@ func WithMetrics(metrics *mempool.Metrics) TxPoolOption {
 	return func(txmp *TxPool) { txmp.metrics = metrics }
 }
 
+// WithWAL sets the journal the mempool persists its transactions to.
+func WithWAL(wal *mempool.WAL) TxPoolOption {
+	return func(txmp *TxPool) { txmp.wal = wal }
+}
+
 // Lock is a noop as ABCI calls are serialized
 func (txmp *TxPool) Lock() {}
 
@@ -141,6 +155,10 @@ func (txmp *TxPool) Size() int { return txmp.store.size() }
 // mempool. It is thread-safe.
 func (txmp *TxPool) SizeBytes() int64 { return txmp.store.totalBytes() }
 
+// SizeBlobBytes returns the total sum in bytes of the blobs of the valid
+// transactions in the mempool. It is thread-safe.
+func (txmp *TxPool) SizeBlobBytes() int64 { return txmp.store.totalBlobBytes() }
+
 // FlushAppConn executes FlushSync on the mempool's proxyAppConn.
 //
 // The caller must hold an exclusive mempool lock (by calling txmp.Lock) before
@@ -193,6 +211,62 @@ func (txmp *TxPool) WasRecentlyEvicted(txKey types.TxKey) bool {
 	return txmp.evictedTxCache.Has(txKey)
 }
 
+// GetPendingTx returns the details of the transaction with the specified key
+// if it is in the mempool. Its position is the number of transactions that
+// are reaped before it, see mempool.OrderTxs.
+func (txmp *TxPool) GetPendingTx(txKey types.TxKey) (*mempool.PendingTx, bool) {
+	wtx := txmp.store.get(txKey)
+	if wtx == nil {
+		return nil, false
+	}
+
+	position := 0
+	for _, w := range txmp.allEntriesSorted() {
+		if w.key == wtx.key {
+			break
+		}
+		position++
+	}
+
+	return &mempool.PendingTx{
+		Priority:  wtx.priority,
+		GasWanted: wtx.gasWanted,
+		Height:    wtx.height,
+		Timestamp: wtx.timestamp,
+		Position:  position,
+	}, true
+}
+
+// GetRemovalReason returns the reason the transaction with the specified key
+// was recently evicted or rejected.
+func (txmp *TxPool) GetRemovalReason(txKey types.TxKey) (mempool.RemovalReason, bool) {
+	if reason, ok := txmp.evictedTxCache.Get(txKey); ok {
+		return reason, true
+	}
+	return txmp.rejectionReasons.Get(txKey)
+}
+
+// SetTxListener sets the listener notified of the changes to the transactions
+// in the pool. It is called with the lock of the store held.
+func (txmp *TxPool) SetTxListener(l mempool.TxListener) {
+	txmp.store.feed.SetListener(l)
+}
+
+// Snapshot returns the transactions in the pool in the order they are reaped,
+// see mempool.OrderTxs, along with the version of the last change to them.
+func (txmp *TxPool) Snapshot() *mempool.Snapshot {
+	wtxs, version := txmp.store.snapshot()
+	wtxs = mempool.OrderTxs(wtxs, (*wrappedTx).order)
+	txs := make([]mempool.TxMeta, len(wtxs))
+	for i, w := range wtxs {
+		txs[i] = w.meta()
+	}
+	return &mempool.Snapshot{
+		Version: version,
+		Txs:     txs,
+	}
+}
+
 // IsRejectedTx returns true if the transaction was recently rejected and is
 // currently within the cache
 func (txmp *TxPool) IsRejectedTx(txKey types.TxKey) bool {
@@ -212,7 +286,8 @@ func (txmp *TxPool) CheckToPurgeExpiredTxs() {
 		purgedTxs, numExpired := txmp.store.purgeExpiredTxs(0, expirationAge)
 		// Add the purged transactions to the evicted cache
 		for _, tx := range purgedTxs {
-			txmp.evictedTxCache.Push(tx.key)
+			txmp.evictedTxCache.Push(tx.key, mempool.RemovalReasonExpired)
+			txmp.removeFromWAL(tx.key)
 		}
 		txmp.metrics.EvictedTxs.Add(float64(numExpired))
 		txmp.lastPurgeTime = time.Now()
@@ -339,13 +414,14 @@ func (txmp *TxPool) TryAddNewTx(tx types.Tx, key types.TxKey, txInfo mempool.TxI
 		if txmp.config.KeepInvalidTxsInCache {
 			txmp.rejectedTxCache.Push(key)
 		}
+		txmp.rejectionReasons.Push(key, mempool.RemovalReasonCheckTxFailed)
 		txmp.metrics.FailedTxs.Add(1)
 		return rsp, fmt.Errorf("application rejected transaction with code %d (Log: %s)", rsp.Code, rsp.Log)
 	}
 
 	// Create wrapped tx
 	wtx := newWrappedTx(
-		tx, key, txmp.Height(), rsp.GasWanted, rsp.Priority, rsp.Sender,
+		tx, key, txmp.Height(), rsp.GasWanted, rsp.Priority, rsp.Sender, rsp.Sequence,
 	)
 
 	// Perform the post check
@@ -354,6 +430,7 @@ func (txmp *TxPool) TryAddNewTx(tx types.Tx, key types.TxKey, txInfo mempool.TxI
 		if txmp.config.KeepInvalidTxsInCache {
 			txmp.rejectedTxCache.Push(key)
 		}
+		txmp.rejectionReasons.Push(key, mempool.RemovalReasonCheckTxFailed)
 		txmp.metrics.FailedTxs.Add(1)
 		return rsp, fmt.Errorf("rejected bad transaction after post check: %w", err)
 	}
@@ -378,6 +455,7 @@ func (txmp *TxPool) removeTxByKey(txKey types.TxKey) {
 	txmp.rejectedTxCache.Push(txKey)
 	_ = txmp.store.remove(txKey)
 	txmp.seenByPeersSet.RemoveKey(txKey)
+	txmp.removeFromWAL(txKey)
 }
 
 // Flush purges the contents of the mempool and the cache, leaving both empty.
@@ -386,10 +464,14 @@ func (txmp *TxPool) Flush() {
 	// Remove all the transactions in the list explicitly, so that the sizes
 	// and indexes get updated properly.
 	size := txmp.Size()
+	for _, key := range txmp.store.getAllKeys() {
+		txmp.removeFromWAL(key)
+	}
 	txmp.store.reset()
 	txmp.seenByPeersSet.Reset()
 	txmp.rejectedTxCache.Reset()
 	txmp.evictedTxCache.Reset()
+	txmp.rejectionReasons.Reset()
 	txmp.metrics.EvictedTxs.Add(float64(size))
 	txmp.broadcastMtx.Lock()
 	defer txmp.broadcastMtx.Unlock()
@@ -404,40 +486,54 @@ func (txmp *TxPool) PeerHasTx(peer uint16, txKey types.TxKey) {
 
 // allEntriesSorted returns a slice of all the transactions currently in the
 // mempool, sorted in nonincreasing order by priority with ties broken by
-// increasing order of arrival time.
+// increasing order of arrival time, and the transactions of a sender sorted
+// by increasing sequence, see mempool.OrderTxs.
 func (txmp *TxPool) allEntriesSorted() []*wrappedTx {
-	txs := txmp.store.getAllTxs()
-	sort.Slice(txs, func(i, j int) bool {
-		if txs[i].priority == txs[j].priority {
-			return txs[i].timestamp.Before(txs[j].timestamp)
-		}
-		return txs[i].priority > txs[j].priority // N.B. higher priorities first
-	})
-	return txs
+	return mempool.OrderTxs(txmp.store.getAllTxs(), (*wrappedTx).order)
 }
 
 // ReapMaxBytesMaxGas returns a slice of valid transactions that fit within the
 // size and gas constraints. The results are ordered by nonincreasing priority,
-// with ties broken by increasing order of arrival. Reaping transactions does
-// not remove them from the mempool
+// with ties broken by increasing order of arrival, and the transactions of a
+// sender by increasing sequence. Reaping transactions does not remove them
+// from the mempool
+//
+// The sequenced transactions of a sender are reaped as a run of consecutive
+// sequences: once one of them doesn't fit or is missing, the following ones
+// are skipped as well, see mempool.SequenceGate.
 //
 // If maxBytes < 0, no limit is set on the total size in bytes.
 // If maxGas < 0, no limit is set on the total gas cost.
+// In the square reap mode, the transactions must also fit in the estimated
+// shares of a data square of the configured max square size.
 //
 // If the mempool is empty or has no transactions fitting within the given
 // constraints, the result will also be empty.
 func (txmp *TxPool) ReapMaxBytesMaxGas(maxBytes, maxGas int64) types.Txs {
 	var totalGas, totalBytes int64
 
+	var square *mempool.SquareEstimator
+	if txmp.config.ReapMode == config.MempoolReapModeSquare {
+		square = mempool.NewSquareEstimator(txmp.config.MaxSquareSize)
+	}
+
 	var keep []types.Tx //nolint:prealloc
+	gate := mempool.NewSequenceGate()
 	for _, w := range txmp.allEntriesSorted() {
+		if !gate.Admit(w.order()) {
+			continue
+		}
+
 		// N.B. When computing byte size, we need to include the overhead for
 		// encoding as protobuf to send to the application. This actually overestimates it
 		// as we add the proto overhead to each transaction
 		txBytes := types.ComputeProtoSizeForTxs([]types.Tx{w.tx})
-		if (maxGas >= 0 && totalGas+w.gasWanted > maxGas) || (maxBytes >= 0 && totalBytes+txBytes > maxBytes) {
+		if (maxGas >= 0 && totalGas+w.gasWanted > maxGas) || (maxBytes >= 0 && totalBytes+txBytes > maxBytes) ||
+			!square.Add(len(w.tx), w.blobs) {
+			gate.Skip(w.order())
 			continue
 		}
+		gate.Reap(w.order())
 		totalBytes += txBytes
 		totalGas += w.gasWanted
 		keep = append(keep, w.tx)
@@ -447,7 +543,8 @@ func (txmp *TxPool) ReapMaxBytesMaxGas(maxBytes, maxGas int64) types.Txs {
 
 // ReapMaxTxs returns up to max transactions from the mempool. The results are
 // ordered by nonincreasing priority with ties broken by increasing order of
-// arrival. Reaping transactions does not remove them from the mempool.
+// arrival, and the transactions of a sender by increasing sequence. Reaping
+// transactions does not remove them from the mempool.
 //
 // If max < 0, all transactions in the mempool are reaped.
 //
@@ -511,6 +608,13 @@ func (txmp *TxPool) Update(
 
 	txmp.purgeExpiredTxs(blockHeight)
 
+	// Journal the removal of the committed and expired transactions.
+	if txmp.wal != nil {
+		if err := txmp.wal.Sync(); err != nil {
+			txmp.logger.Error("failed to sync the mempool WAL", "err", err)
+		}
+	}
+
 	// If there any uncommitted transactions left in the mempool, we either
 	// initiate re-CheckTx per remaining transaction or notify that remaining
 	// transactions are left.
@@ -541,20 +645,65 @@ func (txmp *TxPool) Update(
 //
 // Finally, the new transaction is added and size stats updated.
 func (txmp *TxPool) addNewTransaction(wtx *wrappedTx, checkTxRes *abci.ResponseCheckTx) error {
+	// A transaction replaces the one of the same sender and sequence only if
+	// its priority is higher by the configured bump.
+	replaced, err := txmp.checkReplacement(wtx)
+	if err != nil {
+		checkTxRes.MempoolError = err.Error()
+		return err
+	}
+	// The replaced transaction, if any, makes room for the new one.
+	var replacedBytes int64
+	if replaced != nil {
+		replacedBytes = replaced.size()
+	}
+
+	// Limit the share of the mempool held by the sender, the replaced
+	// transaction, if any, being released.
+	addedTxs := 1
+	if replaced != nil {
+		addedTxs = 0
+	}
+	if err := txmp.store.senderQuotas.Check(wtx.sender, addedTxs, wtx.size()-replacedBytes); err != nil {
+		txmp.metrics.QuotaExceededTxs.With("quota", "sender").Add(1)
+		checkTxRes.Code = mempool.CodeTypeSenderQuotaExceeded
+		checkTxRes.Codespace = mempool.Codespace
+		checkTxRes.MempoolError = err.Error()
+		return err
+	}
+
+	// Limit the total size of the blobs in the mempool, the ones of the
+	// replaced transaction, if any, being released.
+	blobBytes := txmp.SizeBlobBytes()
+	if replaced != nil {
+		blobBytes -= replaced.blobs.Bytes
+	}
+	if err := mempool.CheckBlobBytes(wtx.blobs, blobBytes, txmp.config.MaxBlobBytes); err != nil {
+		checkTxRes.MempoolError = err.Error()
+		return err
+	}
+
 	// At this point the application has ruled the transaction valid, but the
 	// mempool might be full. If so, find the lowest-priority items with lower
 	// priority than the application assigned to this new one, and evict as many
 	// of them as necessary to make room for tx. If no such items exist, we
 	// discard tx.
-	if !txmp.canAddTx(wtx.size()) {
-		victims, victimBytes := txmp.store.getTxsBelowPriority(wtx.priority)
+	if !txmp.canAddTx(wtx.size() - replacedBytes) {
+		// The eligible transactions for eviction, in the order they are
+		// evicted, see mempool.EvictionCandidates. The replaced transaction,
+		// of the same sender, is not among them.
+		victims := mempool.EvictionCandidates(txmp.store.getAllTxs(), (*wrappedTx).order, wtx.order())
+		var victimBytes int64
+		for _, tx := range victims {
+			victimBytes += tx.size()
+		}
 
 		// If there are no suitable eviction candidates, or the total size of
 		// those candidates is not enough to make room for the new transaction,
 		// drop the new one.
-		if len(victims) == 0 || victimBytes < wtx.size() {
+		if (len(victims) == 0 && replaced == nil) || victimBytes+replacedBytes < wtx.size() {
 			txmp.metrics.EvictedTxs.Add(1)
-			txmp.evictedTxCache.Push(wtx.key)
+			txmp.evictedTxCache.Push(wtx.key, mempool.RemovalReasonMempoolFull)
 			checkTxRes.MempoolError = fmt.Sprintf("rejected valid incoming transaction; mempool is full (%X)",
 				wtx.key)
 			return fmt.Errorf("rejected valid incoming transaction; mempool is full (%X). Size: (%d:%d)",
@@ -566,19 +715,8 @@ func (txmp *TxPool) addNewTransaction(wtx *wrappedTx, checkTxRes *abci.ResponseC
 			"new_priority", wtx.priority,
 		)
 
-		// Sort lowest priority items first so they will be evicted first.  Break
-		// ties in favor of newer items (to maintain FIFO semantics in a group).
-		sort.Slice(victims, func(i, j int) bool {
-			iw := victims[i]
-			jw := victims[j]
-			if iw.priority == jw.priority {
-				return iw.timestamp.After(jw.timestamp)
-			}
-			return iw.priority < jw.priority
-		})
-
 		// Evict as many of the victims as necessary to make room.
-		availableBytes := txmp.availableBytes()
+		availableBytes := txmp.availableBytes() + replacedBytes
 		for _, tx := range victims {
 			txmp.evictTx(tx)
 
@@ -591,7 +729,29 @@ func (txmp *TxPool) addNewTransaction(wtx *wrappedTx, checkTxRes *abci.ResponseC
 		}
 	}
 
-	txmp.store.set(wtx)
+	if replaced != nil {
+		txmp.replaceTx(replaced, wtx)
+	}
+	if !txmp.store.set(wtx) {
+		// a transaction of the same sender and sequence was added concurrently
+		checkTxRes.MempoolError = fmt.Sprintf(
+			"rejected valid incoming transaction; tx already exists for sender %q and sequence %d",
+			wtx.sender, wtx.sequence)
+		return errors.New(checkTxRes.MempoolError)
+	}
+	if txmp.wal != nil {
+		err := txmp.wal.AddTx(&mempool.WALTx{
+			Tx:        wtx.tx,
+			Priority:  wtx.priority,
+			Sender:    wtx.sender,
+			Height:    wtx.height,
+			Timestamp: wtx.timestamp,
+		})
+		if err != nil {
+			txmp.logger.Error("failed to journal transaction in the mempool WAL",
+				"tx", fmt.Sprintf("%X", wtx.key), "err", err)
+		}
+	}
 
 	txmp.metrics.TxSizeBytes.Observe(float64(wtx.size()))
 	txmp.metrics.Size.Set(float64(txmp.Size()))
@@ -608,8 +768,9 @@ func (txmp *TxPool) addNewTransaction(wtx *wrappedTx, checkTxRes *abci.ResponseC
 }
 
 func (txmp *TxPool) evictTx(wtx *wrappedTx) {
-	txmp.store.remove(wtx.key)
-	txmp.evictedTxCache.Push(wtx.key)
+	txmp.store.evict(wtx.key, mempool.RemovalReasonMempoolFull)
+	txmp.removeFromWAL(wtx.key)
+	txmp.evictedTxCache.Push(wtx.key, mempool.RemovalReasonMempoolFull)
 	txmp.metrics.EvictedTxs.Add(1)
 	txmp.logger.Debug(
 		"evicted valid existing transaction; mempool full",
@@ -618,6 +779,50 @@ func (txmp *TxPool) evictTx(wtx *wrappedTx) {
 	)
 }
 
+// checkReplacement returns the transaction of the same sender and sequence
+// as wtx that wtx replaces, if any. It returns an error if the priority of
+// wtx isn't high enough to replace it.
+func (txmp *TxPool) checkReplacement(wtx *wrappedTx) (*wrappedTx, error) {
+	if !wtx.sequenced() {
+		return nil, nil
+	}
+	existing := txmp.store.getBySequence(wtx.sender, wtx.sequence)
+	if existing == nil {
+		return nil, nil
+	}
+	minPriority := mempool.MinReplacementPriority(existing.priority, txmp.config.ReplacementPriorityBump)
+	if wtx.priority < minPriority {
+		return nil, fmt.Errorf("rejected valid incoming transaction; tx already exists for sender %q and sequence %d (%X) "+
+			"and priority %d is below the replacement priority %d",
+			wtx.sender, wtx.sequence, existing.key, wtx.priority, minPriority)
+	}
+	return existing, nil
+}
+
+// replaceTx evicts the transaction replaced by wtx, of the same sender and
+// sequence.
+func (txmp *TxPool) replaceTx(replaced, wtx *wrappedTx) {
+	txmp.store.evict(replaced.key, mempool.RemovalReasonReplaced)
+	txmp.removeFromWAL(replaced.key)
+	txmp.evictedTxCache.Push(replaced.key, mempool.RemovalReasonReplaced)
+	txmp.metrics.EvictedTxs.Add(1)
+	txmp.logger.Debug(
+		"replaced valid existing transaction",
+		"old_tx", fmt.Sprintf("%X", replaced.key),
+		"old_priority", replaced.priority,
+		"new_tx", fmt.Sprintf("%X", wtx.key),
+		"new_priority", wtx.priority,
+	)
+}
+
+// removeFromWAL journals the removal of the transaction from the mempool, if
+// the WAL is enabled.
+func (txmp *TxPool) removeFromWAL(txKey types.TxKey) {
+	if txmp.wal != nil {
+		txmp.wal.RemoveTx(txKey)
+	}
+}
+
 // handleRecheckResult handles the responses from ABCI CheckTx calls issued
 // during the recheck phase of a block Update.  It removes any transactions
 // invalidated by the application.
@@ -643,7 +848,9 @@ func (txmp *TxPool) handleRecheckResult(wtx *wrappedTx, checkTxRes *abci.Respons
 		"err", err,
 		"code", checkTxRes.Code,
 	)
-	txmp.store.remove(wtx.key)
+	txmp.store.evict(wtx.key, mempool.RemovalReasonRecheckFailed)
+	txmp.removeFromWAL(wtx.key)
+	txmp.evictedTxCache.Push(wtx.key, mempool.RemovalReasonRecheckFailed)
 	if txmp.config.KeepInvalidTxsInCache {
 		txmp.rejectedTxCache.Push(wtx.key)
 	}
@@ -668,11 +875,10 @@ func (txmp *TxPool) recheckTransactions() {
 		"height", txmp.Height(),
 	)
 
-	// Collect transactions currently in the mempool requiring recheck.
-	// TODO: we are iterating over a map, which may scramble the order of transactions
-	// such that they are not in order, dictated by nonce and then priority. This may
-	// cause transactions to needlessly be kicked out in RecheckTx
-	wtxs := txmp.store.getAllTxs()
+	// Collect transactions currently in the mempool requiring recheck, in the
+	// order they are reaped so that the transactions of a sender are rechecked
+	// by increasing sequence.
+	wtxs := txmp.allEntriesSorted()
 
 	// Issue CheckTx calls for each remaining transaction, and when all the
 	// rechecks are complete signal watchers that transactions may be available.
@@ -737,7 +943,8 @@ func (txmp *TxPool) purgeExpiredTxs(blockHeight int64) {
 	purgedTxs, numExpired := txmp.store.purgeExpiredTxs(expirationHeight, expirationAge)
 	// Add the purged transactions to the evicted cache
 	for _, tx := range purgedTxs {
-		txmp.evictedTxCache.Push(tx.key)
+		txmp.evictedTxCache.Push(tx.key, mempool.RemovalReasonExpired)
+		txmp.removeFromWAL(tx.key)
 	}
 	txmp.metrics.ExpiredTxs.Add(float64(numExpired))
 
@@ -9,6 +9,7 @@ import (
 	"os"
 	"sort"
 	"strconv"
+	"strings"
 	"sync"
 	"testing"
 	"time"
@@ -51,11 +52,18 @@ func (app *application) CheckTx(req abci.RequestCheckTx) abci.ResponseCheckTx {
 	var (
 		priority int64
 		sender   string
+		sequence uint64
 	)
 
-	// infer the priority from the raw transaction value (sender=key=value)
+	// evaluate the tx wrapped by a BlobTx
+	if bTx, isBlob := types.UnmarshalBlobTx(req.Tx); isBlob {
+		req.Tx = bTx.Tx
+	}
+
+	// infer the priority from the raw transaction value (sender=key=value),
+	// and the sequence from an optional suffix (sender=key=value=sequence)
 	parts := bytes.Split(req.Tx, []byte("="))
-	if len(parts) == 3 {
+	if len(parts) == 3 || len(parts) == 4 {
 		v, err := strconv.ParseInt(string(parts[2]), 10, 64)
 		if err != nil {
 			return abci.ResponseCheckTx{
@@ -64,6 +72,16 @@ func (app *application) CheckTx(req abci.RequestCheckTx) abci.ResponseCheckTx {
 				GasWanted: 1,
 			}
 		}
+		if len(parts) == 4 {
+			sequence, err = strconv.ParseUint(string(parts[3]), 10, 64)
+			if err != nil {
+				return abci.ResponseCheckTx{
+					Priority:  priority,
+					Code:      100,
+					GasWanted: 1,
+				}
+			}
+		}
 
 		priority = v
 		sender = string(parts[0])
@@ -78,6 +96,7 @@ func (app *application) CheckTx(req abci.RequestCheckTx) abci.ResponseCheckTx {
 	return abci.ResponseCheckTx{
 		Priority:  priority,
 		Sender:    sender,
+		Sequence:  sequence,
 		Code:      code.CodeTypeOK,
 		GasWanted: 1,
 	}
@@ -245,6 +264,9 @@ func TestTxPool_Eviction(t *testing.T) {
 	require.True(t, txExists("key1=0000=25"))
 	require.False(t, txExists(bigTx))
 	require.True(t, txmp.WasRecentlyEvicted(types.Tx(bigTx).Key()))
+	reason, ok := txmp.GetRemovalReason(types.Tx(bigTx).Key())
+	require.True(t, ok)
+	require.Equal(t, mempool.RemovalReasonMempoolFull, reason)
 	require.Equal(t, int64(len("key1=0000=25")), txmp.SizeBytes())
 
 	// Now fill up the rest of the slots with other transactions.
@@ -596,6 +618,45 @@ func TestTxPool_ExpiredTxs_Timestamp(t *testing.T) {
 	}
 }
 
+func TestTxPool_TxStatusDetails(t *testing.T) {
+	txmp := setup(t, 500)
+	txmp.config.TTLNumBlocks = 1
+
+	mustCheckTx(t, txmp, "key1=0000=5")
+	mustCheckTx(t, txmp, "key2=0001=10")
+	mustCheckTx(t, txmp, "key3=0002=5")
+
+	// txs are ordered by priority, then by arrival
+	for spec, position := range map[string]int{"key2=0001=10": 0, "key1=0000=5": 1, "key3=0002=5": 2} {
+		pending, ok := txmp.GetPendingTx(types.Tx(spec).Key())
+		require.True(t, ok, spec)
+		require.Equal(t, position, pending.Position, spec)
+		require.EqualValues(t, 1, pending.GasWanted)
+		require.EqualValues(t, 1, pending.Height)
+		require.False(t, pending.Timestamp.IsZero())
+	}
+	pending, _ := txmp.GetPendingTx(types.Tx("key2=0001=10").Key())
+	require.EqualValues(t, 10, pending.Priority)
+
+	// a tx failing CheckTx is rejected
+	require.Error(t, txmp.CheckTx(types.Tx("invalid"), nil, mempool.TxInfo{}))
+	reason, ok := txmp.GetRemovalReason(types.Tx("invalid").Key())
+	require.True(t, ok)
+	require.Equal(t, mempool.RemovalReasonCheckTxFailed, reason)
+	require.False(t, txmp.WasRecentlyEvicted(types.Tx("invalid").Key()))
+
+	// txs are evicted once they expire
+	require.NoError(t, txmp.Update(3, nil, nil, nil, nil))
+	_, ok = txmp.GetPendingTx(types.Tx("key1=0000=5").Key())
+	require.False(t, ok)
+	reason, ok = txmp.GetRemovalReason(types.Tx("key1=0000=5").Key())
+	require.True(t, ok)
+	require.Equal(t, mempool.RemovalReasonExpired, reason)
+
+	_, ok = txmp.GetRemovalReason(types.Tx("unknown").Key())
+	require.False(t, ok)
+}
+
 func TestTxPool_ExpiredTxs_NumBlocks(t *testing.T) {
 	txmp := setup(t, 500)
 	txmp.height = 100
@@ -712,6 +773,62 @@ func TestTxPool_RemoveBlobTx(t *testing.T) {
 	require.EqualValues(t, 0, txmp.SizeBytes())
 }
 
+// newBlobTx returns a BlobTx wrapping tx, with a blob of size bytes.
+func newBlobTx(t *testing.T, tx string, size int) types.Tx {
+	bTx, err := types.MarshalBlobTx([]byte(tx), &tmproto.Blob{
+		NamespaceId: bytes.Repeat([]byte{1}, consts.NamespaceIDSize),
+		Data:        make([]byte, size),
+	})
+	require.NoError(t, err)
+	return bTx
+}
+
+func TestTxPool_MaxBlobBytes(t *testing.T) {
+	txmp := setup(t, 100)
+	txmp.config.MaxBlobBytes = 1000
+
+	mustCheckTx(t, txmp, string(newBlobTx(t, "a=1=1=1", 600)))
+	require.EqualValues(t, 600, txmp.SizeBlobBytes())
+
+	// a blob tx exceeding the max blob bytes is rejected, but not a regular tx
+	err := txmp.CheckTx(newBlobTx(t, "b=1=1", 600), nil, mempool.TxInfo{})
+	var blobErr mempool.ErrBlobBytesExceeded
+	require.True(t, errors.As(err, &blobErr))
+	mustCheckTx(t, txmp, "c=1=1")
+	require.Equal(t, 2, txmp.Size())
+
+	// a replacement releases the blobs of the tx it replaces
+	replacement := newBlobTx(t, "a=1=2=1", 900)
+	mustCheckTx(t, txmp, string(replacement))
+	require.EqualValues(t, 900, txmp.SizeBlobBytes())
+
+	// the blobs of a removed tx are released
+	require.NoError(t, txmp.RemoveTxByKey(replacement.Key()))
+	require.EqualValues(t, 0, txmp.SizeBlobBytes())
+}
+
+func TestTxPool_ReapSquare(t *testing.T) {
+	txmp := setup(t, 100)
+
+	// blob txs of 5 shares each, by decreasing priority, then a regular tx
+	for i := 0; i < 4; i++ {
+		mustCheckTx(t, txmp, string(newBlobTx(t, fmt.Sprintf("sender-%d=key=%d", i, 10-i), 2000)))
+	}
+	mustCheckTx(t, txmp, "sender-4=key=1")
+
+	require.Len(t, txmp.ReapMaxBytesMaxGas(-1, -1), 5)
+
+	// a 4x4 square holds the shares of 3 blobs and of the compact share of
+	// their txs only
+	txmp.config.ReapMode = config.MempoolReapModeSquare
+	txmp.config.MaxSquareSize = 4
+	reaped := txmp.ReapMaxBytesMaxGas(-1, -1)
+	require.Len(t, reaped, 3)
+	for _, tx := range reaped {
+		require.True(t, mempool.NewBlobInfo(tx).IsBlobTx())
+	}
+}
+
 func abciResponses(n int, code uint32) []*abci.ResponseDeliverTx {
 	responses := make([]*abci.ResponseDeliverTx, 0, n)
 	for i := 0; i < n; i++ {
@@ -782,3 +899,203 @@ func TestTxPool_BroadcastQueue(t *testing.T) {
 
 	wg.Wait()
 }
+
+func TestTxPool_WAL(t *testing.T) {
+	walDir := t.TempDir()
+	wal, _, err := mempool.OpenWAL(walDir)
+	require.NoError(t, err)
+
+	txmp := setup(t, 0, WithWAL(wal))
+	txs := checkTxs(t, txmp, 10, 0)
+	require.Equal(t, 10, wal.Size())
+
+	rawTxs := make([]types.Tx, len(txs))
+	for i, tx := range txs {
+		rawTxs[i] = tx.tx
+	}
+	txmp.Lock()
+	require.NoError(t, txmp.Update(2, rawTxs[:4], abciResponses(4, abci.CodeTypeOK), nil, nil))
+	txmp.Unlock()
+	require.Equal(t, 6, wal.Size())
+	require.NoError(t, wal.Close())
+
+	// the txs left are replayed into a new mempool, with their metadata
+	wal, walTxs, err := mempool.OpenWAL(walDir)
+	require.NoError(t, err)
+	require.Len(t, walTxs, 6)
+	for i, walTx := range walTxs {
+		require.Equal(t, txs[4+i].tx, walTx.Tx)
+		require.Equal(t, txs[4+i].priority, walTx.Priority)
+		require.Equal(t, int64(1), walTx.Height)
+	}
+
+	txmp = setup(t, 0, WithWAL(wal))
+	require.NoError(t, mempool.ReplayWAL(txmp, wal, walTxs))
+	require.Equal(t, 6, txmp.Size())
+	require.Equal(t, 6, wal.Size())
+
+	// flushing the mempool empties the WAL
+	txmp.Flush()
+	require.Zero(t, wal.Size())
+	require.NoError(t, wal.Close())
+}
+
+func TestTxPool_SequenceOrdering(t *testing.T) {
+	txmp := setup(t, 0)
+
+	// the txs of a sender are checked in out of order
+	mustCheckTx(t, txmp, "a=3=30=3")
+	mustCheckTx(t, txmp, "a=1=10=1")
+	mustCheckTx(t, txmp, "a=2=50=2")
+	mustCheckTx(t, txmp, "b=1=20")
+	mustCheckTx(t, txmp, "c=1=40=1")
+	require.Equal(t, 5, txmp.Size())
+
+	expected := types.Txs{
+		types.Tx("c=1=40=1"),
+		types.Tx("b=1=20"),
+		types.Tx("a=1=10=1"),
+		types.Tx("a=2=50=2"),
+		types.Tx("a=3=30=3"),
+	}
+	require.Equal(t, expected, txmp.ReapMaxTxs(-1))
+	require.Equal(t, expected, txmp.ReapMaxBytesMaxGas(-1, -1))
+
+	pending, ok := txmp.GetPendingTx(types.Tx("a=2=50=2").Key())
+	require.True(t, ok)
+	require.Equal(t, 3, pending.Position)
+}
+
+func TestTxPool_SequenceReplacement(t *testing.T) {
+	txmp := setup(t, 100)
+
+	mustCheckTx(t, txmp, "a=1=100=1")
+
+	// the priority bump is too low
+	err := txmp.CheckTx(types.Tx("a=2=105=1"), nil, mempool.TxInfo{})
+	require.ErrorContains(t, err, "below the replacement priority 110")
+	require.Equal(t, types.Txs{types.Tx("a=1=100=1")}, txmp.ReapMaxTxs(-1))
+
+	mustCheckTx(t, txmp, "a=3=110=1")
+	require.Equal(t, types.Txs{types.Tx("a=3=110=1")}, txmp.ReapMaxTxs(-1))
+	reason, ok := txmp.GetRemovalReason(types.Tx("a=1=100=1").Key())
+	require.True(t, ok)
+	require.Equal(t, mempool.RemovalReasonReplaced, reason)
+
+	// once committed, the sequence of a sender can be reused
+	require.NoError(t, txmp.Update(2, types.Txs{types.Tx("a=3=110=1")}, abciResponses(1, abci.CodeTypeOK), nil, nil))
+	mustCheckTx(t, txmp, "a=4=1=1")
+	require.Equal(t, 1, txmp.Size())
+}
+
+func TestTxPool_SequenceReapContiguous(t *testing.T) {
+	txmp := setup(t, 0)
+
+	mustCheckTx(t, txmp, "a=1=30=1")
+	mustCheckTx(t, txmp, fmt.Sprintf("a=%s=20=2", strings.Repeat("x", 100)))
+	mustCheckTx(t, txmp, "a=3=20=3")
+	mustCheckTx(t, txmp, "b=1=10")
+
+	// the second tx of a doesn't fit, so the third one is skipped as well
+	maxBytes := types.ComputeProtoSizeForTxs(types.Txs{
+		types.Tx("a=1=30=1"), types.Tx("a=3=20=3"), types.Tx("b=1=10"),
+	})
+	require.Equal(t, types.Txs{types.Tx("a=1=30=1"), types.Tx("b=1=10")}, txmp.ReapMaxBytesMaxGas(maxBytes, -1))
+}
+
+func TestTxPool_SequenceReapStopsAtGap(t *testing.T) {
+	txmp := setup(t, 0)
+
+	mustCheckTx(t, txmp, "a=1=30=1")
+	mustCheckTx(t, txmp, "a=3=20=3")
+	mustCheckTx(t, txmp, "a=4=20=4")
+	mustCheckTx(t, txmp, "b=1=10")
+
+	// the second sequence of a is missing, so the following ones are skipped
+	require.Equal(t, types.Txs{types.Tx("a=1=30=1"), types.Tx("b=1=10")}, txmp.ReapMaxBytesMaxGas(-1, -1))
+}
+
+func TestTxPool_SequenceEvictionFromTail(t *testing.T) {
+	txmp := setup(t, 100)
+	// room for three txs of a
+	txmp.config.MaxTxsBytes = 21
+
+	mustCheckTx(t, txmp, "a=1=1=1")
+	mustCheckTx(t, txmp, "a=2=5=2")
+	mustCheckTx(t, txmp, "a=3=2=3")
+
+	// the last tx of a is evicted rather than the one of lowest priority,
+	// which the others depend on
+	mustCheckTx(t, txmp, "b=1=10")
+	require.Equal(t,
+		types.Txs{types.Tx("b=1=10"), types.Tx("a=1=1=1"), types.Tx("a=2=5=2")},
+		txmp.ReapMaxBytesMaxGas(-1, -1))
+	reason, ok := txmp.GetRemovalReason(types.Tx("a=3=2=3").Key())
+	require.True(t, ok)
+	require.Equal(t, mempool.RemovalReasonMempoolFull, reason)
+
+	// the txs of a sender are not evicted for a following one
+	require.Error(t, txmp.CheckTx(types.Tx("a=4=5=4"), nil, mempool.TxInfo{}))
+	require.Equal(t, 3, txmp.Size())
+	_, ok = txmp.GetRemovalReason(types.Tx("a=4=5=4").Key())
+	require.True(t, ok)
+}
+
+func TestTxPool_SenderQuota(t *testing.T) {
+	txmp := setup(t, 100)
+	// 50% of 4 txs
+	txmp.store.senderQuotas = mempool.NewSenderQuotas(50, 4, 0)
+
+	mustCheckTx(t, txmp, "a=1=10=1")
+	mustCheckTx(t, txmp, "a=2=10=2")
+	err := txmp.CheckTx(types.Tx("a=3=10=3"), nil, mempool.TxInfo{})
+	var quotaErr mempool.ErrSenderQuotaExceeded
+	require.True(t, errors.As(err, &quotaErr))
+	require.Equal(t, "a", quotaErr.Sender)
+	require.Equal(t, 2, txmp.Size())
+
+	// a replacement doesn't count against the quota
+	mustCheckTx(t, txmp, "a=4=20=2")
+	require.Equal(t, 2, txmp.Size())
+
+	// the quota is released once the txs of the sender are committed
+	require.NoError(t, txmp.Update(1, types.Txs{types.Tx("a=1=10=1")}, abciResponses(1, abci.CodeTypeOK), nil, nil))
+	mustCheckTx(t, txmp, "a=3=10=3")
+	require.Equal(t, 2, txmp.Size())
+}
+
+func TestTxPool_Snapshot(t *testing.T) {
+	txmp := setup(t, 100)
+	var deltas []mempool.TxDelta
+	txmp.SetTxListener(func(d mempool.TxDelta) { deltas = append(deltas, d) })
+
+	mustCheckTx(t, txmp, "a=1=10=1")
+	mustCheckTx(t, txmp, "b=1=20")
+	mustCheckTx(t, txmp, "a=2=30=1") // replaces a=1=10=1
+	require.Len(t, deltas, 4)
+	for i, d := range deltas {
+		require.EqualValues(t, i+1, d.Version)
+	}
+	require.Equal(t, mempool.TxDeltaAdd, deltas[0].Type)
+	require.Equal(t, "a", deltas[0].Tx.Sender)
+	require.EqualValues(t, 10, deltas[0].Tx.Priority)
+	require.Equal(t, mempool.TxDeltaEvict, deltas[2].Type)
+	require.Equal(t, types.Tx("a=1=10=1").Key(), deltas[2].Tx.Key)
+	require.Equal(t, mempool.RemovalReasonReplaced, deltas[2].Reason)
+	require.Equal(t, mempool.TxDeltaAdd, deltas[3].Type)
+
+	// the snapshot is ordered as the txs are reaped
+	snapshot := txmp.Snapshot()
+	require.EqualValues(t, 4, snapshot.Version)
+	require.Len(t, snapshot.Txs, 2)
+	require.Equal(t, types.Tx("a=2=30=1").Key(), snapshot.Txs[0].Key)
+	require.EqualValues(t, len("a=2=30=1"), snapshot.Txs[0].Size)
+	require.Equal(t, types.Tx("b=1=20").Key(), snapshot.Txs[1].Key)
+
+	// committed txs are removed
+	require.NoError(t, txmp.Update(1, types.Txs{types.Tx("b=1=20")}, abciResponses(1, abci.CodeTypeOK), nil, nil))
+	require.Len(t, deltas, 5)
+	require.Equal(t, mempool.TxDeltaRemove, deltas[4].Type)
+	require.Equal(t, types.Tx("b=1=20").Key(), deltas[4].Tx.Key)
+	require.EqualValues(t, 5, txmp.Snapshot().Version)
+}
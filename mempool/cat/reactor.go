@@ -41,6 +41,9 @@ type Reactor struct {
 	mempool     *TxPool
 	ids         *mempoolIDs
 	requests    *requestScheduler
+	quotas      *mempool.PeerQuotas
+	budget      *outboundBudget
+	wants       wantQueue // WantTx requests deferred by the budget
 	traceClient trace.Tracer
 }
 
@@ -56,6 +59,22 @@ type ReactorOptions struct {
 	// arrive before issuing a new request to a different peer
 	MaxGossipDelay time.Duration
 
+	// PullOnly means that the node only announces the transactions it receives
+	// with SeenTx messages, and sends them to the peers requesting them with
+	// WantTx messages, instead of pushing the new ones to all its peers.
+	PullOnly bool
+
+	// MaxOutboundBytesPerSecond, if non-zero, is the maximum number of bytes of
+	// transactions sent to all the peers per second.
+	MaxOutboundBytesPerSecond int64
+
+	// MaxPeerOutboundBytesPerSecond, if non-zero, is the maximum number of
+	// bytes of transactions sent to each peer per second.
+	//
+	// Over budget, the new transactions are announced instead of being pushed,
+	// and the WantTx requests are deferred to be served in order of priority.
+	MaxPeerOutboundBytesPerSecond int64
+
 	// TraceClient is the trace client for collecting trace level events
 	TraceClient trace.Tracer
 }
@@ -77,20 +96,35 @@ func (opts *ReactorOptions) VerifyAndComplete() error {
 		return fmt.Errorf("max gossip delay (%d) cannot be negative", opts.MaxGossipDelay)
 	}
 
+	if opts.MaxOutboundBytesPerSecond < 0 {
+		return fmt.Errorf("max outbound bytes per second (%d) cannot be negative", opts.MaxOutboundBytesPerSecond)
+	}
+
+	if opts.MaxPeerOutboundBytesPerSecond < 0 {
+		return fmt.Errorf("max peer outbound bytes per second (%d) cannot be negative",
+			opts.MaxPeerOutboundBytesPerSecond)
+	}
+
 	return nil
 }
 
 // NewReactor returns a new Reactor with the given config and mempool.
-func NewReactor(mempool *TxPool, opts *ReactorOptions) (*Reactor, error) {
+func NewReactor(mp *TxPool, opts *ReactorOptions) (*Reactor, error) {
 	err := opts.VerifyAndComplete()
 	if err != nil {
 		return nil, err
 	}
 	memR := &Reactor{
-		opts:        opts,
-		mempool:     mempool,
-		ids:         newMempoolIDs(),
-		requests:    newRequestScheduler(opts.MaxGossipDelay, defaultGlobalRequestTimeout),
+		opts:     opts,
+		mempool:  mp,
+		ids:      newMempoolIDs(),
+		requests: newRequestScheduler(opts.MaxGossipDelay, defaultGlobalRequestTimeout),
+		quotas: mempool.NewPeerQuotas(
+			mp.config.PeerQuotaWindow, mp.config.MaxPeerTxsPerWindow, mp.config.MaxPeerBytesPerWindow,
+		),
+		budget: newOutboundBudget(
+			budgetWindow, opts.MaxOutboundBytesPerSecond, opts.MaxPeerOutboundBytesPerSecond,
+		),
 		traceClient: trace.NoOpTracer(),
 	}
 	memR.BaseReactor = *p2p.NewBaseReactor("Mempool", memR)
@@ -112,15 +146,35 @@ func (memR *Reactor) OnStart() error {
 					return
 
 				// listen in for any newly verified tx via RPC, then immediately
-				// broadcast it to all connected peers.
+				// broadcast it, or only announce it in pull-only mode, to all
+				// connected peers.
 				case nextTx := <-memR.mempool.next():
-					memR.broadcastNewTx(nextTx)
+					if memR.opts.PullOnly {
+						memR.broadcastSeenTx(nextTx.key)
+					} else {
+						memR.broadcastNewTx(nextTx)
+					}
 				}
 			}
 		}()
 	} else {
 		memR.Logger.Info("Tx broadcasting is disabled")
 	}
+	// serve the WantTx requests deferred by the outbound budget as it allows
+	if memR.budget.enabled() {
+		go func() {
+			ticker := time.NewTicker(budgetWindow / 10)
+			defer ticker.Stop()
+			for {
+				select {
+				case <-ticker.C:
+					memR.serveDeferredWants()
+				case <-memR.Quit():
+					return
+				}
+			}
+		}()
+	}
 	// run a separate go routine to check for time based TTLs
 	if memR.mempool.config.TTLDuration > 0 {
 		go func() {
@@ -189,6 +243,9 @@ func (memR *Reactor) InitPeer(peer p2p.Peer) p2p.Peer {
 // peer it will find a new peer to rerequest the same transactions.
 func (memR *Reactor) RemovePeer(peer p2p.Peer, reason interface{}) {
 	peerID := memR.ids.Reclaim(peer.ID())
+	memR.quotas.RemovePeer(peer.ID())
+	memR.budget.removePeer(peerID)
+	memR.wants.removePeer(peerID)
 	// clear all memory of seen txs by that peer
 	memR.mempool.seenByPeersSet.RemovePeer(peerID)
 
@@ -247,6 +304,17 @@ func (memR *Reactor) ReceiveEnvelope(e p2p.Envelope) {
 				memR.requests.MarkReceived(peerID, key)
 				memR.Logger.Debug("received a response for a requested transaction", "peerID", peerID, "txKey", key)
 			} else {
+				// Unsolicited transactions new to the pool count against the quota of
+				// the peer. A peer exceeding it is disconnected, to keep it from
+				// crowding out the txs of the other peers.
+				if !memR.mempool.Has(key) {
+					if err := memR.quotas.Consume(e.Src.ID(), len(tx), time.Now()); err != nil {
+						memR.Logger.Info("peer exceeded its mempool quota", "src", e.Src, "err", err)
+						memR.mempool.metrics.QuotaExceededTxs.With("quota", "peer").Add(1)
+						memR.Switch.StopPeerForError(e.Src, err)
+						return
+					}
+				}
 				// If we didn't request the transaction we simply mark the peer as having the
 				// tx (we'd have already done it if we were requesting the tx).
 				memR.mempool.PeerHasTx(peerID, key)
@@ -318,22 +386,18 @@ func (memR *Reactor) ReceiveEnvelope(e p2p.Envelope) {
 			txKey[:],
 			schema.Download,
 		)
-		tx, has := memR.mempool.GetTxByKey(txKey)
-		if has && !memR.opts.ListenOnly {
-			peerID := memR.ids.GetIDForPeer(e.Src.ID())
-			memR.Logger.Debug("sending a tx in response to a want msg", "peer", peerID)
-			if p2p.SendEnvelopeShim(e.Src, p2p.Envelope{ //nolint:staticcheck
-				ChannelID: mempool.MempoolChannel,
-				Message:   &protomem.Txs{Txs: [][]byte{tx}},
-			}, memR.Logger) {
-				memR.mempool.PeerHasTx(peerID, txKey)
-				schema.WriteMempoolTx(
-					memR.traceClient,
-					string(e.Src.ID()),
-					txKey[:],
-					len(tx),
-					schema.Upload,
-				)
+		wtx := memR.mempool.store.get(txKey)
+		if wtx != nil && !memR.opts.ListenOnly {
+			want := pendingWant{
+				peer:     e.Src,
+				peerID:   memR.ids.GetIDForPeer(e.Src.ID()),
+				key:      txKey,
+				priority: wtx.priority,
+			}
+			// Over budget, or behind requests deferred already, the request is
+			// deferred to be served in order of priority.
+			if memR.wants.len() > 0 || !memR.sendWantedTx(want, wtx) {
+				memR.deferWant(want)
 			}
 		}
 
@@ -349,21 +413,23 @@ type PeerState interface {
 	GetHeight() int64
 }
 
+// PeerHasTx returns whether the peer is known to have seen the transaction,
+// either because it sent it to us or announced it with a SeenTx message.
+// It is used by the consensus reactor to decide which txs to send along with
+// compact blocks.
+func (memR *Reactor) PeerHasTx(peerID p2p.ID, txKey types.TxKey) bool {
+	id := memR.ids.GetIDForPeer(peerID)
+	if id == 0 {
+		return false
+	}
+	return memR.mempool.seenByPeersSet.Has(txKey, id)
+}
+
 // broadcastSeenTx broadcasts a SeenTx message to all peers unless we
 // know they have already seen the transaction
 func (memR *Reactor) broadcastSeenTx(txKey types.TxKey) {
 	memR.Logger.Debug("broadcasting seen tx to all peers", "tx_key", txKey.String())
-	msg := &protomem.Message{
-		Sum: &protomem.Message_SeenTx{
-			SeenTx: &protomem.SeenTx{
-				TxKey: txKey[:],
-			},
-		},
-	}
-	bz, err := msg.Marshal()
-	if err != nil {
-		panic(err)
-	}
+	bz := marshalSeenTx(txKey)
 
 	// Add jitter to when the node broadcasts it's seen txs to stagger when nodes
 	// in the network broadcast their seenTx messages.
@@ -389,7 +455,25 @@ func (memR *Reactor) broadcastSeenTx(txKey types.TxKey) {
 	}
 }
 
+// marshalSeenTx returns the SeenTx message announcing the transaction.
+func marshalSeenTx(txKey types.TxKey) []byte {
+	msg := &protomem.Message{
+		Sum: &protomem.Message_SeenTx{
+			SeenTx: &protomem.SeenTx{
+				TxKey: txKey[:],
+			},
+		},
+	}
+	bz, err := msg.Marshal()
+	if err != nil {
+		panic(err)
+	}
+	return bz
+}
+
 // broadcastNewTx broadcast new transaction to all peers unless we are already sure they have seen the tx.
+// The peers the outbound budget doesn't allow sending it to are sent a SeenTx
+// message instead, to request it when they need it.
 func (memR *Reactor) broadcastNewTx(wtx *wrappedTx) {
 	msg := &protomem.Message{
 		Sum: &protomem.Message_Txs{
@@ -418,10 +502,79 @@ func (memR *Reactor) broadcastNewTx(wtx *wrappedTx) {
 			continue
 		}
 
+		if !memR.budget.consume(id, wtx.size(), time.Now()) {
+			peer.Send(MempoolStateChannel, marshalSeenTx(wtx.key)) //nolint:staticcheck
+			continue
+		}
+
 		if peer.Send(mempool.MempoolChannel, bz) { //nolint:staticcheck
 			memR.mempool.PeerHasTx(id, wtx.key)
 		}
 	}
+	memR.reportBudget()
+}
+
+// sendWantedTx sends the transaction requested by a WantTx message to the
+// peer if the outbound budget allows it, and returns whether it did.
+func (memR *Reactor) sendWantedTx(want pendingWant, wtx *wrappedTx) bool {
+	if !memR.budget.consume(want.peerID, wtx.size(), time.Now()) {
+		return false
+	}
+	defer memR.reportBudget()
+
+	memR.Logger.Debug("sending a tx in response to a want msg", "peer", want.peerID)
+	if p2p.SendEnvelopeShim(want.peer, p2p.Envelope{ //nolint:staticcheck
+		ChannelID: mempool.MempoolChannel,
+		Message:   &protomem.Txs{Txs: [][]byte{wtx.tx}},
+	}, memR.Logger) {
+		memR.mempool.PeerHasTx(want.peerID, want.key)
+		schema.WriteMempoolTx(
+			memR.traceClient,
+			string(want.peer.ID()),
+			want.key[:],
+			len(wtx.tx),
+			schema.Upload,
+		)
+	}
+	return true
+}
+
+// deferWant defers a WantTx request until the outbound budget allows serving
+// it.
+func (memR *Reactor) deferWant(want pendingWant) {
+	memR.mempool.metrics.DeferredWantTxs.Add(1)
+	if dropped := memR.wants.push(want); dropped != nil {
+		memR.Logger.Debug("dropped a deferred want msg of a low priority tx",
+			"peer", dropped.peerID, "txKey", dropped.key)
+	}
+}
+
+// serveDeferredWants serves the deferred WantTx requests in order of priority,
+// as long as the outbound budget allows it. The requests of transactions no
+// longer in the pool are dropped.
+func (memR *Reactor) serveDeferredWants() {
+	if memR.wants.len() == 0 {
+		return
+	}
+	memR.wants.drain(func(want pendingWant) bool {
+		wtx := memR.mempool.store.get(want.key)
+		if wtx == nil {
+			return true
+		}
+		return memR.sendWantedTx(want, wtx)
+	}, func() bool {
+		return memR.budget.exhausted(time.Now())
+	})
+}
+
+// reportBudget reports the utilization of the outbound budget.
+func (memR *Reactor) reportBudget() {
+	if !memR.budget.enabled() {
+		return
+	}
+	global, peer := memR.budget.utilization()
+	memR.mempool.metrics.OutboundBudgetUtilization.With("budget", "global").Set(global)
+	memR.mempool.metrics.OutboundBudgetUtilization.With("budget", "peer").Set(peer)
 }
 
 // requestTx requests a transaction from a peer and tracks it,
@@ -2,6 +2,7 @@ package cat
 
 import (
 	"encoding/hex"
+	"fmt"
 	"os"
 	"sort"
 	"sync"
@@ -11,6 +12,7 @@ import (
 	"github.com/go-kit/log/term"
 	"github.com/gogo/protobuf/proto"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
 
 	"github.com/tendermint/tendermint/abci/example/kvstore"
@@ -276,6 +278,155 @@ func TestLegacyReactorReceiveBasic(t *testing.T) {
 	})
 }
 
+func TestReactorStopsPeerExceedingQuota(t *testing.T) {
+	reactor := makeAndConnectReactors(t, cfg.TestConfig(), 1)[0]
+	reactor.quotas = mempool.NewPeerQuotas(time.Minute, 2, 0)
+
+	peer := p2pmock.NewPeer(nil)
+	reactor.InitPeer(peer)
+	reactor.AddPeer(peer)
+	for i := 0; i < 3; i++ {
+		reactor.ReceiveEnvelope(p2p.Envelope{
+			Src:       peer,
+			Message:   &protomem.Txs{Txs: [][]byte{newDefaultTx(fmt.Sprintf("tx-%d", i))}},
+			ChannelID: mempool.MempoolChannel,
+		})
+	}
+	require.False(t, peer.IsRunning())
+	require.Equal(t, 2, reactor.mempool.Size())
+}
+
+func TestReactorDoesNotChargeQuotaForCachedTxs(t *testing.T) {
+	reactor := makeAndConnectReactors(t, cfg.TestConfig(), 1)[0]
+	reactor.quotas = mempool.NewPeerQuotas(time.Minute, 2, 0)
+
+	peer := p2pmock.NewPeer(nil)
+	reactor.InitPeer(peer)
+	reactor.AddPeer(peer)
+	receive := func(tx string) {
+		reactor.ReceiveEnvelope(p2p.Envelope{
+			Src:       peer,
+			Message:   &protomem.Txs{Txs: [][]byte{newDefaultTx(tx)}},
+			ChannelID: mempool.MempoolChannel,
+		})
+	}
+
+	// txs relayed again by the peer are already in the pool
+	for i := 0; i < 3; i++ {
+		receive("tx-0")
+		receive("tx-1")
+	}
+	require.True(t, peer.IsRunning())
+	require.Equal(t, 2, reactor.mempool.Size())
+
+	receive("tx-2")
+	require.False(t, peer.IsRunning())
+}
+
+func TestReactorPullOnlyAnnouncesNewTx(t *testing.T) {
+	app := &application{kvstore.NewApplication()}
+	pool, cleanup := newMempoolWithApp(proxy.NewLocalClientCreator(app))
+	t.Cleanup(cleanup)
+	reactor, err := NewReactor(pool, &ReactorOptions{PullOnly: true})
+	require.NoError(t, err)
+	reactor.SetLogger(mempoolLogger())
+
+	tx := newDefaultTx("hello")
+	announced := make(chan struct{})
+	peer := genPeer()
+	peer.On("Send", MempoolStateChannel, marshalSeenTx(tx.Key())).Return(true).Run(func(mock.Arguments) {
+		close(announced)
+	})
+	reactor.InitPeer(peer)
+
+	require.NoError(t, reactor.Start())
+	t.Cleanup(func() { require.NoError(t, reactor.Stop()) })
+	require.NoError(t, pool.CheckTx(tx, nil, mempool.TxInfo{}))
+
+	// the tx is only announced, not pushed, to the peer
+	select {
+	case <-announced:
+	case <-time.After(time.Second):
+		t.Fatal("the new tx wasn't announced")
+	}
+	peer.AssertExpectations(t)
+}
+
+func TestReactorAnnouncesTxOverBudget(t *testing.T) {
+	reactor, pool := setupReactor(t)
+	txs := []types.Tx{newDefaultTx("hello"), newDefaultTx("world")}
+	for _, tx := range txs {
+		require.NoError(t, pool.CheckTx(tx, nil, mempool.TxInfo{}))
+	}
+	reactor.budget = newOutboundBudget(time.Hour, 0, int64(len(txs[0])))
+
+	// the first tx is pushed to the peer, the second one over its budget is
+	// only announced
+	txMsg, err := (&protomem.Message{
+		Sum: &protomem.Message_Txs{Txs: &protomem.Txs{Txs: [][]byte{txs[0]}}},
+	}).Marshal()
+	require.NoError(t, err)
+	peer := genPeer()
+	peer.On("Send", mempool.MempoolChannel, txMsg).Return(true).Once()
+	peer.On("Send", MempoolStateChannel, marshalSeenTx(txs[1].Key())).Return(true).Once()
+	reactor.InitPeer(peer)
+
+	for _, tx := range txs {
+		reactor.broadcastNewTx(pool.store.get(tx.Key()))
+	}
+	peer.AssertExpectations(t)
+}
+
+func TestReactorServesDeferredWantsByPriority(t *testing.T) {
+	reactor, pool := setupReactor(t)
+	txs := make([]types.Tx, 4)
+	for i := range txs {
+		txs[i] = newTx(i, 0, []byte("hello"), int64(i+1))
+		require.NoError(t, pool.CheckTx(txs[i], nil, mempool.TxInfo{}))
+	}
+	// one tx per window
+	reactor.budget = newOutboundBudget(time.Hour, 1, 0)
+
+	peer := genPeer()
+	reactor.InitPeer(peer)
+	expectTx := func(tx types.Tx) {
+		peer.On("SendEnvelope", p2p.Envelope{
+			Message:   &protomem.Txs{Txs: [][]byte{tx}},
+			ChannelID: mempool.MempoolChannel,
+		}).Return(true).Once()
+	}
+	wantTx := func(tx types.Tx) {
+		key := tx.Key()
+		reactor.ReceiveEnvelope(p2p.Envelope{
+			Src:       peer,
+			Message:   &protomem.WantTx{TxKey: key[:]},
+			ChannelID: MempoolStateChannel,
+		})
+	}
+	newWindow := func() {
+		reactor.budget.mtx.Lock()
+		reactor.budget.start = time.Time{}
+		reactor.budget.mtx.Unlock()
+	}
+
+	// the first want is served right away, the next ones are deferred
+	expectTx(txs[0])
+	for _, tx := range txs {
+		wantTx(tx)
+	}
+	peer.AssertExpectations(t)
+	require.Equal(t, 3, reactor.wants.len())
+
+	// and served by priority as the budget allows
+	for _, i := range []int{3, 2, 1} {
+		expectTx(txs[i])
+		newWindow()
+		reactor.serveDeferredWants()
+		peer.AssertExpectations(t)
+	}
+	require.Zero(t, reactor.wants.len())
+}
+
 func setupReactor(t *testing.T) (*Reactor, *TxPool) {
 	app := &application{kvstore.NewApplication()}
 	cc := proxy.NewLocalClientCreator(app)
@@ -4,6 +4,7 @@ import (
 	"sync"
 	"time"
 
+	"github.com/tendermint/tendermint/mempool"
 	"github.com/tendermint/tendermint/types"
 )
 
@@ -11,30 +12,53 @@ import (
 type store struct {
 	mtx         sync.RWMutex
 	bytes       int64
+	blobBytes   int64
 	txs         map[types.TxKey]*wrappedTx
 	reservedTxs map[types.TxKey]struct{}
+	// sequenced transactions by sender and sequence
+	txsBySequence map[string]map[uint64]*wrappedTx
+	// share of the store held by each sender, not limited by default
+	senderQuotas *mempool.SenderQuotas
+	// changes to the transactions, published with mtx held
+	feed mempool.TxFeed
 }
 
 func newStore() *store {
 	return &store{
-		bytes:       0,
-		txs:         make(map[types.TxKey]*wrappedTx),
-		reservedTxs: make(map[types.TxKey]struct{}),
+		bytes:         0,
+		txs:           make(map[types.TxKey]*wrappedTx),
+		reservedTxs:   make(map[types.TxKey]struct{}),
+		txsBySequence: make(map[string]map[uint64]*wrappedTx),
+		senderQuotas:  mempool.NewSenderQuotas(0, 0, 0),
 	}
 }
 
+// set adds the transaction to the store, unless it already holds it or a
+// transaction of the same sender and sequence.
 func (s *store) set(wtx *wrappedTx) bool {
 	if wtx == nil {
 		return false
 	}
 	s.mtx.Lock()
 	defer s.mtx.Unlock()
-	if _, exists := s.txs[wtx.key]; !exists {
-		s.txs[wtx.key] = wtx
-		s.bytes += wtx.size()
-		return true
+	if _, exists := s.txs[wtx.key]; exists {
+		return false
 	}
-	return false
+	if wtx.sequenced() {
+		if _, exists := s.txsBySequence[wtx.sender][wtx.sequence]; exists {
+			return false
+		}
+		if s.txsBySequence[wtx.sender] == nil {
+			s.txsBySequence[wtx.sender] = make(map[uint64]*wrappedTx)
+		}
+		s.txsBySequence[wtx.sender][wtx.sequence] = wtx
+	}
+	s.txs[wtx.key] = wtx
+	s.bytes += wtx.size()
+	s.blobBytes += wtx.blobs.Bytes
+	s.senderQuotas.Add(wtx.sender, wtx.size())
+	s.feed.Add(wtx.meta())
+	return true
 }
 
 func (s *store) get(txKey types.TxKey) *wrappedTx {
@@ -43,6 +67,14 @@ func (s *store) get(txKey types.TxKey) *wrappedTx {
 	return s.txs[txKey]
 }
 
+// getBySequence returns the transaction of the given sender and sequence, if
+// any.
+func (s *store) getBySequence(sender string, sequence uint64) *wrappedTx {
+	s.mtx.RLock()
+	defer s.mtx.RUnlock()
+	return s.txsBySequence[sender][sequence]
+}
+
 func (s *store) has(txKey types.TxKey) bool {
 	s.mtx.RLock()
 	defer s.mtx.RUnlock()
@@ -57,11 +89,48 @@ func (s *store) remove(txKey types.TxKey) bool {
 	if !exists {
 		return false
 	}
-	s.bytes -= tx.size()
-	delete(s.txs, txKey)
+	s.deleteTx(tx)
+	s.feed.Remove(tx.meta())
+	return true
+}
+
+// evict removes the transaction from the store before it is committed, for
+// the given reason.
+func (s *store) evict(txKey types.TxKey, reason mempool.RemovalReason) bool {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+	tx, exists := s.txs[txKey]
+	if !exists {
+		return false
+	}
+	s.deleteTx(tx)
+	s.feed.Evict(tx.meta(), reason)
 	return true
 }
 
+// deleteTx removes the transaction from the store and its indexes. The caller
+// must hold s.mtx exclusively.
+func (s *store) deleteTx(wtx *wrappedTx) {
+	s.bytes -= wtx.size()
+	s.blobBytes -= wtx.blobs.Bytes
+	delete(s.txs, wtx.key)
+	s.unindexSequence(wtx)
+	s.senderQuotas.Remove(wtx.sender, wtx.size())
+}
+
+// unindexSequence removes a sequenced transaction from the index by sender
+// and sequence. The caller must hold s.mtx exclusively.
+func (s *store) unindexSequence(wtx *wrappedTx) {
+	if !wtx.sequenced() {
+		return
+	}
+	seqs := s.txsBySequence[wtx.sender]
+	delete(seqs, wtx.sequence)
+	if len(seqs) == 0 {
+		delete(s.txsBySequence, wtx.sender)
+	}
+}
+
 // reserve adds an empty placeholder for the specified key to prevent
 // a transaction with the same key from being added
 func (s *store) reserve(txKey types.TxKey) bool {
@@ -102,6 +171,12 @@ func (s *store) totalBytes() int64 {
 	return s.bytes
 }
 
+func (s *store) totalBlobBytes() int64 {
+	s.mtx.RLock()
+	defer s.mtx.RUnlock()
+	return s.blobBytes
+}
+
 func (s *store) getAllKeys() []types.TxKey {
 	s.mtx.RLock()
 	defer s.mtx.RUnlock()
@@ -126,6 +201,18 @@ func (s *store) getAllTxs() []*wrappedTx {
 	return txs
 }
 
+// snapshot returns all the transactions along with the version of the last
+// change to them.
+func (s *store) snapshot() ([]*wrappedTx, uint64) {
+	s.mtx.RLock()
+	defer s.mtx.RUnlock()
+	txs := make([]*wrappedTx, 0, len(s.txs))
+	for _, tx := range s.txs {
+		txs = append(txs, tx)
+	}
+	return txs, s.feed.Version()
+}
+
 func (s *store) getTxsBelowPriority(priority int64) ([]*wrappedTx, int64) {
 	s.mtx.RLock()
 	defer s.mtx.RUnlock()
@@ -149,10 +236,10 @@ func (s *store) purgeExpiredTxs(expirationHeight int64, expirationAge time.Time)
 	var purgedTxs []*wrappedTx
 	counter := 0
 
-	for key, tx := range s.txs {
+	for _, tx := range s.txs {
 		if tx.height < expirationHeight || tx.timestamp.Before(expirationAge) {
-			s.bytes -= tx.size()
-			delete(s.txs, key)
+			s.deleteTx(tx)
+			s.feed.Evict(tx.meta(), mempool.RemovalReasonExpired)
 			purgedTxs = append(purgedTxs, tx)
 			counter++
 		}
@@ -163,6 +250,12 @@ func (s *store) purgeExpiredTxs(expirationHeight int64, expirationAge time.Time)
 func (s *store) reset() {
 	s.mtx.Lock()
 	defer s.mtx.Unlock()
+	for _, tx := range s.txs {
+		s.feed.Remove(tx.meta())
+	}
 	s.bytes = 0
+	s.blobBytes = 0
 	s.txs = make(map[types.TxKey]*wrappedTx)
+	s.txsBySequence = make(map[string]map[uint64]*wrappedTx)
+	s.senderQuotas.Reset()
 }
@@ -16,7 +16,7 @@ func TestStoreSimple(t *testing.T) {
 
 	tx := types.Tx("tx1")
 	key := tx.Key()
-	wtx := newWrappedTx(tx, key, 1, 1, 1, "")
+	wtx := newWrappedTx(tx, key, 1, 1, 1, "", 0)
 
 	// asset zero state
 	require.Nil(t, store.get(key))
@@ -47,7 +47,7 @@ func TestStoreReservingTxs(t *testing.T) {
 
 	tx := types.Tx("tx1")
 	key := tx.Key()
-	wtx := newWrappedTx(tx, key, 1, 1, 1, "")
+	wtx := newWrappedTx(tx, key, 1, 1, 1, "", 0)
 
 	// asset zero state
 	store.release(key)
@@ -105,7 +105,7 @@ func TestStoreConcurrentAccess(t *testing.T) {
 			for range ticker.C {
 				tx := types.Tx(fmt.Sprintf("tx%d", i%(numTxs/10)))
 				key := tx.Key()
-				wtx := newWrappedTx(tx, key, 1, 1, 1, "")
+				wtx := newWrappedTx(tx, key, 1, 1, 1, "", 0)
 				existingTx := store.get(key)
 				if existingTx != nil && bytes.Equal(existingTx.tx, tx) {
 					// tx has already been added
@@ -138,7 +138,7 @@ func TestStoreGetTxs(t *testing.T) {
 	for i := 0; i < numTxs; i++ {
 		tx := types.Tx(fmt.Sprintf("tx%d", i))
 		key := tx.Key()
-		wtx := newWrappedTx(tx, key, 1, 1, int64(i), "")
+		wtx := newWrappedTx(tx, key, 1, 1, int64(i), "", 0)
 		store.set(wtx)
 	}
 
@@ -168,7 +168,7 @@ func TestStoreExpiredTxs(t *testing.T) {
 	for i := 0; i < numTxs; i++ {
 		tx := types.Tx(fmt.Sprintf("tx%d", i))
 		key := tx.Key()
-		wtx := newWrappedTx(tx, key, int64(i), 1, 1, "")
+		wtx := newWrappedTx(tx, key, int64(i), 1, 1, "", 0)
 		store.set(wtx)
 	}
 
@@ -184,3 +184,27 @@ func TestStoreExpiredTxs(t *testing.T) {
 	store.purgeExpiredTxs(int64(0), time.Now().Add(time.Second))
 	require.Empty(t, store.getAllTxs())
 }
+
+func TestStoreSequences(t *testing.T) {
+	store := newStore()
+
+	tx1, tx2, tx3 := types.Tx("tx1"), types.Tx("tx2"), types.Tx("tx3")
+	wtx1 := newWrappedTx(tx1, tx1.Key(), 1, 1, 1, "sender", 1)
+	wtx2 := newWrappedTx(tx2, tx2.Key(), 1, 1, 2, "sender", 1)
+	wtx3 := newWrappedTx(tx3, tx3.Key(), 1, 1, 1, "sender", 2)
+
+	require.True(t, store.set(wtx1))
+	require.Equal(t, wtx1, store.getBySequence("sender", 1))
+	// a tx of the same sender and sequence can't be added
+	require.False(t, store.set(wtx2))
+	require.True(t, store.set(wtx3))
+	require.Equal(t, 2, store.size())
+
+	require.True(t, store.remove(wtx1.key))
+	require.Nil(t, store.getBySequence("sender", 1))
+	require.True(t, store.set(wtx2))
+	require.Equal(t, wtx2, store.getBySequence("sender", 1))
+
+	store.reset()
+	require.Nil(t, store.getBySequence("sender", 2))
+}
@@ -3,6 +3,7 @@ package cat
 import (
 	"time"
 
+	"github.com/tendermint/tendermint/mempool"
 	"github.com/tendermint/tendermint/types"
 )
 
@@ -11,16 +12,20 @@ import (
 // seen this transaction, this struct should never be modified
 type wrappedTx struct {
 	// these fields are immutable
-	tx        types.Tx    // the original transaction data
-	key       types.TxKey // the transaction hash
-	height    int64       // height when this transaction was initially checked (for expiry)
-	timestamp time.Time   // time when transaction was entered (for TTL)
-	gasWanted int64       // app: gas required to execute this transaction
-	priority  int64       // app: priority value for this transaction
-	sender    string      // app: assigned sender label
+	tx        types.Tx         // the original transaction data
+	key       types.TxKey      // the transaction hash
+	height    int64            // height when this transaction was initially checked (for expiry)
+	timestamp time.Time        // time when transaction was entered (for TTL)
+	gasWanted int64            // app: gas required to execute this transaction
+	priority  int64            // app: priority value for this transaction
+	sender    string           // app: assigned sender label
+	sequence  uint64           // app: sequence of the transaction among the ones of its sender
+	blobs     mempool.BlobInfo // the blobs of the transaction, if it is a BlobTx
 }
 
-func newWrappedTx(tx types.Tx, key types.TxKey, height, gasWanted, priority int64, sender string) *wrappedTx {
+func newWrappedTx(
+	tx types.Tx, key types.TxKey, height, gasWanted, priority int64, sender string, sequence uint64,
+) *wrappedTx {
 	return &wrappedTx{
 		tx:        tx,
 		key:       key,
@@ -29,8 +34,39 @@ func newWrappedTx(tx types.Tx, key types.TxKey, height, gasWanted, priority int6
 		gasWanted: gasWanted,
 		priority:  priority,
 		sender:    sender,
+		sequence:  sequence,
+		blobs:     mempool.NewBlobInfo(tx),
 	}
 }
 
 // Size reports the size of the raw transaction in bytes.
 func (w *wrappedTx) size() int64 { return int64(len(w.tx)) }
+
+// sequenced reports whether the transaction is ordered among the other
+// transactions of its sender.
+func (w *wrappedTx) sequenced() bool { return w.sender != "" && w.sequence > 0 }
+
+// order returns the metadata the pool orders the transaction by.
+func (w *wrappedTx) order() mempool.TxOrder {
+	return mempool.TxOrder{
+		Priority:  w.priority,
+		Timestamp: w.timestamp,
+		Sender:    w.sender,
+		Sequence:  w.sequence,
+	}
+}
+
+// meta returns the metadata of the transaction reported in the snapshots of
+// the pool.
+func (w *wrappedTx) meta() mempool.TxMeta {
+	return mempool.TxMeta{
+		Key:       w.key,
+		Size:      w.size(),
+		Priority:  w.priority,
+		GasWanted: w.gasWanted,
+		Sender:    w.sender,
+		Blobs:     w.blobs,
+		Height:    w.height,
+		Timestamp: w.timestamp,
+	}
+}
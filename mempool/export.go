@@ -0,0 +1,97 @@
+package mempool
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+
+	cmtos "github.com/tendermint/tendermint/libs/os"
+	"github.com/tendermint/tendermint/types"
+)
+
+// ExportTxs returns the txs in mp in order of arrival, along with their
+// metadata, in the serialization of the WAL common to all the mempools.
+// Checking them into a mempool of any version in that order, see ImportTxs,
+// migrates the mempool.
+func ExportTxs(mp Mempool) []*WALTx {
+	snapshot := mp.Snapshot()
+	txs := make([]*WALTx, 0, len(snapshot.Txs))
+	for _, meta := range snapshot.Txs {
+		tx, ok := mp.GetTxByKey(meta.Key)
+		if !ok {
+			// removed since the snapshot
+			continue
+		}
+		txs = append(txs, &WALTx{
+			Tx:        tx,
+			Priority:  meta.Priority,
+			Sender:    meta.Sender,
+			Height:    meta.Height,
+			Timestamp: meta.Timestamp,
+		})
+	}
+	// the snapshot is in reap order, which is the order of arrival for equal
+	// timestamps only in the priority mempools
+	sort.SliceStable(txs, func(i, j int) bool {
+		return txs[i].Timestamp.Before(txs[j].Timestamp)
+	})
+	return txs
+}
+
+// WriteWAL writes txs as a WAL in dir, replacing any WAL there, for OpenWAL
+// to read them back in the same order.
+func WriteWAL(dir string, txs []*WALTx) error {
+	if err := cmtos.EnsureDir(dir, 0700); err != nil {
+		return fmt.Errorf("failed to create mempool WAL dir: %w", err)
+	}
+
+	wal := &WAL{
+		path: filepath.Join(dir, walFileName),
+		live: make(map[types.TxKey]struct{}, len(txs)),
+	}
+	if err := wal.rewrite(txs); err != nil {
+		return err
+	}
+	return wal.file.Close()
+}
+
+// ReadWAL returns the txs held by the WAL in dir in order of admission,
+// without opening it for writing. It returns no txs if there is no WAL.
+func ReadWAL(dir string) ([]*WALTx, error) {
+	return readWAL(filepath.Join(dir, walFileName))
+}
+
+// ImportTxs checks the txs exported to the WAL in dir into mp in their order,
+// then removes the export, so that the txs are imported once. It returns the
+// number of txs read from the export.
+//
+// The txs for which keep returns false, e.g. because they expired, are
+// skipped. The priority of the txs is derived again by CheckTx, and so is the
+// same in the new mempool as in the old one for the same application state.
+func ImportTxs(mp Mempool, dir string, keep func(*WALTx) bool) (int, error) {
+	txs, err := ReadWAL(dir)
+	if err != nil {
+		return 0, err
+	}
+
+	for _, tx := range txs {
+		if !keep(tx) {
+			continue
+		}
+		// the txs rejected are dropped
+		_ = mp.CheckTx(tx.Tx, nil, TxInfo{SenderID: UnknownPeerID})
+	}
+	// wait for the txs checked asynchronously
+	mp.Lock()
+	err = mp.FlushAppConn()
+	mp.Unlock()
+	if err != nil {
+		return 0, err
+	}
+
+	if err := os.RemoveAll(dir); err != nil {
+		return 0, fmt.Errorf("failed to remove mempool export: %w", err)
+	}
+	return len(txs), nil
+}
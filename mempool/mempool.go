@@ -5,6 +5,7 @@ import (
 	"errors"
 	"fmt"
 	"math"
+	"time"
 
 	abci "github.com/tendermint/tendermint/abci/types"
 	"github.com/tendermint/tendermint/types"
@@ -100,6 +101,28 @@ type Mempool interface {
 	// Used in the RPC endpoint: TxStatus.
 	WasRecentlyEvicted(key types.TxKey) bool
 
+	// GetPendingTx returns the details of the tx with the given key if it is
+	// in the mempool.
+	// Used in the RPC endpoint: TxStatus.
+	GetPendingTx(key types.TxKey) (*PendingTx, bool)
+
+	// GetRemovalReason returns the reason the tx with the given key was
+	// recently evicted from, or rejected by, the mempool, if it is still
+	// known.
+	// Used in the RPC endpoint: TxStatus.
+	GetRemovalReason(key types.TxKey) (RemovalReason, bool)
+
+	// SetTxListener sets the listener notified of the transactions added to,
+	// evicted from and removed from the mempool.
+	// Used in the RPC websocket subscriptions to the mempool.
+	SetTxListener(l TxListener)
+
+	// Snapshot returns the transactions in the mempool, in the order they are
+	// reaped, along with the version of the last change notified to the
+	// TxListener.
+	// Used in the RPC endpoint: MempoolSnapshot.
+	Snapshot() *Snapshot
+
 	// Size returns the number of transactions in the mempool.
 	Size() int
 
@@ -187,6 +210,48 @@ func (e ErrMempoolIsFull) Error() string {
 	)
 }
 
+// ErrPeerQuotaExceeded defines an error where a peer sent more transactions
+// than its quota allows in a window.
+type ErrPeerQuotaExceeded struct {
+	Txs      int
+	MaxTxs   int
+	Bytes    int64
+	MaxBytes int64
+	Window   time.Duration
+}
+
+func (e ErrPeerQuotaExceeded) Error() string {
+	return fmt.Sprintf(
+		"peer exceeded its mempool quota: %d txs (max: %d), %d bytes (max: %d) in %v",
+		e.Txs,
+		e.MaxTxs,
+		e.Bytes,
+		e.MaxBytes,
+		e.Window,
+	)
+}
+
+// ErrSenderQuotaExceeded defines an error where the transactions of a sender
+// would take up more than its share of the mempool.
+type ErrSenderQuotaExceeded struct {
+	Sender   string
+	Txs      int
+	MaxTxs   int
+	Bytes    int64
+	MaxBytes int64
+}
+
+func (e ErrSenderQuotaExceeded) Error() string {
+	return fmt.Sprintf(
+		"sender %q exceeded its share of the mempool: %d txs (max: %d), %d bytes (max: %d)",
+		e.Sender,
+		e.Txs,
+		e.MaxTxs,
+		e.Bytes,
+		e.MaxBytes,
+	)
+}
+
 // ErrPreCheck defines an error where a transaction fails a pre-check.
 type ErrPreCheck struct {
 	Reason error
@@ -61,6 +61,20 @@ type Metrics struct {
 	// Number of connections being actively used for gossiping transactions
 	// (experimental feature).
 	ActiveOutboundConnections metrics.Gauge
+
+	// QuotaExceededTxs defines the number of transactions rejected because a
+	// peer exceeded its quota or a sender its share of the mempool, labeled
+	// by "peer" or "sender".
+	QuotaExceededTxs metrics.Counter
+
+	// OutboundBudgetUtilization defines the fraction of the outbound budget of
+	// tx gossip used in the current window, labeled by "global" for the
+	// budget of all the peers or "peer" for the most demanding peer.
+	OutboundBudgetUtilization metrics.Gauge
+
+	// DeferredWantTxs defines the number of requests for transactions that
+	// were deferred because the outbound budget was used up.
+	DeferredWantTxs metrics.Counter
 }
 
 // PrometheusMetrics returns Metrics build using Prometheus client library.
@@ -155,6 +169,27 @@ func PrometheusMetrics(namespace string, labelsAndValues ...string) *Metrics {
 			Name:      "active_outbound_connections",
 			Help:      "Number of connections being actively used for gossiping transactions (experimental feature).",
 		}, labels).With(labelsAndValues...),
+
+		QuotaExceededTxs: prometheus.NewCounterFrom(stdprometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: MetricsSubsystem,
+			Name:      "quota_exceeded_txs",
+			Help:      "Number of transactions rejected because a peer or a sender exceeded its quota.",
+		}, append(labels, "quota")).With(labelsAndValues...),
+
+		OutboundBudgetUtilization: prometheus.NewGaugeFrom(stdprometheus.GaugeOpts{
+			Namespace: namespace,
+			Subsystem: MetricsSubsystem,
+			Name:      "outbound_budget_utilization",
+			Help:      "Fraction of the outbound budget of transaction gossip used in the current window.",
+		}, append(labels, "budget")).With(labelsAndValues...),
+
+		DeferredWantTxs: prometheus.NewCounterFrom(stdprometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: MetricsSubsystem,
+			Name:      "deferred_want_txs",
+			Help:      "Number of requests for transactions deferred because the outbound budget was used up.",
+		}, labels).With(labelsAndValues...),
 	}
 }
 
@@ -173,5 +208,8 @@ func NopMetrics() *Metrics {
 		RequestedTxs:              discard.NewCounter(),
 		RerequestedTxs:            discard.NewCounter(),
 		ActiveOutboundConnections: discard.NewGauge(),
+		QuotaExceededTxs:          discard.NewCounter(),
+		OutboundBudgetUtilization: discard.NewGauge(),
+		DeferredWantTxs:           discard.NewCounter(),
 	}
 }
@@ -30,15 +30,19 @@ func (Mempool) Update(
 ) error {
 	return nil
 }
-func (Mempool) Flush()                                    {}
-func (Mempool) FlushAppConn() error                       { return nil }
-func (Mempool) TxsAvailable() <-chan struct{}             { return make(chan struct{}) }
-func (Mempool) EnableTxsAvailable()                       {}
-func (Mempool) SizeBytes() int64                          { return 0 }
-func (m Mempool) GetTxByKey(types.TxKey) (types.Tx, bool) { return nil, false }
-func (m Mempool) WasRecentlyEvicted(types.TxKey) bool     { return false }
-func (Mempool) TxsFront() *clist.CElement                 { return nil }
-func (Mempool) TxsWaitChan() <-chan struct{}              { return nil }
+func (Mempool) Flush()                                                     {}
+func (Mempool) FlushAppConn() error                                        { return nil }
+func (Mempool) TxsAvailable() <-chan struct{}                              { return make(chan struct{}) }
+func (Mempool) EnableTxsAvailable()                                        {}
+func (Mempool) SizeBytes() int64                                           { return 0 }
+func (m Mempool) GetTxByKey(types.TxKey) (types.Tx, bool)                  { return nil, false }
+func (m Mempool) WasRecentlyEvicted(types.TxKey) bool                      { return false }
+func (Mempool) GetPendingTx(types.TxKey) (*mempool.PendingTx, bool)        { return nil, false }
+func (Mempool) GetRemovalReason(types.TxKey) (mempool.RemovalReason, bool) { return "", false }
+func (Mempool) SetTxListener(mempool.TxListener)                           {}
+func (Mempool) Snapshot() *mempool.Snapshot                                { return &mempool.Snapshot{} }
+func (Mempool) TxsFront() *clist.CElement                                  { return nil }
+func (Mempool) TxsWaitChan() <-chan struct{}                               { return nil }
 
 func (Mempool) InitWAL() error { return nil }
 func (Mempool) CloseWAL()      {}
@@ -0,0 +1,196 @@
+package mempool
+
+import (
+	"sync"
+	"time"
+
+	"github.com/tendermint/tendermint/p2p"
+)
+
+const (
+	// Codespace is the codespace of the codes the mempool sets in the CheckTx
+	// responses of the transactions it rejects, to tell them apart from the
+	// codes of the application.
+	Codespace = "mempool"
+
+	// CodeTypeSenderQuotaExceeded is the code of the transactions rejected
+	// because their sender exceeded its share of the mempool.
+	CodeTypeSenderQuotaExceeded uint32 = 1
+)
+
+// PeerQuotas limits the number and total size of the transactions each peer
+// can send to the mempool per window. A zero limit is not enforced.
+//
+// PeerQuotas is safe for concurrent use.
+type PeerQuotas struct {
+	window   time.Duration
+	maxTxs   int
+	maxBytes int64
+
+	mtx   sync.Mutex
+	peers map[p2p.ID]*peerQuota
+}
+
+// peerQuota is the usage of a peer in its current window.
+type peerQuota struct {
+	start time.Time
+	txs   int
+	bytes int64
+}
+
+// NewPeerQuotas returns quotas of maxTxs transactions and maxBytes bytes per
+// window and peer.
+func NewPeerQuotas(window time.Duration, maxTxs int, maxBytes int64) *PeerQuotas {
+	return &PeerQuotas{
+		window:   window,
+		maxTxs:   maxTxs,
+		maxBytes: maxBytes,
+		peers:    make(map[p2p.ID]*peerQuota),
+	}
+}
+
+// Enabled returns whether any quota is enforced.
+func (q *PeerQuotas) Enabled() bool {
+	return q.maxTxs > 0 || q.maxBytes > 0
+}
+
+// Consume counts a transaction of txBytes bytes received from the peer at
+// now against its quota, or returns ErrPeerQuotaExceeded without counting it
+// if it exceeds the quota.
+func (q *PeerQuotas) Consume(peerID p2p.ID, txBytes int, now time.Time) error {
+	if !q.Enabled() {
+		return nil
+	}
+
+	q.mtx.Lock()
+	defer q.mtx.Unlock()
+
+	quota, ok := q.peers[peerID]
+	if !ok || now.Sub(quota.start) >= q.window {
+		quota = &peerQuota{start: now}
+		q.peers[peerID] = quota
+	}
+	txs, bytes := quota.txs+1, quota.bytes+int64(txBytes)
+	if (q.maxTxs > 0 && txs > q.maxTxs) || (q.maxBytes > 0 && bytes > q.maxBytes) {
+		return ErrPeerQuotaExceeded{
+			Txs:      txs,
+			MaxTxs:   q.maxTxs,
+			Bytes:    bytes,
+			MaxBytes: q.maxBytes,
+			Window:   q.window,
+		}
+	}
+	quota.txs, quota.bytes = txs, bytes
+	return nil
+}
+
+// RemovePeer forgets the usage of a peer that disconnected.
+func (q *PeerQuotas) RemovePeer(peerID p2p.ID) {
+	q.mtx.Lock()
+	defer q.mtx.Unlock()
+
+	delete(q.peers, peerID)
+}
+
+// SenderQuotas limits the number and total size of the transactions each
+// sender assigned by the application holds in the mempool. A zero limit is
+// not enforced, and the empty sender is not limited.
+//
+// SenderQuotas is safe for concurrent use.
+type SenderQuotas struct {
+	maxTxs   int
+	maxBytes int64
+
+	mtx     sync.Mutex
+	senders map[string]senderQuota
+}
+
+// senderQuota is the usage of a sender.
+type senderQuota struct {
+	txs   int
+	bytes int64
+}
+
+// NewSenderQuotas returns quotas of maxShare percent of maxTxs transactions
+// and maxBytes bytes per sender, as for the MaxSenderShare of the mempool
+// config.
+func NewSenderQuotas(maxShare int64, maxTxs int, maxBytes int64) *SenderQuotas {
+	q := &SenderQuotas{senders: make(map[string]senderQuota)}
+	if maxShare > 0 {
+		q.maxTxs = int(int64(maxTxs) * maxShare / 100)
+		q.maxBytes = maxBytes / 100 * maxShare
+		// a sender can always hold one transaction
+		if q.maxTxs < 1 {
+			q.maxTxs = 1
+		}
+	}
+	return q
+}
+
+// Enabled returns whether any quota is enforced.
+func (q *SenderQuotas) Enabled() bool {
+	return q.maxTxs > 0 || q.maxBytes > 0
+}
+
+// Check returns ErrSenderQuotaExceeded if the sender would exceed its quota
+// by adding txs transactions of the given total size to the mempool. Both may
+// be negative, as for a transaction replacing a larger one.
+func (q *SenderQuotas) Check(sender string, txs int, bytes int64) error {
+	if sender == "" || !q.Enabled() {
+		return nil
+	}
+
+	q.mtx.Lock()
+	defer q.mtx.Unlock()
+
+	quota := q.senders[sender]
+	txs, bytes = quota.txs+txs, quota.bytes+bytes
+	if (q.maxTxs > 0 && txs > q.maxTxs) || (q.maxBytes > 0 && bytes > q.maxBytes) {
+		return ErrSenderQuotaExceeded{
+			Sender:   sender,
+			Txs:      txs,
+			MaxTxs:   q.maxTxs,
+			Bytes:    bytes,
+			MaxBytes: q.maxBytes,
+		}
+	}
+	return nil
+}
+
+// Add counts a transaction of txBytes bytes added to the mempool against the
+// quota of its sender.
+func (q *SenderQuotas) Add(sender string, txBytes int64) {
+	q.update(sender, 1, txBytes)
+}
+
+// Remove releases the quota of a transaction of txBytes bytes removed from
+// the mempool.
+func (q *SenderQuotas) Remove(sender string, txBytes int64) {
+	q.update(sender, -1, -txBytes)
+}
+
+// Reset releases the quotas of all the senders.
+func (q *SenderQuotas) Reset() {
+	q.mtx.Lock()
+	defer q.mtx.Unlock()
+
+	q.senders = make(map[string]senderQuota)
+}
+
+func (q *SenderQuotas) update(sender string, txs int, bytes int64) {
+	if sender == "" || !q.Enabled() {
+		return
+	}
+
+	q.mtx.Lock()
+	defer q.mtx.Unlock()
+
+	quota := q.senders[sender]
+	quota.txs += txs
+	quota.bytes += bytes
+	if quota.txs <= 0 {
+		delete(q.senders, sender)
+		return
+	}
+	q.senders[sender] = quota
+}
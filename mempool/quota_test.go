@@ -0,0 +1,91 @@
+package mempool
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/tendermint/tendermint/p2p"
+)
+
+func TestPeerQuotas(t *testing.T) {
+	var (
+		q     = NewPeerQuotas(time.Minute, 3, 100)
+		now   = time.Now()
+		peerA = p2p.ID("a")
+		peerB = p2p.ID("b")
+	)
+	require.True(t, q.Enabled())
+
+	for i := 0; i < 3; i++ {
+		require.NoError(t, q.Consume(peerA, 10, now))
+	}
+	err := q.Consume(peerA, 10, now)
+	var quotaErr ErrPeerQuotaExceeded
+	require.True(t, errors.As(err, &quotaErr))
+	require.Equal(t, 4, quotaErr.Txs)
+
+	// the quotas of the peers are independent
+	require.NoError(t, q.Consume(peerB, 60, now))
+	// a tx exceeding the quota isn't counted
+	require.Error(t, q.Consume(peerB, 50, now))
+	require.NoError(t, q.Consume(peerB, 40, now))
+
+	// the quota is reset at the end of the window
+	require.NoError(t, q.Consume(peerA, 10, now.Add(time.Minute)))
+
+	// and when the peer disconnects
+	q.RemovePeer(peerB)
+	require.NoError(t, q.Consume(peerB, 100, now))
+
+	disabled := NewPeerQuotas(time.Minute, 0, 0)
+	require.False(t, disabled.Enabled())
+	for i := 0; i < 10; i++ {
+		require.NoError(t, disabled.Consume(peerA, 1000, now))
+	}
+}
+
+func TestSenderQuotas(t *testing.T) {
+	// 10% of 50 txs and 1000 bytes
+	q := NewSenderQuotas(10, 50, 1000)
+	require.True(t, q.Enabled())
+
+	for i := 0; i < 5; i++ {
+		require.NoError(t, q.Check("a", 1, 10))
+		q.Add("a", 10)
+	}
+	err := q.Check("a", 1, 10)
+	var quotaErr ErrSenderQuotaExceeded
+	require.True(t, errors.As(err, &quotaErr))
+	require.Equal(t, "a", quotaErr.Sender)
+	require.Equal(t, 5, quotaErr.MaxTxs)
+	require.Equal(t, int64(100), quotaErr.MaxBytes)
+
+	// a replacement doesn't add a tx
+	require.NoError(t, q.Check("a", 0, 50))
+	require.Error(t, q.Check("a", 0, 51))
+
+	// removing a tx releases its quota
+	q.Remove("a", 10)
+	require.NoError(t, q.Check("a", 1, 10))
+
+	// the quotas of the senders are independent
+	require.NoError(t, q.Check("b", 1, 100))
+	require.Error(t, q.Check("b", 1, 101))
+
+	// the empty sender isn't limited
+	require.NoError(t, q.Check("", 100, 10000))
+
+	q.Reset()
+	require.NoError(t, q.Check("a", 5, 100))
+
+	// a sender can always hold one tx
+	q = NewSenderQuotas(1, 10, 0)
+	require.NoError(t, q.Check("a", 1, 1000))
+	q.Add("a", 1000)
+	require.Error(t, q.Check("a", 1, 1))
+
+	require.False(t, NewSenderQuotas(0, 10, 1000).Enabled())
+}
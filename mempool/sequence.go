@@ -0,0 +1,250 @@
+package mempool
+
+import (
+	"container/heap"
+	"math"
+	"sort"
+	"time"
+)
+
+// TxOrder holds the metadata the priority mempools order transactions by.
+type TxOrder struct {
+	Priority  int64
+	Timestamp time.Time
+	// Sender and Sequence are assigned by the application in CheckTx. An
+	// empty sender or a zero sequence means the transaction is not sequenced.
+	Sender   string
+	Sequence uint64
+}
+
+// sequenced returns whether the transaction is ordered among the other
+// transactions of its sender.
+func (o TxOrder) sequenced() bool {
+	return o.Sender != "" && o.Sequence > 0
+}
+
+// OrderTxs returns txs in the order they are reaped by the priority mempools:
+// by nonincreasing priority with ties broken by increasing order of arrival,
+// except for the sequenced transactions of a sender, which are ordered by
+// increasing sequence. The next sequenced transaction of a sender is ordered
+// among the others by its own priority, so that a transaction of high
+// priority can't get ahead of the transactions of its sender it depends on.
+func OrderTxs[T any](txs []T, order func(T) TxOrder) []T {
+	var (
+		runs     = &txRunHeap[T]{runs: make([]*txRun[T], 0, len(txs)), less: reapedBefore}
+		bySender = make(map[string]int)
+	)
+	for _, tx := range txs {
+		o := order(tx)
+		if !o.sequenced() {
+			runs.runs = append(runs.runs, &txRun[T]{txs: []orderedTx[T]{{tx, o}}})
+			continue
+		}
+		if i, ok := bySender[o.Sender]; ok {
+			runs.runs[i].txs = append(runs.runs[i].txs, orderedTx[T]{tx, o})
+			continue
+		}
+		bySender[o.Sender] = len(runs.runs)
+		runs.runs = append(runs.runs, &txRun[T]{txs: []orderedTx[T]{{tx, o}}})
+	}
+	for _, i := range bySender {
+		run := runs.runs[i].txs
+		sort.Slice(run, func(i, j int) bool { return run[i].order.Sequence < run[j].order.Sequence })
+	}
+	return runs.merge()
+}
+
+// EvictionCandidates returns the txs which may be evicted to make room for an
+// incoming transaction, in the order they should be evicted: by increasing
+// priority with ties broken in favor of newer transactions.
+//
+// Only transactions of lower priority than the incoming one are candidates.
+// The sequenced transactions of a sender are evicted from the tail of its
+// run, so that none is left waiting for an evicted one: a transaction is a
+// candidate only if those of higher sequences of its sender are as well, and
+// it is ordered after them. The sequenced transactions of the sender of the
+// incoming transaction are never candidates, as it may depend on them.
+func EvictionCandidates[T any](txs []T, order func(T) TxOrder, incoming TxOrder) []T {
+	var (
+		runs     = &txRunHeap[T]{less: evictedBefore}
+		bySender = make(map[string][]orderedTx[T])
+	)
+	for _, tx := range txs {
+		o := order(tx)
+		switch {
+		case o.sequenced():
+			if !incoming.sequenced() || o.Sender != incoming.Sender {
+				bySender[o.Sender] = append(bySender[o.Sender], orderedTx[T]{tx, o})
+			}
+		case o.Priority < incoming.Priority:
+			runs.runs = append(runs.runs, &txRun[T]{txs: []orderedTx[T]{{tx, o}}})
+		}
+	}
+	for _, run := range bySender {
+		sort.Slice(run, func(i, j int) bool { return run[i].order.Sequence > run[j].order.Sequence })
+		tail := 0
+		for tail < len(run) && run[tail].order.Priority < incoming.Priority {
+			tail++
+		}
+		if tail > 0 {
+			runs.runs = append(runs.runs, &txRun[T]{txs: run[:tail]})
+		}
+	}
+	return runs.merge()
+}
+
+// SequenceGate admits the transactions reaped in the order of OrderTxs, so
+// that the sequenced transactions of a sender are reaped as a run of
+// consecutive sequences, as those following a gap would fail in DeliverTx. The
+// run of a sender ends at its first missing sequence, or at its first
+// transaction which is skipped, e.g. as it doesn't fit in the block.
+//
+// The mempool doesn't know the next sequence the application expects from a
+// sender, so the run starts at the lowest sequence of the sender's pending
+// transactions.
+type SequenceGate struct {
+	next  map[string]uint64
+	ended map[string]struct{}
+}
+
+// NewSequenceGate returns a gate for reaping transactions.
+func NewSequenceGate() *SequenceGate {
+	return &SequenceGate{
+		next:  make(map[string]uint64),
+		ended: make(map[string]struct{}),
+	}
+}
+
+// Admit returns whether the transaction may be reaped: either it is not
+// sequenced, or it is next in the run of its sender.
+func (g *SequenceGate) Admit(o TxOrder) bool {
+	if !o.sequenced() {
+		return true
+	}
+	if _, ended := g.ended[o.Sender]; ended {
+		return false
+	}
+	if next, ok := g.next[o.Sender]; ok && o.Sequence != next {
+		g.ended[o.Sender] = struct{}{}
+		return false
+	}
+	return true
+}
+
+// Reap records that the admitted transaction was reaped.
+func (g *SequenceGate) Reap(o TxOrder) {
+	if o.sequenced() {
+		g.next[o.Sender] = o.Sequence + 1
+	}
+}
+
+// Skip records that the admitted transaction was not reaped, which ends the
+// run of its sender.
+func (g *SequenceGate) Skip(o TxOrder) {
+	if o.sequenced() {
+		g.ended[o.Sender] = struct{}{}
+	}
+}
+
+// MinReplacementPriority returns the minimum priority of a transaction
+// replacing a transaction of the given priority, which must be at least
+// bumpPercent percent higher, and higher by at least one.
+func MinReplacementPriority(priority, bumpPercent int64) int64 {
+	abs := priority
+	if abs < 0 {
+		abs = -abs
+	}
+	bump := int64(1)
+	if bumpPercent > 0 && abs > 0 {
+		// avoid overflowing abs * bumpPercent
+		if abs > math.MaxInt64/bumpPercent {
+			bump = abs / 100 * bumpPercent
+		} else {
+			bump = abs * bumpPercent / 100
+		}
+		if bump < 1 {
+			bump = 1
+		}
+	}
+	if priority > math.MaxInt64-bump {
+		return math.MaxInt64
+	}
+	return priority + bump
+}
+
+type orderedTx[T any] struct {
+	tx    T
+	order TxOrder
+}
+
+// reapedBefore orders transactions by nonincreasing priority, with ties
+// broken by increasing order of arrival.
+func reapedBefore(a, b TxOrder) bool {
+	if a.Priority == b.Priority {
+		return a.Timestamp.Before(b.Timestamp)
+	}
+	return a.Priority > b.Priority // N.B. higher priorities first
+}
+
+// evictedBefore orders transactions by increasing priority, with ties broken
+// in favor of newer transactions.
+func evictedBefore(a, b TxOrder) bool {
+	if a.Priority == b.Priority {
+		return a.Timestamp.After(b.Timestamp)
+	}
+	return a.Priority < b.Priority
+}
+
+// txRun is a run of transactions to be taken in a row, ordered by the first
+// one of the run.
+type txRun[T any] struct {
+	txs []orderedTx[T]
+}
+
+// txRunHeap is a heap of runs ordered by their first transaction.
+type txRunHeap[T any] struct {
+	runs []*txRun[T]
+	less func(a, b TxOrder) bool
+}
+
+// merge returns the transactions of all the runs, repeatedly taking the
+// first transaction of the run ordered first.
+func (h *txRunHeap[T]) merge() []T {
+	var n int
+	for _, run := range h.runs {
+		n += len(run.txs)
+	}
+
+	heap.Init(h)
+	ordered := make([]T, 0, n)
+	for h.Len() > 0 {
+		run := h.runs[0]
+		ordered = append(ordered, run.txs[0].tx)
+		run.txs = run.txs[1:]
+		if len(run.txs) == 0 {
+			heap.Pop(h)
+		} else {
+			heap.Fix(h, 0)
+		}
+	}
+	return ordered
+}
+
+func (h *txRunHeap[T]) Len() int { return len(h.runs) }
+
+func (h *txRunHeap[T]) Less(i, j int) bool {
+	return h.less(h.runs[i].txs[0].order, h.runs[j].txs[0].order)
+}
+
+func (h *txRunHeap[T]) Swap(i, j int) { h.runs[i], h.runs[j] = h.runs[j], h.runs[i] }
+
+func (h *txRunHeap[T]) Push(x any) { h.runs = append(h.runs, x.(*txRun[T])) }
+
+func (h *txRunHeap[T]) Pop() any {
+	old := h.runs
+	n := len(old)
+	run := old[n-1]
+	old[n-1] = nil
+	h.runs = old[:n-1]
+	return run
+}
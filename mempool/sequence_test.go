@@ -0,0 +1,107 @@
+package mempool
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestOrderTxs(t *testing.T) {
+	now := time.Now()
+	txs := []TxOrder{
+		{Priority: 5, Timestamp: now, Sender: "a", Sequence: 2},
+		{Priority: 1, Timestamp: now, Sender: "a", Sequence: 1},
+		{Priority: 3, Timestamp: now.Add(time.Second)},
+		{Priority: 3, Timestamp: now},
+		{Priority: 4, Timestamp: now, Sender: "b"},
+		{Priority: 2, Timestamp: now, Sender: "c", Sequence: 7},
+		{Priority: 9, Timestamp: now, Sender: "c", Sequence: 8},
+	}
+	ordered := OrderTxs(txs, func(o TxOrder) TxOrder { return o })
+	require.Equal(t, []TxOrder{txs[4], txs[3], txs[2], txs[5], txs[6], txs[1], txs[0]}, ordered)
+
+	require.Empty(t, OrderTxs(nil, func(o TxOrder) TxOrder { return o }))
+}
+
+func TestEvictionCandidates(t *testing.T) {
+	now := time.Now()
+	txs := []TxOrder{
+		{Priority: 1, Timestamp: now, Sender: "a", Sequence: 1},
+		{Priority: 5, Timestamp: now, Sender: "a", Sequence: 2},
+		{Priority: 2, Timestamp: now.Add(-time.Second), Sender: "a", Sequence: 3},
+		{Priority: 2, Timestamp: now},
+		{Priority: 2, Timestamp: now.Add(time.Second)},
+		{Priority: 9, Timestamp: now},
+		{Priority: 1, Timestamp: now, Sender: "b", Sequence: 4},
+		{Priority: 3, Timestamp: now, Sender: "b", Sequence: 5},
+		{Priority: 1, Timestamp: now, Sender: "c", Sequence: 1},
+	}
+	identity := func(o TxOrder) TxOrder { return o }
+
+	// the run of a is only evicted down to the transaction of higher priority,
+	// and the one of b from its tail although its head has a lower priority
+	incoming := TxOrder{Priority: 4, Timestamp: now, Sender: "c", Sequence: 2}
+	require.Equal(t,
+		[]TxOrder{txs[4], txs[3], txs[2], txs[7], txs[6]},
+		EvictionCandidates(txs, identity, incoming))
+
+	// the run of b stops at a transaction of higher priority
+	incoming = TxOrder{Priority: 3, Timestamp: now}
+	require.Equal(t,
+		[]TxOrder{txs[8], txs[4], txs[3], txs[2]},
+		EvictionCandidates(txs, identity, incoming))
+}
+
+func TestSequenceGate(t *testing.T) {
+	reap := func(txs []TxOrder, skip map[int]bool) []TxOrder {
+		gate := NewSequenceGate()
+		var reaped []TxOrder
+		for i, o := range txs {
+			if !gate.Admit(o) {
+				continue
+			}
+			if skip[i] {
+				gate.Skip(o)
+				continue
+			}
+			gate.Reap(o)
+			reaped = append(reaped, o)
+		}
+		return reaped
+	}
+
+	txs := []TxOrder{
+		{Sender: "a", Sequence: 2},
+		{Sender: "b", Sequence: 7},
+		{Sender: "a", Sequence: 4}, // 3 is missing
+		{Sender: "a", Sequence: 5},
+		{Sender: "b", Sequence: 8},
+		{Priority: 1},
+	}
+	require.Equal(t, []TxOrder{txs[0], txs[1], txs[4], txs[5]}, reap(txs, nil))
+
+	// a skipped transaction ends the run of its sender
+	require.Equal(t, []TxOrder{txs[0], txs[5]}, reap(txs, map[int]bool{1: true}))
+}
+
+func TestMinReplacementPriority(t *testing.T) {
+	testCases := []struct {
+		priority    int64
+		bumpPercent int64
+		expected    int64
+	}{
+		{100, 10, 110},
+		{100, 0, 101},
+		{5, 10, 6},
+		{0, 10, 1},
+		{-100, 10, -90},
+		{math.MaxInt64 - 1, 10, math.MaxInt64},
+		{math.MaxInt64, 0, math.MaxInt64},
+		{math.MaxInt64 / 2, 1000, math.MaxInt64},
+	}
+	for i, tc := range testCases {
+		require.Equal(t, tc.expected, MinReplacementPriority(tc.priority, tc.bumpPercent), i)
+	}
+}
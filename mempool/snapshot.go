@@ -0,0 +1,123 @@
+package mempool
+
+import (
+	"sync"
+	"time"
+
+	"github.com/tendermint/tendermint/types"
+)
+
+// TxMeta describes a transaction in a snapshot of, or a change to, the
+// mempool. The fields a mempool doesn't track are left zero.
+type TxMeta struct {
+	Key       types.TxKey
+	Size      int64
+	Priority  int64
+	GasWanted int64
+	Sender    string
+	// Blobs are the blobs of the transaction, if it is a BlobTx.
+	Blobs BlobInfo
+
+	// Height and Timestamp are the height of the mempool and the time at which
+	// the transaction was added.
+	Height    int64
+	Timestamp time.Time
+}
+
+// Snapshot is a view of the transactions in the mempool as of a version of
+// its TxFeed. Applying the changes of greater versions to it follows the
+// content of the mempool.
+type Snapshot struct {
+	Version uint64
+	Txs     []TxMeta
+}
+
+// TxDeltaType is the type of a change to the transactions in the mempool.
+type TxDeltaType string
+
+const (
+	// TxDeltaAdd is the type of the changes adding a transaction that passed
+	// CheckTx to the mempool.
+	TxDeltaAdd TxDeltaType = "add"
+	// TxDeltaEvict is the type of the changes removing a transaction from the
+	// mempool before it is committed, for the reason of the change.
+	TxDeltaEvict TxDeltaType = "evict"
+	// TxDeltaRemove is the type of the changes removing a transaction that was
+	// committed, or removed by RemoveTxByKey or Flush.
+	TxDeltaRemove TxDeltaType = "remove"
+)
+
+// TxDelta is a change to the transactions in the mempool. Changes are
+// idempotent: adding a transaction already in a snapshot, or removing one not
+// in it, leaves it as is.
+type TxDelta struct {
+	Version uint64
+	Type    TxDeltaType
+	Tx      TxMeta
+	// Reason is set for TxDeltaEvict only.
+	Reason RemovalReason
+}
+
+// TxListener is notified of the changes to the transactions in the mempool,
+// in order of version. It is called with the mempool locked, so it must not
+// block nor call the mempool back.
+type TxListener func(TxDelta)
+
+// TxFeed versions the changes to the transactions of a mempool and notifies
+// its listener of them. Mempools publish their changes as they make them, so
+// that a snapshot taken along with Version is consistent with the changes of
+// greater versions.
+//
+// The zero value is ready to use. TxFeed is safe for concurrent use.
+type TxFeed struct {
+	mtx      sync.Mutex
+	version  uint64
+	listener TxListener
+}
+
+// SetListener sets the listener notified of the changes, replacing any
+// previous one. A nil listener is not notified.
+func (f *TxFeed) SetListener(l TxListener) {
+	f.mtx.Lock()
+	defer f.mtx.Unlock()
+
+	f.listener = l
+}
+
+// Version returns the version of the last change published.
+func (f *TxFeed) Version() uint64 {
+	f.mtx.Lock()
+	defer f.mtx.Unlock()
+
+	return f.version
+}
+
+// Add publishes the addition of a transaction.
+func (f *TxFeed) Add(tx TxMeta) {
+	f.publish(TxDeltaAdd, tx, "")
+}
+
+// Evict publishes the eviction of a transaction for the given reason.
+func (f *TxFeed) Evict(tx TxMeta, reason RemovalReason) {
+	f.publish(TxDeltaEvict, tx, reason)
+}
+
+// Remove publishes the removal of a transaction.
+func (f *TxFeed) Remove(tx TxMeta) {
+	f.publish(TxDeltaRemove, tx, "")
+}
+
+func (f *TxFeed) publish(typ TxDeltaType, tx TxMeta, reason RemovalReason) {
+	f.mtx.Lock()
+	defer f.mtx.Unlock()
+
+	f.version++
+	if f.listener != nil {
+		f.listener(TxDelta{
+			Version: f.version,
+			Type:    typ,
+			Tx:      tx,
+			Reason:  reason,
+		})
+	}
+}
@@ -0,0 +1,36 @@
+package mempool
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/tendermint/tendermint/types"
+)
+
+func TestTxFeed(t *testing.T) {
+	var (
+		feed   TxFeed
+		deltas []TxDelta
+		tx     = TxMeta{Key: types.Tx("tx").Key(), Size: 2}
+	)
+	// changes are versioned before a listener is set
+	feed.Add(tx)
+	require.EqualValues(t, 1, feed.Version())
+
+	feed.SetListener(func(d TxDelta) { deltas = append(deltas, d) })
+	feed.Remove(tx)
+	feed.Add(tx)
+	feed.Evict(tx, RemovalReasonExpired)
+	require.EqualValues(t, 4, feed.Version())
+	require.Equal(t, []TxDelta{
+		{Version: 2, Type: TxDeltaRemove, Tx: tx},
+		{Version: 3, Type: TxDeltaAdd, Tx: tx},
+		{Version: 4, Type: TxDeltaEvict, Tx: tx, Reason: RemovalReasonExpired},
+	}, deltas)
+
+	feed.SetListener(nil)
+	feed.Add(tx)
+	require.EqualValues(t, 5, feed.Version())
+	require.Len(t, deltas, 3)
+}
@@ -1,6 +1,8 @@
 package mempool
 
 import (
+	"time"
+
 	"github.com/tendermint/tendermint/p2p"
 )
 
@@ -15,3 +17,47 @@ type TxInfo struct {
 	// SenderP2PID is the actual p2p.ID of the sender, used e.g. for logging.
 	SenderP2PID p2p.ID
 }
+
+// PendingTx describes a transaction pending in the mempool.
+// Used in the RPC endpoint: TxStatus.
+type PendingTx struct {
+	// Priority is the priority assigned to the transaction by the application.
+	// It is always zero in mempools that don't order transactions by priority.
+	Priority  int64
+	GasWanted int64
+
+	// Height and Timestamp are the height of the mempool and the time at which
+	// the transaction was added.
+	Height    int64
+	Timestamp time.Time
+
+	// Position is the number of transactions ReapMaxBytesMaxGas orders before
+	// this one. It is an estimate of the position of the transaction in the
+	// queue for the next blocks, as transactions that don't fit in a block are
+	// skipped and new transactions may be ordered before it.
+	Position int
+}
+
+// RemovalReason is the reason a transaction was evicted from, or rejected
+// by, the mempool.
+type RemovalReason string
+
+const (
+	// RemovalReasonExpired is the reason of transactions evicted because they
+	// stayed in the mempool longer than TTLNumBlocks or TTLDuration.
+	RemovalReasonExpired RemovalReason = "ttl_expired"
+	// RemovalReasonMempoolFull is the reason of transactions evicted to make
+	// room for a transaction of higher priority, or not added because the
+	// mempool is full.
+	RemovalReasonMempoolFull RemovalReason = "mempool_full"
+	// RemovalReasonRecheckFailed is the reason of transactions evicted because
+	// they failed CheckTx, or the post-check, when rechecked after a block.
+	RemovalReasonRecheckFailed RemovalReason = "recheck_failed"
+	// RemovalReasonCheckTxFailed is the reason of transactions rejected
+	// because they failed CheckTx, or the post-check, when first received.
+	RemovalReasonCheckTxFailed RemovalReason = "check_tx_failed"
+	// RemovalReasonReplaced is the reason of transactions evicted because a
+	// transaction of the same sender and sequence, and of high enough
+	// priority, replaced them.
+	RemovalReasonReplaced RemovalReason = "replaced"
+)
@@ -5,6 +5,7 @@ import (
 	"errors"
 	"sync"
 	"sync/atomic"
+	"time"
 
 	abci "github.com/tendermint/tendermint/abci/types"
 	"github.com/tendermint/tendermint/config"
@@ -25,8 +26,9 @@ import (
 // be efficiently accessed by multiple concurrent readers.
 type CListMempool struct {
 	// Atomic integers
-	height   int64 // the last block Update()'d to
-	txsBytes int64 // total size of mempool, in bytes
+	height    int64 // the last block Update()'d to
+	txsBytes  int64 // total size of mempool, in bytes
+	blobBytes int64 // total size of the blobs of the txs of mempool, in bytes
 
 	// notify listeners (ie. consensus) when txs are available
 	notifiedTxsAvailable bool
@@ -57,6 +59,17 @@ type CListMempool struct {
 	// This reduces the pressure on the proxyApp.
 	cache mempool.TxCache
 
+	// Keep the reasons txs were recently evicted or rejected, reported by
+	// TxStatus.
+	evictedTxs  *mempool.RemovedTxCache
+	rejectedTxs *mempool.RemovedTxCache
+
+	// Journal of the txs, if enabled.
+	wal *mempool.WAL
+
+	// Changes to the txs, published as they are made.
+	feed mempool.TxFeed
+
 	logger  log.Logger
 	metrics *mempool.Metrics
 }
@@ -82,6 +95,8 @@ func NewCListMempool(
 		height:        height,
 		recheckCursor: nil,
 		recheckEnd:    nil,
+		evictedTxs:    mempool.NewRemovedTxCache(cfg.CacheSize / 5),
+		rejectedTxs:   mempool.NewRemovedTxCache(cfg.CacheSize / 5),
 		logger:        log.NewNopLogger(),
 		metrics:       mempool.NopMetrics(),
 	}
@@ -130,6 +145,11 @@ func WithMetrics(metrics *mempool.Metrics) CListMempoolOption {
 	return func(mem *CListMempool) { mem.metrics = metrics }
 }
 
+// WithWAL sets the journal the mempool persists its txs to.
+func WithWAL(wal *mempool.WAL) CListMempoolOption {
+	return func(mem *CListMempool) { mem.wal = wal }
+}
+
 // Safe for concurrent use by multiple goroutines.
 func (mem *CListMempool) Lock() {
 	mem.updateMtx.Lock()
@@ -150,6 +170,11 @@ func (mem *CListMempool) SizeBytes() int64 {
 	return atomic.LoadInt64(&mem.txsBytes)
 }
 
+// Safe for concurrent use by multiple goroutines.
+func (mem *CListMempool) SizeBlobBytes() int64 {
+	return atomic.LoadInt64(&mem.blobBytes)
+}
+
 // Lock() must be help by the caller during execution.
 func (mem *CListMempool) FlushAppConn() error {
 	return mem.proxyAppConn.FlushSync()
@@ -161,11 +186,17 @@ func (mem *CListMempool) Flush() {
 	defer mem.updateMtx.RUnlock()
 
 	_ = atomic.SwapInt64(&mem.txsBytes, 0)
+	_ = atomic.SwapInt64(&mem.blobBytes, 0)
 	mem.cache.Reset()
 
 	for e := mem.txs.Front(); e != nil; e = e.Next() {
 		mem.txs.Remove(e)
 		e.DetachPrev()
+		memTx := e.Value.(*mempoolTx)
+		if mem.wal != nil {
+			mem.wal.RemoveTx(memTx.tx.Key())
+		}
+		mem.feed.Remove(memTx.meta())
 	}
 
 	mem.txsMap.Range(func(key, _ interface{}) bool {
@@ -193,9 +224,66 @@ func (mem *CListMempool) GetTxByKey(key types.TxKey) (types.Tx, bool) {
 	return memTx.tx, ok
 }
 
-// WasRecentlyEvicted returns false consistently as this implementation does not support transaction eviction.
+// WasRecentlyEvicted returns true if the tx was recently removed from the
+// mempool because it failed a recheck, or not added because the mempool was
+// full. This implementation never evicts txs to make room for others.
 func (mem *CListMempool) WasRecentlyEvicted(key types.TxKey) bool {
-	return false
+	return mem.evictedTxs.Has(key)
+}
+
+// GetPendingTx returns the details of the tx with the given key if it is in
+// the mempool. As this implementation doesn't order txs by priority, the
+// position of a tx is its position in the mempool.
+func (mem *CListMempool) GetPendingTx(key types.TxKey) (*mempool.PendingTx, bool) {
+	e, ok := mem.txsMap.Load(key)
+	if !ok {
+		return nil, false
+	}
+	elem := e.(*clist.CElement)
+	memTx := elem.Value.(*mempoolTx)
+
+	position := 0
+	for cur := mem.txs.Front(); cur != nil && cur != elem; cur = cur.Next() {
+		position++
+	}
+
+	return &mempool.PendingTx{
+		GasWanted: memTx.gasWanted,
+		Height:    memTx.Height(),
+		Timestamp: memTx.timestamp,
+		Position:  position,
+	}, true
+}
+
+// GetRemovalReason returns the reason the tx with the given key was recently
+// evicted or rejected.
+func (mem *CListMempool) GetRemovalReason(key types.TxKey) (mempool.RemovalReason, bool) {
+	if reason, ok := mem.evictedTxs.Get(key); ok {
+		return reason, true
+	}
+	return mem.rejectedTxs.Get(key)
+}
+
+// SetTxListener sets the listener notified of the changes to the txs in the
+// mempool.
+func (mem *CListMempool) SetTxListener(l mempool.TxListener) {
+	mem.feed.SetListener(l)
+}
+
+// Snapshot returns the txs in the mempool in the order they are reaped, along
+// with the version of the last change to them. As txs are added and removed
+// by the callbacks of the app connection, without the mempool lock, the
+// snapshot may already reflect the next changes.
+func (mem *CListMempool) Snapshot() *mempool.Snapshot {
+	version := mem.feed.Version()
+	txs := make([]mempool.TxMeta, 0, mem.Size())
+	for e := mem.txs.Front(); e != nil; e = e.Next() {
+		txs = append(txs, e.Value.(*mempoolTx).meta())
+	}
+	return &mempool.Snapshot{
+		Version: version,
+		Txs:     txs,
+	}
 }
 
 // TxsWaitChan returns a channel to wait on transactions. It will be closed
@@ -231,6 +319,12 @@ func (mem *CListMempool) CheckTx(
 		return err
 	}
 
+	if err := mempool.CheckBlobBytes(
+		mempool.NewBlobInfo(tx), mem.SizeBlobBytes(), mem.config.MaxBlobBytes,
+	); err != nil {
+		return err
+	}
+
 	if txSize > mem.config.MaxTxBytes {
 		return mempool.ErrTxTooLarge{
 			Max:    mem.config.MaxTxBytes,
@@ -335,24 +429,59 @@ func (mem *CListMempool) addTx(memTx *mempoolTx) {
 	e := mem.txs.PushBack(memTx)
 	mem.txsMap.Store(memTx.tx.Key(), e)
 	atomic.AddInt64(&mem.txsBytes, int64(len(memTx.tx)))
+	atomic.AddInt64(&mem.blobBytes, memTx.blobs.Bytes)
 	mem.metrics.TxSizeBytes.Observe(float64(len(memTx.tx)))
+
+	if mem.wal != nil {
+		err := mem.wal.AddTx(&mempool.WALTx{
+			Tx:        memTx.tx,
+			Height:    memTx.height,
+			Timestamp: memTx.timestamp,
+		})
+		if err != nil {
+			mem.logger.Error("failed to journal tx in the mempool WAL", "tx", memTx.tx.Hash(), "err", err)
+		}
+	}
+	mem.feed.Add(memTx.meta())
 }
 
 // Called from:
 //   - Update (lock held) if tx was committed
 //   - resCbRecheck (lock not held) if tx was invalidated
-func (mem *CListMempool) removeTx(tx types.Tx, elem *clist.CElement, removeFromCache bool) {
+//
+// Given a reason, the tx is evicted: it is added to the evicted txs for that
+// reason.
+func (mem *CListMempool) removeTx(
+	tx types.Tx,
+	elem *clist.CElement,
+	removeFromCache bool,
+	reason mempool.RemovalReason,
+) {
 	mem.txs.Remove(elem)
 	elem.DetachPrev()
 	mem.txsMap.Delete(tx.Key())
-	if memtx, ok := elem.Value.(*mempoolTx); ok {
-		tx = memtx.tx
+	memTx, ok := elem.Value.(*mempoolTx)
+	if ok {
+		tx = memTx.tx
+		atomic.AddInt64(&mem.blobBytes, -memTx.blobs.Bytes)
 	}
 	atomic.AddInt64(&mem.txsBytes, int64(-len(tx)))
 
 	if removeFromCache {
 		mem.cache.Remove(tx)
 	}
+	if mem.wal != nil {
+		mem.wal.RemoveTx(tx.Key())
+	}
+	if !ok {
+		return
+	}
+	if reason != "" {
+		mem.evictedTxs.Push(tx.Key(), reason)
+		mem.feed.Evict(memTx.meta(), reason)
+	} else {
+		mem.feed.Remove(memTx.meta())
+	}
 }
 
 // RemoveTxByKey removes a transaction from the mempool by its TxKey index.
@@ -360,7 +489,7 @@ func (mem *CListMempool) RemoveTxByKey(txKey types.TxKey) error {
 	if e, ok := mem.txsMap.Load(txKey); ok {
 		memTx := e.(*clist.CElement).Value.(*mempoolTx)
 		if memTx != nil {
-			mem.removeTx(memTx.tx, e.(*clist.CElement), false)
+			mem.removeTx(memTx.tx, e.(*clist.CElement), false, "")
 			return nil
 		}
 		return errors.New("transaction not found")
@@ -405,9 +534,15 @@ func (mem *CListMempool) resCbFirstTime(
 		if (r.CheckTx.Code == abci.CodeTypeOK) && postCheckErr == nil {
 			// Check mempool isn't full again to reduce the chance of exceeding the
 			// limits.
-			if err := mem.isFull(len(tx)); err != nil {
+			blobs := mempool.NewBlobInfo(tx)
+			err := mem.isFull(len(tx))
+			if err == nil {
+				err = mempool.CheckBlobBytes(blobs, mem.SizeBlobBytes(), mem.config.MaxBlobBytes)
+			}
+			if err != nil {
 				// remove from cache (mempool might have a space later)
 				mem.cache.Remove(tx)
+				mem.evictedTxs.Push(types.Tx(tx).Key(), mempool.RemovalReasonMempoolFull)
 				mem.logger.Error(err.Error())
 				return
 			}
@@ -428,8 +563,10 @@ func (mem *CListMempool) resCbFirstTime(
 
 			memTx := &mempoolTx{
 				height:    mem.height,
+				timestamp: time.Now().UTC(),
 				gasWanted: r.CheckTx.GasWanted,
 				tx:        tx,
+				blobs:     blobs,
 			}
 			memTx.senders.Store(peerID, true)
 			mem.addTx(memTx)
@@ -451,6 +588,7 @@ func (mem *CListMempool) resCbFirstTime(
 				"err", postCheckErr,
 			)
 			mem.metrics.FailedTxs.Add(1)
+			mem.rejectedTxs.Push(types.Tx(tx).Key(), mempool.RemovalReasonCheckTxFailed)
 
 			if !mem.config.KeepInvalidTxsInCache {
 				// remove from cache (it might be good later)
@@ -512,7 +650,7 @@ func (mem *CListMempool) resCbRecheck(req *abci.Request, res *abci.Response) {
 			// Tx became invalidated due to newly committed block.
 			mem.logger.Debug("tx is no longer valid", "tx", types.Tx(tx).Hash(), "res", r, "err", postCheckErr)
 			// NOTE: we remove tx from the cache because it might be good later
-			mem.removeTx(tx, mem.recheckCursor, !mem.config.KeepInvalidTxsInCache)
+			mem.removeTx(tx, mem.recheckCursor, !mem.config.KeepInvalidTxsInCache, mempool.RemovalReasonRecheckFailed)
 		}
 		if mem.recheckCursor == mem.recheckEnd {
 			mem.recheckCursor = nil
@@ -562,6 +700,11 @@ func (mem *CListMempool) ReapMaxBytesMaxGas(maxBytes, maxGas int64) types.Txs {
 		runningSize int64
 	)
 
+	var square *mempool.SquareEstimator
+	if mem.config.ReapMode == config.MempoolReapModeSquare {
+		square = mempool.NewSquareEstimator(mem.config.MaxSquareSize)
+	}
+
 	// TODO: we will get a performance boost if we have a good estimate of avg
 	// size per tx, and set the initial capacity based off of that.
 	// txs := make([]types.Tx, 0, cmtmath.MinInt(mem.txs.Len(), max/mem.avgTxSize))
@@ -580,6 +723,11 @@ func (mem *CListMempool) ReapMaxBytesMaxGas(maxBytes, maxGas int64) types.Txs {
 
 		runningSize += dataSize
 
+		// Check the share usage requirement in the square reap mode.
+		if !square.Add(len(memTx.tx), memTx.blobs) {
+			return txs[:len(txs)-1]
+		}
+
 		// Check total gas requirement.
 		// If maxGas is negative, skip this check.
 		// Since newTotalGas < masGas, which
@@ -650,7 +798,14 @@ func (mem *CListMempool) Update(
 		//   100
 		// https://github.com/tendermint/tendermint/issues/3322.
 		if e, ok := mem.txsMap.Load(tx.Key()); ok {
-			mem.removeTx(tx, e.(*clist.CElement), false)
+			mem.removeTx(tx, e.(*clist.CElement), false, "")
+		}
+	}
+
+	// Journal the removal of the committed txs.
+	if mem.wal != nil {
+		if err := mem.wal.Sync(); err != nil {
+			mem.logger.Error("failed to sync the mempool WAL", "err", err)
 		}
 	}
 
@@ -700,9 +855,11 @@ func (mem *CListMempool) recheckTxs() {
 
 // mempoolTx is a transaction that successfully ran
 type mempoolTx struct {
-	height    int64    // height that this tx had been validated in
-	gasWanted int64    // amount of gas this tx states it will require
-	tx        types.Tx //
+	height    int64            // height that this tx had been validated in
+	timestamp time.Time        // time that this tx was added to the mempool
+	gasWanted int64            // amount of gas this tx states it will require
+	tx        types.Tx         //
+	blobs     mempool.BlobInfo // blobs of this tx, if it is a BlobTx
 
 	// ids of peers who've sent us this tx (as a map for quick lookups).
 	// senders: PeerID -> bool
@@ -713,3 +870,16 @@ type mempoolTx struct {
 func (memTx *mempoolTx) Height() int64 {
 	return atomic.LoadInt64(&memTx.height)
 }
+
+// meta returns the metadata of the tx reported in the snapshots of the
+// mempool.
+func (memTx *mempoolTx) meta() mempool.TxMeta {
+	return mempool.TxMeta{
+		Key:       memTx.tx.Key(),
+		Size:      int64(len(memTx.tx)),
+		GasWanted: memTx.gasWanted,
+		Blobs:     memTx.blobs,
+		Height:    memTx.Height(),
+		Timestamp: memTx.timestamp,
+	}
+}
@@ -3,6 +3,7 @@ package v0
 import (
 	"bytes"
 	"encoding/binary"
+	"errors"
 	"fmt"
 	mrand "math/rand"
 	"os"
@@ -586,6 +587,45 @@ func TestGetTxByKey(t *testing.T) {
 	require.Nil(t, randomTx)
 }
 
+func TestTxStatusDetails(t *testing.T) {
+	app := kvstore.NewApplication()
+	cc := proxy.NewLocalClientCreator(app)
+
+	mp, cleanup := newMempoolWithApp(cc)
+	defer cleanup()
+
+	// txs are ordered by arrival
+	txs := checkTxs(t, mp, 3, mempool.UnknownPeerID)
+	for i, tx := range txs {
+		pending, ok := mp.GetPendingTx(tx.Key())
+		require.True(t, ok)
+		require.Equal(t, i, pending.Position)
+		require.EqualValues(t, 0, pending.Height)
+		require.False(t, pending.Timestamp.IsZero())
+	}
+	_, ok := mp.GetPendingTx(types.Tx([]byte{0x02}).Key())
+	require.False(t, ok)
+
+	// txs failing the recheck are evicted
+	err := mp.Update(1, nil, nil, nil, mempool.PostCheckMaxGas(0))
+	require.NoError(t, err)
+	require.Zero(t, mp.Size())
+	for _, tx := range txs {
+		require.True(t, mp.WasRecentlyEvicted(tx.Key()))
+		reason, ok := mp.GetRemovalReason(tx.Key())
+		require.True(t, ok)
+		require.Equal(t, mempool.RemovalReasonRecheckFailed, reason)
+	}
+
+	// txs failing the post-check are rejected
+	tx := types.Tx([]byte{0x01})
+	require.NoError(t, mp.CheckTx(tx, nil, mempool.TxInfo{}))
+	reason, ok := mp.GetRemovalReason(tx.Key())
+	require.True(t, ok)
+	require.Equal(t, mempool.RemovalReasonCheckTxFailed, reason)
+	require.False(t, mp.WasRecentlyEvicted(tx.Key()))
+}
+
 func TestMempoolTxsBytes(t *testing.T) {
 	app := kvstore.NewApplication()
 	cc := proxy.NewLocalClientCreator(app)
@@ -792,11 +832,65 @@ func TestRemoveBlobTx(t *testing.T) {
 
 	err = mp.CheckTx(bTx, nil, mempool.TxInfo{})
 	require.NoError(t, err)
+	assert.EqualValues(t, len(b.Data), mp.SizeBlobBytes())
 
 	err = mp.Update(1, []types.Tx{indexWrapper}, abciResponses(1, abci.CodeTypeOK), nil, nil)
 	require.NoError(t, err)
 	assert.EqualValues(t, 0, mp.Size())
 	assert.EqualValues(t, 0, mp.SizeBytes())
+	assert.EqualValues(t, 0, mp.SizeBlobBytes())
+}
+
+// newBlobTx returns a BlobTx wrapping tx, with a blob of size bytes.
+func newBlobTx(t *testing.T, tx string, size int) types.Tx {
+	bTx, err := types.MarshalBlobTx([]byte(tx), &tmproto.Blob{
+		NamespaceId: bytes.Repeat([]byte{1}, consts.NamespaceIDSize),
+		Data:        make([]byte, size),
+	})
+	require.NoError(t, err)
+	return bTx
+}
+
+func TestMempoolMaxBlobBytes(t *testing.T) {
+	app := kvstore.NewApplication()
+	cc := proxy.NewLocalClientCreator(app)
+	cfg := config.ResetTestRoot("mempool_test")
+	cfg.Mempool.MaxBlobBytes = 1000
+	mp, cleanup := newMempoolWithAppAndConfig(cc, cfg)
+	defer cleanup()
+
+	require.NoError(t, mp.CheckTx(newBlobTx(t, "a", 600), nil, mempool.TxInfo{}))
+
+	// a blob tx exceeding the max blob bytes is rejected, but not a regular tx
+	err := mp.CheckTx(newBlobTx(t, "b", 600), nil, mempool.TxInfo{})
+	var blobErr mempool.ErrBlobBytesExceeded
+	require.True(t, errors.As(err, &blobErr))
+	require.NoError(t, mp.CheckTx(types.Tx("c"), nil, mempool.TxInfo{}))
+	require.Equal(t, 2, mp.Size())
+	require.EqualValues(t, 600, mp.SizeBlobBytes())
+
+	mp.Flush()
+	require.EqualValues(t, 0, mp.SizeBlobBytes())
+}
+
+func TestReapSquare(t *testing.T) {
+	app := kvstore.NewApplication()
+	cc := proxy.NewLocalClientCreator(app)
+	cfg := config.ResetTestRoot("mempool_test")
+	mp, cleanup := newMempoolWithAppAndConfig(cc, cfg)
+	defer cleanup()
+
+	// blob txs of 5 shares each
+	for i := 0; i < 4; i++ {
+		require.NoError(t, mp.CheckTx(newBlobTx(t, strconv.Itoa(i), 2000), nil, mempool.TxInfo{}))
+	}
+	require.Len(t, mp.ReapMaxBytesMaxGas(-1, -1), 4)
+
+	// a 4x4 square holds the shares of 3 blobs and of the compact share of
+	// their txs only
+	cfg.Mempool.ReapMode = config.MempoolReapModeSquare
+	cfg.Mempool.MaxSquareSize = 4
+	require.Len(t, mp.ReapMaxBytesMaxGas(-1, -1), 3)
 }
 
 // caller must close server
@@ -821,3 +915,84 @@ func abciResponses(n int, code uint32) []*abci.ResponseDeliverTx {
 	}
 	return responses
 }
+
+func TestMempoolWAL(t *testing.T) {
+	app := kvstore.NewApplication()
+	cc := proxy.NewLocalClientCreator(app)
+	cfg := config.ResetTestRoot("mempool_test")
+	defer os.RemoveAll(cfg.RootDir)
+
+	newMempool := func(wal *mempool.WAL) *CListMempool {
+		appConnMem, err := cc.NewABCIClient()
+		require.NoError(t, err)
+		require.NoError(t, appConnMem.Start())
+		t.Cleanup(func() { require.NoError(t, appConnMem.Stop()) })
+
+		mp := NewCListMempool(cfg.Mempool, appConnMem, 0, WithWAL(wal))
+		mp.SetLogger(log.TestingLogger())
+		return mp
+	}
+
+	walDir := t.TempDir()
+	wal, _, err := mempool.OpenWAL(walDir)
+	require.NoError(t, err)
+
+	mp := newMempool(wal)
+	txs := checkTxs(t, mp, 10, mempool.UnknownPeerID)
+	require.Equal(t, 10, wal.Size())
+
+	mp.Lock()
+	require.NoError(t, mp.Update(1, txs[:4], abciResponses(4, abci.CodeTypeOK), nil, nil))
+	mp.Unlock()
+	require.Equal(t, 6, wal.Size())
+	require.NoError(t, wal.Close())
+
+	wal, walTxs, err := mempool.OpenWAL(walDir)
+	require.NoError(t, err)
+	require.Len(t, walTxs, 6)
+
+	mp = newMempool(wal)
+	require.NoError(t, mempool.ReplayWAL(mp, wal, walTxs))
+	require.Equal(t, 6, mp.Size())
+	for _, tx := range txs[4:] {
+		_, ok := mp.GetTxByKey(tx.Key())
+		require.True(t, ok)
+	}
+	require.NoError(t, wal.Close())
+}
+
+func TestMempoolSnapshot(t *testing.T) {
+	app := kvstore.NewApplication()
+	cc := proxy.NewLocalClientCreator(app)
+
+	mp, cleanup := newMempoolWithApp(cc)
+	defer cleanup()
+	var deltas []mempool.TxDelta
+	mp.SetTxListener(func(d mempool.TxDelta) { deltas = append(deltas, d) })
+
+	txs := checkTxs(t, mp, 3, mempool.UnknownPeerID)
+	require.Len(t, deltas, 3)
+	snapshot := mp.Snapshot()
+	require.EqualValues(t, 3, snapshot.Version)
+	require.Len(t, snapshot.Txs, 3)
+	for i, tx := range txs {
+		require.Equal(t, mempool.TxDeltaAdd, deltas[i].Type)
+		require.EqualValues(t, i+1, deltas[i].Version)
+		// txs are ordered by arrival
+		require.Equal(t, tx.Key(), snapshot.Txs[i].Key)
+	}
+
+	// committed txs are removed and the ones failing the recheck are evicted
+	err := mp.Update(1, txs[:1], abciResponses(1, abci.CodeTypeOK), nil, mempool.PostCheckMaxGas(0))
+	require.NoError(t, err)
+	require.Len(t, deltas, 6)
+	require.Equal(t, mempool.TxDeltaRemove, deltas[3].Type)
+	require.Equal(t, txs[0].Key(), deltas[3].Tx.Key)
+	for _, d := range deltas[4:] {
+		require.Equal(t, mempool.TxDeltaEvict, d.Type)
+		require.Equal(t, mempool.RemovalReasonRecheckFailed, d.Reason)
+	}
+	snapshot = mp.Snapshot()
+	require.EqualValues(t, 6, snapshot.Version)
+	require.Empty(t, snapshot.Txs)
+}
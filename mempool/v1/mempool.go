@@ -2,7 +2,6 @@ package v1
 
 import (
 	"fmt"
-	"sort"
 	"sync"
 	"sync/atomic"
 	"time"
@@ -40,7 +39,8 @@ type TxMempool struct {
 	cache        mempool.TxCache // seen transactions
 
 	// Atomically-updated fields
-	txsBytes int64 // atomic: the total size of all transactions in the mempool, in bytes
+	txsBytes  int64 // atomic: the total size of all transactions in the mempool, in bytes
+	blobBytes int64 // atomic: the total size of the blobs of the transactions in the mempool, in bytes
 
 	// Synchronized fields, protected by mtx.
 	mtx                  *sync.RWMutex
@@ -51,10 +51,16 @@ type TxMempool struct {
 	height               int64     // the latest height passed to Update
 	lastPurgeTime        time.Time // the last time we attempted to purge transactions via the TTL
 
-	txs        *clist.CList // valid transactions (passed CheckTx)
-	txByKey    map[types.TxKey]*clist.CElement
-	txBySender map[string]*clist.CElement // for sender != ""
-	evictedTxs mempool.TxCache            // for tracking evicted transactions
+	txs          *clist.CList // valid transactions (passed CheckTx)
+	txByKey      map[types.TxKey]*clist.CElement
+	txBySender   map[string]*clist.CElement            // for sender != "" and sequence == 0
+	txBySequence map[string]map[uint64]*clist.CElement // for sender != "" and sequence != 0
+	evictedTxs   *mempool.RemovedTxCache               // for tracking evicted transactions
+	rejectedTxs  *mempool.RemovedTxCache               // for tracking rejected transactions
+	senderQuotas *mempool.SenderQuotas                 // share of the mempool held by each sender
+	feed         mempool.TxFeed                        // changes to the transactions, published with mtx held
+
+	wal *mempool.WAL // journal of the transactions, if enabled
 
 	traceClient trace.Tracer
 }
@@ -80,11 +86,14 @@ func NewTxMempool(
 		height:       height,
 		txByKey:      make(map[types.TxKey]*clist.CElement),
 		txBySender:   make(map[string]*clist.CElement),
+		txBySequence: make(map[string]map[uint64]*clist.CElement),
+		evictedTxs:   mempool.NewRemovedTxCache(cfg.CacheSize / 5),
+		rejectedTxs:  mempool.NewRemovedTxCache(cfg.CacheSize / 5),
+		senderQuotas: mempool.NewSenderQuotas(cfg.MaxSenderShare, cfg.Size, cfg.MaxTxsBytes),
 		traceClient:  trace.NoOpTracer(),
 	}
 	if cfg.CacheSize > 0 {
 		txmp.cache = mempool.NewLRUTxCache(cfg.CacheSize)
-		txmp.evictedTxs = mempool.NewLRUTxCache(cfg.CacheSize / 5)
 	}
 
 	for _, opt := range options {
@@ -113,6 +122,11 @@ func WithMetrics(metrics *mempool.Metrics) TxMempoolOption {
 	return func(txmp *TxMempool) { txmp.metrics = metrics }
 }
 
+// WithWAL sets the journal the mempool persists its transactions to.
+func WithWAL(wal *mempool.WAL) TxMempoolOption {
+	return func(txmp *TxMempool) { txmp.wal = wal }
+}
+
 func WithTraceClient(tc trace.Tracer) TxMempoolOption {
 	return func(txmp *TxMempool) {
 		txmp.traceClient = tc
@@ -134,6 +148,10 @@ func (txmp *TxMempool) Size() int { return txmp.txs.Len() }
 // mempool. It is thread-safe.
 func (txmp *TxMempool) SizeBytes() int64 { return atomic.LoadInt64(&txmp.txsBytes) }
 
+// SizeBlobBytes returns the total sum in bytes of the blobs of the valid
+// transactions in the mempool. It is thread-safe.
+func (txmp *TxMempool) SizeBlobBytes() int64 { return atomic.LoadInt64(&txmp.blobBytes) }
+
 // FlushAppConn executes FlushSync on the mempool's proxyAppConn.
 //
 // The caller must hold an exclusive mempool lock (by calling txmp.Lock) before
@@ -240,6 +258,7 @@ func (txmp *TxMempool) CheckTx(tx types.Tx, cb func(*abci.Response), txInfo memp
 		hash:      tx.Key(),
 		timestamp: time.Now().UTC(),
 		height:    height,
+		blobs:     mempool.NewBlobInfo(tx),
 	}
 	wtx.SetPeer(txInfo.SenderID)
 	txmp.addNewTransaction(wtx, rsp)
@@ -273,35 +292,120 @@ func (txmp *TxMempool) GetTxByKey(txKey types.TxKey) (types.Tx, bool) {
 // WasRecentlyEvicted returns a bool indicating whether the transaction with
 // the specified key was recently evicted and is currently within the evicted cache.
 func (txmp *TxMempool) WasRecentlyEvicted(txKey types.TxKey) bool {
-	return txmp.evictedTxs.HasKey(txKey)
+	return txmp.evictedTxs.Has(txKey)
+}
+
+// GetPendingTx returns the details of the transaction with the specified key
+// if it is in the mempool. Its position is the number of transactions that
+// are reaped before it, see mempool.OrderTxs.
+func (txmp *TxMempool) GetPendingTx(txKey types.TxKey) (*mempool.PendingTx, bool) {
+	txmp.mtx.RLock()
+	defer txmp.mtx.RUnlock()
+
+	elt, ok := txmp.txByKey[txKey]
+	if !ok {
+		return nil, false
+	}
+	wtx := elt.Value.(*WrappedTx)
+
+	position := 0
+	for _, w := range txmp.sortedEntries() {
+		if w == wtx {
+			break
+		}
+		position++
+	}
+
+	return &mempool.PendingTx{
+		Priority:  wtx.Priority(),
+		GasWanted: wtx.GasWanted(),
+		Height:    wtx.height,
+		Timestamp: wtx.timestamp,
+		Position:  position,
+	}, true
+}
+
+// GetRemovalReason returns the reason the transaction with the specified key
+// was recently evicted or rejected.
+func (txmp *TxMempool) GetRemovalReason(txKey types.TxKey) (mempool.RemovalReason, bool) {
+	if reason, ok := txmp.evictedTxs.Get(txKey); ok {
+		return reason, true
+	}
+	return txmp.rejectedTxs.Get(txKey)
+}
+
+// SetTxListener sets the listener notified of the changes to the transactions
+// in the mempool. It is called with txmp.mtx held.
+func (txmp *TxMempool) SetTxListener(l mempool.TxListener) {
+	txmp.feed.SetListener(l)
+}
+
+// Snapshot returns the transactions in the mempool in the order they are
+// reaped, see mempool.OrderTxs, along with the version of the last change to
+// them.
+func (txmp *TxMempool) Snapshot() *mempool.Snapshot {
+	txmp.mtx.RLock()
+	defer txmp.mtx.RUnlock()
+
+	wtxs := txmp.sortedEntries()
+	txs := make([]mempool.TxMeta, len(wtxs))
+	for i, w := range wtxs {
+		txs[i] = w.meta()
+	}
+	return &mempool.Snapshot{
+		Version: txmp.feed.Version(),
+		Txs:     txs,
+	}
 }
 
 // removeTxByKey removes the specified transaction key from the mempool.
 // The caller must hold txmp.mtx excluxively.
 func (txmp *TxMempool) removeTxByKey(key types.TxKey) error {
 	if elt, ok := txmp.txByKey[key]; ok {
-		w := elt.Value.(*WrappedTx)
-		delete(txmp.txByKey, key)
-		delete(txmp.txBySender, w.sender)
-		txmp.txs.Remove(elt)
-		elt.DetachPrev()
-		elt.DetachNext()
-		atomic.AddInt64(&txmp.txsBytes, -w.Size())
+		txmp.removeTxByElement(elt, "")
 		return nil
 	}
 	return fmt.Errorf("transaction %x not found", key)
 }
 
 // removeTxByElement removes the specified transaction element from the mempool.
+// Given a reason, the transaction is evicted: it is added to the evicted
+// transactions cache for that reason.
 // The caller must hold txmp.mtx exclusively.
-func (txmp *TxMempool) removeTxByElement(elt *clist.CElement) {
+func (txmp *TxMempool) removeTxByElement(elt *clist.CElement, reason mempool.RemovalReason) {
 	w := elt.Value.(*WrappedTx)
 	delete(txmp.txByKey, w.tx.Key())
-	delete(txmp.txBySender, w.sender)
+	txmp.unindexSender(w)
 	txmp.txs.Remove(elt)
 	elt.DetachPrev()
 	elt.DetachNext()
 	atomic.AddInt64(&txmp.txsBytes, -w.Size())
+	atomic.AddInt64(&txmp.blobBytes, -w.blobs.Bytes)
+	if txmp.wal != nil {
+		txmp.wal.RemoveTx(w.tx.Key())
+	}
+	if reason != "" {
+		txmp.evictedTxs.Push(w.hash, reason)
+		txmp.feed.Evict(w.meta(), reason)
+	} else {
+		txmp.feed.Remove(w.meta())
+	}
+}
+
+// unindexSender removes w from the index of the transactions of its sender,
+// releasing its share of the mempool. The caller must hold txmp.mtx
+// exclusively.
+func (txmp *TxMempool) unindexSender(w *WrappedTx) {
+	txmp.senderQuotas.Remove(w.sender, w.Size())
+	if w.sequence == 0 {
+		delete(txmp.txBySender, w.sender)
+		return
+	}
+	seqs := txmp.txBySequence[w.sender]
+	delete(seqs, w.sequence)
+	if len(seqs) == 0 {
+		delete(txmp.txBySequence, w.sender)
+	}
 }
 
 // Flush purges the contents of the mempool and the cache, leaving both empty.
@@ -315,7 +419,7 @@ func (txmp *TxMempool) Flush() {
 	cur := txmp.txs.Front()
 	for cur != nil {
 		next := cur.Next()
-		txmp.removeTxByElement(cur)
+		txmp.removeTxByElement(cur, "")
 		cur = next
 	}
 	txmp.cache.Reset()
@@ -323,46 +427,67 @@ func (txmp *TxMempool) Flush() {
 
 // allEntriesSorted returns a slice of all the transactions currently in the
 // mempool, sorted in nonincreasing order by priority with ties broken by
-// increasing order of arrival time.
+// increasing order of arrival time, and the transactions of a sender sorted
+// by increasing sequence, see mempool.OrderTxs.
 func (txmp *TxMempool) allEntriesSorted() []*WrappedTx {
 	txmp.mtx.RLock()
 	defer txmp.mtx.RUnlock()
 
+	return txmp.sortedEntries()
+}
+
+// sortedEntries is allEntriesSorted for callers holding txmp.mtx.
+func (txmp *TxMempool) sortedEntries() []*WrappedTx {
 	all := make([]*WrappedTx, 0, len(txmp.txByKey))
 	for _, tx := range txmp.txByKey {
 		all = append(all, tx.Value.(*WrappedTx))
 	}
-	sort.Slice(all, func(i, j int) bool {
-		if all[i].priority == all[j].priority {
-			return all[i].timestamp.Before(all[j].timestamp)
-		}
-		return all[i].priority > all[j].priority // N.B. higher priorities first
-	})
-	return all
+	return mempool.OrderTxs(all, (*WrappedTx).order)
 }
 
 // ReapMaxBytesMaxGas returns a slice of valid transactions that fit within the
 // size and gas constraints. The results are ordered by nonincreasing priority,
-// with ties broken by increasing order of arrival.  Reaping transactions does
-// not remove them from the mempool.
+// with ties broken by increasing order of arrival, and the transactions of a
+// sender by increasing sequence.  Reaping transactions does not remove them
+// from the mempool.
+//
+// The sequenced transactions of a sender are reaped as a run of consecutive
+// sequences: once one of them doesn't fit or is missing, the following ones
+// are skipped as well, see mempool.SequenceGate.
 //
 // If maxBytes < 0, no limit is set on the total size in bytes.
 // If maxGas < 0, no limit is set on the total gas cost.
+// In the square reap mode, the transactions must also fit in the estimated
+// shares of a data square of the configured max square size.
 //
 // If the mempool is empty or has no transactions fitting within the given
 // constraints, the result will also be empty.
 func (txmp *TxMempool) ReapMaxBytesMaxGas(maxBytes, maxGas int64) types.Txs {
 	var totalGas, totalBytes int64
 
+	var square *mempool.SquareEstimator
+	if txmp.config.ReapMode == config.MempoolReapModeSquare {
+		square = mempool.NewSquareEstimator(txmp.config.MaxSquareSize)
+	}
+
 	var keep []types.Tx //nolint:prealloc
+	gate := mempool.NewSequenceGate()
 	for _, w := range txmp.allEntriesSorted() {
+		order := w.order()
+		if !gate.Admit(order) {
+			continue
+		}
+
 		// N.B. When computing byte size, we need to include the overhead for
 		// encoding as protobuf to send to the application. This actually overestimates it
 		// as we add the proto overhead to each transaction
 		txBytes := types.ComputeProtoSizeForTxs([]types.Tx{w.tx})
-		if (maxGas >= 0 && totalGas+w.gasWanted > maxGas) || (maxBytes >= 0 && totalBytes+txBytes > maxBytes) {
+		if (maxGas >= 0 && totalGas+w.gasWanted > maxGas) || (maxBytes >= 0 && totalBytes+txBytes > maxBytes) ||
+			!square.Add(len(w.tx), w.blobs) {
+			gate.Skip(order)
 			continue
 		}
+		gate.Reap(order)
 		totalBytes += txBytes
 		totalGas += w.gasWanted
 		keep = append(keep, w.tx)
@@ -380,7 +505,8 @@ func (txmp *TxMempool) TxsFront() *clist.CElement { return txmp.txs.Front() }
 
 // ReapMaxTxs returns up to max transactions from the mempool. The results are
 // ordered by nonincreasing priority with ties broken by increasing order of
-// arrival. Reaping transactions does not remove them from the mempool.
+// arrival, and the transactions of a sender by increasing sequence. Reaping
+// transactions does not remove them from the mempool.
 //
 // If max < 0, all transactions in the mempool are reaped.
 //
@@ -449,6 +575,13 @@ func (txmp *TxMempool) Update(
 
 	txmp.purgeExpiredTxs(blockHeight)
 
+	// Journal the removal of the committed and expired transactions.
+	if txmp.wal != nil {
+		if err := txmp.wal.Sync(); err != nil {
+			txmp.logger.Error("failed to sync the mempool WAL", "err", err)
+		}
+	}
+
 	// If there any uncommitted transactions left in the mempool, we either
 	// initiate re-CheckTx per remaining transaction or notify that remaining
 	// transactions are left.
@@ -498,6 +631,7 @@ func (txmp *TxMempool) addNewTransaction(wtx *WrappedTx, checkTxRes *abci.Respon
 		)
 
 		txmp.metrics.FailedTxs.Add(1)
+		txmp.rejectedTxs.Push(wtx.hash, mempool.RemovalReasonCheckTxFailed)
 
 		// Remove the invalid transaction from the cache, unless the operator has
 		// instructed us to keep invalid transactions.
@@ -515,11 +649,15 @@ func (txmp *TxMempool) addNewTransaction(wtx *WrappedTx, checkTxRes *abci.Respon
 
 	priority := checkTxRes.Priority
 	sender := checkTxRes.Sender
+	sequence := checkTxRes.Sequence
+	if sender == "" {
+		sequence = 0
+	}
 
 	// Disallow multiple concurrent transactions from the same sender assigned
-	// by the ABCI application. As a special case, an empty sender is not
-	// restricted.
-	if sender != "" {
+	// by the ABCI application, unless they are sequenced. As a special case,
+	// an empty sender is not restricted.
+	if sender != "" && sequence == 0 {
 		elt, ok := txmp.txBySender[sender]
 		if ok {
 			w := elt.Value.(*WrappedTx)
@@ -535,6 +673,60 @@ func (txmp *TxMempool) addNewTransaction(wtx *WrappedTx, checkTxRes *abci.Respon
 		}
 	}
 
+	// The sequenced transactions of a sender can't be mixed with unsequenced
+	// ones, and a transaction replaces the one of the same sender and sequence
+	// only if its priority is higher by the configured bump.
+	replaced, err := txmp.checkSequence(sender, sequence, priority)
+	if err != nil {
+		txmp.logger.Debug(
+			"rejected valid incoming transaction",
+			"tx", fmt.Sprintf("%X", wtx.tx.Hash()),
+			"sender", sender,
+			"sequence", sequence,
+			"err", err,
+		)
+		checkTxRes.MempoolError = err.Error()
+		return
+	}
+
+	// Limit the share of the mempool held by the sender, the replaced
+	// transaction, if any, being released.
+	addedTxs, addedBytes := 1, wtx.Size()
+	if replaced != nil {
+		addedTxs, addedBytes = 0, addedBytes-replaced.Value.(*WrappedTx).Size()
+	}
+	if err := txmp.senderQuotas.Check(sender, addedTxs, addedBytes); err != nil {
+		txmp.logger.Debug(
+			"rejected valid incoming transaction; sender quota exceeded",
+			"tx", fmt.Sprintf("%X", wtx.tx.Hash()),
+			"sender", sender,
+			"err", err,
+		)
+		txmp.cache.Remove(wtx.tx)
+		txmp.metrics.QuotaExceededTxs.With("quota", "sender").Add(1)
+		checkTxRes.Code = mempool.CodeTypeSenderQuotaExceeded
+		checkTxRes.Codespace = mempool.Codespace
+		checkTxRes.MempoolError = err.Error()
+		return
+	}
+
+	// Limit the total size of the blobs in the mempool, the ones of the
+	// replaced transaction, if any, being released.
+	blobBytes := txmp.SizeBlobBytes()
+	if replaced != nil {
+		blobBytes -= replaced.Value.(*WrappedTx).blobs.Bytes
+	}
+	if err := mempool.CheckBlobBytes(wtx.blobs, blobBytes, txmp.config.MaxBlobBytes); err != nil {
+		txmp.logger.Debug(
+			"rejected valid incoming transaction; mempool is full of blobs",
+			"tx", fmt.Sprintf("%X", wtx.tx.Hash()),
+			"err", err,
+		)
+		txmp.cache.Remove(wtx.tx)
+		checkTxRes.MempoolError = err.Error()
+		return
+	}
+
 	// At this point the application has ruled the transaction valid, but the
 	// mempool might be full. If so, find the lowest-priority items with lower
 	// priority than the application assigned to this new one, and evict as many
@@ -542,20 +734,32 @@ func (txmp *TxMempool) addNewTransaction(wtx *WrappedTx, checkTxRes *abci.Respon
 	// discard tx.
 
 	if err := txmp.canAddTx(wtx); err != nil {
-		var victims []*clist.CElement // eligible transactions for eviction
-		var victimBytes int64         // total size of victims
+		// The eligible transactions for eviction, in the order they are
+		// evicted, see mempool.EvictionCandidates. The replaced transaction,
+		// of the same sender, is not among them.
+		var all []*clist.CElement
 		for cur := txmp.txs.Front(); cur != nil; cur = cur.Next() {
-			cw := cur.Value.(*WrappedTx)
-			if cw.priority < priority {
-				victims = append(victims, cur)
-				victimBytes += cw.Size()
-			}
+			all = append(all, cur)
+		}
+		incoming := mempool.TxOrder{Priority: priority, Timestamp: wtx.timestamp, Sender: sender, Sequence: sequence}
+		victims := mempool.EvictionCandidates(all, func(elt *clist.CElement) mempool.TxOrder {
+			return elt.Value.(*WrappedTx).order()
+		}, incoming)
+		var victimBytes int64 // total size of victims
+		for _, vic := range victims {
+			victimBytes += vic.Value.(*WrappedTx).Size()
+		}
+
+		// The replaced transaction, if any, makes room for the new one too.
+		var replacedBytes int64
+		if replaced != nil {
+			replacedBytes = replaced.Value.(*WrappedTx).Size()
 		}
 
 		// If there are no suitable eviction candidates, or the total size of
 		// those candidates is not enough to make room for the new transaction,
 		// drop the new one.
-		if len(victims) == 0 || victimBytes < wtx.Size() {
+		if (len(victims) == 0 && replaced == nil) || victimBytes+replacedBytes < wtx.Size() {
 			txmp.cache.Remove(wtx.tx)
 			txmp.logger.Error(
 				"rejected valid incoming transaction; mempool is full",
@@ -567,7 +771,7 @@ func (txmp *TxMempool) addNewTransaction(wtx *WrappedTx, checkTxRes *abci.Respon
 					wtx.tx.Hash())
 			txmp.metrics.EvictedTxs.Add(1)
 			// Add it to evicted transactions cache
-			txmp.evictedTxs.Push(wtx.tx)
+			txmp.evictedTxs.Push(wtx.hash, mempool.RemovalReasonMempoolFull)
 			return
 		}
 
@@ -576,20 +780,14 @@ func (txmp *TxMempool) addNewTransaction(wtx *WrappedTx, checkTxRes *abci.Respon
 			"new_priority", priority,
 		)
 
-		// Sort lowest priority items first so they will be evicted first.  Break
-		// ties in favor of newer items (to maintain FIFO semantics in a group).
-		sort.Slice(victims, func(i, j int) bool {
-			iw := victims[i].Value.(*WrappedTx)
-			jw := victims[j].Value.(*WrappedTx)
-			if iw.Priority() == jw.Priority() {
-				return iw.timestamp.After(jw.timestamp)
-			}
-			return iw.Priority() < jw.Priority()
-		})
-
 		// Evict as many of the victims as necessary to make room.
-		var evictedBytes int64
+		evictedBytes := replacedBytes
 		for _, vic := range victims {
+			// We may not need to evict all the eligible transactions.  Bail out
+			// early if we have made enough room.
+			if evictedBytes >= wtx.Size() {
+				break
+			}
 			w := vic.Value.(*WrappedTx)
 
 			txmp.logger.Debug(
@@ -597,23 +795,31 @@ func (txmp *TxMempool) addNewTransaction(wtx *WrappedTx, checkTxRes *abci.Respon
 				"old_tx", fmt.Sprintf("%X", w.tx.Hash()),
 				"old_priority", w.priority,
 			)
-			txmp.removeTxByElement(vic)
+			txmp.removeTxByElement(vic, mempool.RemovalReasonMempoolFull)
 			txmp.cache.Remove(w.tx)
 			txmp.metrics.EvictedTxs.Add(1)
-			// Add it to evicted transactions cache
-			txmp.evictedTxs.Push(w.tx)
-			// We may not need to evict all the eligible transactions.  Bail out
-			// early if we have made enough room.
 			evictedBytes += w.Size()
-			if evictedBytes >= wtx.Size() {
-				break
-			}
 		}
 	}
 
+	if replaced != nil {
+		w := replaced.Value.(*WrappedTx)
+		txmp.logger.Debug(
+			"replaced valid existing transaction",
+			"old_tx", fmt.Sprintf("%X", w.tx.Hash()),
+			"old_priority", w.Priority(),
+			"new_tx", fmt.Sprintf("%X", wtx.tx.Hash()),
+			"new_priority", priority,
+		)
+		txmp.removeTxByElement(replaced, mempool.RemovalReasonReplaced)
+		txmp.cache.Remove(w.tx)
+		txmp.metrics.EvictedTxs.Add(1)
+	}
+
 	wtx.SetGasWanted(checkTxRes.GasWanted)
 	wtx.SetPriority(priority)
 	wtx.SetSender(sender)
+	wtx.SetSequence(sequence)
 	txmp.insertTx(wtx)
 
 	txmp.metrics.TxSizeBytes.Observe(float64(wtx.Size()))
@@ -629,14 +835,67 @@ func (txmp *TxMempool) addNewTransaction(wtx *WrappedTx, checkTxRes *abci.Respon
 	txmp.notifyTxsAvailable()
 }
 
+// checkSequence checks whether a transaction of the given sender, sequence
+// and priority can be added to the mempool, and returns the transaction it
+// replaces, if any. The caller must hold txmp.mtx.
+func (txmp *TxMempool) checkSequence(sender string, sequence uint64, priority int64) (*clist.CElement, error) {
+	if sender == "" {
+		return nil, nil
+	}
+	if sequence == 0 {
+		if len(txmp.txBySequence[sender]) > 0 {
+			return nil, fmt.Errorf("rejected valid incoming transaction; sequenced txs already exist for sender %q",
+				sender)
+		}
+		return nil, nil
+	}
+	if _, ok := txmp.txBySender[sender]; ok {
+		return nil, fmt.Errorf("rejected valid incoming transaction; unsequenced tx already exists for sender %q",
+			sender)
+	}
+
+	elt, ok := txmp.txBySequence[sender][sequence]
+	if !ok {
+		return nil, nil
+	}
+	w := elt.Value.(*WrappedTx)
+	if minPriority := mempool.MinReplacementPriority(w.Priority(), txmp.config.ReplacementPriorityBump); priority < minPriority {
+		return nil, fmt.Errorf("rejected valid incoming transaction; tx already exists for sender %q and sequence %d (%X) "+
+			"and priority %d is below the replacement priority %d", sender, sequence, w.tx.Hash(), priority, minPriority)
+	}
+	return elt, nil
+}
+
 func (txmp *TxMempool) insertTx(wtx *WrappedTx) {
 	elt := txmp.txs.PushBack(wtx)
 	txmp.txByKey[wtx.tx.Key()] = elt
-	if s := wtx.Sender(); s != "" {
+	if s, seq := wtx.Sender(), wtx.Sequence(); s != "" && seq == 0 {
 		txmp.txBySender[s] = elt
+	} else if s != "" {
+		if txmp.txBySequence[s] == nil {
+			txmp.txBySequence[s] = make(map[uint64]*clist.CElement)
+		}
+		txmp.txBySequence[s][seq] = elt
 	}
 
 	atomic.AddInt64(&txmp.txsBytes, wtx.Size())
+	atomic.AddInt64(&txmp.blobBytes, wtx.blobs.Bytes)
+	txmp.senderQuotas.Add(wtx.Sender(), wtx.Size())
+	txmp.feed.Add(wtx.meta())
+
+	if txmp.wal != nil {
+		err := txmp.wal.AddTx(&mempool.WALTx{
+			Tx:        wtx.tx,
+			Priority:  wtx.Priority(),
+			Sender:    wtx.Sender(),
+			Height:    wtx.height,
+			Timestamp: wtx.timestamp,
+		})
+		if err != nil {
+			txmp.logger.Error("failed to journal transaction in the mempool WAL",
+				"tx", fmt.Sprintf("%X", wtx.tx.Hash()), "err", err)
+		}
+	}
 }
 
 // handleRecheckResult handles the responses from ABCI CheckTx calls issued
@@ -675,7 +934,7 @@ func (txmp *TxMempool) handleRecheckResult(tx types.Tx, checkTxRes *abci.Respons
 		"err", err,
 		"code", checkTxRes.Code,
 	)
-	txmp.removeTxByElement(elt)
+	txmp.removeTxByElement(elt, mempool.RemovalReasonRecheckFailed)
 	txmp.metrics.FailedTxs.Add(1)
 	if !txmp.config.KeepInvalidTxsInCache {
 		txmp.cache.Remove(wtx.tx)
@@ -700,11 +959,10 @@ func (txmp *TxMempool) recheckTransactions() {
 		"height", txmp.height,
 	)
 
-	// Collect transactions currently in the mempool requiring recheck.
-	wtxs := make([]*WrappedTx, 0, txmp.txs.Len())
-	for e := txmp.txs.Front(); e != nil; e = e.Next() {
-		wtxs = append(wtxs, e.Value.(*WrappedTx))
-	}
+	// Collect transactions currently in the mempool requiring recheck, in the
+	// order they are reaped so that the transactions of a sender are rechecked
+	// by increasing sequence.
+	wtxs := txmp.sortedEntries()
 
 	// Issue CheckTx calls for each remaining transaction, and when all the
 	// rechecks are complete signal watchers that transactions may be available.
@@ -777,9 +1035,8 @@ func (txmp *TxMempool) purgeExpiredTxs(blockHeight int64) {
 		w := cur.Value.(*WrappedTx)
 		if txmp.config.TTLNumBlocks > 0 && (blockHeight-w.height) > txmp.config.TTLNumBlocks ||
 			txmp.config.TTLDuration > 0 && now.Sub(w.timestamp) > txmp.config.TTLDuration {
-			txmp.removeTxByElement(cur)
+			txmp.removeTxByElement(cur, mempool.RemovalReasonExpired)
 			txmp.cache.Remove(w.tx)
-			txmp.evictedTxs.Push(w.tx)
 			txmp.metrics.ExpiredTxs.Add(1)
 		}
 		cur = next
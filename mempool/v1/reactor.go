@@ -27,6 +27,7 @@ type Reactor struct {
 	config      *cfg.MempoolConfig
 	mempool     *TxMempool
 	ids         *mempoolIDs
+	quotas      *mempool.PeerQuotas
 	traceClient trace.Tracer
 }
 
@@ -94,11 +95,12 @@ func newMempoolIDs() *mempoolIDs {
 }
 
 // NewReactor returns a new Reactor with the given config and mempool.
-func NewReactor(config *cfg.MempoolConfig, mempool *TxMempool, traceClient trace.Tracer) *Reactor {
+func NewReactor(config *cfg.MempoolConfig, mp *TxMempool, traceClient trace.Tracer) *Reactor {
 	memR := &Reactor{
 		config:      config,
-		mempool:     mempool,
+		mempool:     mp,
 		ids:         newMempoolIDs(),
+		quotas:      mempool.NewPeerQuotas(config.PeerQuotaWindow, config.MaxPeerTxsPerWindow, config.MaxPeerBytesPerWindow),
 		traceClient: traceClient,
 	}
 	memR.BaseReactor = *p2p.NewBaseReactor("Mempool", memR)
@@ -171,6 +173,7 @@ func (memR *Reactor) AddPeer(peer p2p.Peer) {
 // RemovePeer implements Reactor.
 func (memR *Reactor) RemovePeer(peer p2p.Peer, reason interface{}) {
 	memR.ids.Reclaim(peer)
+	memR.quotas.RemovePeer(peer.ID())
 	// broadcast routine checks if peer is gone and returns
 }
 
@@ -200,6 +203,17 @@ func (memR *Reactor) ReceiveEnvelope(e p2p.Envelope) {
 				len(tx),
 				schema.Download,
 			)
+			// A peer exceeding its quota is disconnected, to keep it from
+			// crowding out the txs of the other peers. Only txs new to the
+			// cache count against it, as honest peers flood the same txs.
+			if !memR.mempool.cache.Has(ntx) {
+				if err := memR.quotas.Consume(e.Src.ID(), len(tx), time.Now()); err != nil {
+					memR.Logger.Info("peer exceeded its mempool quota", "src", e.Src, "err", err)
+					memR.mempool.metrics.QuotaExceededTxs.With("quota", "peer").Add(1)
+					memR.Switch.StopPeerForError(e.Src, err)
+					return
+				}
+			}
 			err = memR.mempool.CheckTx(ntx, nil, txInfo)
 			if errors.Is(err, mempool.ErrTxInCache) {
 				memR.Logger.Debug("Tx already exists in cache", "tx", ntx.String())
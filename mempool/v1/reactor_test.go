@@ -2,6 +2,7 @@ package v1
 
 import (
 	"encoding/hex"
+	"fmt"
 	"os"
 	"sync"
 	"testing"
@@ -155,6 +156,59 @@ func TestLegacyReactorReceiveBasic(t *testing.T) {
 	})
 }
 
+func TestReactorStopsPeerExceedingQuota(t *testing.T) {
+	config := cfg.TestConfig()
+	config.Mempool.MaxPeerTxsPerWindow = 2
+	reactor := makeAndConnectReactors(config, 1)[0]
+	defer func() {
+		assert.NoError(t, reactor.Stop())
+	}()
+
+	peer := mock.NewPeer(nil)
+	reactor.InitPeer(peer)
+	reactor.AddPeer(peer)
+	for i := 0; i < 3; i++ {
+		reactor.ReceiveEnvelope(p2p.Envelope{
+			Src:       peer,
+			Message:   &memproto.Txs{Txs: [][]byte{types.Tx(fmt.Sprintf("tx-%d", i))}},
+			ChannelID: mempool.MempoolChannel,
+		})
+	}
+	require.False(t, peer.IsRunning())
+	require.Equal(t, 2, reactor.mempool.Size())
+}
+
+func TestReactorDoesNotChargeQuotaForCachedTxs(t *testing.T) {
+	config := cfg.TestConfig()
+	config.Mempool.MaxPeerTxsPerWindow = 2
+	reactor := makeAndConnectReactors(config, 1)[0]
+	defer func() {
+		assert.NoError(t, reactor.Stop())
+	}()
+
+	peer := mock.NewPeer(nil)
+	reactor.InitPeer(peer)
+	reactor.AddPeer(peer)
+	receive := func(tx string) {
+		reactor.ReceiveEnvelope(p2p.Envelope{
+			Src:       peer,
+			Message:   &memproto.Txs{Txs: [][]byte{types.Tx(tx)}},
+			ChannelID: mempool.MempoolChannel,
+		})
+	}
+
+	// txs gossiped again by the peer are already in the cache
+	for i := 0; i < 3; i++ {
+		receive("tx-0")
+		receive("tx-1")
+	}
+	require.True(t, peer.IsRunning())
+	require.Equal(t, 2, reactor.mempool.Size())
+
+	receive("tx-2")
+	require.False(t, peer.IsRunning())
+}
+
 func makeAndConnectReactors(config *cfg.Config, n int) []*Reactor {
 	reactors := make([]*Reactor, n)
 	logger := mempoolLogger()
@@ -4,21 +4,24 @@ import (
 	"sync"
 	"time"
 
+	"github.com/tendermint/tendermint/mempool"
 	"github.com/tendermint/tendermint/types"
 )
 
 // WrappedTx defines a wrapper around a raw transaction with additional metadata
 // that is used for indexing.
 type WrappedTx struct {
-	tx        types.Tx    // the original transaction data
-	hash      types.TxKey // the transaction hash
-	height    int64       // height when this transaction was initially checked (for expiry)
-	timestamp time.Time   // time when transaction was entered (for TTL)
+	tx        types.Tx         // the original transaction data
+	hash      types.TxKey      // the transaction hash
+	height    int64            // height when this transaction was initially checked (for expiry)
+	timestamp time.Time        // time when transaction was entered (for TTL)
+	blobs     mempool.BlobInfo // the blobs of the transaction, if it is a BlobTx
 
 	mtx       sync.Mutex
 	gasWanted int64           // app: gas required to execute this transaction
 	priority  int64           // app: priority value for this transaction
 	sender    string          // app: assigned sender label
+	sequence  uint64          // app: sequence of the transaction among the ones of its sender
 	peers     map[uint16]bool // peer IDs who have sent us this transaction
 }
 
@@ -85,3 +88,45 @@ func (w *WrappedTx) Priority() int64 {
 	defer w.mtx.Unlock()
 	return w.priority
 }
+
+// SetSequence sets the application-assigned sequence of w.
+func (w *WrappedTx) SetSequence(seq uint64) {
+	w.mtx.Lock()
+	defer w.mtx.Unlock()
+	w.sequence = seq
+}
+
+// Sequence reports the application-assigned sequence of w.
+func (w *WrappedTx) Sequence() uint64 {
+	w.mtx.Lock()
+	defer w.mtx.Unlock()
+	return w.sequence
+}
+
+// order returns the metadata the mempool orders w by.
+func (w *WrappedTx) order() mempool.TxOrder {
+	w.mtx.Lock()
+	defer w.mtx.Unlock()
+	return mempool.TxOrder{
+		Priority:  w.priority,
+		Timestamp: w.timestamp,
+		Sender:    w.sender,
+		Sequence:  w.sequence,
+	}
+}
+
+// meta returns the metadata of w reported in the snapshots of the mempool.
+func (w *WrappedTx) meta() mempool.TxMeta {
+	w.mtx.Lock()
+	defer w.mtx.Unlock()
+	return mempool.TxMeta{
+		Key:       w.hash,
+		Size:      int64(len(w.tx)),
+		Priority:  w.priority,
+		GasWanted: w.gasWanted,
+		Sender:    w.sender,
+		Blobs:     w.blobs,
+		Height:    w.height,
+		Timestamp: w.timestamp,
+	}
+}
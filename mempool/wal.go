@@ -0,0 +1,405 @@
+package mempool
+
+import (
+	"bufio"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"hash/crc32"
+	"io"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/gogo/protobuf/proto"
+
+	cmtos "github.com/tendermint/tendermint/libs/os"
+	protomem "github.com/tendermint/tendermint/proto/tendermint/mempool"
+	"github.com/tendermint/tendermint/types"
+)
+
+const (
+	walFileName = "wal"
+
+	// maxWALRecordSizeBytes bounds the size of a WAL record, which holds at
+	// most a tx or the keys of the txs removed from a full mempool.
+	maxWALRecordSizeBytes = types.MaxBlockSizeBytes
+)
+
+var walCRCTable = crc32.MakeTable(crc32.Castagnoli)
+
+// WALTx is a tx journaled in the mempool WAL. Mempools fill in the metadata
+// they track, leaving the rest zero.
+type WALTx struct {
+	Tx        types.Tx
+	Priority  int64
+	Sender    string
+	Height    int64
+	Timestamp time.Time
+}
+
+// Expired returns whether the tx outlived ttlNumBlocks at height or
+// ttlDuration at now, zero TTLs and metadata never expiring, as for the
+// TTLNumBlocks and TTLDuration of the mempool config.
+func (tx *WALTx) Expired(height int64, now time.Time, ttlNumBlocks int64, ttlDuration time.Duration) bool {
+	if ttlNumBlocks > 0 && tx.Height > 0 && height-tx.Height > ttlNumBlocks {
+		return true
+	}
+	return ttlDuration > 0 && !tx.Timestamp.IsZero() && now.Sub(tx.Timestamp) > ttlDuration
+}
+
+// WAL is an on-disk journal of the txs admitted to a mempool, for them to be
+// checked back into the mempool after a restart, see ReplayWAL.
+//
+// Txs are appended to the WAL as they are admitted, while the txs removed
+// from the mempool are journaled on the next admission or Sync. The WAL is
+// compacted by Sync once it holds more removed txs than txs still in the
+// mempool, so it doesn't grow without bound.
+//
+// The WAL is safe for concurrent use.
+type WAL struct {
+	mtx  sync.Mutex
+	path string
+	file *os.File
+	wr   *bufio.Writer
+
+	// txs journaled and not removed since
+	live map[types.TxKey]struct{}
+	// txs removed but not journaled as such yet
+	removed []types.TxKey
+	// txs journaled then removed since the last compaction
+	dead int
+}
+
+// OpenWAL opens the WAL in dir, creating it if needed, and returns it along
+// with the txs it holds in order of admission. A record torn by a crash ends
+// the WAL, losing the records written after it.
+func OpenWAL(dir string) (*WAL, []*WALTx, error) {
+	if err := cmtos.EnsureDir(dir, 0700); err != nil {
+		return nil, nil, fmt.Errorf("failed to create mempool WAL dir: %w", err)
+	}
+
+	path := filepath.Join(dir, walFileName)
+	txs, err := readWAL(path)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	wal := &WAL{
+		path: path,
+		live: make(map[types.TxKey]struct{}, len(txs)),
+	}
+	for _, tx := range txs {
+		wal.live[tx.Tx.Key()] = struct{}{}
+	}
+	// drop the removed txs and any torn record right away
+	if err := wal.rewrite(txs); err != nil {
+		return nil, nil, err
+	}
+	return wal, txs, nil
+}
+
+// AddTx journals a tx admitted to the mempool, unless it is already journaled.
+func (wal *WAL) AddTx(tx *WALTx) error {
+	wal.mtx.Lock()
+	defer wal.mtx.Unlock()
+
+	key := tx.Tx.Key()
+	if _, ok := wal.live[key]; ok {
+		return nil
+	}
+
+	// the tx may be added back after being removed
+	if err := wal.writeRemovedTxs(); err != nil {
+		return err
+	}
+	if err := writeWALRecord(wal.wr, walTxRecord(tx)); err != nil {
+		return err
+	}
+	if err := wal.wr.Flush(); err != nil {
+		return err
+	}
+	wal.live[key] = struct{}{}
+	return nil
+}
+
+// RemoveTx journals the removal of a tx from the mempool, if it is journaled.
+// The removal is written on the next call to AddTx or Sync.
+func (wal *WAL) RemoveTx(key types.TxKey) {
+	wal.mtx.Lock()
+	defer wal.mtx.Unlock()
+
+	if _, ok := wal.live[key]; !ok {
+		return
+	}
+	delete(wal.live, key)
+	wal.removed = append(wal.removed, key)
+	wal.dead++
+}
+
+// Sync writes the pending removals and flushes the WAL to disk, compacting it
+// if it mostly holds removed txs. It is called by mempools on Update.
+func (wal *WAL) Sync() error {
+	wal.mtx.Lock()
+	defer wal.mtx.Unlock()
+
+	if wal.dead > len(wal.live) {
+		return wal.compact()
+	}
+	if err := wal.writeRemovedTxs(); err != nil {
+		return err
+	}
+	if err := wal.wr.Flush(); err != nil {
+		return err
+	}
+	return wal.file.Sync()
+}
+
+// Close syncs and closes the WAL.
+func (wal *WAL) Close() error {
+	if err := wal.Sync(); err != nil {
+		return err
+	}
+
+	wal.mtx.Lock()
+	defer wal.mtx.Unlock()
+
+	return wal.file.Close()
+}
+
+// Size returns the number of txs journaled and not removed.
+func (wal *WAL) Size() int {
+	wal.mtx.Lock()
+	defer wal.mtx.Unlock()
+
+	return len(wal.live)
+}
+
+func (wal *WAL) writeRemovedTxs() error {
+	if len(wal.removed) == 0 {
+		return nil
+	}
+
+	keys := make([][]byte, len(wal.removed))
+	for i := range wal.removed {
+		keys[i] = wal.removed[i][:]
+	}
+	record := &protomem.WALRecord{
+		Sum: &protomem.WALRecord_RemovedTxs{RemovedTxs: &protomem.WALRemovedTxs{TxKeys: keys}},
+	}
+	if err := writeWALRecord(wal.wr, record); err != nil {
+		return err
+	}
+	wal.removed = nil
+	return nil
+}
+
+// compact rewrites the WAL with the txs still in the mempool only.
+func (wal *WAL) compact() error {
+	if err := wal.wr.Flush(); err != nil {
+		return err
+	}
+	txs, err := readWAL(wal.path)
+	if err != nil {
+		return err
+	}
+
+	liveTxs := make([]*WALTx, 0, len(wal.live))
+	for _, tx := range txs {
+		if _, ok := wal.live[tx.Tx.Key()]; ok {
+			liveTxs = append(liveTxs, tx)
+		}
+	}
+	if err := wal.file.Close(); err != nil {
+		return err
+	}
+	return wal.rewrite(liveTxs)
+}
+
+// rewrite atomically replaces the WAL file with the given txs, and opens it
+// for appending.
+func (wal *WAL) rewrite(txs []*WALTx) error {
+	tmpPath := wal.path + ".tmp"
+	tmp, err := os.OpenFile(tmpPath, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0600)
+	if err != nil {
+		return fmt.Errorf("failed to create mempool WAL: %w", err)
+	}
+	wr := bufio.NewWriter(tmp)
+	for _, tx := range txs {
+		if err := writeWALRecord(wr, walTxRecord(tx)); err != nil {
+			tmp.Close()
+			return err
+		}
+	}
+	if err := wr.Flush(); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Sync(); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	if err := os.Rename(tmpPath, wal.path); err != nil {
+		return fmt.Errorf("failed to replace mempool WAL: %w", err)
+	}
+
+	file, err := os.OpenFile(wal.path, os.O_WRONLY|os.O_APPEND, 0600)
+	if err != nil {
+		return fmt.Errorf("failed to open mempool WAL: %w", err)
+	}
+	wal.file = file
+	wal.wr = bufio.NewWriter(file)
+	wal.removed = nil
+	wal.dead = 0
+	return nil
+}
+
+// ReplayWAL checks the txs read from the WAL back into mp, before the node
+// joins consensus. The mempool journals again the txs it admits, while the
+// ones it rejects are removed from the WAL.
+func ReplayWAL(mp Mempool, wal *WAL, txs []*WALTx) error {
+	for _, tx := range txs {
+		// the txs rejected are removed from the WAL below
+		_ = mp.CheckTx(tx.Tx, nil, TxInfo{SenderID: UnknownPeerID})
+	}
+	// wait for the txs checked asynchronously
+	mp.Lock()
+	err := mp.FlushAppConn()
+	mp.Unlock()
+	if err != nil {
+		return err
+	}
+
+	for _, tx := range txs {
+		key := tx.Tx.Key()
+		if _, ok := mp.GetTxByKey(key); !ok {
+			wal.RemoveTx(key)
+		}
+	}
+	return wal.Sync()
+}
+
+//-----------------------------------------------------------------------------
+
+func walTxRecord(tx *WALTx) *protomem.WALRecord {
+	return &protomem.WALRecord{
+		Sum: &protomem.WALRecord_Tx{Tx: &protomem.WALTx{
+			Tx:        tx.Tx,
+			Priority:  tx.Priority,
+			Sender:    tx.Sender,
+			Height:    tx.Height,
+			Timestamp: tx.Timestamp,
+		}},
+	}
+}
+
+// writeWALRecord writes a record prefixed by its checksum and length, as the
+// records of the consensus WAL.
+func writeWALRecord(wr io.Writer, record *protomem.WALRecord) error {
+	data, err := proto.Marshal(record)
+	if err != nil {
+		return fmt.Errorf("failed to encode mempool WAL record: %w", err)
+	}
+	if len(data) > maxWALRecordSizeBytes {
+		return fmt.Errorf("mempool WAL record is too big: %d bytes, max: %d bytes", len(data), maxWALRecordSizeBytes)
+	}
+
+	buf := make([]byte, 8+len(data))
+	binary.BigEndian.PutUint32(buf[0:4], crc32.Checksum(data, walCRCTable))
+	binary.BigEndian.PutUint32(buf[4:8], uint32(len(data)))
+	copy(buf[8:], data)
+	_, err = wr.Write(buf)
+	return err
+}
+
+// readWAL returns the txs journaled in the WAL file at path and not removed,
+// in order of admission, stopping at the first corrupted record.
+func readWAL(path string) ([]*WALTx, error) {
+	file, err := os.Open(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to open mempool WAL: %w", err)
+	}
+	defer file.Close()
+
+	var (
+		rd    = bufio.NewReader(file)
+		txs   []*WALTx
+		index = make(map[types.TxKey]int)
+	)
+	for {
+		record, ok := readWALRecord(rd)
+		if !ok {
+			break
+		}
+
+		switch sum := record.Sum.(type) {
+		case *protomem.WALRecord_Tx:
+			tx := &WALTx{
+				Tx:        sum.Tx.Tx,
+				Priority:  sum.Tx.Priority,
+				Sender:    sum.Tx.Sender,
+				Height:    sum.Tx.Height,
+				Timestamp: sum.Tx.Timestamp,
+			}
+			key := tx.Tx.Key()
+			if _, ok := index[key]; !ok {
+				index[key] = len(txs)
+				txs = append(txs, tx)
+			}
+		case *protomem.WALRecord_RemovedTxs:
+			for _, bz := range sum.RemovedTxs.TxKeys {
+				key, err := types.TxKeyFromBytes(bz)
+				if err != nil {
+					continue
+				}
+				if i, ok := index[key]; ok {
+					txs[i] = nil
+					delete(index, key)
+				}
+			}
+		}
+	}
+
+	liveTxs := make([]*WALTx, 0, len(index))
+	for _, tx := range txs {
+		if tx != nil {
+			liveTxs = append(liveTxs, tx)
+		}
+	}
+	return liveTxs, nil
+}
+
+// readWALRecord reads the next record, returning false at the end of the WAL
+// or on a corrupted record.
+func readWALRecord(rd io.Reader) (*protomem.WALRecord, bool) {
+	header := make([]byte, 8)
+	if _, err := io.ReadFull(rd, header); err != nil {
+		return nil, false
+	}
+	crc := binary.BigEndian.Uint32(header[0:4])
+	length := binary.BigEndian.Uint32(header[4:8])
+	if length > maxWALRecordSizeBytes {
+		return nil, false
+	}
+
+	data := make([]byte, length)
+	if _, err := io.ReadFull(rd, data); err != nil {
+		return nil, false
+	}
+	if crc32.Checksum(data, walCRCTable) != crc {
+		return nil, false
+	}
+
+	record := new(protomem.WALRecord)
+	if err := proto.Unmarshal(data, record); err != nil {
+		return nil, false
+	}
+	return record, true
+}
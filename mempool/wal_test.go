@@ -0,0 +1,186 @@
+package mempool
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/tendermint/tendermint/types"
+)
+
+func newWALTx(i int) *WALTx {
+	return &WALTx{
+		Tx:        types.Tx(fmt.Sprintf("tx-%d", i)),
+		Priority:  int64(i),
+		Sender:    fmt.Sprintf("sender-%d", i),
+		Height:    int64(i + 1),
+		Timestamp: time.Unix(int64(i), 0).UTC(),
+	}
+}
+
+func TestWALReopen(t *testing.T) {
+	dir := t.TempDir()
+	wal, txs, err := OpenWAL(dir)
+	require.NoError(t, err)
+	require.Empty(t, txs)
+
+	for i := 0; i < 5; i++ {
+		require.NoError(t, wal.AddTx(newWALTx(i)))
+	}
+	// journaling a tx twice is a noop
+	require.NoError(t, wal.AddTx(newWALTx(0)))
+	wal.RemoveTx(newWALTx(1).Tx.Key())
+	wal.RemoveTx(newWALTx(3).Tx.Key())
+	// a removed tx can be journaled again
+	require.NoError(t, wal.AddTx(newWALTx(1)))
+	require.Equal(t, 4, wal.Size())
+	require.NoError(t, wal.Close())
+
+	wal, txs, err = OpenWAL(dir)
+	require.NoError(t, err)
+	require.Equal(t, []*WALTx{newWALTx(0), newWALTx(2), newWALTx(4), newWALTx(1)}, txs)
+	require.Equal(t, 4, wal.Size())
+	require.NoError(t, wal.Close())
+}
+
+func TestWriteWAL(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "export")
+	txs, err := ReadWAL(dir)
+	require.NoError(t, err)
+	require.Empty(t, txs)
+
+	written := []*WALTx{newWALTx(2), newWALTx(0), newWALTx(1)}
+	require.NoError(t, WriteWAL(dir, written))
+	txs, err = ReadWAL(dir)
+	require.NoError(t, err)
+	require.Equal(t, written, txs)
+
+	// the WAL written replaces the one in dir
+	require.NoError(t, WriteWAL(dir, written[:1]))
+	wal, txs, err := OpenWAL(dir)
+	require.NoError(t, err)
+	require.Equal(t, written[:1], txs)
+	require.NoError(t, wal.Close())
+}
+
+func TestWALRemovalsWrittenOnSync(t *testing.T) {
+	dir := t.TempDir()
+	wal, _, err := OpenWAL(dir)
+	require.NoError(t, err)
+
+	for i := 0; i < 3; i++ {
+		require.NoError(t, wal.AddTx(newWALTx(i)))
+	}
+	wal.RemoveTx(newWALTx(0).Tx.Key())
+
+	// the removal isn't written before Sync
+	txs, err := readWAL(filepath.Join(dir, walFileName))
+	require.NoError(t, err)
+	require.Len(t, txs, 3)
+
+	require.NoError(t, wal.Sync())
+	txs, err = readWAL(filepath.Join(dir, walFileName))
+	require.NoError(t, err)
+	require.Equal(t, []*WALTx{newWALTx(1), newWALTx(2)}, txs)
+	require.NoError(t, wal.Close())
+}
+
+func TestWALCompaction(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, walFileName)
+	wal, _, err := OpenWAL(dir)
+	require.NoError(t, err)
+
+	for i := 0; i < 100; i++ {
+		require.NoError(t, wal.AddTx(newWALTx(i)))
+	}
+	require.NoError(t, wal.Sync())
+	info, err := os.Stat(path)
+	require.NoError(t, err)
+	fullSize := info.Size()
+
+	// removing half the txs doesn't compact the WAL
+	for i := 0; i < 50; i++ {
+		wal.RemoveTx(newWALTx(i).Tx.Key())
+	}
+	require.NoError(t, wal.Sync())
+	info, err = os.Stat(path)
+	require.NoError(t, err)
+	require.Greater(t, info.Size(), fullSize)
+
+	// removing most of them does
+	for i := 50; i < 90; i++ {
+		wal.RemoveTx(newWALTx(i).Tx.Key())
+	}
+	require.NoError(t, wal.Sync())
+	info, err = os.Stat(path)
+	require.NoError(t, err)
+	require.Less(t, info.Size(), fullSize/5)
+
+	// and keeps appending to the compacted WAL
+	require.NoError(t, wal.AddTx(newWALTx(100)))
+	require.NoError(t, wal.Close())
+
+	_, txs, err := OpenWAL(dir)
+	require.NoError(t, err)
+	require.Len(t, txs, 11)
+	for i, tx := range txs {
+		require.Equal(t, newWALTx(90+i), tx)
+	}
+}
+
+func TestWALTornRecord(t *testing.T) {
+	dir := t.TempDir()
+	wal, _, err := OpenWAL(dir)
+	require.NoError(t, err)
+	for i := 0; i < 3; i++ {
+		require.NoError(t, wal.AddTx(newWALTx(i)))
+	}
+	require.NoError(t, wal.Close())
+
+	// simulate a crash while writing a record
+	file, err := os.OpenFile(filepath.Join(dir, walFileName), os.O_WRONLY|os.O_APPEND, 0600)
+	require.NoError(t, err)
+	_, err = file.Write([]byte{0x01, 0x02, 0x03, 0x04, 0x00, 0x00, 0x00, 0x10, 0xff})
+	require.NoError(t, err)
+	require.NoError(t, file.Close())
+
+	wal, txs, err := OpenWAL(dir)
+	require.NoError(t, err)
+	require.Equal(t, []*WALTx{newWALTx(0), newWALTx(1), newWALTx(2)}, txs)
+
+	// the torn record is dropped so that new records can be read back
+	require.NoError(t, wal.AddTx(newWALTx(3)))
+	require.NoError(t, wal.Close())
+	_, txs, err = OpenWAL(dir)
+	require.NoError(t, err)
+	require.Len(t, txs, 4)
+}
+
+func TestWALTxExpired(t *testing.T) {
+	now := time.Now()
+	tx := &WALTx{Tx: types.Tx("tx"), Height: 10, Timestamp: now.Add(-time.Minute)}
+
+	testCases := []struct {
+		height       int64
+		ttlNumBlocks int64
+		ttlDuration  time.Duration
+		expired      bool
+	}{
+		{100, 0, 0, false},
+		{15, 5, 0, false},
+		{16, 5, 0, true},
+		{100, 0, 2 * time.Minute, false},
+		{100, 0, 30 * time.Second, true},
+	}
+	for i, tc := range testCases {
+		require.Equal(t, tc.expired, tx.Expired(tc.height, now, tc.ttlNumBlocks, tc.ttlDuration), i)
+	}
+
+	// txs missing metadata never expire
+	require.False(t, (&WALTx{Tx: types.Tx("tx")}).Expired(100, now, 5, time.Second))
+}
@@ -38,6 +38,7 @@ import (
 	mempoolv1 "github.com/tendermint/tendermint/mempool/v1"
 	"github.com/tendermint/tendermint/p2p"
 	"github.com/tendermint/tendermint/p2p/pex"
+	"github.com/tendermint/tendermint/p2p/trust"
 	"github.com/tendermint/tendermint/privval"
 	"github.com/tendermint/tendermint/proxy"
 	rpccore "github.com/tendermint/tendermint/rpc/core"
@@ -209,6 +210,7 @@ type Node struct {
 	transport   *p2p.MultiplexTransport
 	sw          *p2p.Switch  // p2p connections
 	addrBook    pex.AddrBook // known peers
+	trustDB     dbm.DB       // trust history of known peers, nil if not tracked
 	nodeInfo    p2p.NodeInfo
 	nodeKey     *p2p.NodeKey // our node privkey
 	isListening bool
@@ -220,6 +222,7 @@ type Node struct {
 	bcReactor         p2p.Reactor       // for fast-syncing
 	mempoolReactor    p2p.Reactor       // for gossipping transactions
 	mempool           mempl.Mempool
+	mempoolWAL        *mempl.WAL              // nil if the mempool WAL is disabled
 	stateSync         bool                    // whether the node should state sync on startup
 	stateSyncReactor  *statesync.Reactor      // for hosting and restoring state sync snapshots
 	stateSyncProvider statesync.StateProvider // provides state data for bootstrapping a node
@@ -233,6 +236,7 @@ type Node struct {
 	txIndexer         txindex.TxIndexer
 	blockIndexer      indexer.BlockIndexer
 	indexerService    *txindex.IndexerService
+	txInfoPruner      *store.TxInfoPruner // nil if tx info is pruned with blocks
 	prometheusSrv     *http.Server
 	tracer            trace.Tracer
 	pyroscopeProfiler *pyroscope.Profiler
@@ -245,7 +249,11 @@ func initDBs(config *cfg.Config, dbProvider DBProvider) (blockStore *store.Block
 	if err != nil {
 		return
 	}
-	blockStore = store.NewBlockStore(blockStoreDB)
+	var options []store.BlockStoreOption
+	if config.Storage.TxInfoRetainBlocks > 0 {
+		options = append(options, store.WithSeparateTxInfoPruning())
+	}
+	blockStore = store.NewBlockStore(blockStoreDB, options...)
 
 	stateDB, err = dbProvider(&DBContext{"state", config})
 	if err != nil {
@@ -278,47 +286,85 @@ func createAndStartIndexerService(
 	chainID string,
 	dbProvider DBProvider,
 	eventBus *types.EventBus,
+	blockStore sm.BlockStore,
+	stateStore sm.Store,
 	logger log.Logger,
 ) (*txindex.IndexerService, txindex.TxIndexer, indexer.BlockIndexer, error) {
 	var (
-		txIndexer    txindex.TxIndexer
-		blockIndexer indexer.BlockIndexer
+		txIndexer      txindex.TxIndexer
+		blockIndexer   indexer.BlockIndexer
+		indexerService *txindex.IndexerService
 	)
 
-	switch config.TxIndex.Indexer {
+	if indexers := config.TxIndex.Indexers(); len(indexers) > 1 {
+		// index into several sinks, the first one serving the RPC queries
+		sinks := make([]txindex.Sink, 0, len(indexers))
+		for _, name := range indexers {
+			txIdxr, blockIdxr, err := createIndexerSink(config, chainID, dbProvider, name)
+			if err != nil {
+				return nil, nil, nil, err
+			}
+			sinks = append(sinks, txindex.Sink{Name: name, TxIndexer: txIdxr, BlockIndexer: blockIdxr})
+		}
+		heights, err := dbProvider(&DBContext{"indexer", config})
+		if err != nil {
+			return nil, nil, nil, err
+		}
+		txIndexer, blockIndexer = sinks[0].TxIndexer, sinks[0].BlockIndexer
+		fanOut := txindex.NewFanOut(sinks, heights, blockStore, stateStore)
+		indexerService = txindex.NewFanOutIndexerService(fanOut, eventBus)
+	} else {
+		var err error
+		txIndexer, blockIndexer, err = createIndexerSink(config, chainID, dbProvider, config.TxIndex.Indexer)
+		if err != nil {
+			return nil, nil, nil, err
+		}
+		indexerService = txindex.NewIndexerService(txIndexer, blockIndexer, eventBus, false)
+	}
+	indexerService.SetLogger(logger.With("module", "txindex"))
+
+	if err := indexerService.Start(); err != nil {
+		return nil, nil, nil, err
+	}
+
+	return indexerService, txIndexer, blockIndexer, nil
+}
+
+// createIndexerSink returns the tx and block indexers of the named indexer.
+func createIndexerSink(
+	config *cfg.Config,
+	chainID string,
+	dbProvider DBProvider,
+	name string,
+) (txindex.TxIndexer, indexer.BlockIndexer, error) {
+	filter, err := indexer.NewEventFilter(config.TxIndex.EventPatterns(name))
+	if err != nil {
+		return nil, nil, fmt.Errorf("creating %s indexer event filter: %w", name, err)
+	}
+
+	switch name {
 	case "kv":
 		store, err := dbProvider(&DBContext{"tx_index", config})
 		if err != nil {
-			return nil, nil, nil, err
+			return nil, nil, err
 		}
 
-		txIndexer = kv.NewTxIndex(store)
-		blockIndexer = blockidxkv.New(dbm.NewPrefixDB(store, []byte("block_events")))
+		return kv.NewTxIndex(store, kv.WithEventFilter(filter)),
+			blockidxkv.New(dbm.NewPrefixDB(store, []byte("block_events")), blockidxkv.WithEventFilter(filter)), nil
 
 	case "psql":
 		if config.TxIndex.PsqlConn == "" {
-			return nil, nil, nil, errors.New(`no psql-conn is set for the "psql" indexer`)
+			return nil, nil, errors.New(`no psql-conn is set for the "psql" indexer`)
 		}
-		es, err := psql.NewEventSink(config.TxIndex.PsqlConn, chainID)
+		es, err := psql.NewEventSink(config.TxIndex.PsqlConn, chainID, psql.WithEventFilter(filter))
 		if err != nil {
-			return nil, nil, nil, fmt.Errorf("creating psql indexer: %w", err)
+			return nil, nil, fmt.Errorf("creating psql indexer: %w", err)
 		}
-		txIndexer = es.TxIndexer()
-		blockIndexer = es.BlockIndexer()
+		return es.TxIndexer(), es.BlockIndexer(), nil
 
 	default:
-		txIndexer = &null.TxIndex{}
-		blockIndexer = &blockidxnull.BlockerIndexer{}
-	}
-
-	indexerService := txindex.NewIndexerService(txIndexer, blockIndexer, eventBus, false)
-	indexerService.SetLogger(logger.With("module", "txindex"))
-
-	if err := indexerService.Start(); err != nil {
-		return nil, nil, nil, err
+		return &null.TxIndex{}, &blockidxnull.BlockerIndexer{}, nil
 	}
-
-	return indexerService, txIndexer, blockIndexer, nil
 }
 
 func doHandshake(
@@ -377,28 +423,38 @@ func createMempoolAndMempoolReactor(
 	proxyApp proxy.AppConns,
 	state sm.State,
 	memplMetrics *mempl.Metrics,
+	mempoolWAL *mempl.WAL,
 	logger log.Logger,
 	traceClient trace.Tracer,
 ) (mempl.Mempool, p2p.Reactor) {
 	switch config.Mempool.Version {
 	case cfg.MempoolV2:
+		options := []mempoolv2.TxPoolOption{
+			mempoolv2.WithMetrics(memplMetrics),
+			mempoolv2.WithPreCheck(sm.TxPreCheck(state)),
+			mempoolv2.WithPostCheck(sm.TxPostCheck(state)),
+		}
+		if mempoolWAL != nil {
+			options = append(options, mempoolv2.WithWAL(mempoolWAL))
+		}
 		mp := mempoolv2.NewTxPool(
 			logger,
 			config.Mempool,
 			proxyApp.Mempool(),
 			state.LastBlockHeight,
-			mempoolv2.WithMetrics(memplMetrics),
-			mempoolv2.WithPreCheck(sm.TxPreCheck(state)),
-			mempoolv2.WithPostCheck(sm.TxPostCheck(state)),
+			options...,
 		)
 
 		reactor, err := mempoolv2.NewReactor(
 			mp,
 			&mempoolv2.ReactorOptions{
-				ListenOnly:     !config.Mempool.Broadcast,
-				MaxTxSize:      config.Mempool.MaxTxBytes,
-				TraceClient:    traceClient,
-				MaxGossipDelay: config.Mempool.MaxGossipDelay,
+				ListenOnly:                    !config.Mempool.Broadcast,
+				MaxTxSize:                     config.Mempool.MaxTxBytes,
+				TraceClient:                   traceClient,
+				MaxGossipDelay:                config.Mempool.MaxGossipDelay,
+				PullOnly:                      config.Mempool.PullOnly,
+				MaxOutboundBytesPerSecond:     config.Mempool.MaxOutboundBytesPerSecond,
+				MaxPeerOutboundBytesPerSecond: config.Mempool.MaxPeerOutboundBytesPerSecond,
 			},
 		)
 		if err != nil {
@@ -412,15 +468,21 @@ func createMempoolAndMempoolReactor(
 
 		return mp, reactor
 	case cfg.MempoolV1:
+		options := []mempoolv1.TxMempoolOption{
+			mempoolv1.WithMetrics(memplMetrics),
+			mempoolv1.WithPreCheck(sm.TxPreCheck(state)),
+			mempoolv1.WithPostCheck(sm.TxPostCheck(state)),
+			mempoolv1.WithTraceClient(traceClient),
+		}
+		if mempoolWAL != nil {
+			options = append(options, mempoolv1.WithWAL(mempoolWAL))
+		}
 		mp := mempoolv1.NewTxMempool(
 			logger,
 			config.Mempool,
 			proxyApp.Mempool(),
 			state.LastBlockHeight,
-			mempoolv1.WithMetrics(memplMetrics),
-			mempoolv1.WithPreCheck(sm.TxPreCheck(state)),
-			mempoolv1.WithPostCheck(sm.TxPostCheck(state)),
-			mempoolv1.WithTraceClient(traceClient),
+			options...,
 		)
 
 		reactor := mempoolv1.NewReactor(
@@ -436,13 +498,19 @@ func createMempoolAndMempoolReactor(
 		return mp, reactor
 
 	case cfg.MempoolV0:
+		options := []mempoolv0.CListMempoolOption{
+			mempoolv0.WithMetrics(memplMetrics),
+			mempoolv0.WithPreCheck(sm.TxPreCheck(state)),
+			mempoolv0.WithPostCheck(sm.TxPostCheck(state)),
+		}
+		if mempoolWAL != nil {
+			options = append(options, mempoolv0.WithWAL(mempoolWAL))
+		}
 		mp := mempoolv0.NewCListMempool(
 			config.Mempool,
 			proxyApp.Mempool(),
 			state.LastBlockHeight,
-			mempoolv0.WithMetrics(memplMetrics),
-			mempoolv0.WithPreCheck(sm.TxPreCheck(state)),
-			mempoolv0.WithPostCheck(sm.TxPostCheck(state)),
+			options...,
 		)
 
 		mp.SetLogger(logger)
@@ -463,6 +531,90 @@ func createMempoolAndMempoolReactor(
 	}
 }
 
+// mempoolTxPublisher returns a listener publishing the changes to the mempool
+// to the event bus, for the RPC subscriptions to the mempool.
+func mempoolTxPublisher(eventBus *types.EventBus, logger log.Logger) mempl.TxListener {
+	return func(delta mempl.TxDelta) {
+		err := eventBus.PublishEventMempoolTx(types.EventDataMempoolTx{
+			Version:   delta.Version,
+			Type:      string(delta.Type),
+			Reason:    string(delta.Reason),
+			Hash:      delta.Tx.Key[:],
+			Size:      delta.Tx.Size,
+			Priority:  delta.Tx.Priority,
+			GasWanted: delta.Tx.GasWanted,
+			Sender:    delta.Tx.Sender,
+			Height:    delta.Tx.Height,
+			Timestamp: delta.Tx.Timestamp,
+		})
+		if err != nil {
+			logger.Error("failed to publish mempool tx event", "err", err)
+		}
+	}
+}
+
+// replayMempoolWAL checks the txs of the mempool WAL back into the mempool,
+// but for the ones that expired while the node was down.
+func replayMempoolWAL(
+	config *cfg.Config,
+	mempool mempl.Mempool,
+	wal *mempl.WAL,
+	txs []*mempl.WALTx,
+	height int64,
+	logger log.Logger,
+) error {
+	replayed := make([]*mempl.WALTx, 0, len(txs))
+	now := time.Now()
+	for _, tx := range txs {
+		// the v0 mempool has no TTL
+		if config.Mempool.Version != cfg.MempoolV0 &&
+			tx.Expired(height, now, config.Mempool.TTLNumBlocks, config.Mempool.TTLDuration) {
+			wal.RemoveTx(tx.Tx.Key())
+			continue
+		}
+		replayed = append(replayed, tx)
+	}
+
+	if err := mempl.ReplayWAL(mempool, wal, replayed); err != nil {
+		return err
+	}
+	logger.Info("Replayed mempool WAL", "txs", len(replayed), "expired", len(txs)-len(replayed),
+		"admitted", mempool.Size())
+	return nil
+}
+
+// importMempoolExport checks the txs exported by the unsafe_export_mempool
+// RPC endpoint into the mempool, but for the ones that expired while the node
+// was down, and removes the export.
+func importMempoolExport(
+	config *cfg.Config,
+	mempool mempl.Mempool,
+	height int64,
+	logger log.Logger,
+) error {
+	var (
+		now     = time.Now()
+		expired int
+	)
+	exported, err := mempl.ImportTxs(mempool, config.Mempool.ExportDir(), func(tx *mempl.WALTx) bool {
+		// the v0 mempool has no TTL
+		if config.Mempool.Version != cfg.MempoolV0 &&
+			tx.Expired(height, now, config.Mempool.TTLNumBlocks, config.Mempool.TTLDuration) {
+			expired++
+			return false
+		}
+		return true
+	})
+	if err != nil {
+		return err
+	}
+	if exported > 0 {
+		logger.Info("Imported mempool export", "txs", exported, "expired", expired,
+			"admitted", mempool.Size(), "version", config.Mempool.Version)
+	}
+	return nil
+}
+
 func createEvidenceReactor(config *cfg.Config, dbProvider DBProvider,
 	stateDB dbm.DB, blockStore *store.BlockStore, logger log.Logger,
 ) (*evidence.Reactor, *evidence.Pool, error) {
@@ -488,10 +640,12 @@ func createBlockchainReactor(config *cfg.Config,
 	blockStore *store.BlockStore,
 	fastSync bool,
 	logger log.Logger,
+	tracer trace.Tracer,
 ) (bcReactor p2p.Reactor, err error) {
 	switch config.FastSync.Version {
 	case "v0":
-		bcReactor = bcv0.NewBlockchainReactor(state.Copy(), blockExec, blockStore, fastSync)
+		bcReactor = bcv0.NewBlockchainReactor(state.Copy(), blockExec, blockStore, fastSync,
+			bcv0.WithTraceClient(tracer))
 	case "v1":
 		bcReactor = bcv1.NewBlockchainReactor(state.Copy(), blockExec, blockStore, fastSync)
 	case "v2":
@@ -509,6 +663,7 @@ func createConsensusReactor(config *cfg.Config,
 	blockExec *sm.BlockExecutor,
 	blockStore sm.BlockStore,
 	mempool mempl.Mempool,
+	mempoolReactor p2p.Reactor,
 	evidencePool *evidence.Pool,
 	privValidator types.PrivValidator,
 	csMetrics *cs.Metrics,
@@ -531,12 +686,17 @@ func createConsensusReactor(config *cfg.Config,
 	if privValidator != nil {
 		consensusState.SetPrivValidator(privValidator)
 	}
-	consensusReactor := cs.NewReactor(
-		consensusState,
-		waitSync,
+	reactorOptions := []cs.ReactorOption{
 		cs.ReactorMetrics(csMetrics),
 		cs.ReactorTracing(traceClient),
-	)
+	}
+	if config.Consensus.CompactBlocks {
+		// mempool reactors tracking the txs of their peers, such as the CAT
+		// one, spare sending peers the txs they already have
+		peerTxs, _ := mempoolReactor.(cs.PeerTxTracker)
+		reactorOptions = append(reactorOptions, cs.ReactorCompactBlocks(mempool, peerTxs))
+	}
+	consensusReactor := cs.NewReactor(consensusState, waitSync, reactorOptions...)
 	consensusReactor.SetLogger(consensusLogger)
 	// services which will be publishing and/or subscribing for messages (events)
 	// consensusReactor will set it on consensusState and blockExecutor
@@ -625,6 +785,7 @@ func createSwitch(config *cfg.Config,
 	evidenceReactor *evidence.Reactor,
 	nodeInfo p2p.NodeInfo,
 	nodeKey *p2p.NodeKey,
+	trustStore *trust.MetricStore,
 	p2pLogger log.Logger,
 	tracer trace.Tracer,
 ) *p2p.Switch {
@@ -634,6 +795,7 @@ func createSwitch(config *cfg.Config,
 		p2p.WithMetrics(p2pMetrics),
 		p2p.SwitchPeerFilters(peerFilters...),
 		p2p.WithTracer(tracer),
+		p2p.WithTrustMetricStore(trustStore),
 	)
 	sw.SetLogger(p2pLogger)
 	sw.AddReactor("MEMPOOL", mempoolReactor)
@@ -672,6 +834,9 @@ func createAddrBookAndSetOnSwitch(config *cfg.Config, sw *p2p.Switch,
 	}
 
 	sw.SetAddrBook(addrBook)
+	if config.P2P.TrustMetrics {
+		addrBook.SetTrustScorer(sw)
+	}
 
 	return addrBook, nil
 }
@@ -816,7 +981,7 @@ func NewNodeWithContext(ctx context.Context,
 	}
 
 	indexerService, txIndexer, blockIndexer, err := createAndStartIndexerService(config,
-		genDoc.ChainID, dbProvider, eventBus, logger)
+		genDoc.ChainID, dbProvider, eventBus, blockStore, stateStore, logger)
 	if err != nil {
 		return nil, err
 	}
@@ -892,8 +1057,38 @@ func NewNodeWithContext(ctx context.Context,
 		return nil, err
 	}
 
+	// Open the mempool WAL, if enabled, to replay its txs into the mempool.
+	var (
+		mempoolWAL *mempl.WAL
+		walTxs     []*mempl.WALTx
+	)
+	if config.Mempool.WalEnabled() {
+		mempoolWAL, walTxs, err = mempl.OpenWAL(config.Mempool.WalDir())
+		if err != nil {
+			return nil, fmt.Errorf("failed to open mempool WAL: %w", err)
+		}
+	}
+
 	// Make MempoolReactor
-	mempool, mempoolReactor := createMempoolAndMempoolReactor(config, proxyApp, state, memplMetrics, logger, tracer)
+	mempool, mempoolReactor := createMempoolAndMempoolReactor(config, proxyApp, state, memplMetrics, mempoolWAL,
+		logger, tracer)
+	mempool.SetTxListener(mempoolTxPublisher(eventBus, logger.With("module", "mempool")))
+
+	// Replay the mempool WAL before joining consensus.
+	if mempoolWAL != nil {
+		err = replayMempoolWAL(config, mempool, mempoolWAL, walTxs, state.LastBlockHeight,
+			logger.With("module", "mempool"))
+		if err != nil {
+			return nil, fmt.Errorf("failed to replay mempool WAL: %w", err)
+		}
+	}
+
+	// Import the txs exported from the mempool before the restart, e.g. to
+	// migrate them to another mempool version.
+	err = importMempoolExport(config, mempool, state.LastBlockHeight, logger.With("module", "mempool"))
+	if err != nil {
+		return nil, fmt.Errorf("failed to import mempool export: %w", err)
+	}
 
 	// Make Evidence Reactor
 	evidenceReactor, evidencePool, err := createEvidenceReactor(config, dbProvider, stateDB, blockStore, logger)
@@ -913,7 +1108,7 @@ func NewNodeWithContext(ctx context.Context,
 	)
 
 	// Make BlockchainReactor. Don't start fast sync if we're doing a state sync first.
-	bcReactor, err := createBlockchainReactor(config, state, blockExec, blockStore, fastSync && !stateSync, logger)
+	bcReactor, err := createBlockchainReactor(config, state, blockExec, blockStore, fastSync && !stateSync, logger, tracer)
 	if err != nil {
 		return nil, fmt.Errorf("could not create blockchain reactor: %w", err)
 	}
@@ -926,7 +1121,7 @@ func NewNodeWithContext(ctx context.Context,
 		csMetrics.FastSyncing.Set(1)
 	}
 	consensusReactor, consensusState := createConsensusReactor(
-		config, state, blockExec, blockStore, mempool, evidencePool,
+		config, state, blockExec, blockStore, mempool, mempoolReactor, evidencePool,
 		privValidator, csMetrics, stateSync || fastSync, eventBus, consensusLogger, tracer,
 	)
 
@@ -940,6 +1135,7 @@ func NewNodeWithContext(ctx context.Context,
 		proxyApp.Snapshot(),
 		proxyApp.Query(),
 		config.StateSync.TempDir,
+		statesync.WithTraceClient(tracer),
 	)
 	stateSyncReactor.SetLogger(logger.With("module", "statesync"))
 
@@ -951,11 +1147,25 @@ func NewNodeWithContext(ctx context.Context,
 	// Setup Transport.
 	transport, peerFilters := createTransport(config, nodeInfo, nodeKey, proxyApp, tracer)
 
-	// Setup Switch.
+	// Setup the trust metric store, which scores peers by their history.
 	p2pLogger := logger.With("module", "p2p")
+	var (
+		trustDB    dbm.DB
+		trustStore *trust.MetricStore
+	)
+	if config.P2P.TrustMetrics {
+		trustDB, err = dbProvider(&DBContext{"trusthistory", config})
+		if err != nil {
+			return nil, err
+		}
+		trustStore = trust.NewTrustMetricStore(trustDB, trust.DefaultConfig())
+		trustStore.SetLogger(p2pLogger.With("book", "trusthistory"))
+	}
+
+	// Setup Switch.
 	sw := createSwitch(
 		config, transport, p2pMetrics, peerFilters, mempoolReactor, bcReactor,
-		stateSyncReactor, consensusReactor, evidenceReactor, nodeInfo, nodeKey, p2pLogger, tracer,
+		stateSyncReactor, consensusReactor, evidenceReactor, nodeInfo, nodeKey, trustStore, p2pLogger, tracer,
 	)
 
 	err = sw.AddPersistentPeers(splitAndTrimEmpty(config.P2P.PersistentPeers, ",", " "))
@@ -1006,6 +1216,7 @@ func NewNodeWithContext(ctx context.Context,
 		transport: transport,
 		sw:        sw,
 		addrBook:  addrBook,
+		trustDB:   trustDB,
 		nodeInfo:  nodeInfo,
 		nodeKey:   nodeKey,
 
@@ -1014,6 +1225,7 @@ func NewNodeWithContext(ctx context.Context,
 		bcReactor:        bcReactor,
 		mempoolReactor:   mempoolReactor,
 		mempool:          mempool,
+		mempoolWAL:       mempoolWAL,
 		consensusState:   consensusState,
 		consensusReactor: consensusReactor,
 		stateSyncReactor: stateSyncReactor,
@@ -1030,6 +1242,12 @@ func NewNodeWithContext(ctx context.Context,
 	}
 	node.BaseService = *service.NewBaseService(logger, "Node", node)
 
+	if config.Storage.TxInfoRetainBlocks > 0 {
+		node.txInfoPruner = store.NewTxInfoPruner(blockStore,
+			config.Storage.TxInfoRetainBlocks, config.Storage.TxInfoPruneInterval)
+		node.txInfoPruner.SetLogger(logger.With("module", "txinfo"))
+	}
+
 	for _, option := range options {
 		option(node)
 	}
@@ -1087,6 +1305,12 @@ func (n *Node) OnStart() error {
 
 	n.isListening = true
 
+	if n.txInfoPruner != nil {
+		if err := n.txInfoPruner.Start(); err != nil {
+			return err
+		}
+	}
+
 	// Start the switch (the P2P server).
 	err = n.sw.Start()
 	if err != nil {
@@ -1128,6 +1352,11 @@ func (n *Node) OnStop() {
 	if err := n.indexerService.Stop(); err != nil {
 		n.Logger.Error("Error closing indexerService", "err", err)
 	}
+	if n.txInfoPruner != nil {
+		if err := n.txInfoPruner.Stop(); err != nil {
+			n.Logger.Error("Error closing txInfoPruner", "err", err)
+		}
+	}
 
 	// now stop the reactors
 	if err := n.sw.Stop(); err != nil {
@@ -1138,6 +1367,18 @@ func (n *Node) OnStop() {
 		n.Logger.Error("Error closing transport", "err", err)
 	}
 
+	if n.trustDB != nil {
+		if err := n.trustDB.Close(); err != nil {
+			n.Logger.Error("Error closing trust history DB", "err", err)
+		}
+	}
+
+	if n.mempoolWAL != nil {
+		if err := n.mempoolWAL.Close(); err != nil {
+			n.Logger.Error("Error closing mempool WAL", "err", err)
+		}
+	}
+
 	n.isListening = false
 
 	// finally stop the listeners / external services
@@ -1224,6 +1465,8 @@ func (n *Node) ConfigureRPC() error {
 		EventBus:         n.eventBus,
 		Mempool:          n.mempool,
 
+		MempoolExportDir: n.config.Mempool.ExportDir(),
+
 		Logger: n.Logger.With("module", "rpc"),
 
 		Config: *n.config.RPC,
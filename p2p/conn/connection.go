@@ -698,6 +698,11 @@ type ChannelStatus struct {
 	SendQueueSize     int
 	Priority          int
 	RecentlySent      int64
+	// SentBytes and RecvBytes are the total number of bytes sent and
+	// received on the channel, including the packet overhead for the sent
+	// bytes.
+	SentBytes int64
+	RecvBytes int64
 }
 
 func (c *MConnection) Status() ConnectionStatus {
@@ -714,6 +719,8 @@ func (c *MConnection) Status() ConnectionStatus {
 			SendQueueSize:     int(atomic.LoadInt32(&channel.sendQueueSize)),
 			Priority:          channel.desc.Priority,
 			RecentlySent:      atomic.LoadInt64(&channel.recentlySent),
+			SentBytes:         atomic.LoadInt64(&channel.sentBytes),
+			RecvBytes:         atomic.LoadInt64(&channel.recvBytes),
 		}
 	}
 	return status
@@ -754,6 +761,8 @@ type Channel struct {
 	recving       []byte
 	sending       []byte
 	recentlySent  int64 // exponential moving average
+	sentBytes     int64 // atomic.
+	recvBytes     int64 // atomic.
 
 	maxPacketMsgPayloadSize int
 
@@ -851,6 +860,7 @@ func (ch *Channel) writePacketMsgTo(w io.Writer) (n int, err error) {
 	packet := ch.nextPacketMsg()
 	n, err = protoio.NewDelimitedWriter(w).WriteMsg(mustWrapPacket(&packet))
 	atomic.AddInt64(&ch.recentlySent, int64(n))
+	atomic.AddInt64(&ch.sentBytes, int64(n))
 	return
 }
 
@@ -864,6 +874,7 @@ func (ch *Channel) recvPacketMsg(packet tmp2p.PacketMsg) ([]byte, error) {
 		return nil, fmt.Errorf("received message exceeds available capacity: %v < %v", recvCap, recvReceived)
 	}
 	ch.recving = append(ch.recving, packet.Data...)
+	atomic.AddInt64(&ch.recvBytes, int64(len(packet.Data)))
 	if packet.EOF {
 		msgBytes := ch.recving
 
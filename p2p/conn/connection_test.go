@@ -163,6 +163,42 @@ func TestMConnectionStatus(t *testing.T) {
 	assert.Zero(t, status.Channels[0].SendQueueSize)
 }
 
+func TestMConnectionStatusChannelBytes(t *testing.T) {
+	server, client := NetPipe()
+	defer server.Close()
+	defer client.Close()
+
+	receivedCh := make(chan []byte)
+	onReceive := func(chID byte, msgBytes []byte) {
+		receivedCh <- msgBytes
+	}
+	onError := func(r interface{}) {}
+	mconn1 := createMConnectionWithCallbacks(client, onReceive, onError)
+	err := mconn1.Start()
+	require.Nil(t, err)
+	defer mconn1.Stop() //nolint:errcheck // ignore for tests
+
+	mconn2 := createTestMConnection(server)
+	err = mconn2.Start()
+	require.Nil(t, err)
+	defer mconn2.Stop() //nolint:errcheck // ignore for tests
+
+	msg := []byte("Cyclops")
+	assert.True(t, mconn2.Send(0x01, msg))
+
+	select {
+	case <-receivedCh:
+	case <-time.After(500 * time.Millisecond):
+		t.Fatalf("Did not receive %s message in 500ms", msg)
+	}
+
+	// received bytes only count the payload, sent bytes include the packet
+	// overhead
+	assert.EqualValues(t, len(msg), mconn1.Status().Channels[0].RecvBytes)
+	assert.Greater(t, mconn2.Status().Channels[0].SentBytes, int64(len(msg)))
+	assert.Zero(t, mconn1.Status().Channels[0].SentBytes)
+}
+
 func TestMConnectionPongTimeoutResultsInError(t *testing.T) {
 	server, client := net.Pipe()
 	defer server.Close()
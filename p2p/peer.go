@@ -513,6 +513,9 @@ func PeerMetrics(metrics *Metrics) PeerOption {
 }
 
 func (p *peer) metricsReporter() {
+	// the totals of the bytes sent and received on each channel at the
+	// previous tick
+	var sent, recv map[byte]int64
 	for {
 		select {
 		case <-p.metricsTicker.C:
@@ -526,6 +529,18 @@ func (p *peer) metricsReporter() {
 
 			p.metrics.PeerPendingSendBytes.With("peer_id", string(p.ID())).Set(sendQueueSize)
 			schema.WritePendingBytes(p.traceClient, string(p.ID()), queues)
+
+			if p.traceClient.IsCollecting(schema.ChannelThroughputTable) {
+				if sent == nil {
+					sent, recv = make(map[byte]int64), make(map[byte]int64)
+				}
+				for _, chStatus := range status.Channels {
+					schema.WriteChannelThroughput(p.traceClient, string(p.ID()), chStatus.ID,
+						chStatus.SentBytes-sent[chStatus.ID], chStatus.RecvBytes-recv[chStatus.ID],
+						chStatus.SendQueueSize, chStatus.SendQueueCapacity)
+					sent[chStatus.ID], recv[chStatus.ID] = chStatus.SentBytes, chStatus.RecvBytes
+				}
+			}
 		case <-p.Quit():
 			return
 		}
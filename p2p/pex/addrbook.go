@@ -28,6 +28,13 @@ import (
 const (
 	bucketTypeNew = 0x01
 	bucketTypeOld = 0x02
+
+	// number of addresses of a bucket PickAddress picks the one of the highest
+	// trust score from
+	trustCandidates = 3
+	// trust score of the peers of unknown trust, below the one of the peers
+	// which behaved well so far
+	neutralTrustScore = 50
 )
 
 // AddrBook is an address book used for tracking peers
@@ -59,6 +66,8 @@ type AddrBook interface {
 
 	// Pick an address to dial
 	PickAddress(biasTowardsNewAddrs int) *p2p.NetAddress
+	// Prefer the addresses of the peers of higher trust scores when picking
+	SetTrustScorer(TrustScorer)
 
 	// Mark address
 	MarkGood(p2p.ID)
@@ -83,6 +92,12 @@ type AddrBook interface {
 
 var _ AddrBook = (*addrBook)(nil)
 
+// TrustScorer reports the trust scores of the peers, between 0 and 100, and
+// whether it tracks the trust of a peer. It is implemented by p2p.Switch.
+type TrustScorer interface {
+	PeerTrustScore(id p2p.ID) (int, bool)
+}
+
 // addrBook - concurrency safe peer address manager.
 // Implements AddrBook.
 type addrBook struct {
@@ -99,6 +114,7 @@ type addrBook struct {
 	bucketsNew []map[string]*knownAddress
 	nOld       int
 	nNew       int
+	scorer     TrustScorer // nil if the trust scores are ignored
 
 	// immutable after creation
 	filePath          string
@@ -306,6 +322,30 @@ func (a *addrBook) PickAddress(biasTowardsNewAddrs int) *p2p.NetAddress {
 			bucket = a.bucketsNew[a.rand.Intn(len(a.bucketsNew))]
 		}
 	}
+	if a.scorer == nil {
+		return a.pickFromBucket(bucket)
+	}
+	// pick the address of the highest trust score of a few random ones, the
+	// peers we don't know the trust of being neither trusted nor distrusted
+	var (
+		picked    *p2p.NetAddress
+		bestScore = -1
+	)
+	for i := 0; i < cmtmath.MinInt(trustCandidates, len(bucket)); i++ {
+		addr := a.pickFromBucket(bucket)
+		score, ok := a.scorer.PeerTrustScore(addr.ID)
+		if !ok {
+			score = neutralTrustScore
+		}
+		if score > bestScore {
+			picked, bestScore = addr, score
+		}
+	}
+	return picked
+}
+
+// pickFromBucket returns a random address of the non-empty bucket.
+func (a *addrBook) pickFromBucket(bucket map[string]*knownAddress) *p2p.NetAddress {
 	// pick a random index and loop over the map to return that index
 	randIndex := a.rand.Intn(len(bucket))
 	for _, ka := range bucket {
@@ -317,6 +357,15 @@ func (a *addrBook) PickAddress(biasTowardsNewAddrs int) *p2p.NetAddress {
 	return nil
 }
 
+// SetTrustScorer implements AddrBook - PickAddress then prefers the addresses
+// of the peers of higher trust scores.
+func (a *addrBook) SetTrustScorer(scorer TrustScorer) {
+	a.mtx.Lock()
+	defer a.mtx.Unlock()
+
+	a.scorer = scorer
+}
+
 // MarkGood implements AddrBook - it marks the peer as good and
 // moves it into an "old" bucket.
 func (a *addrBook) MarkGood(id p2p.ID) {
@@ -57,6 +57,48 @@ func TestAddrBookPickAddress(t *testing.T) {
 	assert.Nil(t, addr, "did not expected an address")
 }
 
+type testTrustScorer map[p2p.ID]int
+
+func (s testTrustScorer) PeerTrustScore(id p2p.ID) (int, bool) {
+	score, ok := s[id]
+	return score, ok
+}
+
+func TestAddrBookPickAddressTrust(t *testing.T) {
+	fname := createTempFileName("addrbook_test")
+	defer deleteTempFile(fname)
+
+	book := NewAddrBook(fname, true)
+	book.SetLogger(log.TestingLogger())
+	randAddrs := randNetAddressPairs(t, 2)
+	for _, addrSrc := range randAddrs {
+		require.NoError(t, book.AddAddress(addrSrc.addr, addrSrc.src))
+	}
+
+	// gather the addresses in a single bucket
+	ab := book.(*addrBook)
+	bucket := make(map[string]*knownAddress)
+	for _, ka := range ab.addrLookup {
+		bucket[ka.Addr.String()] = ka
+	}
+	for i := range ab.bucketsNew {
+		ab.bucketsNew[i] = make(map[string]*knownAddress)
+	}
+	ab.bucketsNew[0] = bucket
+
+	trusted, distrusted := randAddrs[0].addr, randAddrs[1].addr
+	book.SetTrustScorer(testTrustScorer{distrusted.ID: 10})
+
+	// the address of unknown trust is picked unless both candidates are the
+	// distrusted one
+	picks := make(map[p2p.ID]int)
+	for i := 0; i < 200; i++ {
+		picks[book.PickAddress(100).ID]++
+	}
+	assert.Greater(t, picks[trusted.ID], 120)
+	assert.Equal(t, 200, picks[trusted.ID]+picks[distrusted.ID])
+}
+
 func TestAddrBookSaveLoad(t *testing.T) {
 	fname := createTempFileName("addrbook_test")
 	defer deleteTempFile(fname)
@@ -12,6 +12,7 @@ import (
 	"github.com/tendermint/tendermint/libs/rand"
 	"github.com/tendermint/tendermint/libs/service"
 	"github.com/tendermint/tendermint/p2p/conn"
+	"github.com/tendermint/tendermint/p2p/trust"
 	"github.com/tendermint/tendermint/pkg/trace"
 	"github.com/tendermint/tendermint/pkg/trace/schema"
 )
@@ -30,6 +31,10 @@ const (
 	// ie. 3**10 = 16hrs
 	reconnectBackOffAttempts    = 10
 	reconnectBackOffBaseSeconds = 3
+
+	// the trust score of the peers with no trust metric, below the one of the
+	// peers which behaved well so far
+	neutralTrustScore = 50
 )
 
 // MConnConfig returns an MConnConfig with fields updated
@@ -93,6 +98,9 @@ type Switch struct {
 
 	rng *rand.Rand // seed for randomizing dial times and orders
 
+	// trust metrics of the peers, nil if not tracked
+	trustStore *trust.MetricStore
+
 	metrics     *Metrics
 	mlc         *metricsLabelCache
 	traceClient trace.Tracer
@@ -163,6 +171,12 @@ func WithTracer(tracer trace.Tracer) SwitchOption {
 	return func(sw *Switch) { sw.traceClient = tracer }
 }
 
+// WithTrustMetricStore sets the store tracking the trust metrics of the peers,
+// which is started and stopped with the switch.
+func WithTrustMetricStore(store *trust.MetricStore) SwitchOption {
+	return func(sw *Switch) { sw.trustStore = store }
+}
+
 //---------------------------------------------------------------------
 // Switch setup
 
@@ -237,6 +251,12 @@ func (sw *Switch) SetNodeKey(nodeKey *NodeKey) {
 
 // OnStart implements BaseService. It starts all the reactors and peers.
 func (sw *Switch) OnStart() error {
+	if sw.trustStore != nil {
+		if err := sw.trustStore.Start(); err != nil {
+			return fmt.Errorf("failed to start trust metric store: %w", err)
+		}
+	}
+
 	// Start reactors
 	for _, reactor := range sw.reactors {
 		err := reactor.Start()
@@ -265,6 +285,12 @@ func (sw *Switch) OnStop() {
 			sw.Logger.Error("error while stopped reactor", "reactor", reactor, "error", err)
 		}
 	}
+
+	if sw.trustStore != nil {
+		if err := sw.trustStore.Stop(); err != nil {
+			sw.Logger.Error("error while stopping trust metric store", "error", err)
+		}
+	}
 }
 
 //---------------------------------------------------------------------
@@ -369,15 +395,18 @@ func (sw *Switch) Peers() IPeerSet {
 	return sw.peers
 }
 
-// StopPeerForError disconnects from a peer due to external error.
+// StopPeerForError disconnects from a peer due to external error, which
+// lowers its trust score.
 // If the peer is persistent, it will attempt to reconnect.
-// TODO: make record depending on reason.
 func (sw *Switch) StopPeerForError(peer Peer, reason interface{}) {
 	if !peer.IsRunning() {
 		return
 	}
 
 	sw.Logger.Error("Stopping peer for error", "peer", peer, "err", reason)
+	if sw.trustStore != nil {
+		sw.trustStore.GetPeerTrustMetric(string(peer.ID())).BadEvents(1)
+	}
 	sw.stopAndRemovePeer(peer, reason)
 
 	if peer.IsPersistent() {
@@ -423,6 +452,9 @@ func (sw *Switch) StopPeerGracefully(peer Peer) {
 
 func (sw *Switch) stopAndRemovePeer(peer Peer, reason interface{}) {
 	sw.transport.Cleanup(peer)
+	if sw.trustStore != nil {
+		sw.trustStore.PeerDisconnected(string(peer.ID()))
+	}
 	schema.WritePeerUpdate(sw.traceClient, string(peer.ID()), schema.PeerDisconnect, fmt.Sprintf("%v", reason))
 	if err := peer.Stop(); err != nil {
 		sw.Logger.Error("error while stopping peer", "error", err) // TODO: should return error to be handled accordingly
@@ -508,11 +540,70 @@ func (sw *Switch) SetAddrBook(addrBook AddrBook) {
 }
 
 // MarkPeerAsGood marks the given peer as good when it did something useful
-// like contributed to consensus.
+// like contributed to consensus, which raises its trust score.
 func (sw *Switch) MarkPeerAsGood(peer Peer) {
 	if sw.addrBook != nil {
 		sw.addrBook.MarkGood(peer.ID())
 	}
+	if sw.trustStore != nil {
+		sw.trustStore.GetPeerTrustMetric(string(peer.ID())).GoodEvents(1)
+	}
+}
+
+// MarkPeerAsUseful raises the trust score of the given peer when it sent us
+// something useful, like a block part we were missing, without marking it as
+// good in the address book.
+func (sw *Switch) MarkPeerAsUseful(peer Peer) {
+	if sw.trustStore != nil {
+		sw.trustStore.GetPeerTrustMetric(string(peer.ID())).GoodEvents(1)
+	}
+}
+
+// MarkPeerAsBad lowers the trust score of the given peer when it misbehaved
+// in a way not worth disconnecting from it, like responding slowly.
+func (sw *Switch) MarkPeerAsBad(peer Peer, reason interface{}) {
+	sw.Logger.Debug("Marking peer as bad", "peer", peer, "reason", reason)
+	if sw.trustStore != nil {
+		sw.trustStore.GetPeerTrustMetric(string(peer.ID())).BadEvents(1)
+	}
+}
+
+// PeerTrustScore returns the trust score of the peer with the given ID,
+// between 0 and 100, and whether the trust of the peer is tracked.
+func (sw *Switch) PeerTrustScore(id ID) (int, bool) {
+	if sw.trustStore == nil {
+		return 0, false
+	}
+	return sw.trustStore.PeerTrustScore(string(id))
+}
+
+// inboundPeerToEvict returns the inbound peer of the lowest trust score, which
+// is neither persistent nor unconditional, if its score is lower than the one
+// of the peer with the given ID, or nil. A peer we have no trust history of
+// never evicts another one, as new node IDs come for free.
+func (sw *Switch) inboundPeerToEvict(id ID) Peer {
+	score, ok := sw.PeerTrustScore(id)
+	if !ok {
+		return nil
+	}
+
+	var (
+		evicted     Peer
+		lowestScore = score
+	)
+	for _, peer := range sw.peers.List() {
+		if peer.IsOutbound() || peer.IsPersistent() || sw.IsPeerUnconditional(peer.ID()) {
+			continue
+		}
+		peerScore, ok := sw.PeerTrustScore(peer.ID())
+		if !ok {
+			peerScore = neutralTrustScore
+		}
+		if peerScore < lowestScore {
+			evicted, lowestScore = peer, peerScore
+		}
+	}
+	return evicted
 }
 
 //---------------------------------------------------------------------
@@ -746,10 +837,16 @@ func (sw *Switch) acceptRoutine() {
 			break
 		}
 
+		var evicted Peer
 		if !sw.IsPeerUnconditional(p.NodeInfo().ID()) {
 			// Ignore connection if we already have enough peers.
+			// Unless an inbound peer of lower trust score makes room for it,
+			// once it is added.
 			_, in, _ := sw.NumPeers()
 			if in >= sw.config.MaxNumInboundPeers {
+				evicted = sw.inboundPeerToEvict(p.NodeInfo().ID())
+			}
+			if in >= sw.config.MaxNumInboundPeers && evicted == nil {
 				sw.Logger.Info(
 					"Ignoring inbound connection: already have enough inbound peers",
 					"address", p.SocketAddr(),
@@ -774,6 +871,14 @@ func (sw *Switch) acceptRoutine() {
 				"err", err,
 				"id", p.ID(),
 			)
+
+			continue
+		}
+
+		if evicted != nil && evicted.IsRunning() && sw.peers.Has(p.ID()) {
+			sw.Logger.Info("Evicting inbound peer for a peer of higher trust score",
+				"peer", evicted, "newPeer", p.ID())
+			sw.stopAndRemovePeer(evicted, fmt.Errorf("evicted for peer %v of higher trust score", p.ID()))
 		}
 	}
 }
@@ -910,6 +1015,10 @@ func (sw *Switch) addPeer(p Peer) error {
 	}
 	sw.metrics.Peers.Add(float64(1))
 	schema.WritePeerUpdate(sw.traceClient, string(p.ID()), schema.PeerJoin, "")
+	if sw.trustStore != nil {
+		// track the trust of the peer, resuming its history if any
+		sw.trustStore.GetPeerTrustMetric(string(p.ID()))
+	}
 
 	// Start all the reactor protocols on the peer.
 	for _, reactor := range sw.reactors {
@@ -14,6 +14,7 @@ import (
 	"testing"
 	"time"
 
+	dbm "github.com/cometbft/cometbft-db"
 	"github.com/gogo/protobuf/proto"
 	"github.com/prometheus/client_golang/prometheus/promhttp"
 	"github.com/stretchr/testify/assert"
@@ -24,6 +25,7 @@ import (
 	"github.com/tendermint/tendermint/libs/log"
 	cmtsync "github.com/tendermint/tendermint/libs/sync"
 	"github.com/tendermint/tendermint/p2p/conn"
+	"github.com/tendermint/tendermint/p2p/trust"
 	p2pproto "github.com/tendermint/tendermint/proto/tendermint/p2p"
 )
 
@@ -711,6 +713,80 @@ func TestSwitchAcceptRoutine(t *testing.T) {
 	}
 }
 
+func TestSwitchEvictsInboundPeerOfLowerTrust(t *testing.T) {
+	inboundCfg := *cfg
+	inboundCfg.MaxNumInboundPeers = 2
+
+	store := trust.NewTrustMetricStore(dbm.NewMemDB(), trust.DefaultConfig())
+	sw := MakeSwitch(&inboundCfg, 1, "testing", "123.123.123", initSwitchFunc, WithTrustMetricStore(store))
+	err := sw.Start()
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		err := sw.Stop()
+		require.NoError(t, err)
+	})
+
+	newPeer := func() *remotePeer {
+		rp := &remotePeer{PrivKey: ed25519.GenPrivKey(), Config: &inboundCfg}
+		rp.Start()
+		t.Cleanup(rp.Stop)
+		return rp
+	}
+	dial := func(rp *remotePeer) {
+		c, err := rp.Dial(sw.NetAddress())
+		require.NoError(t, err)
+		// spawn a reading routine to prevent connection from closing
+		go func(c net.Conn) {
+			for {
+				one := make([]byte, 1)
+				_, err := c.Read(one)
+				if err != nil {
+					return
+				}
+			}
+		}(c)
+	}
+
+	distrusted, trusted := newPeer(), newPeer()
+	dial(distrusted)
+	dial(trusted)
+	waitUntilSwitchHasAtLeastNPeers(sw, 2)
+	sw.MarkPeerAsBad(sw.Peers().Get(distrusted.ID()), "slow response")
+	sw.MarkPeerAsGood(sw.Peers().Get(trusted.ID()))
+
+	score, ok := sw.PeerTrustScore(distrusted.ID())
+	require.True(t, ok)
+	assert.Less(t, score, 100)
+	score, ok = sw.PeerTrustScore(trusted.ID())
+	require.True(t, ok)
+	assert.Equal(t, 100, score)
+
+	// a peer we have no trust history of doesn't evict any peer
+	unknown := newPeer()
+	dial(unknown)
+	time.Sleep(100 * time.Millisecond)
+	assert.Equal(t, 2, sw.Peers().Size())
+	assert.False(t, sw.Peers().Has(unknown.ID()))
+	assert.True(t, sw.Peers().Has(distrusted.ID()))
+
+	// the distrusted peer is evicted for a peer which behaved well before
+	known := newPeer()
+	store.GetPeerTrustMetric(string(known.ID())).GoodEvents(1)
+	store.PeerDisconnected(string(known.ID()))
+	dial(known)
+	time.Sleep(100 * time.Millisecond)
+	assert.Equal(t, 2, sw.Peers().Size())
+	assert.False(t, sw.Peers().Has(distrusted.ID()))
+	assert.True(t, sw.Peers().Has(known.ID()))
+
+	// no peer has a lower trust score than the distrusted one
+	store.PeerDisconnected(string(distrusted.ID()))
+	dial(distrusted)
+	time.Sleep(100 * time.Millisecond)
+	assert.Equal(t, 2, sw.Peers().Size())
+	assert.False(t, sw.Peers().Has(distrusted.ID()))
+}
+
 type errorTransport struct {
 	acceptErr error
 }
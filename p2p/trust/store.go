@@ -116,13 +116,30 @@ func (tms *MetricStore) GetPeerTrustMetric(key string) *Metric {
 	return tm
 }
 
-// PeerDisconnected pauses the trust metric associated with the peer identified by the key
+// PeerTrustScore returns the trust score of the peer identified by the key, and
+// whether the store has a trust metric for it. Unlike GetPeerTrustMetric, it
+// doesn't create the metric of unknown peers.
+func (tms *MetricStore) PeerTrustScore(key string) (int, bool) {
+	tms.mtx.Lock()
+	defer tms.mtx.Unlock()
+
+	tm, ok := tms.peerMetrics[key]
+	if !ok {
+		return 0, false
+	}
+	return tm.TrustScore(), true
+}
+
+// PeerDisconnected pauses the trust metric associated with the peer identified by the key,
+// once the events of the current time interval are added to its history, as they would
+// be discarded when the metric is unpaused
 func (tms *MetricStore) PeerDisconnected(key string) {
 	tms.mtx.Lock()
 	defer tms.mtx.Unlock()
 
 	// If the Peer that disconnected has a metric, pause it
 	if tm, ok := tms.peerMetrics[key]; ok {
+		tm.NextTimeInterval()
 		tm.Pause()
 	}
 }
@@ -173,6 +190,8 @@ func (tms *MetricStore) loadFromDB() bool {
 			tms.Logger.Error("unable to start metric", "error", err)
 		}
 		tm.Init(p)
+		// The peer is not connected yet
+		tm.Pause()
 		// Load the peer trust metric into the store
 		tms.peerMetrics[key] = tm
 	}
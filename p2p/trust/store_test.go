@@ -137,6 +137,9 @@ func TestTrustMetricStorePeerScore(t *testing.T) {
 	require.NoError(t, err)
 
 	key := "TestKey"
+	_, ok := store.PeerTrustScore(key)
+	assert.False(t, ok, "looking up the score of an unknown peer shouldn't create its metric")
+	assert.Zero(t, store.Size())
 	tm := store.GetPeerTrustMetric(key)
 
 	// This peer is innocent so far
@@ -158,6 +161,9 @@ func TestTrustMetricStorePeerScore(t *testing.T) {
 	// We will remember our experiences with this peer
 	tm = store.GetPeerTrustMetric(key)
 	assert.NotEqual(t, 100, tm.TrustScore())
+	score, ok := store.PeerTrustScore(key)
+	assert.True(t, ok)
+	assert.Equal(t, tm.TrustScore(), score)
 	err = store.Stop()
 	require.NoError(t, err)
 }
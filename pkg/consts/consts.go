@@ -30,6 +30,41 @@ const (
 
 	// NamespaceSize is the size of a namespace in bytes.
 	NamespaceSize = NamespaceIDSize + NamespaceVersionSize
+
+	// ShareSize is the size of a share of the data square in bytes.
+	ShareSize = 512
+
+	// ShareInfoBytes is the size of the info byte (share version and sequence
+	// start indicator) of a share.
+	ShareInfoBytes = 1
+
+	// SequenceLenBytes is the size of the sequence length stored in the first
+	// share of a sequence.
+	SequenceLenBytes = 4
+
+	// CompactShareReservedBytes is the size of the location of the first unit
+	// of data stored in each compact share.
+	CompactShareReservedBytes = 4
+
+	// FirstSparseShareContentSize is the number of bytes of a blob stored in
+	// the first share of its sequence.
+	FirstSparseShareContentSize = ShareSize - NamespaceSize - ShareInfoBytes - SequenceLenBytes
+
+	// ContinuationSparseShareContentSize is the number of bytes of a blob
+	// stored in each following share of its sequence.
+	ContinuationSparseShareContentSize = ShareSize - NamespaceSize - ShareInfoBytes
+
+	// FirstCompactShareContentSize is the number of bytes of transactions
+	// stored in the first compact share of a sequence.
+	FirstCompactShareContentSize = FirstSparseShareContentSize - CompactShareReservedBytes
+
+	// ContinuationCompactShareContentSize is the number of bytes of
+	// transactions stored in each following compact share of a sequence.
+	ContinuationCompactShareContentSize = ContinuationSparseShareContentSize - CompactShareReservedBytes
+
+	// SubtreeRootThreshold bounds the number of subtree roots of the share
+	// commitment of a blob, and so the alignment of blobs in the data square.
+	SubtreeRootThreshold = 64
 )
 
 var (
@@ -41,7 +76,8 @@ var (
 	NewBaseHashFunc = sha256.New
 
 	// DataCommitmentBlocksLimit is the limit to the number of blocks we can generate a data commitment for.
-	// Deprecated: this is no longer used as we're moving towards Blobstream X. However, we're leaving it
-	// here for backwards compatibility purpose until it's removed in the next breaking release.
-	DataCommitmentBlocksLimit = 1000
+	// Deprecated: use da.DataCommitmentBlocksLimit, the limit enforced by full nodes and light clients,
+	// which this matches. It's left here for backwards compatibility purpose until it's removed in the
+	// next breaking release.
+	DataCommitmentBlocksLimit = 10_000
 )
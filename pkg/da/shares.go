@@ -0,0 +1,149 @@
+package da
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+
+	"github.com/tendermint/tendermint/pkg/consts"
+)
+
+var (
+	// TxNamespace is the namespace of the compact shares holding the txs of a
+	// block, apart from the PayForBlobs txs.
+	TxNamespace = primaryNamespace(0x01)
+
+	// PayForBlobNamespace is the namespace of the compact shares holding the
+	// PayForBlobs txs of a block, each wrapped in an IndexWrapper.
+	PayForBlobNamespace = primaryNamespace(0x04)
+)
+
+// primaryNamespace returns the version 0 namespace reserved for block data
+// whose ID ends with the given byte.
+func primaryNamespace(b byte) []byte {
+	namespace := make([]byte, consts.NamespaceSize)
+	namespace[consts.NamespaceSize-1] = b
+	return namespace
+}
+
+// IsCompactNamespace returns true if shares of the namespace are compact, i.e.
+// they hold length delimited txs rather than blobs.
+func IsCompactNamespace(namespace []byte) bool {
+	return bytes.Equal(namespace, TxNamespace) || bytes.Equal(namespace, PayForBlobNamespace)
+}
+
+// IsSequenceStart returns true if the share is the first share of a sequence,
+// i.e. of the shares of a namespace for compact shares.
+func IsSequenceStart(share []byte) bool {
+	return len(share) > consts.NamespaceSize && share[consts.NamespaceSize]&1 == 1
+}
+
+// SplitCompactShares writes the units, like txs, into the compact shares of a
+// sequence of the namespace. It is the inverse of ParseCompactShares.
+func SplitCompactShares(namespace []byte, units [][]byte) [][]byte {
+	var (
+		data []byte
+		// the offsets in data at which units start
+		starts []int
+	)
+	for _, unit := range units {
+		starts = append(starts, len(data))
+		data = binary.AppendUvarint(data, uint64(len(unit)))
+		data = append(data, unit...)
+	}
+
+	var shares [][]byte
+	for offset := 0; offset < len(data) || len(shares) == 0; {
+		share := make([]byte, 0, consts.ShareSize)
+		share = append(share, namespace...)
+		contentSize := consts.ContinuationCompactShareContentSize
+		if len(shares) == 0 {
+			share = append(share, 1)
+			//nolint:gosec
+			share = binary.BigEndian.AppendUint32(share, uint32(len(data)))
+			contentSize = consts.FirstCompactShareContentSize
+		} else {
+			share = append(share, 0)
+		}
+		end := min(offset+contentSize, len(data))
+
+		// The reserved bytes locate the first unit starting in this share.
+		reserved := 0
+		for len(starts) > 0 && starts[0] < offset {
+			starts = starts[1:]
+		}
+		if len(starts) > 0 && starts[0] < end {
+			reserved = len(share) + consts.CompactShareReservedBytes + starts[0] - offset
+		}
+		//nolint:gosec
+		share = binary.BigEndian.AppendUint32(share, uint32(reserved))
+
+		share = append(share, data[offset:end]...)
+		share = append(share, make([]byte, consts.ShareSize-len(share))...)
+		shares = append(shares, share)
+		offset = end
+	}
+	return shares
+}
+
+// ParseCompactShares returns the units, like txs, found in the compact shares,
+// in the order they are stored. The shares may start or end in the middle of
+// a sequence: units which start before the first share or end after the last
+// one are skipped. Consecutive shares must belong to the same sequence unless
+// the latter starts a new one.
+func ParseCompactShares(shares [][]byte) ([][]byte, error) {
+	var (
+		units [][]byte
+		// the data of the current sequence, from the first unit starting in
+		// the shares
+		data      []byte
+		namespace []byte
+	)
+	for i, share := range shares {
+		if len(share) != consts.ShareSize {
+			return nil, fmt.Errorf("share %d is of %d bytes, expected %d", i, len(share), consts.ShareSize)
+		}
+		if !bytes.Equal(share[:consts.NamespaceSize], namespace) || IsSequenceStart(share) {
+			if namespace != nil && !IsSequenceStart(share) {
+				return nil, fmt.Errorf("share %d of namespace %X doesn't start a sequence", i, share[:consts.NamespaceSize])
+			}
+			units = append(units, parseUnits(data)...)
+			data, namespace = nil, share[:consts.NamespaceSize]
+		}
+
+		offset := consts.NamespaceSize + consts.ShareInfoBytes
+		if IsSequenceStart(share) {
+			offset += consts.SequenceLenBytes
+		}
+		reserved := int(binary.BigEndian.Uint32(share[offset:]))
+		offset += consts.CompactShareReservedBytes
+		switch {
+		case data != nil:
+			data = append(data, share[offset:]...)
+		case reserved == 0:
+			// no unit starts in this share
+		case reserved < offset || reserved >= len(share):
+			return nil, fmt.Errorf("share %d locates its first unit at %d, out of its data [%d, %d)",
+				i, reserved, offset, len(share))
+		default:
+			data = append([]byte{}, share[reserved:]...)
+		}
+	}
+	return append(units, parseUnits(data)...), nil
+}
+
+// parseUnits returns the length delimited units of the data, up to the
+// padding at the end of a sequence or the first unit which is cut off.
+func parseUnits(data []byte) [][]byte {
+	var units [][]byte
+	for len(data) > 0 {
+		size, n := binary.Uvarint(data)
+		//nolint:gosec
+		if n <= 0 || size == 0 || size > uint64(len(data)-n) {
+			break
+		}
+		units = append(units, data[n:n+int(size)])
+		data = data[n+int(size):]
+	}
+	return units
+}
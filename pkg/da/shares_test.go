@@ -0,0 +1,48 @@
+package da
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCompactShares(t *testing.T) {
+	txs := [][]byte{
+		[]byte("tx0"),
+		bytes.Repeat([]byte{1}, 1000), // spans three shares
+		[]byte("tx2"),
+		bytes.Repeat([]byte{3}, 600),
+	}
+	shares := SplitCompactShares(TxNamespace, txs)
+	require.Len(t, shares, 4)
+	assert.True(t, IsSequenceStart(shares[0]))
+	assert.False(t, IsSequenceStart(shares[1]))
+
+	units, err := ParseCompactShares(shares)
+	require.NoError(t, err)
+	assert.Equal(t, txs, units)
+
+	// units starting before the first share or ending after the last one are
+	// skipped
+	units, err = ParseCompactShares(shares[1:3])
+	require.NoError(t, err)
+	assert.Equal(t, txs[2:3], units)
+	units, err = ParseCompactShares(shares[1:2])
+	require.NoError(t, err)
+	assert.Empty(t, units)
+
+	// each namespace is a sequence of its own
+	pfbs := [][]byte{[]byte("pfb0"), []byte("pfb1")}
+	units, err = ParseCompactShares(append(shares, SplitCompactShares(PayForBlobNamespace, pfbs)...))
+	require.NoError(t, err)
+	assert.Equal(t, append(txs, pfbs...), units)
+
+	// a namespace must start with a sequence
+	_, err = ParseCompactShares(append(shares, SplitCompactShares(PayForBlobNamespace, txs)[1:]...))
+	require.Error(t, err)
+
+	_, err = ParseCompactShares([][]byte{shares[0][:100]})
+	require.Error(t, err)
+}
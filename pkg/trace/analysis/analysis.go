@@ -0,0 +1,446 @@
+package analysis
+
+import (
+	"math"
+	"sort"
+	"time"
+
+	cstypes "github.com/tendermint/tendermint/consensus/types"
+	"github.com/tendermint/tendermint/pkg/trace/schema"
+)
+
+// Causes of round changes, see RoundChange.
+const (
+	// CauseMissingProposal means that the node never received the proposal of
+	// the round before its propose timeout.
+	CauseMissingProposal = "missing_proposal"
+	// CauseNilPrecommits means that +2/3 of the validators precommitted nil,
+	// e.g. because the proposal or its block parts arrived late or the block
+	// was invalid.
+	CauseNilPrecommits = "nil_precommits"
+	// CausePrecommitTimeout means that +2/3 of the validators precommitted,
+	// but not for the same block before the precommit timeout.
+	CausePrecommitTimeout = "precommit_timeout"
+	// CauseRoundSkip means that the node moved to a later round before
+	// precommitting, after seeing +2/3 of the votes for that round.
+	CauseRoundSkip = "round_skip"
+)
+
+// Origins of the block propagation latencies, see HeightReport.
+const (
+	// OriginProposal is the first time the proposer sent the proposal or one
+	// of its block parts. It's only available if the proposer was traced.
+	OriginProposal = "proposal"
+	// OriginBlockTime is the time of the block header, as set by the
+	// proposer. It is subject to the clock drift between the nodes.
+	OriginBlockTime = "block_time"
+)
+
+// Report is the result of the analysis of the traces of a network.
+type Report struct {
+	Nodes        []string          `json:"nodes"`
+	Heights      []HeightReport    `json:"heights"`
+	Votes        VoteReport        `json:"votes"`
+	RoundChanges RoundChangeReport `json:"round_changes"`
+	TxGossip     TxGossipReport    `json:"tx_gossip"`
+}
+
+// Distribution summarizes a set of durations, in milliseconds.
+type Distribution struct {
+	Count int     `json:"count"`
+	Min   float64 `json:"min_ms"`
+	Mean  float64 `json:"mean_ms"`
+	P50   float64 `json:"p50_ms"`
+	P90   float64 `json:"p90_ms"`
+	P99   float64 `json:"p99_ms"`
+	Max   float64 `json:"max_ms"`
+}
+
+// HeightReport is the propagation of the block committed at a height.
+type HeightReport struct {
+	Height int64 `json:"height"`
+	// Round is the round in which the block was committed.
+	Round        int32     `json:"round"`
+	Origin       time.Time `json:"origin"`
+	OriginSource string    `json:"origin_source"`
+	// Latencies are the times, from the origin, at which each node received
+	// the last part of the block.
+	Latencies   map[string]float64 `json:"latencies_ms"`
+	Propagation Distribution       `json:"propagation"`
+}
+
+// VoteReport is the delay between the signing of the votes and their arrival
+// at the nodes. Only the first arrival of a vote at a node counts.
+type VoteReport struct {
+	ByType map[string]Distribution   `json:"by_type"`
+	ByNode map[string]VoteNodeReport `json:"by_node"`
+}
+
+// VoteNodeReport is the arrival of the votes at a node.
+type VoteNodeReport struct {
+	Arrival Distribution `json:"arrival"`
+	// Duplicates is the number of votes received again after their first
+	// arrival.
+	Duplicates int `json:"duplicates"`
+}
+
+// RoundChangeReport lists the rounds that didn't commit a block and why.
+type RoundChangeReport struct {
+	// Causes counts the round changes by cause.
+	Causes map[string]int `json:"causes"`
+	Rounds []RoundChange  `json:"rounds"`
+}
+
+// RoundChange is a round that the nodes moved on from without committing.
+type RoundChange struct {
+	Height int64 `json:"height"`
+	Round  int32 `json:"round"`
+	// Cause is the most common cause across the nodes.
+	Cause string            `json:"cause"`
+	Nodes map[string]string `json:"nodes"`
+}
+
+// TxGossipReport is the redundancy of the gossiping of transactions.
+type TxGossipReport struct {
+	// UniqueTxs is the number of different transactions received by any node.
+	UniqueTxs int `json:"unique_txs"`
+	Received  int `json:"received"`
+	// CopiesPerTx is the average number of times a transaction was received
+	// across the network.
+	CopiesPerTx float64                 `json:"copies_per_tx"`
+	Nodes       map[string]TxGossipNode `json:"nodes"`
+}
+
+// TxGossipNode is the redundancy of the transactions received by a node.
+type TxGossipNode struct {
+	Received       int `json:"received"`
+	Unique         int `json:"unique"`
+	Duplicates     int `json:"duplicates"`
+	DuplicateBytes int `json:"duplicate_bytes"`
+	// DuplicateRatio is the share of the received transactions that had
+	// already been received.
+	DuplicateRatio float64 `json:"duplicate_ratio"`
+}
+
+type heightRound struct {
+	height int64
+	round  int32
+}
+
+// Analyze analyzes the traces of a network.
+func Analyze(t *Traces) Report {
+	return Report{
+		Nodes:        nodes(t),
+		Heights:      analyzeHeights(t),
+		Votes:        analyzeVotes(t),
+		RoundChanges: analyzeRoundChanges(t),
+		TxGossip:     analyzeTxGossip(t),
+	}
+}
+
+func nodes(t *Traces) []string {
+	set := make(map[string]struct{})
+	for _, ev := range t.RoundStates {
+		set[ev.NodeID] = struct{}{}
+	}
+	for _, ev := range t.BlockParts {
+		set[ev.NodeID] = struct{}{}
+	}
+	for _, ev := range t.Blocks {
+		set[ev.NodeID] = struct{}{}
+	}
+	for _, ev := range t.Votes {
+		set[ev.NodeID] = struct{}{}
+	}
+	for _, ev := range t.Proposals {
+		set[ev.NodeID] = struct{}{}
+	}
+	for _, ev := range t.MempoolTxs {
+		set[ev.NodeID] = struct{}{}
+	}
+	return sortedKeys(set)
+}
+
+func analyzeHeights(t *Traces) []HeightReport {
+	// the round in which each height was committed
+	commitRounds := make(map[int64]int32)
+	for _, ev := range t.RoundStates {
+		if ev.Msg.Step == uint8(cstypes.RoundStepCommit) {
+			commitRounds[ev.Msg.Height] = ev.Msg.Round
+		}
+	}
+	blockTimes := make(map[int64]time.Time)
+	for _, ev := range t.Blocks {
+		blockTimes[ev.Msg.Height] = time.UnixMilli(ev.Msg.UnixMillisecondTimestamp)
+		if _, has := commitRounds[ev.Msg.Height-1]; !has && ev.Msg.Height > 1 {
+			commitRounds[ev.Msg.Height-1] = ev.Msg.LastCommitRound
+		}
+	}
+
+	origins := make(map[heightRound]time.Time)
+	setOrigin := func(hr heightRound, t time.Time) {
+		if origin, has := origins[hr]; !has || t.Before(origin) {
+			origins[hr] = t
+		}
+	}
+	for _, ev := range t.Proposals {
+		if ev.Msg.TransferType == schema.Upload {
+			setOrigin(heightRound{ev.Msg.Height, ev.Msg.Round}, ev.Timestamp)
+		}
+	}
+	// the time at which each node received the last part of each proposal
+	received := make(map[heightRound]map[string]time.Time)
+	for _, ev := range t.BlockParts {
+		hr := heightRound{ev.Msg.Height, ev.Msg.Round}
+		switch {
+		case ev.Msg.TransferType == schema.Upload && !ev.Msg.Catchup:
+			setOrigin(hr, ev.Timestamp)
+		case ev.Msg.TransferType == schema.Download:
+			if received[hr] == nil {
+				received[hr] = make(map[string]time.Time)
+			}
+			if last, has := received[hr][ev.NodeID]; !has || ev.Timestamp.After(last) {
+				received[hr][ev.NodeID] = ev.Timestamp
+			}
+		}
+	}
+
+	heights := make([]int64, 0, len(commitRounds))
+	for h := range commitRounds {
+		heights = append(heights, h)
+	}
+	sort.Slice(heights, func(i, j int) bool { return heights[i] < heights[j] })
+
+	reports := make([]HeightReport, 0, len(heights))
+	for _, h := range heights {
+		hr := heightRound{h, commitRounds[h]}
+		report := HeightReport{Height: h, Round: hr.round, Latencies: make(map[string]float64)}
+		if origin, has := origins[hr]; has {
+			report.Origin, report.OriginSource = origin, OriginProposal
+		} else if blockTime, has := blockTimes[h]; has {
+			report.Origin, report.OriginSource = blockTime, OriginBlockTime
+		} else {
+			continue
+		}
+
+		latencies := make([]float64, 0, len(received[hr]))
+		for node, last := range received[hr] {
+			latency := millis(last.Sub(report.Origin))
+			report.Latencies[node] = latency
+			latencies = append(latencies, latency)
+		}
+		report.Propagation = newDistribution(latencies)
+		reports = append(reports, report)
+	}
+	return reports
+}
+
+func analyzeVotes(t *Traces) VoteReport {
+	type voteKey struct {
+		node      string
+		height    int64
+		round     int32
+		voteType  string
+		validator string
+	}
+	var (
+		seen    = make(map[voteKey]struct{})
+		byType  = make(map[string][]float64)
+		byNode  = make(map[string][]float64)
+		reports = make(map[string]VoteNodeReport)
+	)
+	for _, ev := range t.Votes {
+		v := ev.Msg
+		if v.TransferType != schema.Download {
+			continue
+		}
+		key := voteKey{ev.NodeID, v.VoteHeight, v.VoteRound, v.VoteType, v.ValidatorAddress}
+		if _, has := seen[key]; has {
+			report := reports[ev.NodeID]
+			report.Duplicates++
+			reports[ev.NodeID] = report
+			continue
+		}
+		seen[key] = struct{}{}
+
+		delay := millis(ev.Timestamp.Sub(time.UnixMilli(v.VoteMillisecondTimestamp)))
+		byType[v.VoteType] = append(byType[v.VoteType], delay)
+		byNode[ev.NodeID] = append(byNode[ev.NodeID], delay)
+	}
+
+	report := VoteReport{
+		ByType: make(map[string]Distribution, len(byType)),
+		ByNode: reports,
+	}
+	for voteType, delays := range byType {
+		report.ByType[voteType] = newDistribution(delays)
+	}
+	for node, delays := range byNode {
+		nodeReport := reports[node]
+		nodeReport.Arrival = newDistribution(delays)
+		reports[node] = nodeReport
+	}
+	return report
+}
+
+func analyzeRoundChanges(t *Traces) RoundChangeReport {
+	type nodeHeight struct {
+		node   string
+		height int64
+	}
+	// the steps reached by each node in each round of each height
+	steps := make(map[nodeHeight]map[int32]map[uint8]bool)
+	for _, ev := range t.RoundStates {
+		nh := nodeHeight{ev.NodeID, ev.Msg.Height}
+		if steps[nh] == nil {
+			steps[nh] = make(map[int32]map[uint8]bool)
+		}
+		if steps[nh][ev.Msg.Round] == nil {
+			steps[nh][ev.Msg.Round] = make(map[uint8]bool)
+		}
+		steps[nh][ev.Msg.Round][ev.Msg.Step] = true
+	}
+	type nodeHeightRound struct {
+		node string
+		heightRound
+	}
+	proposals := make(map[nodeHeightRound]bool)
+	for _, ev := range t.Proposals {
+		proposals[nodeHeightRound{ev.NodeID, heightRound{ev.Msg.Height, ev.Msg.Round}}] = true
+	}
+
+	causes := make(map[heightRound]map[string]string)
+	for nh, rounds := range steps {
+		var last int32
+		for round := range rounds {
+			if round > last {
+				last = round
+			}
+		}
+		// every round before the last one was left without committing
+		for round, reached := range rounds {
+			if round == last {
+				continue
+			}
+			hr := heightRound{nh.height, round}
+			var cause string
+			switch {
+			case !proposals[nodeHeightRound{nh.node, hr}]:
+				cause = CauseMissingProposal
+			case reached[uint8(cstypes.RoundStepPrecommitWait)]:
+				cause = CausePrecommitTimeout
+			case reached[uint8(cstypes.RoundStepPrecommit)]:
+				cause = CauseNilPrecommits
+			default:
+				cause = CauseRoundSkip
+			}
+			if causes[hr] == nil {
+				causes[hr] = make(map[string]string)
+			}
+			causes[hr][nh.node] = cause
+		}
+	}
+
+	report := RoundChangeReport{Causes: make(map[string]int), Rounds: make([]RoundChange, 0, len(causes))}
+	for hr, nodeCauses := range causes {
+		counts := make(map[string]int)
+		for _, cause := range nodeCauses {
+			counts[cause]++
+		}
+		var cause string
+		for _, c := range sortedKeys(counts) {
+			if counts[c] > counts[cause] {
+				cause = c
+			}
+		}
+		report.Causes[cause]++
+		report.Rounds = append(report.Rounds, RoundChange{
+			Height: hr.height,
+			Round:  hr.round,
+			Cause:  cause,
+			Nodes:  nodeCauses,
+		})
+	}
+	sort.Slice(report.Rounds, func(i, j int) bool {
+		a, b := report.Rounds[i], report.Rounds[j]
+		return a.Height < b.Height || (a.Height == b.Height && a.Round < b.Round)
+	})
+	return report
+}
+
+func analyzeTxGossip(t *Traces) TxGossipReport {
+	var (
+		report = TxGossipReport{Nodes: make(map[string]TxGossipNode)}
+		unique = make(map[string]struct{})
+		seen   = make(map[string]map[string]struct{})
+	)
+	for _, ev := range t.MempoolTxs {
+		if ev.Msg.TransferType != schema.Download {
+			continue
+		}
+		report.Received++
+		unique[ev.Msg.TxHash] = struct{}{}
+		if seen[ev.NodeID] == nil {
+			seen[ev.NodeID] = make(map[string]struct{})
+		}
+
+		node := report.Nodes[ev.NodeID]
+		node.Received++
+		if _, has := seen[ev.NodeID][ev.Msg.TxHash]; has {
+			node.Duplicates++
+			node.DuplicateBytes += ev.Msg.Size
+		} else {
+			seen[ev.NodeID][ev.Msg.TxHash] = struct{}{}
+			node.Unique++
+		}
+		node.DuplicateRatio = float64(node.Duplicates) / float64(node.Received)
+		report.Nodes[ev.NodeID] = node
+	}
+
+	report.UniqueTxs = len(unique)
+	if report.UniqueTxs > 0 {
+		report.CopiesPerTx = float64(report.Received) / float64(report.UniqueTxs)
+	}
+	return report
+}
+
+// newDistribution summarizes the values, in milliseconds. Percentiles use the
+// nearest rank.
+func newDistribution(values []float64) Distribution {
+	if len(values) == 0 {
+		return Distribution{}
+	}
+	sorted := append([]float64(nil), values...)
+	sort.Float64s(sorted)
+
+	var sum float64
+	for _, v := range sorted {
+		sum += v
+	}
+	percentile := func(p float64) float64 {
+		rank := int(math.Ceil(p/100*float64(len(sorted)))) - 1
+		return sorted[max(rank, 0)]
+	}
+	return Distribution{
+		Count: len(sorted),
+		Min:   sorted[0],
+		Mean:  sum / float64(len(sorted)),
+		P50:   percentile(50),
+		P90:   percentile(90),
+		P99:   percentile(99),
+		Max:   sorted[len(sorted)-1],
+	}
+}
+
+func millis(d time.Duration) float64 {
+	return float64(d) / float64(time.Millisecond)
+}
+
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,160 @@
+package analysis
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	cstypes "github.com/tendermint/tendermint/consensus/types"
+	"github.com/tendermint/tendermint/pkg/trace"
+	"github.com/tendermint/tendermint/pkg/trace/schema"
+	cmtproto "github.com/tendermint/tendermint/proto/tendermint/types"
+)
+
+var (
+	start     = time.UnixMilli(1_700_000_000_000)
+	prevote   = cmtproto.PrevoteType.String()
+	precommit = cmtproto.PrecommitType.String()
+)
+
+func event[T trace.Entry](node string, at time.Duration, msg T) trace.Event[T] {
+	return trace.Event[T]{ChainID: "test", NodeID: node, Table: msg.Table(), Timestamp: start.Add(at), Msg: msg}
+}
+
+func roundStep(node string, at time.Duration, height int64, round int32, step cstypes.RoundStepType) trace.Event[schema.RoundState] {
+	return event(node, at, schema.RoundState{Height: height, Round: round, Step: uint8(step)})
+}
+
+// testTraces are the traces of three nodes: a proposes height 1, which is
+// committed in round 0, and height 2 is committed in round 1 after c
+// precommitted nil in round 0 and b never received the proposal.
+func testTraces() *Traces {
+	ms := time.Millisecond
+	return &Traces{
+		RoundStates: []trace.Event[schema.RoundState]{
+			roundStep("a", 0, 1, 0, cstypes.RoundStepCommit),
+			roundStep("b", 0, 1, 0, cstypes.RoundStepCommit),
+			roundStep("b", 0, 2, 0, cstypes.RoundStepPropose),
+			roundStep("b", 0, 2, 0, cstypes.RoundStepPrevote),
+			roundStep("b", 0, 2, 1, cstypes.RoundStepCommit),
+			roundStep("c", 0, 2, 0, cstypes.RoundStepPrecommit),
+			roundStep("c", 0, 2, 1, cstypes.RoundStepCommit),
+		},
+		Proposals: []trace.Event[schema.Proposal]{
+			event("a", 10*ms, schema.Proposal{Height: 1, Round: 0, TransferType: schema.Upload}),
+			event("c", 0, schema.Proposal{Height: 2, Round: 0, TransferType: schema.Download}),
+		},
+		BlockParts: []trace.Event[schema.BlockPart]{
+			event("a", 20*ms, schema.BlockPart{Height: 1, Index: 0, TransferType: schema.Upload}),
+			event("b", 30*ms, schema.BlockPart{Height: 1, Index: 0, TransferType: schema.Download}),
+			event("b", 50*ms, schema.BlockPart{Height: 1, Index: 1, TransferType: schema.Download}),
+			event("c", 110*ms, schema.BlockPart{Height: 1, Index: 1, TransferType: schema.Download}),
+			event("c", 500*ms, schema.BlockPart{Height: 2, Round: 1, TransferType: schema.Download}),
+		},
+		Blocks: []trace.Event[schema.BlockSummary]{
+			event("c", 0, schema.BlockSummary{Height: 2, UnixMillisecondTimestamp: start.Add(400 * ms).UnixMilli()}),
+		},
+		Votes: []trace.Event[schema.Vote]{
+			event("b", 15*ms, schema.Vote{VoteHeight: 1, VoteType: prevote, ValidatorAddress: "a",
+				VoteMillisecondTimestamp: start.UnixMilli(), TransferType: schema.Download}),
+			event("b", 25*ms, schema.Vote{VoteHeight: 1, VoteType: prevote, ValidatorAddress: "a",
+				VoteMillisecondTimestamp: start.UnixMilli(), TransferType: schema.Download}),
+			event("c", 35*ms, schema.Vote{VoteHeight: 1, VoteType: precommit, ValidatorAddress: "a",
+				VoteMillisecondTimestamp: start.UnixMilli(), TransferType: schema.Download}),
+			event("a", 0, schema.Vote{VoteHeight: 1, VoteType: precommit, ValidatorAddress: "a",
+				VoteMillisecondTimestamp: start.UnixMilli(), TransferType: schema.Upload}),
+		},
+		MempoolTxs: []trace.Event[schema.MempoolTx]{
+			event("b", 0, schema.MempoolTx{TxHash: "01", Size: 10, TransferType: schema.Download}),
+			event("b", 0, schema.MempoolTx{TxHash: "01", Size: 10, TransferType: schema.Download}),
+			event("b", 0, schema.MempoolTx{TxHash: "02", Size: 20, TransferType: schema.Download}),
+			event("c", 0, schema.MempoolTx{TxHash: "01", Size: 10, TransferType: schema.Download}),
+			event("a", 0, schema.MempoolTx{TxHash: "01", Size: 10, TransferType: schema.Upload}),
+		},
+	}
+}
+
+func TestAnalyze(t *testing.T) {
+	report := Analyze(testTraces())
+	assert.Equal(t, []string{"a", "b", "c"}, report.Nodes)
+
+	require.Len(t, report.Heights, 2)
+	h1 := report.Heights[0]
+	assert.Equal(t, OriginProposal, h1.OriginSource)
+	assert.Equal(t, map[string]float64{"b": 40, "c": 100}, h1.Latencies)
+	assert.Equal(t, 2, h1.Propagation.Count)
+	h2 := report.Heights[1]
+	assert.EqualValues(t, 1, h2.Round)
+	assert.Equal(t, OriginBlockTime, h2.OriginSource)
+	assert.Equal(t, map[string]float64{"c": 100}, h2.Latencies)
+
+	assert.Equal(t, 1, report.Votes.ByType[prevote].Count)
+	assert.EqualValues(t, 15, report.Votes.ByType[prevote].Max)
+	assert.EqualValues(t, 35, report.Votes.ByType[precommit].Max)
+	assert.Equal(t, 1, report.Votes.ByNode["b"].Duplicates)
+
+	require.Len(t, report.RoundChanges.Rounds, 1)
+	rc := report.RoundChanges.Rounds[0]
+	assert.EqualValues(t, 2, rc.Height)
+	assert.EqualValues(t, 0, rc.Round)
+	assert.Equal(t, map[string]string{"b": CauseMissingProposal, "c": CauseNilPrecommits}, rc.Nodes)
+	// ties are broken by name
+	assert.Equal(t, CauseMissingProposal, rc.Cause)
+
+	g := report.TxGossip
+	assert.Equal(t, 2, g.UniqueTxs)
+	assert.Equal(t, 4, g.Received)
+	assert.EqualValues(t, 2, g.CopiesPerTx)
+	assert.Equal(t, TxGossipNode{Received: 3, Unique: 2, Duplicates: 1, DuplicateBytes: 10, DuplicateRatio: 1. / 3},
+		g.Nodes["b"])
+
+	var buf bytes.Buffer
+	require.NoError(t, report.WriteText(&buf))
+	assert.Contains(t, buf.String(), CauseMissingProposal)
+}
+
+func TestNewDistribution(t *testing.T) {
+	assert.Equal(t, Distribution{}, newDistribution(nil))
+
+	values := make([]float64, 0, 100)
+	for i := 100; i > 0; i-- {
+		values = append(values, float64(i))
+	}
+	d := newDistribution(values)
+	assert.Equal(t, Distribution{Count: 100, Min: 1, Mean: 50.5, P50: 50, P90: 90, P99: 99, Max: 100}, d)
+}
+
+// TestLoad tests loading the tables of several nodes laid out as downloaded
+// from S3, i.e. <chain>/<node>/<table>.jsonl.
+func TestLoad(t *testing.T) {
+	dir := t.TempDir()
+	writeTable(t, filepath.Join(dir, "test", "a"), testTraces().RoundStates[:1])
+	writeTable(t, filepath.Join(dir, "test", "b"), testTraces().RoundStates[1:5])
+	writeTable(t, filepath.Join(dir, "test", "b"), testTraces().MempoolTxs[:3])
+
+	traces, err := Load(dir)
+	require.NoError(t, err)
+	assert.Len(t, traces.RoundStates, 5)
+	assert.Len(t, traces.MempoolTxs, 3)
+	assert.Empty(t, traces.Votes)
+
+	_, err = Load(filepath.Join(dir, "missing"))
+	require.Error(t, err)
+}
+
+func writeTable[T trace.Entry](t *testing.T, dir string, events []trace.Event[T]) {
+	require.NoError(t, os.MkdirAll(dir, 0o755))
+	f, err := os.Create(filepath.Join(dir, events[0].Table+".jsonl"))
+	require.NoError(t, err)
+	defer f.Close()
+	enc := json.NewEncoder(f)
+	for _, ev := range events {
+		require.NoError(t, enc.Encode(ev))
+	}
+}
@@ -0,0 +1,92 @@
+// Package analysis makes sense of the consensus and mempool tables traced by
+// several nodes of a network. Traces are loaded from the directories written
+// by the local tracer, or downloaded with trace.GetTable or trace.S3Download,
+// and analyzed offline.
+package analysis
+
+import (
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+
+	"github.com/tendermint/tendermint/pkg/trace"
+	"github.com/tendermint/tendermint/pkg/trace/schema"
+)
+
+// Tables returns the tables used by the analysis.
+func Tables() []string {
+	return []string{
+		schema.RoundStateTable,
+		schema.BlockPartsTable,
+		schema.BlockTable,
+		schema.VoteTable,
+		schema.ProposalTable,
+		schema.MempoolTxTable,
+	}
+}
+
+// Traces are the events of the tables used by the analysis, from all nodes.
+// Events are identified by the node that traced them by their NodeID.
+type Traces struct {
+	RoundStates []trace.Event[schema.RoundState]
+	BlockParts  []trace.Event[schema.BlockPart]
+	Blocks      []trace.Event[schema.BlockSummary]
+	Votes       []trace.Event[schema.Vote]
+	Proposals   []trace.Event[schema.Proposal]
+	MempoolTxs  []trace.Event[schema.MempoolTx]
+}
+
+// Load loads the traces from the given directories. Directories are searched
+// recursively for table files, so a directory can hold the traces of several
+// nodes, e.g. as laid out by trace.S3Download. Missing tables are skipped, as
+// not all nodes necessarily trace all tables.
+func Load(dirs ...string) (*Traces, error) {
+	t := &Traces{}
+	for _, dir := range dirs {
+		err := filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+			if err != nil {
+				return err
+			}
+			if d.IsDir() {
+				return nil
+			}
+			switch d.Name() {
+			case schema.RoundStateTable + ".jsonl":
+				return decodeTable(path, &t.RoundStates)
+			case schema.BlockPartsTable + ".jsonl":
+				return decodeTable(path, &t.BlockParts)
+			case schema.BlockTable + ".jsonl":
+				return decodeTable(path, &t.Blocks)
+			case schema.VoteTable + ".jsonl":
+				return decodeTable(path, &t.Votes)
+			case schema.ProposalTable + ".jsonl":
+				return decodeTable(path, &t.Proposals)
+			case schema.MempoolTxTable + ".jsonl":
+				return decodeTable(path, &t.MempoolTxs)
+			}
+			return nil
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to load traces from %s: %w", dir, err)
+		}
+	}
+	return t, nil
+}
+
+// decodeTable decodes the table file at path, along with its rotated
+// segments, and appends its events to events.
+func decodeTable[T any](path string, events *[]trace.Event[T]) error {
+	f, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	decoded, err := trace.DecodeFile[T](f)
+	if err != nil {
+		return fmt.Errorf("failed to decode %s: %w", path, err)
+	}
+	*events = append(*events, decoded...)
+	return nil
+}
@@ -0,0 +1,65 @@
+package analysis
+
+import (
+	"fmt"
+	"io"
+	"text/tabwriter"
+)
+
+// WriteText writes a human readable version of the report to w.
+func (r Report) WriteText(w io.Writer) error {
+	tw := tabwriter.NewWriter(w, 0, 4, 2, ' ', 0)
+
+	fmt.Fprintf(tw, "Nodes: %d\n\n", len(r.Nodes))
+
+	fmt.Fprintln(tw, "Block propagation (ms after origin)")
+	fmt.Fprintln(tw, "HEIGHT\tROUND\tORIGIN\tNODES\tMIN\tP50\tP90\tMAX")
+	for _, h := range r.Heights {
+		d := h.Propagation
+		fmt.Fprintf(tw, "%d\t%d\t%s\t%d\t%.1f\t%.1f\t%.1f\t%.1f\n",
+			h.Height, h.Round, h.OriginSource, d.Count, d.Min, d.P50, d.P90, d.Max)
+	}
+	fmt.Fprintln(tw)
+
+	fmt.Fprintln(tw, "Vote arrival (ms after signing)")
+	fmt.Fprintln(tw, "VOTES\tCOUNT\tMIN\tMEAN\tP50\tP90\tP99\tMAX\tDUPLICATES")
+	for _, voteType := range sortedKeys(r.Votes.ByType) {
+		writeDistribution(tw, voteType, r.Votes.ByType[voteType], "-")
+	}
+	for _, node := range sortedKeys(r.Votes.ByNode) {
+		report := r.Votes.ByNode[node]
+		writeDistribution(tw, node, report.Arrival, fmt.Sprint(report.Duplicates))
+	}
+	fmt.Fprintln(tw)
+
+	fmt.Fprintln(tw, "Round changes")
+	fmt.Fprintln(tw, "CAUSE\tCOUNT")
+	for _, cause := range sortedKeys(r.RoundChanges.Causes) {
+		fmt.Fprintf(tw, "%s\t%d\n", cause, r.RoundChanges.Causes[cause])
+	}
+	if len(r.RoundChanges.Rounds) > 0 {
+		fmt.Fprintln(tw)
+		fmt.Fprintln(tw, "HEIGHT\tROUND\tCAUSE\tNODES")
+		for _, rc := range r.RoundChanges.Rounds {
+			fmt.Fprintf(tw, "%d\t%d\t%s\t%d\n", rc.Height, rc.Round, rc.Cause, len(rc.Nodes))
+		}
+	}
+	fmt.Fprintln(tw)
+
+	g := r.TxGossip
+	fmt.Fprintf(tw, "Tx gossip: %d unique txs received %d times (%.2f copies per tx)\n",
+		g.UniqueTxs, g.Received, g.CopiesPerTx)
+	fmt.Fprintln(tw, "NODE\tRECEIVED\tUNIQUE\tDUPLICATES\tDUPLICATE BYTES\tDUPLICATE RATIO")
+	for _, node := range sortedKeys(g.Nodes) {
+		n := g.Nodes[node]
+		fmt.Fprintf(tw, "%s\t%d\t%d\t%d\t%d\t%.2f\n",
+			node, n.Received, n.Unique, n.Duplicates, n.DuplicateBytes, n.DuplicateRatio)
+	}
+
+	return tw.Flush()
+}
+
+func writeDistribution(w io.Writer, name string, d Distribution, duplicates string) {
+	fmt.Fprintf(w, "%s\t%d\t%.1f\t%.1f\t%.1f\t%.1f\t%.1f\t%.1f\t%s\n",
+		name, d.Count, d.Min, d.Mean, d.P50, d.P90, d.P99, d.Max, duplicates)
+}
@@ -5,8 +5,11 @@ import (
 	"errors"
 	"io"
 	"os"
+	"path/filepath"
+	"strings"
 	"sync"
 	"sync/atomic"
+	"time"
 )
 
 // bufferedFile is a file that is being written to and read from. It is thread
@@ -25,27 +28,183 @@ type bufferedFile struct {
 
 	// writer is the buffered writer that is writing to the file.
 	wr *bufio.Writer
+
+	// start is the offset of the table at which the file starts, i.e. the
+	// number of bytes of the table in the rotated segments.
+	start int64
+
+	// size is the number of bytes written to the table, including the ones
+	// still buffered.
+	size int64
+
+	// created is the time at which the file was opened or rotated.
+	created time.Time
+
+	// rotation configures when the file is rotated into a new segment, see
+	// rotate.
+	rotation rotationPolicy
+
+	// subs are the subscribers to the writes, see subscribe.
+	subs map[chan tableLine]struct{}
+}
+
+// rotationPolicy dictates when a table file is rotated into a new segment.
+type rotationPolicy struct {
+	// maxSize is the size in bytes after which the file is rotated. 0
+	// disables rotation by size.
+	maxSize int64
+	// maxAge is the age after which the file is rotated. 0 disables rotation
+	// by age.
+	maxAge time.Duration
+	// onRotate is called with every new segment.
+	onRotate func(segment)
+}
+
+// tableLine is a single line written to a table, along with the offset of the
+// table at which it was written.
+type tableLine struct {
+	offset int64
+	data   []byte
 }
 
-// newbufferedFile creates a new buffered file that writes to the given file.
-func newbufferedFile(file *os.File) *bufferedFile {
+// newbufferedFile creates a new buffered file that writes to the given file,
+// which starts at the given offset of its table.
+func newbufferedFile(file *os.File, start int64, rotation rotationPolicy) *bufferedFile {
+	size := start
+	if fi, err := file.Stat(); err == nil {
+		size += fi.Size()
+	}
 	return &bufferedFile{
-		file:    file,
-		wr:      bufio.NewWriter(file),
-		reading: atomic.Bool{},
-		mut:     &sync.Mutex{},
+		file:     file,
+		wr:       bufio.NewWriter(file),
+		reading:  atomic.Bool{},
+		mut:      &sync.Mutex{},
+		start:    start,
+		size:     size,
+		created:  time.Now(),
+		rotation: rotation,
+		subs:     make(map[chan tableLine]struct{}),
 	}
 }
 
 // Write writes the given bytes to the file. If the file is currently being read
-// from, the write will be lost.
+// from, the write will be lost. Successful writes are passed on to the
+// subscribers. b must be a whole line, as the file may be rotated after it.
 func (f *bufferedFile) Write(b []byte) (int, error) {
 	if f.reading.Load() {
 		return 0, nil
 	}
 	f.mut.Lock()
 	defer f.mut.Unlock()
-	return f.wr.Write(b)
+	offset := f.size
+	n, err := f.wr.Write(b)
+	f.size += int64(n)
+	if err != nil {
+		return n, err
+	}
+	if f.shouldRotate() {
+		if err := f.rotate(); err != nil {
+			return n, err
+		}
+	}
+	if len(f.subs) == 0 {
+		return n, nil
+	}
+
+	line := tableLine{offset: offset, data: b}
+	for sub := range f.subs {
+		select {
+		case sub <- line:
+		default:
+			// never block writes on a slow subscriber, drop it instead
+			delete(f.subs, sub)
+			close(sub)
+		}
+	}
+	return n, nil
+}
+
+func (f *bufferedFile) shouldRotate() bool {
+	written := f.size - f.start
+	if written == 0 {
+		return false
+	}
+	p := f.rotation
+	return (p.maxSize > 0 && written >= p.maxSize) ||
+		(p.maxAge > 0 && time.Since(f.created) >= p.maxAge)
+}
+
+// rotate renames the file to a segment holding the offsets written to it and
+// opens a new file in its place. It must be called with the mutex held.
+func (f *bufferedFile) rotate() error {
+	if err := f.wr.Flush(); err != nil {
+		return err
+	}
+	name := f.file.Name()
+	if err := f.file.Close(); err != nil {
+		return err
+	}
+
+	table := strings.TrimSuffix(filepath.Base(name), jsonL)
+	seg := segment{path: segmentPath(filepath.Dir(name), table, f.start, f.size), start: f.start, end: f.size}
+	if err := os.Rename(name, seg.path); err != nil {
+		return err
+	}
+	file, err := os.OpenFile(name, os.O_APPEND|os.O_CREATE|os.O_RDWR, 0644)
+	if err != nil {
+		return err
+	}
+
+	f.file = file
+	f.wr.Reset(file)
+	f.start = f.size
+	f.created = time.Now()
+	if f.rotation.onRotate != nil {
+		f.rotation.onRotate(seg)
+	}
+	return nil
+}
+
+// open flushes the file and opens it again for reading. It returns the offset
+// of the table at which the file starts and the size of the table. All the
+// data before that size has been flushed, and the table before the start
+// offset is in rotated segments. The caller must close the file.
+func (f *bufferedFile) open() (*os.File, int64, int64, error) {
+	f.mut.Lock()
+	defer f.mut.Unlock()
+	if err := f.wr.Flush(); err != nil {
+		return nil, 0, 0, err
+	}
+	file, err := os.Open(f.file.Name())
+	if err != nil {
+		return nil, 0, 0, err
+	}
+	return file, f.start, f.size, nil
+}
+
+// subscribe returns a channel receiving every line written to the file from
+// now on, and the size of the table at the time of subscribing. All the data
+// before that size is flushed to the file, so reading it through another file
+// descriptor doesn't miss or duplicate lines. The channel is closed if the
+// subscriber falls more than buffer lines behind, or the file is closed. The
+// returned function must be called to unsubscribe.
+func (f *bufferedFile) subscribe(buffer int) (<-chan tableLine, int64, func(), error) {
+	f.mut.Lock()
+	defer f.mut.Unlock()
+	if err := f.wr.Flush(); err != nil {
+		return nil, 0, nil, err
+	}
+	sub := make(chan tableLine, buffer)
+	f.subs[sub] = struct{}{}
+	unsubscribe := func() {
+		f.mut.Lock()
+		defer f.mut.Unlock()
+		if _, has := f.subs[sub]; has {
+			delete(f.subs, sub)
+			close(sub)
+		}
+	}
+	return sub, f.size, unsubscribe, nil
 }
 
 func (f *bufferedFile) startReading() error {
@@ -97,5 +256,9 @@ func (f *bufferedFile) Close() error {
 	f.mut.Lock()
 	defer f.mut.Unlock()
 	f.reading.Store(true)
+	for sub := range f.subs {
+		delete(f.subs, sub)
+		close(sub)
+	}
 	return f.file.Close()
 }
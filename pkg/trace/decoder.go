@@ -4,16 +4,40 @@ import (
 	"bufio"
 	"encoding/json"
 	"io"
+	"math"
 	"os"
+	"path/filepath"
+	"strings"
 )
 
 // DecodeFile reads a file and decodes it into a slice of events via
 // scanning. The table parameter is used to determine the type of the events.
 // The file should be a jsonl file. The generic here are passed to the event
 // type.
+//
+// If the file is a table file written by the local tracer, i.e. <table>.jsonl,
+// the rotated segments of the table next to it are decoded first, so that the
+// whole table is returned. Compressed files and segments are decompressed.
 func DecodeFile[T any](f *os.File) ([]Event[T], error) {
+	var segments io.ReadCloser = io.NopCloser(strings.NewReader(""))
+	// segments have the offsets they hold in their name after a dot
+	if table, ok := strings.CutSuffix(filepath.Base(f.Name()), jsonL); ok && !strings.Contains(table, ".") {
+		var err error
+		segments, err = readSegments(filepath.Dir(f.Name()), table, 0, math.MaxInt64)
+		if err != nil {
+			return nil, err
+		}
+	}
+	defer segments.Close()
+
+	file, err := decompress(f)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
 	var out []Event[T]
-	r := bufio.NewReader(f)
+	r := bufio.NewReader(io.MultiReader(segments, file))
 	for {
 		line, err := r.ReadString('\n')
 		if err == io.EOF {
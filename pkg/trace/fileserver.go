@@ -39,16 +39,18 @@ func (lt *LocalTracer) getTableHandler() http.HandlerFunc {
 			return
 		}
 
-		f, done, err := lt.readTable(inputString)
+		// the rotated segments are decompressed, so the table is served as a
+		// single file
+		table, err := lt.openTable(inputString, 0, -1)
 		if err != nil {
 			http.Error(w, fmt.Sprintf("failed to read table: %v", err), http.StatusInternalServerError)
 			return
 		}
-		defer done() //nolint:errcheck
+		defer table.Close()
 
 		// Use the pump function to continuously read from the file and write to
 		// the response writer
-		reader, writer := pump(inputString, bufio.NewReader(f))
+		reader, writer := pump(inputString, bufio.NewReader(table))
 		defer reader.Close()
 
 		// Set the content type to the writer's form data content type
@@ -95,6 +97,7 @@ func pump(table string, br *bufio.Reader) (*io.PipeReader, *multipart.Writer) {
 func (lt *LocalTracer) servePullData() {
 	mux := http.NewServeMux()
 	mux.HandleFunc("/get_table", lt.getTableHandler())
+	mux.HandleFunc("/stream_tables", lt.streamTablesHandler())
 	err := http.ListenAndServe(lt.cfg.Instrumentation.TracePullAddress, mux) //nolint:gosec
 	if err != nil {
 		lt.logger.Error("trace pull server failure", "err", err)
@@ -242,8 +245,14 @@ func (lt *LocalTracer) pushLoop() {
 	}
 }
 
+// PushAll pushes the files of all tables, along with the rotated segments that
+// haven't been pushed yet.
 func (lt *LocalTracer) PushAll() error {
 	for table := range lt.fileMap {
+		if err := lt.pushSegments(table); err != nil {
+			return err
+		}
+
 		f, done, err := lt.readTable(table)
 		if err != nil {
 			return err
@@ -264,10 +273,45 @@ func (lt *LocalTracer) PushAll() error {
 	return nil
 }
 
+// pushSegments pushes the rotated segments of the table that haven't been
+// pushed yet. Segments are only pushed once compressed, if compression is
+// enabled, as they don't change afterwards.
+func (lt *LocalTracer) pushSegments(table string) error {
+	lt.pushMtx.Lock()
+	defer lt.pushMtx.Unlock()
+
+	segments, err := listSegments(lt.dir, table)
+	if err != nil {
+		return err
+	}
+	compression := lt.cfg.Instrumentation.TraceCompression
+	for _, s := range segments {
+		if _, pushed := lt.pushed[s.path]; pushed {
+			continue
+		}
+		if !s.compressed() && compression != "" && compression != "none" {
+			continue
+		}
+		f, err := os.Open(s.path)
+		if err != nil {
+			// deleted to enforce the disk budget
+			continue
+		}
+		err = PushS3(lt.chainID, lt.nodeID, lt.s3Config, f)
+		f.Close()
+		if err != nil {
+			return fmt.Errorf("failed to push segment %s: %w", s.path, err)
+		}
+		lt.pushed[s.path] = struct{}{}
+	}
+	return nil
+}
+
 // S3Download downloads files that match some prefix from an S3 bucket to a
 // local directory dst.
 // fileNames is a list of traced jsonl file names to download. If it is empty, all traces are downloaded.
-// fileNames should not have .jsonl suffix.
+// fileNames should not have .jsonl suffix. The rotated segments of the tables
+// are downloaded along with them.
 func S3Download(dst, prefix string, cfg S3Config, fileNames ...string) error {
 	// Ensure local directory structure exists
 	err := os.MkdirAll(dst, os.ModePerm)
@@ -307,7 +351,8 @@ func S3Download(dst, prefix string, cfg S3Config, fileNames ...string) error {
 			for _, filename := range fileNames {
 				// Add .jsonl suffix to the fileNames
 				fullFilename := filename + jsonL
-				if strings.HasSuffix(key, fullFilename) {
+				_, _, isSegment := parseSegment(path.Base(key), filename)
+				if strings.HasSuffix(key, fullFilename) || isSegment {
 					localFilePath := filepath.Join(dst, prefix, strings.TrimPrefix(key, prefix))
 					fmt.Printf("Downloading %s to %s\n", key, localFilePath)
 
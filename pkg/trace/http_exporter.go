@@ -0,0 +1,94 @@
+package trace
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/tendermint/tendermint/config"
+	"github.com/tendermint/tendermint/libs/log"
+)
+
+// NDJSONContentType is the content type of the requests sent by the
+// HTTPExporter.
+const NDJSONContentType = "application/x-ndjson"
+
+// HTTPRetryPolicy is the retry policy of the http tracer. Collectors are
+// expected to run close to the node, so failures are retried quickly.
+var HTTPRetryPolicy = RetryPolicy{
+	MaxRetries: 3,
+	Backoff:    500 * time.Millisecond,
+	MaxBackoff: 5 * time.Second,
+}
+
+// HTTPExporter pushes events to a collector as newline delimited JSON, one
+// Event per line, in the same format as the files written by the LocalTracer.
+// Each batch is sent as the body of a single POST request.
+type HTTPExporter struct {
+	url    string
+	client *http.Client
+}
+
+// NewHTTPExporter creates an exporter that pushes events to the given URL.
+func NewHTTPExporter(url string) *HTTPExporter {
+	return &HTTPExporter{
+		url:    url,
+		client: &http.Client{Timeout: remoteExportTimeout},
+	}
+}
+
+// NewHTTPTracer creates a tracer that pushes events to the collector at the
+// trace_remote_url config option. Events are dropped, rather than slowing
+// down the node, when the collector can't keep up.
+func NewHTTPTracer(cfg *config.Config, logger log.Logger, chainID, nodeID string) (*RemoteTracer, error) {
+	if cfg.Instrumentation.TraceRemoteURL == "" {
+		return nil, errors.New("trace_remote_url is required by the http tracer")
+	}
+	exporter := NewHTTPExporter(cfg.Instrumentation.TraceRemoteURL)
+	return NewRemoteTracer(cfg, logger, chainID, nodeID, exporter, HTTPRetryPolicy, DropNewest), nil
+}
+
+func (e *HTTPExporter) Export(ctx context.Context, events []Event[Entry]) error {
+	var buf bytes.Buffer
+	enc := json.NewEncoder(&buf)
+	for _, ev := range events {
+		if err := enc.Encode(ev); err != nil {
+			return PermanentError{Err: fmt.Errorf("failed to marshal event: %w", err)}
+		}
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, e.url, &buf)
+	if err != nil {
+		return PermanentError{Err: err}
+	}
+	req.Header.Set("Content-Type", NDJSONContentType)
+
+	resp, err := e.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	_, _ = io.Copy(io.Discard, resp.Body)
+
+	return checkExportStatus(resp.StatusCode)
+}
+
+// checkExportStatus converts a non 2xx status code into an error. Client
+// errors, apart from throttling, are permanent.
+func checkExportStatus(code int) error {
+	switch {
+	case code >= 200 && code < 300:
+		return nil
+	case code == http.StatusTooManyRequests || code == http.StatusRequestTimeout:
+		return fmt.Errorf("unexpected status code: %d", code)
+	case code >= 400 && code < 500:
+		return PermanentError{Err: fmt.Errorf("unexpected status code: %d", code)}
+	default:
+		return fmt.Errorf("unexpected status code: %d", code)
+	}
+}
@@ -3,10 +3,14 @@ package trace
 import (
 	"encoding/json"
 	"fmt"
+	"io"
 	"os"
 	"path"
+	"path/filepath"
+	"sort"
 	"strconv"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/tendermint/tendermint/config"
@@ -19,6 +23,10 @@ const (
 	PushAccessKey  = "TRACE_PUSH_ACCESS_KEY"
 	PushKey        = "TRACE_PUSH_SECRET_KEY"
 	PushDelay      = "TRACE_PUSH_DELAY"
+
+	// rotatedBufferSize is the number of rotated segments that can wait to be
+	// compressed before the disk budget is enforced without compressing them.
+	rotatedBufferSize = 100
 )
 
 // Event wraps some trace data with metadata that dictates the table and things
@@ -54,6 +62,9 @@ type LocalTracer struct {
 	cfg             *config.Config
 	s3Config        S3Config
 
+	// dir is the directory of the table files and their rotated segments.
+	dir string
+
 	// fileMap maps tables to their open files files are threadsafe, but the map
 	// is not. Therefore don't create new files after initialization to remain
 	// threadsafe.
@@ -61,6 +72,18 @@ type LocalTracer struct {
 	// canal is a channel for all events that are being written. It acts as an
 	// extra buffer to avoid blocking the caller when writing to files.
 	canal chan Event[Entry]
+
+	// rotated receives the segments rotated by the table files, to be
+	// compressed and to enforce the disk budget. See maintainSegments.
+	rotated chan segment
+
+	// pushed are the rotated segments that have already been pushed to S3.
+	pushMtx sync.Mutex
+	pushed  map[string]struct{}
+
+	stopOnce sync.Once
+	quit     chan struct{}
+	done     chan struct{}
 }
 
 // NewLocalTracer creates a struct that will save all of the events passed to
@@ -69,32 +92,55 @@ type LocalTracer struct {
 // safe to avoid the overhead of locking with each event save. Only pass events
 // to the returned channel. Call CloseAll to close all open files. Goroutine to
 // save events is started in this function.
+//
+// Table files are rotated into segments according to the trace_rotate_size
+// and trace_rotate_age config options. Rotated segments are compressed and
+// deleted once trace_max_total_size is exceeded, oldest first.
 func NewLocalTracer(cfg *config.Config, logger log.Logger, chainID, nodeID string) (*LocalTracer, error) {
-	fm := make(map[string]*bufferedFile)
 	p := path.Join(cfg.RootDir, "data", "traces")
+	lt := &LocalTracer{
+		fileMap: make(map[string]*bufferedFile),
+		cfg:     cfg,
+		dir:     p,
+		canal:   make(chan Event[Entry], cfg.Instrumentation.TraceBufferSize),
+		rotated: make(chan segment, rotatedBufferSize),
+		pushed:  make(map[string]struct{}),
+		chainID: chainID,
+		nodeID:  nodeID,
+		logger:  logger,
+		quit:    make(chan struct{}),
+		done:    make(chan struct{}),
+	}
+
+	rotation := rotationPolicy{
+		maxSize:  cfg.Instrumentation.TraceRotateSize,
+		maxAge:   cfg.Instrumentation.TraceRotateAge,
+		onRotate: lt.onRotate,
+	}
 	for _, table := range splitAndTrimEmpty(cfg.Instrumentation.TracingTables, ",", " ") {
 		fileName := fmt.Sprintf("%s/%s.jsonl", p, table)
 		err := os.MkdirAll(p, 0700)
 		if err != nil {
 			return nil, fmt.Errorf("failed to create directory %s: %w", p, err)
 		}
+		// the file continues the table where the last rotated segment ends
+		segments, err := listSegments(p, table)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list segments of table %s: %w", table, err)
+		}
+		var start int64
+		if len(segments) > 0 {
+			start = segments[len(segments)-1].end
+		}
 		file, err := os.OpenFile(fileName, os.O_APPEND|os.O_CREATE|os.O_RDWR, 0644)
 		if err != nil {
 			return nil, fmt.Errorf("failed to open or create file %s: %w", fileName, err)
 		}
-		fm[table] = newbufferedFile(file)
-	}
-
-	lt := &LocalTracer{
-		fileMap: fm,
-		cfg:     cfg,
-		canal:   make(chan Event[Entry], cfg.Instrumentation.TraceBufferSize),
-		chainID: chainID,
-		nodeID:  nodeID,
-		logger:  logger,
+		lt.fileMap[table] = newbufferedFile(file, start, rotation)
 	}
 
 	go lt.drainCanal()
+	go lt.maintainSegments()
 	if cfg.Instrumentation.TracePullAddress != "" {
 		logger.Info("starting pull server", "address", cfg.Instrumentation.TracePullAddress)
 		go lt.servePullData()
@@ -145,6 +191,57 @@ func (lt *LocalTracer) Write(e Entry) {
 	lt.canal <- NewEvent(lt.chainID, lt.nodeID, e.Table(), e)
 }
 
+// openTable returns a reader of the table between the from and to offsets,
+// across its rotated segments. A negative to reads the whole table as of now.
+// Writes are not blocked while reading. The caller must close the reader.
+func (lt *LocalTracer) openTable(table string, from, to int64) (io.ReadCloser, error) {
+	bf, has := lt.getFile(table)
+	if !has {
+		return nil, fmt.Errorf("table %s not found", table)
+	}
+
+	// open the file before listing the segments, so that a concurrent
+	// rotation can't leave out the offsets in between
+	file, start, size, err := bf.open()
+	if err != nil {
+		return nil, err
+	}
+	if to < 0 || to > size {
+		to = size
+	}
+	segments, err := readSegments(lt.dir, table, from, min(start, to))
+	if err != nil {
+		file.Close()
+		return nil, err
+	}
+
+	from = max(from, start)
+	active := io.NewSectionReader(file, from-start, max(to-from, 0))
+	return readCloser{io.MultiReader(segments, active), func() error {
+		segments.Close()
+		return file.Close()
+	}}, nil
+}
+
+// firstOffset returns the first offset of the table that hasn't been deleted
+// to enforce the disk budget.
+func (lt *LocalTracer) firstOffset(table string) (int64, error) {
+	bf, has := lt.getFile(table)
+	if !has {
+		return 0, fmt.Errorf("table %s not found", table)
+	}
+	segments, err := listSegments(lt.dir, table)
+	if err != nil {
+		return 0, err
+	}
+	if len(segments) > 0 {
+		return segments[0].start, nil
+	}
+	bf.mut.Lock()
+	defer bf.mut.Unlock()
+	return bf.start, nil
+}
+
 // ReadTable returns a file for the given table. If the table is not being
 // collected, an error is returned. The caller should not close the file.
 func (lt *LocalTracer) readTable(table string) (*os.File, func() error, error) {
@@ -198,8 +295,122 @@ func (lt *LocalTracer) drainCanal() {
 	}
 }
 
+// onRotate queues a rotated segment for maintenance. Writes are never blocked
+// on maintenance: if the queue is full, the segment is left uncompressed.
+func (lt *LocalTracer) onRotate(s segment) {
+	select {
+	case lt.rotated <- s:
+	case <-lt.quit:
+	default:
+		lt.logger.Error("trace segment maintenance can't keep up, leaving segment uncompressed", "segment", s.path)
+	}
+}
+
+// maintainSegments compresses the rotated segments and enforces the disk
+// budget, starting with the segments left over by a previous run.
+func (lt *LocalTracer) maintainSegments() {
+	defer close(lt.done)
+
+	// clean up compressions interrupted by a previous run
+	tmps, _ := filepath.Glob(filepath.Join(lt.dir, "*"+tmpExt))
+	for _, tmp := range tmps {
+		os.Remove(tmp)
+	}
+	for table := range lt.fileMap {
+		segments, err := listSegments(lt.dir, table)
+		if err != nil {
+			lt.logger.Error("failed to list trace segments", "table", table, "err", err)
+			continue
+		}
+		for _, s := range segments {
+			lt.compressSegment(s)
+		}
+	}
+	lt.enforceBudget()
+
+	for {
+		select {
+		case s := <-lt.rotated:
+			lt.compressSegment(s)
+			lt.enforceBudget()
+		case <-lt.quit:
+			return
+		}
+	}
+}
+
+func (lt *LocalTracer) compressSegment(s segment) {
+	compression := lt.cfg.Instrumentation.TraceCompression
+	if compression == "" || compression == "none" || s.compressed() {
+		return
+	}
+	if _, err := s.compress(compression); err != nil {
+		lt.logger.Error("failed to compress trace segment", "segment", s.path, "err", err)
+	}
+}
+
+// enforceBudget deletes the oldest rotated segments across all tables until
+// the tables fit in trace_max_total_size. The files being written to are
+// never deleted.
+func (lt *LocalTracer) enforceBudget() {
+	budget := lt.cfg.Instrumentation.TraceMaxTotalSize
+	if budget <= 0 {
+		return
+	}
+
+	type sizedSegment struct {
+		path    string
+		size    int64
+		modTime time.Time
+	}
+	var (
+		total    int64
+		segments []sizedSegment
+	)
+	for table := range lt.fileMap {
+		if fi, err := os.Stat(filepath.Join(lt.dir, table+jsonL)); err == nil {
+			total += fi.Size()
+		}
+		tableSegments, err := listSegments(lt.dir, table)
+		if err != nil {
+			lt.logger.Error("failed to list trace segments", "table", table, "err", err)
+			return
+		}
+		for _, s := range tableSegments {
+			fi, err := os.Stat(s.path)
+			if err != nil {
+				continue
+			}
+			total += fi.Size()
+			segments = append(segments, sizedSegment{s.path, fi.Size(), fi.ModTime()})
+		}
+	}
+
+	sort.Slice(segments, func(i, j int) bool { return segments[i].modTime.Before(segments[j].modTime) })
+	for _, s := range segments {
+		if total <= budget {
+			return
+		}
+		if err := os.Remove(s.path); err != nil {
+			lt.logger.Error("failed to delete trace segment", "segment", s.path, "err", err)
+			continue
+		}
+		lt.logger.Debug("deleted trace segment to enforce the disk budget", "segment", s.path)
+		total -= s.size
+	}
+	if total > budget {
+		lt.logger.Error("trace tables exceed trace_max_total_size without rotated segments left to delete",
+			"size", total, "budget", budget)
+	}
+}
+
 // Stop optionally uploads and closes all open files.
 func (lt *LocalTracer) Stop() {
+	lt.stopOnce.Do(func() {
+		close(lt.quit)
+		<-lt.done
+	})
+
 	if lt.s3Config.SecretKey != "" {
 		lt.logger.Info("pushing all tables before stopping")
 		err := lt.PushAll()
@@ -1,6 +1,7 @@
 package trace
 
 import (
+	"context"
 	"fmt"
 	"io"
 	"net"
@@ -181,3 +182,138 @@ func getFreePort() (int, error) {
 	defer l.Close()
 	return l.Addr().(*net.TCPAddr).Port, nil
 }
+
+// TestLocalTracerRotation tests that tables are rotated into compressed
+// segments that are read transparently, and that the oldest segments are
+// deleted to enforce the disk budget.
+func TestLocalTracerRotation(t *testing.T) {
+	for _, compression := range []string{"gzip", "zstd", "none"} {
+		t.Run(compression, func(t *testing.T) {
+			port, err := getFreePort()
+			require.NoError(t, err)
+			cfg := config.DefaultConfig()
+			cfg.SetRoot(t.TempDir())
+			cfg.Instrumentation.TraceBufferSize = 100
+			cfg.Instrumentation.TracingTables = testEventTable
+			cfg.Instrumentation.TracePullAddress = fmt.Sprintf(":%d", port)
+			cfg.Instrumentation.TraceRotateSize = 500
+			cfg.Instrumentation.TraceCompression = compression
+			client, err := NewLocalTracer(cfg, log.NewNopLogger(), "test_chain", "test_node")
+			require.NoError(t, err)
+			defer client.Stop()
+
+			for i := 0; i < 20; i++ {
+				client.Write(testEvent{"Annecy", i})
+			}
+
+			dir := path.Join(cfg.RootDir, "data", "traces")
+			var segments []segment
+			require.Eventually(t, func() bool {
+				segments, err = listSegments(dir, testEventTable)
+				require.NoError(t, err)
+				if len(segments) < 3 {
+					return false
+				}
+				for _, s := range segments {
+					if s.compressed() != (compression != "none") {
+						return false
+					}
+				}
+				return true
+			}, 5*time.Second, 10*time.Millisecond)
+			for i := 1; i < len(segments); i++ {
+				require.Equal(t, segments[i-1].end, segments[i].start)
+			}
+
+			// the active file is decoded along with the segments
+			f, done, err := client.readTable(testEventTable)
+			require.NoError(t, err)
+			events, err := DecodeFile[testEvent](f)
+			require.NoError(t, err)
+			require.NoError(t, done())
+			require.Len(t, events, 20)
+			for i := 0; i < 20; i++ {
+				require.Equal(t, i, events[i].Msg.Length)
+			}
+
+			// the pull server serves the whole table as a single file
+			url := fmt.Sprintf("http://localhost:%d", port)
+			newDir := t.TempDir()
+			require.NoError(t, GetTable(url, testEventTable, newDir))
+			downloaded, err := os.Open(path.Join(newDir, testEventTable+".jsonl"))
+			require.NoError(t, err)
+			defer downloaded.Close()
+			events, err = DecodeFile[testEvent](downloaded)
+			require.NoError(t, err)
+			require.Len(t, events, 20)
+
+			// offsets stay valid across rotations
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+			var streamed []int
+			err = StreamTable(ctx, url, testEventTable, StreamOptions{Offset: &segments[1].start},
+				func(offset int64, ev Event[testEvent]) error {
+					if len(streamed) == 0 {
+						require.Equal(t, segments[1].start, offset)
+					}
+					streamed = append(streamed, ev.Msg.Length)
+					if ev.Msg.Length == 19 {
+						cancel()
+					}
+					return nil
+				})
+			require.ErrorIs(t, err, context.Canceled)
+			require.Equal(t, events[len(events)-len(streamed)].Msg.Length, streamed[0])
+		})
+	}
+}
+
+func TestLocalTracerDiskBudget(t *testing.T) {
+	cfg := config.DefaultConfig()
+	cfg.SetRoot(t.TempDir())
+	cfg.Instrumentation.TraceBufferSize = 100
+	cfg.Instrumentation.TracingTables = testEventTable
+	cfg.Instrumentation.TraceRotateSize = 500
+	cfg.Instrumentation.TraceCompression = "none"
+	cfg.Instrumentation.TraceMaxTotalSize = 1500
+	client, err := NewLocalTracer(cfg, log.NewNopLogger(), "test_chain", "test_node")
+	require.NoError(t, err)
+	defer client.Stop()
+
+	for i := 0; i < 50; i++ {
+		client.Write(testEvent{"Annecy", i})
+	}
+
+	dir := path.Join(cfg.RootDir, "data", "traces")
+	require.Eventually(t, func() bool {
+		segments, err := listSegments(dir, testEventTable)
+		require.NoError(t, err)
+		return len(segments) > 0 && segments[0].start > 0 && diskUsage(t, dir) <= 1500
+	}, 5*time.Second, 10*time.Millisecond)
+
+	// the most recent events are kept
+	f, done, err := client.readTable(testEventTable)
+	require.NoError(t, err)
+	events, err := DecodeFile[testEvent](f)
+	require.NoError(t, err)
+	require.NoError(t, done())
+	require.NotEmpty(t, events)
+	require.Less(t, len(events), 50)
+	require.Equal(t, 49, events[len(events)-1].Msg.Length)
+
+	// deleted offsets can't be streamed
+	var zero int64
+	require.Error(t, client.checkLineStart(testEventTable, zero))
+}
+
+func diskUsage(t *testing.T, dir string) int64 {
+	entries, err := os.ReadDir(dir)
+	require.NoError(t, err)
+	var total int64
+	for _, e := range entries {
+		fi, err := e.Info()
+		require.NoError(t, err)
+		total += fi.Size()
+	}
+	return total
+}
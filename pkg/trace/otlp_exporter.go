@@ -0,0 +1,251 @@
+package trace
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"net/http"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/tendermint/tendermint/config"
+	"github.com/tendermint/tendermint/libs/log"
+)
+
+const otlpScopeName = "github.com/tendermint/tendermint/pkg/trace"
+
+// OTLPRetryPolicy is the retry policy of the otlp tracer. OTLP collectors
+// commonly throttle, so retries back off for longer.
+var OTLPRetryPolicy = RetryPolicy{
+	MaxRetries: 5,
+	Backoff:    time.Second,
+	MaxBackoff: 30 * time.Second,
+}
+
+// OTLPExporter exports events to an OpenTelemetry collector using OTLP/HTTP
+// with JSON encoding. Each batch becomes one span per table, and every event
+// becomes a span event named after its table, with the fields of the schema
+// struct as attributes.
+type OTLPExporter struct {
+	url    string
+	client *http.Client
+}
+
+// NewOTLPExporter creates an exporter that sends spans to the given OTLP/HTTP
+// traces endpoint, usually ending in /v1/traces.
+func NewOTLPExporter(url string) *OTLPExporter {
+	return &OTLPExporter{
+		url:    url,
+		client: &http.Client{Timeout: remoteExportTimeout},
+	}
+}
+
+// NewOTLPTracer creates a tracer that exports events to the OTLP/HTTP endpoint
+// at the trace_remote_url config option. When the collector can't keep up, the
+// oldest events are dropped in favor of the newest.
+func NewOTLPTracer(cfg *config.Config, logger log.Logger, chainID, nodeID string) (*RemoteTracer, error) {
+	if cfg.Instrumentation.TraceRemoteURL == "" {
+		return nil, errors.New("trace_remote_url is required by the otlp tracer")
+	}
+	exporter := NewOTLPExporter(cfg.Instrumentation.TraceRemoteURL)
+	return NewRemoteTracer(cfg, logger, chainID, nodeID, exporter, OTLPRetryPolicy, DropOldest), nil
+}
+
+func (e *OTLPExporter) Export(ctx context.Context, events []Event[Entry]) error {
+	body, err := json.Marshal(otlpRequestFromEvents(events))
+	if err != nil {
+		return PermanentError{Err: fmt.Errorf("failed to marshal spans: %w", err)}
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, e.url, bytes.NewReader(body))
+	if err != nil {
+		return PermanentError{Err: err}
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := e.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	_, _ = io.Copy(io.Discard, resp.Body)
+
+	return checkExportStatus(resp.StatusCode)
+}
+
+// The following types are the subset of the OTLP/JSON trace encoding used by
+// the OTLPExporter. See
+// https://github.com/open-telemetry/opentelemetry-proto/blob/main/docs/specification.md#json-protobuf-encoding
+
+type otlpRequest struct {
+	ResourceSpans []otlpResourceSpans `json:"resourceSpans"`
+}
+
+type otlpResourceSpans struct {
+	Resource   otlpResource     `json:"resource"`
+	ScopeSpans []otlpScopeSpans `json:"scopeSpans"`
+}
+
+type otlpResource struct {
+	Attributes []otlpKeyValue `json:"attributes"`
+}
+
+type otlpScopeSpans struct {
+	Scope otlpScope  `json:"scope"`
+	Spans []otlpSpan `json:"spans"`
+}
+
+type otlpScope struct {
+	Name string `json:"name"`
+}
+
+type otlpSpan struct {
+	TraceID           string          `json:"traceId"`
+	SpanID            string          `json:"spanId"`
+	Name              string          `json:"name"`
+	Kind              int             `json:"kind"`
+	StartTimeUnixNano string          `json:"startTimeUnixNano"`
+	EndTimeUnixNano   string          `json:"endTimeUnixNano"`
+	Events            []otlpSpanEvent `json:"events"`
+}
+
+type otlpSpanEvent struct {
+	TimeUnixNano string         `json:"timeUnixNano"`
+	Name         string         `json:"name"`
+	Attributes   []otlpKeyValue `json:"attributes"`
+}
+
+type otlpKeyValue struct {
+	Key   string       `json:"key"`
+	Value otlpAnyValue `json:"value"`
+}
+
+type otlpAnyValue struct {
+	StringValue *string  `json:"stringValue,omitempty"`
+	BoolValue   *bool    `json:"boolValue,omitempty"`
+	IntValue    *string  `json:"intValue,omitempty"`
+	DoubleValue *float64 `json:"doubleValue,omitempty"`
+}
+
+// otlpSpanKindInternal is SPAN_KIND_INTERNAL.
+const otlpSpanKindInternal = 1
+
+// otlpRequestFromEvents groups the events by node and table. Events of the
+// same node share a resource, and events of the same table a span.
+func otlpRequestFromEvents(events []Event[Entry]) otlpRequest {
+	type nodeKey struct{ chainID, nodeID string }
+	var (
+		nodes  []nodeKey
+		tables = make(map[nodeKey][]string)
+		spans  = make(map[nodeKey]map[string]*otlpSpan)
+	)
+
+	for _, ev := range events {
+		node := nodeKey{ev.ChainID, ev.NodeID}
+		if _, has := spans[node]; !has {
+			nodes = append(nodes, node)
+			spans[node] = make(map[string]*otlpSpan)
+		}
+		span, has := spans[node][ev.Table]
+		if !has {
+			span = &otlpSpan{
+				TraceID:           randomHex(16),
+				SpanID:            randomHex(8),
+				Name:              ev.Table,
+				Kind:              otlpSpanKindInternal,
+				StartTimeUnixNano: unixNano(ev.Timestamp),
+			}
+			spans[node][ev.Table] = span
+			tables[node] = append(tables[node], ev.Table)
+		}
+		span.EndTimeUnixNano = unixNano(ev.Timestamp)
+		span.Events = append(span.Events, otlpSpanEvent{
+			TimeUnixNano: unixNano(ev.Timestamp),
+			Name:         ev.Table,
+			Attributes:   otlpAttributes(ev.Msg),
+		})
+	}
+
+	req := otlpRequest{ResourceSpans: make([]otlpResourceSpans, 0, len(nodes))}
+	for _, node := range nodes {
+		ss := otlpScopeSpans{Scope: otlpScope{Name: otlpScopeName}}
+		for _, table := range tables[node] {
+			ss.Spans = append(ss.Spans, *spans[node][table])
+		}
+		req.ResourceSpans = append(req.ResourceSpans, otlpResourceSpans{
+			Resource: otlpResource{Attributes: []otlpKeyValue{
+				otlpString("service.name", "celestia-core"),
+				otlpString("service.instance.id", node.nodeID),
+				otlpString("chain_id", node.chainID),
+			}},
+			ScopeSpans: []otlpScopeSpans{ss},
+		})
+	}
+	return req
+}
+
+// otlpAttributes flattens the top level JSON fields of a schema struct into
+// span event attributes. Nested values are kept as JSON strings.
+func otlpAttributes(msg Entry) []otlpKeyValue {
+	raw, err := json.Marshal(msg)
+	if err != nil {
+		return nil
+	}
+	fields := make(map[string]json.RawMessage)
+	if err := json.Unmarshal(raw, &fields); err != nil {
+		return nil
+	}
+
+	keys := make([]string, 0, len(fields))
+	for k := range fields {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	attrs := make([]otlpKeyValue, 0, len(keys))
+	for _, k := range keys {
+		var v interface{}
+		if err := json.Unmarshal(fields[k], &v); err != nil {
+			continue
+		}
+		switch v := v.(type) {
+		case nil:
+			continue
+		case string:
+			attrs = append(attrs, otlpString(k, v))
+		case bool:
+			attrs = append(attrs, otlpKeyValue{Key: k, Value: otlpAnyValue{BoolValue: &v}})
+		case float64:
+			if v == math.Trunc(v) && math.Abs(v) < 1<<53 {
+				i := strconv.FormatInt(int64(v), 10)
+				attrs = append(attrs, otlpKeyValue{Key: k, Value: otlpAnyValue{IntValue: &i}})
+			} else {
+				attrs = append(attrs, otlpKeyValue{Key: k, Value: otlpAnyValue{DoubleValue: &v}})
+			}
+		default:
+			attrs = append(attrs, otlpString(k, string(fields[k])))
+		}
+	}
+	return attrs
+}
+
+func otlpString(key, value string) otlpKeyValue {
+	return otlpKeyValue{Key: key, Value: otlpAnyValue{StringValue: &value}}
+}
+
+func unixNano(t time.Time) string {
+	return strconv.FormatInt(t.UnixNano(), 10)
+}
+
+func randomHex(n int) string {
+	b := make([]byte, n)
+	_, _ = rand.Read(b)
+	return hex.EncodeToString(b)
+}
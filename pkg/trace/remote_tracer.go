@@ -0,0 +1,268 @@
+package trace
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/tendermint/tendermint/config"
+	"github.com/tendermint/tendermint/libs/log"
+)
+
+const (
+	// remoteFlushInterval is the maximum amount of time events are buffered
+	// before being exported, even if the batch isn't full.
+	remoteFlushInterval = time.Second
+	// remoteExportTimeout is the timeout of a single export attempt.
+	remoteExportTimeout = 10 * time.Second
+)
+
+// Exporter sends batches of events to a remote backend.
+type Exporter interface {
+	Export(ctx context.Context, events []Event[Entry]) error
+}
+
+// RetryPolicy dictates how a remote tracer retries exporting a batch of events
+// before dropping it.
+type RetryPolicy struct {
+	// MaxRetries is the number of retries after the first failed attempt.
+	MaxRetries int
+	// Backoff is the time to wait before the first retry. It doubles with
+	// each retry.
+	Backoff time.Duration
+	// MaxBackoff caps the time to wait between retries.
+	MaxBackoff time.Duration
+}
+
+// backoff returns the time to wait before the given retry (starting at 0).
+func (p RetryPolicy) backoff(retry int) time.Duration {
+	d := p.Backoff
+	for i := 0; i < retry && d < p.MaxBackoff; i++ {
+		d *= 2
+	}
+	if p.MaxBackoff > 0 && d > p.MaxBackoff {
+		d = p.MaxBackoff
+	}
+	return d
+}
+
+// BackpressurePolicy dictates what a remote tracer does when its buffer is
+// full because the backend can't keep up with the events being written.
+type BackpressurePolicy uint8
+
+const (
+	// DropNewest drops the event being written. Writers are never blocked.
+	DropNewest BackpressurePolicy = iota
+	// DropOldest drops the oldest buffered event to make room for the event
+	// being written. Writers are never blocked.
+	DropOldest
+	// Block blocks writers until there is room in the buffer. This should
+	// only be used for backends that must not lose events, as it slows down
+	// the node to the pace of the backend.
+	Block
+)
+
+// PermanentError wraps an export error that won't be resolved by retrying,
+// for example a rejected request. Batches failing with a PermanentError are
+// dropped immediately.
+type PermanentError struct {
+	Err error
+}
+
+func (e PermanentError) Error() string {
+	return fmt.Sprintf("permanent export error: %v", e.Err)
+}
+
+func (e PermanentError) Unwrap() error {
+	return e.Err
+}
+
+// RemoteTracer buffers events and exports them in batches to a remote backend
+// using an Exporter. Batches are retried according to the RetryPolicy, and
+// events written while the buffer is full are handled according to the
+// BackpressurePolicy.
+type RemoteTracer struct {
+	chainID, nodeID string
+	logger          log.Logger
+
+	exporter     Exporter
+	retry        RetryPolicy
+	backpressure BackpressurePolicy
+	batchSize    int
+
+	tables map[string]struct{}
+
+	// canal buffers the events that haven't been exported yet.
+	canal chan Event[Entry]
+	// dropped counts the events dropped since the last export.
+	dropped atomic.Uint64
+
+	stopOnce sync.Once
+	quit     chan struct{}
+	done     chan struct{}
+}
+
+// NewRemoteTracer creates a tracer that exports the tables listed in the
+// tracing_tables config option using the given exporter and policies. The
+// goroutine exporting the events is started in this function.
+func NewRemoteTracer(
+	cfg *config.Config,
+	logger log.Logger,
+	chainID, nodeID string,
+	exporter Exporter,
+	retry RetryPolicy,
+	backpressure BackpressurePolicy,
+) *RemoteTracer {
+	tables := make(map[string]struct{})
+	for _, table := range splitAndTrimEmpty(cfg.Instrumentation.TracingTables, ",", " ") {
+		tables[table] = struct{}{}
+	}
+
+	batchSize := cfg.Instrumentation.TraceBufferSize
+	if batchSize <= 0 {
+		batchSize = config.DefaultInstrumentationConfig().TraceBufferSize
+	}
+
+	rt := &RemoteTracer{
+		chainID:      chainID,
+		nodeID:       nodeID,
+		logger:       logger,
+		exporter:     exporter,
+		retry:        retry,
+		backpressure: backpressure,
+		batchSize:    batchSize,
+		tables:       tables,
+		canal:        make(chan Event[Entry], batchSize),
+		quit:         make(chan struct{}),
+		done:         make(chan struct{}),
+	}
+
+	go rt.exportLoop()
+
+	return rt
+}
+
+func (rt *RemoteTracer) Write(e Entry) {
+	if !rt.IsCollecting(e.Table()) {
+		return
+	}
+	ev := NewEvent(rt.chainID, rt.nodeID, e.Table(), e)
+
+	switch rt.backpressure {
+	case Block:
+		select {
+		case rt.canal <- ev:
+		case <-rt.quit:
+		}
+	case DropOldest:
+		for {
+			select {
+			case rt.canal <- ev:
+				return
+			default:
+			}
+			select {
+			case <-rt.canal:
+				rt.dropped.Add(1)
+			default:
+			}
+		}
+	default:
+		select {
+		case rt.canal <- ev:
+		default:
+			rt.dropped.Add(1)
+		}
+	}
+}
+
+func (rt *RemoteTracer) IsCollecting(table string) bool {
+	_, has := rt.tables[table]
+	return has
+}
+
+// Stop exports the buffered events and stops the tracer.
+func (rt *RemoteTracer) Stop() {
+	rt.stopOnce.Do(func() {
+		close(rt.quit)
+		<-rt.done
+	})
+}
+
+// exportLoop collects events into batches and exports them once a batch is
+// full or the flush interval has passed.
+func (rt *RemoteTracer) exportLoop() {
+	defer close(rt.done)
+
+	ticker := time.NewTicker(remoteFlushInterval)
+	defer ticker.Stop()
+
+	batch := make([]Event[Entry], 0, rt.batchSize)
+	for {
+		select {
+		case ev := <-rt.canal:
+			batch = append(batch, ev)
+			if len(batch) < rt.batchSize {
+				continue
+			}
+		case <-ticker.C:
+		case <-rt.quit:
+			// drain what's left and give it a last try
+		drain:
+			for {
+				select {
+				case ev := <-rt.canal:
+					batch = append(batch, ev)
+				default:
+					break drain
+				}
+			}
+			rt.export(batch)
+			return
+		}
+
+		batch = rt.export(batch)
+	}
+}
+
+// export exports the batch, retrying according to the retry policy, and
+// returns the emptied batch for reuse. The batch is dropped if all attempts
+// fail.
+func (rt *RemoteTracer) export(batch []Event[Entry]) []Event[Entry] {
+	if dropped := rt.dropped.Swap(0); dropped > 0 {
+		rt.logger.Error("trace backend can't keep up, dropped events", "count", dropped)
+	}
+	if len(batch) == 0 {
+		return batch
+	}
+
+	var err error
+	for attempt := 0; ; attempt++ {
+		ctx, cancel := context.WithTimeout(context.Background(), remoteExportTimeout)
+		err = rt.exporter.Export(ctx, batch)
+		cancel()
+		if err == nil {
+			return batch[:0]
+		}
+
+		var permanent PermanentError
+		if errors.As(err, &permanent) || attempt >= rt.retry.MaxRetries {
+			break
+		}
+		rt.logger.Debug("failed to export trace events, retrying", "attempt", attempt+1, "err", err)
+
+		// don't hold up stopping the tracer with retries
+		select {
+		case <-time.After(rt.retry.backoff(attempt)):
+			continue
+		case <-rt.quit:
+		}
+		break
+	}
+
+	rt.logger.Error("failed to export trace events, dropping batch", "count", len(batch), "err", err)
+	return batch[:0]
+}
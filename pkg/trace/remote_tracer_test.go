@@ -0,0 +1,173 @@
+package trace
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"github.com/tendermint/tendermint/config"
+	"github.com/tendermint/tendermint/libs/log"
+)
+
+func remoteTestConfig(url string) *config.Config {
+	cfg := config.DefaultConfig()
+	cfg.Instrumentation.TracingTables = testEventTable
+	cfg.Instrumentation.TraceBufferSize = 2
+	cfg.Instrumentation.TraceType = "http"
+	cfg.Instrumentation.TraceRemoteURL = url
+	return cfg
+}
+
+func TestHTTPTracer(t *testing.T) {
+	var (
+		mtx      sync.Mutex
+		received []Event[testEvent]
+		attempts atomic.Int32
+	)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, NDJSONContentType, r.Header.Get("Content-Type"))
+		// fail the first attempt to exercise the retries
+		if attempts.Add(1) == 1 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		mtx.Lock()
+		defer mtx.Unlock()
+		scanner := bufio.NewScanner(r.Body)
+		for scanner.Scan() {
+			var ev Event[testEvent]
+			require.NoError(t, json.Unmarshal(scanner.Bytes(), &ev))
+			received = append(received, ev)
+		}
+	}))
+	defer srv.Close()
+
+	tracer, err := NewTracer(remoteTestConfig(srv.URL), log.TestingLogger(), "test_chain", "test_node")
+	require.NoError(t, err)
+	require.IsType(t, &RemoteTracer{}, tracer)
+	require.False(t, tracer.IsCollecting("other"))
+
+	annecy := testEvent{"Annecy", 420}
+	paris := testEvent{"Paris", 420}
+	tracer.Write(annecy)
+	tracer.Write(paris)
+
+	require.Eventually(t, func() bool {
+		mtx.Lock()
+		defer mtx.Unlock()
+		return len(received) == 2
+	}, 5*time.Second, 10*time.Millisecond)
+	tracer.Stop()
+
+	require.Equal(t, annecy, received[0].Msg)
+	require.Equal(t, paris, received[1].Msg)
+	require.Equal(t, "test_node", received[0].NodeID)
+	require.EqualValues(t, 2, attempts.Load())
+}
+
+func TestHTTPTracerRequiresURL(t *testing.T) {
+	_, err := NewTracer(remoteTestConfig(""), log.TestingLogger(), "test_chain", "test_node")
+	require.Error(t, err)
+}
+
+// exporterFunc adapts a function to the Exporter interface.
+type exporterFunc func(ctx context.Context, events []Event[Entry]) error
+
+func (f exporterFunc) Export(ctx context.Context, events []Event[Entry]) error {
+	return f(ctx, events)
+}
+
+func TestRemoteTracerPermanentError(t *testing.T) {
+	var attempts atomic.Int32
+	exporter := exporterFunc(func(context.Context, []Event[Entry]) error {
+		attempts.Add(1)
+		return PermanentError{Err: context.Canceled}
+	})
+	retry := RetryPolicy{MaxRetries: 5, Backoff: time.Millisecond}
+	tracer := NewRemoteTracer(remoteTestConfig(""), log.TestingLogger(), "test_chain", "test_node",
+		exporter, retry, DropNewest)
+
+	tracer.Write(testEvent{"Annecy", 420})
+	tracer.Stop()
+	require.EqualValues(t, 1, attempts.Load())
+}
+
+func TestRemoteTracerBackpressure(t *testing.T) {
+	testCases := []struct {
+		policy BackpressurePolicy
+		cities []string
+	}{
+		// the first event is being exported while the others compete for
+		// the single slot in the buffer
+		{DropNewest, []string{"Annecy", "Paris"}},
+		{DropOldest, []string{"Annecy", "Rennes"}},
+	}
+	for _, tc := range testCases {
+		var (
+			mtx       sync.Mutex
+			exported  []string
+			exporting = make(chan struct{})
+			unblock   = make(chan struct{})
+		)
+		exporter := exporterFunc(func(_ context.Context, events []Event[Entry]) error {
+			mtx.Lock()
+			first := len(exported) == 0
+			for _, ev := range events {
+				exported = append(exported, ev.Msg.(testEvent).City)
+			}
+			mtx.Unlock()
+			if first {
+				close(exporting)
+				<-unblock
+			}
+			return nil
+		})
+		cfg := remoteTestConfig("")
+		cfg.Instrumentation.TraceBufferSize = 1
+		tracer := NewRemoteTracer(cfg, log.TestingLogger(), "test_chain", "test_node",
+			exporter, RetryPolicy{}, tc.policy)
+
+		tracer.Write(testEvent{"Annecy", 420})
+		<-exporting
+		for _, city := range []string{"Paris", "Migennes", "Pontivy", "Rennes"} {
+			tracer.Write(testEvent{city, 420})
+		}
+		close(unblock)
+		tracer.Stop()
+
+		require.Equal(t, tc.cities, exported)
+	}
+}
+
+func TestOTLPRequestFromEvents(t *testing.T) {
+	now := time.Unix(0, 1000)
+	events := []Event[Entry]{
+		{ChainID: "test_chain", NodeID: "a", Table: testEventTable, Timestamp: now, Msg: testEvent{"Annecy", 420}},
+		{ChainID: "test_chain", NodeID: "a", Table: testEventTable, Timestamp: now.Add(1), Msg: testEvent{"Paris", 42}},
+		{ChainID: "test_chain", NodeID: "b", Table: testEventTable, Timestamp: now, Msg: testEvent{"Paris", 42}},
+	}
+
+	req := otlpRequestFromEvents(events)
+	require.Len(t, req.ResourceSpans, 2)
+
+	spans := req.ResourceSpans[0].ScopeSpans[0].Spans
+	require.Len(t, spans, 1)
+	require.Equal(t, testEventTable, spans[0].Name)
+	require.Equal(t, "1000", spans[0].StartTimeUnixNano)
+	require.Equal(t, "1001", spans[0].EndTimeUnixNano)
+	require.Len(t, spans[0].Events, 2)
+
+	attrs := spans[0].Events[0].Attributes
+	require.Len(t, attrs, 2)
+	require.Equal(t, "city", attrs[0].Key)
+	require.Equal(t, "Annecy", *attrs[0].Value.StringValue)
+	require.Equal(t, "length", attrs[1].Key)
+	require.Equal(t, "420", *attrs[1].Value.IntValue)
+}
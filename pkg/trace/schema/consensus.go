@@ -1,6 +1,8 @@
 package schema
 
 import (
+	"time"
+
 	"github.com/tendermint/tendermint/pkg/trace"
 	"github.com/tendermint/tendermint/types"
 )
@@ -15,6 +17,7 @@ func ConsensusTables() []string {
 		VoteTable,
 		ConsensusStateTable,
 		ProposalTable,
+		TimeoutTable,
 	}
 }
 
@@ -248,3 +251,75 @@ func WriteProposal(
 		TransferType: transferType,
 	})
 }
+
+// Schema constants for the "consensus_timeout" table.
+const (
+	// TimeoutTable is the name of the table that stores the propose and
+	// prevote timeouts decided by the adaptive timeouts.
+	TimeoutTable = "consensus_timeout"
+)
+
+// TimeoutStep is the step of the round a timeout is decided for.
+type TimeoutStep string
+
+const (
+	// TimeoutPropose is the propose timeout scheduled on entering the propose
+	// step.
+	TimeoutPropose TimeoutStep = "propose"
+	// TimeoutProposeExtended is the propose timeout extended once the size of
+	// the proposal block is known.
+	TimeoutProposeExtended TimeoutStep = "propose_extended"
+	// TimeoutPrevote is the prevote timeout.
+	TimeoutPrevote TimeoutStep = "prevote"
+)
+
+// Timeout describes schema for the "consensus_timeout" table. The durations
+// are in milliseconds.
+type Timeout struct {
+	Height int64  `json:"height"`
+	Round  int32  `json:"round"`
+	Step   string `json:"step"`
+	// Parts is the number of parts of the proposal block the timeout is
+	// scaled by, estimated from recent heights if the proposal isn't known.
+	Parts uint32 `json:"parts"`
+	// PartTime is the mean time between the arrival of the parts of the
+	// proposal blocks of recent heights, zero if none was observed.
+	PartTime float64 `json:"part_time"`
+	// Static is the timeout set by the config and the application.
+	Static float64 `json:"static"`
+	// Timeout is the timeout scheduled.
+	Timeout float64 `json:"timeout"`
+}
+
+// Table returns the table name for the Timeout struct.
+func (t Timeout) Table() string {
+	return TimeoutTable
+}
+
+// WriteTimeout writes a tracing point for a timeout decided by the adaptive
+// timeouts using the predetermined schema for consensus state tracing.
+func WriteTimeout(
+	client trace.Tracer,
+	height int64,
+	round int32,
+	step TimeoutStep,
+	parts uint32,
+	partTime, static, timeout time.Duration,
+) {
+	if !client.IsCollecting(TimeoutTable) {
+		return
+	}
+	client.Write(Timeout{
+		Height:   height,
+		Round:    round,
+		Step:     string(step),
+		Parts:    parts,
+		PartTime: milliseconds(partTime),
+		Static:   milliseconds(static),
+		Timeout:  milliseconds(timeout),
+	})
+}
+
+func milliseconds(d time.Duration) float64 {
+	return float64(d) / float64(time.Millisecond)
+}
@@ -8,6 +8,7 @@ func P2PTables() []string {
 		PeersTable,
 		PendingBytesTable,
 		ReceivedBytesTable,
+		ChannelThroughputTable,
 	}
 }
 
@@ -80,3 +81,45 @@ func (s ReceivedBytes) Table() string {
 func WriteReceivedBytes(client trace.Tracer, peerID string, channel byte, bytes int) {
 	client.Write(ReceivedBytes{PeerID: peerID, Channel: channel, Bytes: bytes})
 }
+
+const (
+	// ChannelThroughputTable is the name of the table that stores the bytes
+	// sent and received on each channel of each peer connection, along with
+	// the depth of the send queue.
+	ChannelThroughputTable = "p2p_channel_throughput"
+)
+
+// ChannelThroughput describes schema for the "p2p_channel_throughput" table.
+// The bytes are the ones sent and received since the previous entry for the
+// same peer and channel.
+type ChannelThroughput struct {
+	PeerID            string `json:"peer_id"`
+	Channel           byte   `json:"channel"`
+	SentBytes         int64  `json:"sent_bytes"`
+	RecvBytes         int64  `json:"recv_bytes"`
+	SendQueueSize     int    `json:"send_queue_size"`
+	SendQueueCapacity int    `json:"send_queue_capacity"`
+}
+
+func (c ChannelThroughput) Table() string {
+	return ChannelThroughputTable
+}
+
+// WriteChannelThroughput writes a tracing point for the throughput of a
+// channel of a peer connection.
+func WriteChannelThroughput(
+	client trace.Tracer,
+	peerID string,
+	channel byte,
+	sentBytes, recvBytes int64,
+	sendQueueSize, sendQueueCapacity int,
+) {
+	client.Write(ChannelThroughput{
+		PeerID:            peerID,
+		Channel:           channel,
+		SentBytes:         sentBytes,
+		RecvBytes:         recvBytes,
+		SendQueueSize:     sendQueueSize,
+		SendQueueCapacity: sendQueueCapacity,
+	})
+}
@@ -15,6 +15,7 @@ func AllTables() []string {
 	tables = append(tables, MempoolTables()...)
 	tables = append(tables, ConsensusTables()...)
 	tables = append(tables, P2PTables()...)
+	tables = append(tables, SyncTables()...)
 	tables = append(tables, ABCITable)
 	return tables
 }
@@ -0,0 +1,141 @@
+package schema
+
+import (
+	"github.com/tendermint/tendermint/pkg/trace"
+)
+
+// SyncTables returns the list of tables that are used for block sync and
+// state sync tracing.
+func SyncTables() []string {
+	return []string{
+		BlockSyncTable,
+		SnapshotChunkTable,
+	}
+}
+
+// Schema constants for the "blocksync" table.
+const (
+	// BlockSyncTable is the name of the table that stores the block requests
+	// and responses exchanged with peers during block sync.
+	BlockSyncTable = "blocksync"
+)
+
+// BlockSyncUpdateType is the block sync message being traced.
+type BlockSyncUpdateType string
+
+const (
+	BlockSyncRequest         BlockSyncUpdateType = "block_request"
+	BlockSyncResponse        BlockSyncUpdateType = "block_response"
+	BlockSyncNoBlockResponse BlockSyncUpdateType = "no_block_response"
+)
+
+// BlockSync describes schema for the "blocksync" table. Requests and
+// responses are matched by peer and height, the time between them being the
+// response time of the peer.
+type BlockSync struct {
+	Height     int64  `json:"height"`
+	Peer       string `json:"peer"`
+	UpdateType string `json:"update_type"`
+	// Size is the size of the block in a block response.
+	Size         int          `json:"size,omitempty"`
+	TransferType TransferType `json:"transfer_type"`
+}
+
+// Table returns the table name for the BlockSync struct.
+func (b BlockSync) Table() string {
+	return BlockSyncTable
+}
+
+// WriteBlockSync writes a tracing point for a block sync message using the
+// predetermined schema for block sync tracing.
+func WriteBlockSync(
+	client trace.Tracer,
+	height int64,
+	peer string,
+	updateType BlockSyncUpdateType,
+	size int,
+	transferType TransferType,
+) {
+	// this check is redundant to what is checked during client.Write, although it
+	// is an optimization to avoid allocations from creating the entry.
+	if !client.IsCollecting(BlockSyncTable) {
+		return
+	}
+	client.Write(BlockSync{
+		Height:       height,
+		Peer:         peer,
+		UpdateType:   string(updateType),
+		Size:         size,
+		TransferType: transferType,
+	})
+}
+
+// Schema constants for the "statesync_chunk" table.
+const (
+	// SnapshotChunkTable is the name of the table that stores the fetching
+	// and applying of snapshot chunks during state sync.
+	SnapshotChunkTable = "statesync_chunk"
+)
+
+// SnapshotChunkUpdateType is the step of the handling of a chunk being
+// traced.
+type SnapshotChunkUpdateType string
+
+const (
+	SnapshotChunkRequest    SnapshotChunkUpdateType = "request"
+	SnapshotChunkResponse   SnapshotChunkUpdateType = "response"
+	SnapshotChunkApplyStart SnapshotChunkUpdateType = "apply_start"
+	SnapshotChunkApplyEnd   SnapshotChunkUpdateType = "apply_end"
+)
+
+// SnapshotChunk describes schema for the "statesync_chunk" table. Chunk
+// requests are matched to their responses by peer, height, format and index,
+// and the application of a chunk spans from its apply_start to its apply_end.
+type SnapshotChunk struct {
+	Height     uint64 `json:"height"`
+	Format     uint32 `json:"format"`
+	Index      uint32 `json:"index"`
+	Peer       string `json:"peer,omitempty"`
+	UpdateType string `json:"update_type"`
+	// Size is the size of the chunk in a response.
+	Size int `json:"size,omitempty"`
+	// Result is the missing flag of a response, or the result of the
+	// application of a chunk.
+	Result       string       `json:"result,omitempty"`
+	TransferType TransferType `json:"transfer_type"`
+}
+
+// Table returns the table name for the SnapshotChunk struct.
+func (s SnapshotChunk) Table() string {
+	return SnapshotChunkTable
+}
+
+// WriteSnapshotChunk writes a tracing point for a snapshot chunk using the
+// predetermined schema for state sync tracing.
+func WriteSnapshotChunk(
+	client trace.Tracer,
+	height uint64,
+	format uint32,
+	index uint32,
+	peer string,
+	updateType SnapshotChunkUpdateType,
+	size int,
+	result string,
+	transferType TransferType,
+) {
+	// this check is redundant to what is checked during client.Write, although it
+	// is an optimization to avoid allocations from creating the entry.
+	if !client.IsCollecting(SnapshotChunkTable) {
+		return
+	}
+	client.Write(SnapshotChunk{
+		Height:       height,
+		Format:       format,
+		Index:        index,
+		Peer:         peer,
+		UpdateType:   string(updateType),
+		Size:         size,
+		Result:       result,
+		TransferType: transferType,
+	})
+}
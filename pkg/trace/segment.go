@@ -0,0 +1,314 @@
+package trace
+
+import (
+	"bufio"
+	"bytes"
+	"compress/gzip"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/klauspost/compress/zstd"
+)
+
+const (
+	gzipExt = ".gz"
+	zstdExt = ".zst"
+	tmpExt  = ".tmp"
+)
+
+var (
+	gzipMagic = []byte{0x1f, 0x8b}
+	zstdMagic = []byte{0x28, 0xb5, 0x2f, 0xfd}
+)
+
+// segment is a rotated part of a table. Segments are named after the range of
+// offsets of the table they hold, <table>.<start>-<end>.jsonl, followed by the
+// extension of their compression if any. Naming them after the offsets keeps
+// the offsets of the lines of a table valid across rotations.
+type segment struct {
+	path       string
+	start, end int64
+}
+
+// segmentPath returns the path of the uncompressed segment of the table
+// holding the offsets between start and end.
+func segmentPath(dir, table string, start, end int64) string {
+	return filepath.Join(dir, fmt.Sprintf("%s.%020d-%020d%s", table, start, end, jsonL))
+}
+
+// parseSegment returns the offsets held by the segment of the table with the
+// given file name, or false if the file isn't a segment of the table.
+func parseSegment(name, table string) (start, end int64, ok bool) {
+	rest, ok := strings.CutPrefix(name, table+".")
+	if !ok {
+		return 0, 0, false
+	}
+	rest = strings.TrimSuffix(strings.TrimSuffix(rest, gzipExt), zstdExt)
+	rest, ok = strings.CutSuffix(rest, jsonL)
+	if !ok {
+		return 0, 0, false
+	}
+	first, last, ok := strings.Cut(rest, "-")
+	if !ok {
+		return 0, 0, false
+	}
+	start, err := strconv.ParseInt(first, 10, 64)
+	if err != nil {
+		return 0, 0, false
+	}
+	end, err = strconv.ParseInt(last, 10, 64)
+	if err != nil || end < start {
+		return 0, 0, false
+	}
+	return start, end, true
+}
+
+// listSegments returns the rotated segments of the table in dir, ordered by
+// offset. While a segment is being compressed, both of its files exist, in
+// which case the uncompressed one is returned.
+func listSegments(dir, table string) ([]segment, error) {
+	entries, err := os.ReadDir(dir)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	} else if err != nil {
+		return nil, err
+	}
+
+	byStart := make(map[int64]segment)
+	for _, e := range entries {
+		start, end, ok := parseSegment(e.Name(), table)
+		if !ok {
+			continue
+		}
+		path := filepath.Join(dir, e.Name())
+		if s, has := byStart[start]; has && len(s.path) < len(path) {
+			continue
+		}
+		byStart[start] = segment{path: path, start: start, end: end}
+	}
+
+	segments := make([]segment, 0, len(byStart))
+	for _, s := range byStart {
+		segments = append(segments, s)
+	}
+	sort.Slice(segments, func(i, j int) bool { return segments[i].start < segments[j].start })
+	return segments, nil
+}
+
+// compressed returns true if the segment has already been compressed.
+func (s segment) compressed() bool {
+	return filepath.Ext(s.path) != jsonL
+}
+
+// open opens the segment for reading, decompressing it if needed. If the
+// segment has been compressed since it was listed, the compressed file is
+// opened instead.
+func (s segment) open() (io.ReadCloser, error) {
+	f, err := os.Open(s.path)
+	if errors.Is(err, os.ErrNotExist) && !s.compressed() {
+		for _, ext := range []string{gzipExt, zstdExt} {
+			if f, err = os.Open(s.path + ext); err == nil {
+				break
+			}
+		}
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	r, err := decompress(f)
+	if err != nil {
+		f.Close()
+		return nil, err
+	}
+	return readCloser{r, func() error {
+		r.Close()
+		return f.Close()
+	}}, nil
+}
+
+// compress compresses the segment with the given compression, replacing its
+// uncompressed file. It returns the compressed segment.
+func (s segment) compress(compression string) (segment, error) {
+	var ext string
+	switch compression {
+	case "gzip":
+		ext = gzipExt
+	case "zstd":
+		ext = zstdExt
+	default:
+		return s, nil
+	}
+	if s.compressed() {
+		return s, nil
+	}
+
+	src, err := os.Open(s.path)
+	if err != nil {
+		return s, err
+	}
+	defer src.Close()
+	fi, err := src.Stat()
+	if err != nil {
+		return s, err
+	}
+
+	// write to a temporary file first, so that readers never see a partially
+	// compressed segment
+	tmp := s.path + ext + tmpExt
+	dst, err := os.OpenFile(tmp, os.O_CREATE|os.O_TRUNC|os.O_WRONLY, 0644)
+	if err != nil {
+		return s, err
+	}
+	defer os.Remove(tmp)
+	defer dst.Close()
+
+	var zw io.WriteCloser
+	if compression == "gzip" {
+		zw = gzip.NewWriter(dst)
+	} else if zw, err = zstd.NewWriter(dst); err != nil {
+		return s, err
+	}
+	if _, err := io.Copy(zw, src); err != nil {
+		return s, err
+	}
+	if err := zw.Close(); err != nil {
+		return s, err
+	}
+	if err := dst.Sync(); err != nil {
+		return s, err
+	}
+	if err := dst.Close(); err != nil {
+		return s, err
+	}
+	// keep the rotation time, which orders the segments of all tables when
+	// enforcing the disk budget
+	if err := os.Chtimes(tmp, fi.ModTime(), fi.ModTime()); err != nil {
+		return s, err
+	}
+	if err := os.Rename(tmp, s.path+ext); err != nil {
+		return s, err
+	}
+	if err := os.Remove(s.path); err != nil {
+		return s, err
+	}
+	s.path += ext
+	return s, nil
+}
+
+// readSegments returns a reader of the rotated segments of the table between
+// the from and to offsets. Segments are opened one at a time as they are read.
+func readSegments(dir, table string, from, to int64) (io.ReadCloser, error) {
+	segments, err := listSegments(dir, table)
+	if err != nil {
+		return nil, err
+	}
+	r := &segmentsReader{from: from, to: to}
+	for _, s := range segments {
+		if s.end > from && s.start < to {
+			r.segments = append(r.segments, s)
+		}
+	}
+	return r, nil
+}
+
+// segmentsReader reads a range of offsets across segments.
+type segmentsReader struct {
+	segments []segment
+	from, to int64
+
+	cur io.ReadCloser
+}
+
+func (r *segmentsReader) Read(p []byte) (int, error) {
+	for {
+		if r.cur == nil {
+			if len(r.segments) == 0 {
+				return 0, io.EOF
+			}
+			if err := r.next(); err != nil {
+				return 0, err
+			}
+		}
+		n, err := r.cur.Read(p)
+		if err == io.EOF {
+			err = r.cur.Close()
+			r.cur = nil
+			if n == 0 && err == nil {
+				continue
+			}
+		}
+		return n, err
+	}
+}
+
+// next opens the next segment, positioned at the first offset in range.
+func (r *segmentsReader) next() error {
+	s := r.segments[0]
+	r.segments = r.segments[1:]
+
+	rc, err := s.open()
+	if err != nil {
+		return err
+	}
+	start, end := max(s.start, r.from), min(s.end, r.to)
+	if _, err := io.CopyN(io.Discard, rc, start-s.start); err != nil {
+		rc.Close()
+		return fmt.Errorf("failed to seek segment %s: %w", s.path, err)
+	}
+	r.cur = readCloser{io.LimitReader(rc, end-start), rc.Close}
+	return nil
+}
+
+func (r *segmentsReader) Close() error {
+	if r.cur == nil {
+		return nil
+	}
+	err := r.cur.Close()
+	r.cur = nil
+	return err
+}
+
+// decompress returns a reader of the decompressed content of r, detecting the
+// compression from its magic number. Uncompressed content is returned as is.
+// Closing the returned reader doesn't close r.
+func decompress(r io.Reader) (io.ReadCloser, error) {
+	br := bufio.NewReader(r)
+	// a short read only means that the content is too short to be compressed
+	magic, _ := br.Peek(len(zstdMagic))
+	switch {
+	case bytes.HasPrefix(magic, gzipMagic):
+		zr, err := gzip.NewReader(br)
+		if err != nil {
+			return nil, err
+		}
+		return zr, nil
+	case bytes.HasPrefix(magic, zstdMagic):
+		zr, err := zstd.NewReader(br)
+		if err != nil {
+			return nil, err
+		}
+		return readCloser{zr, func() error {
+			zr.Close()
+			return nil
+		}}, nil
+	default:
+		return io.NopCloser(br), nil
+	}
+}
+
+// readCloser combines a reader with a close function.
+type readCloser struct {
+	io.Reader
+	close func() error
+}
+
+func (rc readCloser) Close() error {
+	return rc.close()
+}
@@ -0,0 +1,388 @@
+package trace
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	// streamBufferSize is the number of lines a stream can fall behind the
+	// writes to a table before it is disconnected.
+	streamBufferSize = 1000
+	// streamKeepAlive is the interval at which comments are sent on idle
+	// streams, so that proxies keep the connection open and disconnected
+	// clients are noticed.
+	streamKeepAlive = 15 * time.Second
+
+	// EventStreamContentType is the content type of table streams.
+	EventStreamContentType = "text/event-stream"
+)
+
+// streamTablesHandler streams tables as server-sent events. Every event has
+// the table as its type, "<table>:<offset>" as its id, and a single line of the
+// table as its data, i.e. a JSON encoded Event. The offset is the byte offset
+// of the line in the table, which can be passed back as the offset parameter
+// to resume a single table stream.
+//
+// Query parameters:
+//   - table: the tables to stream. It can be repeated or comma separated.
+//   - offset: if set, the lines already written to the tables starting at that
+//     offset are sent before the new ones, including the ones in rotated
+//     segments.
+//   - since: if set, the lines already written to the tables are sent before
+//     the new ones, skipping events with a timestamp before since (RFC 3339).
+//
+// Without offset and since, only the lines written after connecting are sent.
+func (lt *LocalTracer) streamTablesHandler() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if err := r.ParseForm(); err != nil {
+			http.Error(w, "Failed to parse form", http.StatusBadRequest)
+			return
+		}
+
+		var tables []string
+		for _, v := range r.Form["table"] {
+			tables = append(tables, splitAndTrimEmpty(v, ",", " ")...)
+		}
+		if len(tables) == 0 {
+			http.Error(w, "No table provided", http.StatusBadRequest)
+			return
+		}
+
+		var (
+			catchUp   bool
+			hasOffset bool
+			offset    int64
+			since     time.Time
+			err       error
+		)
+		if v := r.FormValue("offset"); v != "" {
+			catchUp, hasOffset = true, true
+			offset, err = strconv.ParseInt(v, 10, 64)
+			if err != nil || offset < 0 {
+				http.Error(w, fmt.Sprintf("invalid offset %q", v), http.StatusBadRequest)
+				return
+			}
+		}
+		if v := r.FormValue("since"); v != "" {
+			catchUp = true
+			since, err = time.Parse(time.RFC3339Nano, v)
+			if err != nil {
+				http.Error(w, fmt.Sprintf("invalid since %q: %v", v, err), http.StatusBadRequest)
+				return
+			}
+		}
+
+		flusher, ok := w.(http.Flusher)
+		if !ok {
+			http.Error(w, "Streaming is not supported", http.StatusInternalServerError)
+			return
+		}
+
+		// subscribe to all tables before catching up, so that no line is missed
+		subs := make([]<-chan tableLine, len(tables))
+		sizes := make([]int64, len(tables))
+		for i, table := range tables {
+			bf, has := lt.getFile(table)
+			if !has {
+				http.Error(w, fmt.Sprintf("table %s not found", table), http.StatusNotFound)
+				return
+			}
+			sub, size, unsubscribe, err := bf.subscribe(streamBufferSize)
+			if err != nil {
+				http.Error(w, fmt.Sprintf("failed to subscribe to table: %v", err), http.StatusInternalServerError)
+				return
+			}
+			defer unsubscribe()
+			if offset > size {
+				http.Error(w, fmt.Sprintf("offset %d is past the end of table %s", offset, table), http.StatusBadRequest)
+				return
+			}
+			// without an offset, catching up starts at the first line
+			if hasOffset {
+				if err := lt.checkLineStart(table, offset); err != nil {
+					http.Error(w, fmt.Sprintf("invalid offset for table %s: %v", table, err), http.StatusBadRequest)
+					return
+				}
+			}
+			subs[i], sizes[i] = sub, size
+		}
+
+		w.Header().Set("Content-Type", EventStreamContentType)
+		w.Header().Set("Cache-Control", "no-cache")
+		w.WriteHeader(http.StatusOK)
+
+		if catchUp {
+			for i, table := range tables {
+				err := lt.readLines(table, offset, sizes[i], func(line tableLine) error {
+					if !since.IsZero() && lineBefore(line.data, since) {
+						return nil
+					}
+					return writeStreamEvent(w, table, line)
+				})
+				if err != nil {
+					lt.logger.Error("failed to stream table", "table", table, "err", err)
+					return
+				}
+			}
+		}
+		flusher.Flush()
+
+		// fan in the subscriptions
+		lines := make(chan streamedLine)
+		for i, table := range tables {
+			go func(table string, sub <-chan tableLine) {
+				for line := range sub {
+					select {
+					case lines <- streamedLine{table, line}:
+					case <-r.Context().Done():
+						return
+					}
+				}
+				// the subscriber fell behind or the tracer was stopped
+				select {
+				case lines <- streamedLine{table: table}:
+				case <-r.Context().Done():
+				}
+			}(table, subs[i])
+		}
+
+		keepAlive := time.NewTicker(streamKeepAlive)
+		defer keepAlive.Stop()
+		for {
+			select {
+			case sl := <-lines:
+				if sl.line.data == nil {
+					lt.logger.Info("closing table stream", "table", sl.table, "reason", "subscriber fell behind")
+					return
+				}
+				if !since.IsZero() && lineBefore(sl.line.data, since) {
+					continue
+				}
+				if err := writeStreamEvent(w, sl.table, sl.line); err != nil {
+					return
+				}
+			case <-keepAlive.C:
+				if _, err := io.WriteString(w, ": keep-alive\n\n"); err != nil {
+					return
+				}
+			case <-r.Context().Done():
+				return
+			}
+			flusher.Flush()
+		}
+	}
+}
+
+type streamedLine struct {
+	table string
+	line  tableLine
+}
+
+// checkLineStart returns an error if a line of the table doesn't start at the
+// given offset, or if it has been deleted to enforce the disk budget.
+func (lt *LocalTracer) checkLineStart(table string, offset int64) error {
+	first, err := lt.firstOffset(table)
+	if err != nil {
+		return err
+	}
+	if offset < first {
+		return fmt.Errorf("offset %d has been deleted, the table starts at %d", offset, first)
+	}
+	if offset == first {
+		return nil
+	}
+
+	r, err := lt.openTable(table, offset-1, offset)
+	if err != nil {
+		return err
+	}
+	defer r.Close()
+
+	b := make([]byte, 1)
+	if _, err := io.ReadFull(r, b); err != nil {
+		return err
+	}
+	if b[0] != '\n' {
+		return fmt.Errorf("offset %d is not the start of a line", offset)
+	}
+	return nil
+}
+
+// readLines calls fn with every line of the table between the from and to
+// offsets, across its rotated segments. from must be the offset at which a
+// line starts. Lines deleted to enforce the disk budget are skipped.
+func (lt *LocalTracer) readLines(table string, from, to int64, fn func(tableLine) error) error {
+	first, err := lt.firstOffset(table)
+	if err != nil {
+		return err
+	}
+	from = max(from, first)
+
+	f, err := lt.openTable(table, from, to)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	r := bufio.NewReader(f)
+	offset := from
+	for {
+		line, err := r.ReadBytes('\n')
+		if err == io.EOF {
+			return nil
+		} else if err != nil {
+			return err
+		}
+		if err := fn(tableLine{offset: offset, data: line}); err != nil {
+			return err
+		}
+		offset += int64(len(line))
+	}
+}
+
+// lineBefore returns true if the event encoded in the line has a timestamp
+// before t.
+func lineBefore(line []byte, t time.Time) bool {
+	var ev struct {
+		Timestamp time.Time `json:"timestamp"`
+	}
+	if err := json.Unmarshal(line, &ev); err != nil {
+		return false
+	}
+	return ev.Timestamp.Before(t)
+}
+
+func writeStreamEvent(w io.Writer, table string, line tableLine) error {
+	_, err := fmt.Fprintf(w, "id: %s:%d\nevent: %s\ndata: %s\n\n",
+		table, line.offset, table, bytes.TrimRight(line.data, "\n"))
+	return err
+}
+
+// StreamOptions defines where a table stream starts.
+type StreamOptions struct {
+	// Offset, if set, starts the stream at the given byte offset of each
+	// table, as returned along with every streamed event.
+	Offset *int64
+	// Since, if set, starts the stream at the first event with a timestamp at
+	// or after it.
+	Since time.Time
+}
+
+// StreamedEvent is a single event received from a table stream, not yet
+// decoded.
+type StreamedEvent struct {
+	Table string
+	// Offset is the byte offset of the event in its table. Passing it as the
+	// StreamOptions.Offset resumes the stream at this event.
+	Offset int64
+	// Data is the JSON encoded Event.
+	Data []byte
+}
+
+// StreamTables connects to the pull server at serverURL and calls fn with
+// every event written to the given tables, until the context is canceled, fn
+// returns an error or the server closes the stream. The server closes streams
+// that can't keep up with the writes to the tables.
+func StreamTables(
+	ctx context.Context,
+	serverURL string,
+	tables []string,
+	opts StreamOptions,
+	fn func(StreamedEvent) error,
+) error {
+	params := url.Values{}
+	for _, table := range tables {
+		params.Add("table", table)
+	}
+	if opts.Offset != nil {
+		params.Set("offset", strconv.FormatInt(*opts.Offset, 10))
+	}
+	if !opts.Since.IsZero() {
+		params.Set("since", opts.Since.Format(time.RFC3339Nano))
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, serverURL+"/stream_tables?"+params.Encode(), nil)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Accept", EventStreamContentType)
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		msg, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+		return fmt.Errorf("unexpected status code: %d: %s", resp.StatusCode, bytes.TrimSpace(msg))
+	}
+
+	var (
+		ev      StreamedEvent
+		scanner = bufio.NewScanner(resp.Body)
+	)
+	// lines can be as large as the largest event
+	scanner.Buffer(make([]byte, 0, 64*1024), 16*1024*1024)
+	for scanner.Scan() {
+		line := scanner.Text()
+		switch {
+		case line == "":
+			if ev.Data == nil {
+				continue
+			}
+			if err := fn(ev); err != nil {
+				return err
+			}
+			ev = StreamedEvent{}
+		case strings.HasPrefix(line, ":"):
+			// comment, used as keep-alive
+		case strings.HasPrefix(line, "event: "):
+			ev.Table = strings.TrimPrefix(line, "event: ")
+		case strings.HasPrefix(line, "id: "):
+			id := strings.TrimPrefix(line, "id: ")
+			i := strings.LastIndexByte(id, ':')
+			if i < 0 {
+				return fmt.Errorf("invalid event id %q", id)
+			}
+			ev.Offset, err = strconv.ParseInt(id[i+1:], 10, 64)
+			if err != nil {
+				return fmt.Errorf("invalid event id %q: %w", id, err)
+			}
+		case strings.HasPrefix(line, "data: "):
+			ev.Data = append(ev.Data, strings.TrimPrefix(line, "data: ")...)
+		}
+	}
+	if err := scanner.Err(); err != nil && !errors.Is(err, context.Canceled) {
+		return err
+	}
+	return ctx.Err()
+}
+
+// StreamTable streams a single table, decoding every event into the schema
+// struct T. See StreamTables.
+func StreamTable[T any](
+	ctx context.Context,
+	serverURL, table string,
+	opts StreamOptions,
+	fn func(offset int64, ev Event[T]) error,
+) error {
+	return StreamTables(ctx, serverURL, []string{table}, opts, func(se StreamedEvent) error {
+		var ev Event[T]
+		if err := json.Unmarshal(se.Data, &ev); err != nil {
+			return fmt.Errorf("failed to decode event at offset %d: %w", se.Offset, err)
+		}
+		return fn(se.Offset, ev)
+	})
+}
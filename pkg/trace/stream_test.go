@@ -0,0 +1,86 @@
+package trace
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+// TestLocalTracerStreamTable tests catching up on a table and then following
+// the new writes to it.
+func TestLocalTracerStreamTable(t *testing.T) {
+	port, err := getFreePort()
+	require.NoError(t, err)
+	client := setupLocalTracer(t, port)
+	defer client.Stop()
+
+	for i := 0; i < 3; i++ {
+		client.Write(testEvent{"Annecy", i})
+	}
+
+	// Wait for the server to start and the events to be written
+	time.Sleep(100 * time.Millisecond)
+
+	url := fmt.Sprintf("http://localhost:%d", port)
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	// try to stream a table that is not being collected. error expected.
+	err = StreamTables(ctx, url, []string{"canal"}, StreamOptions{}, func(StreamedEvent) error { return nil })
+	require.Error(t, err)
+
+	var (
+		zero    int64
+		offsets []int64
+		events  = make(chan Event[testEvent], 10)
+		errc    = make(chan error, 1)
+	)
+	go func() {
+		errc <- StreamTable(ctx, url, testEventTable, StreamOptions{Offset: &zero},
+			func(offset int64, ev Event[testEvent]) error {
+				offsets = append(offsets, offset)
+				events <- ev
+				return nil
+			})
+	}()
+
+	for i := 0; i < 3; i++ {
+		require.Equal(t, i, (<-events).Msg.Length)
+	}
+
+	// new events are streamed as they are written
+	for i := 3; i < 5; i++ {
+		client.Write(testEvent{"Paris", i})
+	}
+	for i := 3; i < 5; i++ {
+		ev := <-events
+		require.Equal(t, "Paris", ev.Msg.City)
+		require.Equal(t, i, ev.Msg.Length)
+	}
+	cancel()
+	require.ErrorIs(t, <-errc, context.Canceled)
+
+	// resuming from an offset skips the events before it
+	ctx, cancel = context.WithCancel(context.Background())
+	defer cancel()
+	var resumed []int
+	err = StreamTable(ctx, url, testEventTable, StreamOptions{Offset: &offsets[3]},
+		func(_ int64, ev Event[testEvent]) error {
+			resumed = append(resumed, ev.Msg.Length)
+			if len(resumed) == 2 {
+				cancel()
+			}
+			return nil
+		})
+	require.ErrorIs(t, err, context.Canceled)
+	require.Equal(t, []int{3, 4}, resumed)
+
+	// offsets must point at the start of an event
+	bad := offsets[1] + 1
+	err = StreamTables(context.Background(), url, []string{testEventTable}, StreamOptions{Offset: &bad},
+		func(StreamedEvent) error { return nil })
+	require.Error(t, err)
+}
@@ -2,7 +2,10 @@ package trace
 
 import (
 	"errors"
+	"fmt"
 	"os"
+	"sort"
+	"sync"
 
 	"github.com/tendermint/tendermint/config"
 	"github.com/tendermint/tendermint/libs/log"
@@ -22,16 +25,67 @@ type Tracer interface {
 	Stop()
 }
 
-func NewTracer(cfg *config.Config, logger log.Logger, chainID, nodeID string) (Tracer, error) {
-	switch cfg.Instrumentation.TraceType {
-	case "local":
-		return NewLocalTracer(cfg, logger, chainID, nodeID)
-	case "noop":
+// TracerConstructor creates a Tracer for the given node. It is used to
+// register tracer backends that can be selected via the trace_type config
+// option.
+type TracerConstructor func(cfg *config.Config, logger log.Logger, chainID, nodeID string) (Tracer, error)
+
+var (
+	backendsMtx sync.RWMutex
+	backends    = map[string]TracerConstructor{}
+)
+
+func init() {
+	RegisterTracer("noop", func(*config.Config, log.Logger, string, string) (Tracer, error) {
 		return NoOpTracer(), nil
-	default:
-		logger.Error("unknown tracer type, using noop", "type", cfg.Instrumentation.TraceType)
+	})
+	RegisterTracer("local", func(cfg *config.Config, logger log.Logger, chainID, nodeID string) (Tracer, error) {
+		return NewLocalTracer(cfg, logger, chainID, nodeID)
+	})
+	RegisterTracer("http", func(cfg *config.Config, logger log.Logger, chainID, nodeID string) (Tracer, error) {
+		return NewHTTPTracer(cfg, logger, chainID, nodeID)
+	})
+	RegisterTracer("otlp", func(cfg *config.Config, logger log.Logger, chainID, nodeID string) (Tracer, error) {
+		return NewOTLPTracer(cfg, logger, chainID, nodeID)
+	})
+}
+
+// RegisterTracer makes a tracer backend available under the given name, which
+// can then be used as trace_type. It panics if the name is already taken.
+func RegisterTracer(name string, constructor TracerConstructor) {
+	backendsMtx.Lock()
+	defer backendsMtx.Unlock()
+	if _, has := backends[name]; has {
+		panic(fmt.Sprintf("tracer %q is already registered", name))
+	}
+	backends[name] = constructor
+}
+
+// RegisteredTracers returns the sorted names of all registered tracer
+// backends.
+func RegisteredTracers() []string {
+	backendsMtx.RLock()
+	defer backendsMtx.RUnlock()
+	names := make([]string, 0, len(backends))
+	for name := range backends {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// NewTracer creates the tracer backend selected by the trace_type config
+// option. Unknown types fall back to the noop tracer.
+func NewTracer(cfg *config.Config, logger log.Logger, chainID, nodeID string) (Tracer, error) {
+	backendsMtx.RLock()
+	constructor, has := backends[cfg.Instrumentation.TraceType]
+	backendsMtx.RUnlock()
+	if !has {
+		logger.Error("unknown tracer type, using noop", "type", cfg.Instrumentation.TraceType,
+			"available", RegisteredTracers())
 		return NoOpTracer(), nil
 	}
+	return constructor(cfg, logger, chainID, nodeID)
 }
 
 func NoOpTracer() Tracer {
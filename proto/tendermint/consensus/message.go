@@ -16,6 +16,10 @@ var _ p2p.Wrapper = &NewValidBlock{}
 var _ p2p.Wrapper = &NewRoundStep{}
 var _ p2p.Wrapper = &HasVote{}
 var _ p2p.Wrapper = &BlockPart{}
+var _ p2p.Wrapper = &CompactBlock{}
+var _ p2p.Wrapper = &CompactBlockTxsRequest{}
+var _ p2p.Wrapper = &CompactBlockTxs{}
+var _ p2p.Wrapper = &CompactBlockStatus{}
 
 func (m *VoteSetBits) Wrap() proto.Message {
 	cm := &Message{}
@@ -72,6 +76,30 @@ func (m *NewRoundStep) Wrap() proto.Message {
 	return cm
 }
 
+func (m *CompactBlock) Wrap() proto.Message {
+	cm := &Message{}
+	cm.Sum = &Message_CompactBlock{CompactBlock: m}
+	return cm
+}
+
+func (m *CompactBlockTxsRequest) Wrap() proto.Message {
+	cm := &Message{}
+	cm.Sum = &Message_CompactBlockTxsRequest{CompactBlockTxsRequest: m}
+	return cm
+}
+
+func (m *CompactBlockTxs) Wrap() proto.Message {
+	cm := &Message{}
+	cm.Sum = &Message_CompactBlockTxs{CompactBlockTxs: m}
+	return cm
+}
+
+func (m *CompactBlockStatus) Wrap() proto.Message {
+	cm := &Message{}
+	cm.Sum = &Message_CompactBlockStatus{CompactBlockStatus: m}
+	return cm
+}
+
 // Unwrap implements the p2p Wrapper interface and unwraps a wrapped consensus
 // proto message.
 func (m *Message) Unwrap() (proto.Message, error) {
@@ -103,6 +131,18 @@ func (m *Message) Unwrap() (proto.Message, error) {
 	case *Message_VoteSetBits:
 		return m.GetVoteSetBits(), nil
 
+	case *Message_CompactBlock:
+		return m.GetCompactBlock(), nil
+
+	case *Message_CompactBlockTxsRequest:
+		return m.GetCompactBlockTxsRequest(), nil
+
+	case *Message_CompactBlockTxs:
+		return m.GetCompactBlockTxs(), nil
+
+	case *Message_CompactBlockStatus:
+		return m.GetCompactBlockStatus(), nil
+
 	default:
 		return nil, fmt.Errorf("unknown message: %T", msg)
 	}
@@ -312,6 +312,15 @@ func (c *baseRPCClient) NumUnconfirmedTxs(ctx context.Context) (*ctypes.ResultUn
 	return result, nil
 }
 
+func (c *baseRPCClient) MempoolSnapshot(ctx context.Context) (*ctypes.ResultMempoolSnapshot, error) {
+	result := new(ctypes.ResultMempoolSnapshot)
+	_, err := c.caller.Call(ctx, "mempool_snapshot", map[string]interface{}{}, result)
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
 func (c *baseRPCClient) CheckTx(ctx context.Context, tx types.Tx) (*ctypes.ResultCheckTx, error) {
 	result := new(ctypes.ResultCheckTx)
 	_, err := c.caller.Call(ctx, "check_tx", map[string]interface{}{"tx": tx}, result)
@@ -667,6 +676,62 @@ func (c *baseRPCClient) BlockSearch(
 	return result, nil
 }
 
+func (c *baseRPCClient) TxSearchCursor(
+	ctx context.Context,
+	query string,
+	prove bool,
+	cursor string,
+	perPage *int,
+	orderBy string,
+) (*ctypes.ResultTxSearchCursor, error) {
+
+	result := new(ctypes.ResultTxSearchCursor)
+	params := map[string]interface{}{
+		"query":    query,
+		"prove":    prove,
+		"cursor":   cursor,
+		"order_by": orderBy,
+	}
+
+	if perPage != nil {
+		params["per_page"] = perPage
+	}
+
+	_, err := c.caller.Call(ctx, "tx_search_cursor", params, result)
+	if err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+func (c *baseRPCClient) BlockSearchCursor(
+	ctx context.Context,
+	query string,
+	cursor string,
+	perPage *int,
+	orderBy string,
+) (*ctypes.ResultBlockSearchCursor, error) {
+
+	result := new(ctypes.ResultBlockSearchCursor)
+	params := map[string]interface{}{
+		"query":    query,
+		"cursor":   cursor,
+		"order_by": orderBy,
+	}
+
+	if perPage != nil {
+		params["per_page"] = perPage
+	}
+
+	_, err := c.caller.Call(ctx, "block_search_cursor", params, result)
+	if err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
 func (c *baseRPCClient) Validators(
 	ctx context.Context,
 	height *int64,
@@ -107,6 +107,29 @@ type SignClient interface {
 		orderBy string,
 	) (*ctypes.ResultBlockSearch, error)
 
+	// TxSearchCursor defines a method to iterate over the transactions
+	// matching DeliverTx event search criteria, from the cursor returned with
+	// the previous ones, or from the start if empty.
+	TxSearchCursor(
+		ctx context.Context,
+		query string,
+		prove bool,
+		cursor string,
+		perPage *int,
+		orderBy string,
+	) (*ctypes.ResultTxSearchCursor, error)
+
+	// BlockSearchCursor defines a method to iterate over the blocks matching
+	// BeginBlock and EndBlock event search criteria, from the cursor returned
+	// with the previous ones, or from the start if empty.
+	BlockSearchCursor(
+		ctx context.Context,
+		query string,
+		cursor string,
+		perPage *int,
+		orderBy string,
+	) (*ctypes.ResultBlockSearchCursor, error)
+
 	// TxStatus returns the transaction status for a given transaction hash.
 	TxStatus(ctx context.Context, hash []byte) (*ctypes.ResultTxStatus, error)
 }
@@ -154,6 +177,7 @@ type EventsClient interface {
 type MempoolClient interface {
 	UnconfirmedTxs(ctx context.Context, limit *int) (*ctypes.ResultUnconfirmedTxs, error)
 	NumUnconfirmedTxs(context.Context) (*ctypes.ResultUnconfirmedTxs, error)
+	MempoolSnapshot(context.Context) (*ctypes.ResultMempoolSnapshot, error)
 	CheckTx(context.Context, types.Tx) (*ctypes.ResultCheckTx, error)
 }
 
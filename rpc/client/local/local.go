@@ -107,6 +107,10 @@ func (c *Local) NumUnconfirmedTxs(ctx context.Context) (*ctypes.ResultUnconfirme
 	return core.NumUnconfirmedTxs(c.ctx)
 }
 
+func (c *Local) MempoolSnapshot(ctx context.Context) (*ctypes.ResultMempoolSnapshot, error) {
+	return core.MempoolSnapshot(c.ctx)
+}
+
 func (c *Local) CheckTx(ctx context.Context, tx types.Tx) (*ctypes.ResultCheckTx, error) {
 	return core.CheckTx(c.ctx, tx)
 }
@@ -253,6 +257,27 @@ func (c *Local) BlockSearch(
 	return core.BlockSearch(c.ctx, query, page, perPage, orderBy)
 }
 
+func (c *Local) TxSearchCursor(
+	_ context.Context,
+	query string,
+	prove bool,
+	cursor string,
+	perPage *int,
+	orderBy string,
+) (*ctypes.ResultTxSearchCursor, error) {
+	return core.TxSearchCursor(c.ctx, query, prove, cursor, perPage, orderBy)
+}
+
+func (c *Local) BlockSearchCursor(
+	_ context.Context,
+	query string,
+	cursor string,
+	perPage *int,
+	orderBy string,
+) (*ctypes.ResultBlockSearchCursor, error) {
+	return core.BlockSearchCursor(c.ctx, query, cursor, perPage, orderBy)
+}
+
 func (c *Local) TxStatus(ctx context.Context, hash []byte) (*ctypes.ResultTxStatus, error) {
 	return core.TxStatus(c.ctx, hash)
 }
@@ -183,6 +183,29 @@ func (_m *Client) BlockSearch(ctx context.Context, query string, page *int, perP
 	return r0, r1
 }
 
+// BlockSearchCursor provides a mock function with given fields: ctx, query, cursor, perPage, orderBy
+func (_m *Client) BlockSearchCursor(ctx context.Context, query string, cursor string, perPage *int, orderBy string) (*coretypes.ResultBlockSearchCursor, error) {
+	ret := _m.Called(ctx, query, cursor, perPage, orderBy)
+
+	var r0 *coretypes.ResultBlockSearchCursor
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, *int, string) *coretypes.ResultBlockSearchCursor); ok {
+		r0 = rf(ctx, query, cursor, perPage, orderBy)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*coretypes.ResultBlockSearchCursor)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, *int, string) error); ok {
+		r1 = rf(ctx, query, cursor, perPage, orderBy)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // BlockchainInfo provides a mock function with given fields: ctx, minHeight, maxHeight
 func (_m *Client) BlockchainInfo(ctx context.Context, minHeight int64, maxHeight int64) (*coretypes.ResultBlockchainInfo, error) {
 	ret := _m.Called(ctx, minHeight, maxHeight)
@@ -542,6 +565,29 @@ func (_m *Client) IsRunning() bool {
 	return r0
 }
 
+// MempoolSnapshot provides a mock function with given fields: _a0
+func (_m *Client) MempoolSnapshot(_a0 context.Context) (*coretypes.ResultMempoolSnapshot, error) {
+	ret := _m.Called(_a0)
+
+	var r0 *coretypes.ResultMempoolSnapshot
+	if rf, ok := ret.Get(0).(func(context.Context) *coretypes.ResultMempoolSnapshot); ok {
+		r0 = rf(_a0)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*coretypes.ResultMempoolSnapshot)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(_a0)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // NetInfo provides a mock function with given fields: _a0
 func (_m *Client) NetInfo(_a0 context.Context) (*coretypes.ResultNetInfo, error) {
 	ret := _m.Called(_a0)
@@ -797,6 +843,29 @@ func (_m *Client) TxSearch(ctx context.Context, query string, prove bool, page *
 	return r0, r1
 }
 
+// TxSearchCursor provides a mock function with given fields: ctx, query, prove, cursor, perPage, orderBy
+func (_m *Client) TxSearchCursor(ctx context.Context, query string, prove bool, cursor string, perPage *int, orderBy string) (*coretypes.ResultTxSearchCursor, error) {
+	ret := _m.Called(ctx, query, prove, cursor, perPage, orderBy)
+
+	var r0 *coretypes.ResultTxSearchCursor
+	if rf, ok := ret.Get(0).(func(context.Context, string, bool, string, *int, string) *coretypes.ResultTxSearchCursor); ok {
+		r0 = rf(ctx, query, prove, cursor, perPage, orderBy)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*coretypes.ResultTxSearchCursor)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string, bool, string, *int, string) error); ok {
+		r1 = rf(ctx, query, prove, cursor, perPage, orderBy)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // UnconfirmedTxs provides a mock function with given fields: ctx, limit
 func (_m *Client) UnconfirmedTxs(ctx context.Context, limit *int) (*coretypes.ResultUnconfirmedTxs, error) {
 	ret := _m.Called(ctx, limit)
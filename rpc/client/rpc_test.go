@@ -707,6 +707,62 @@ func TestTxSearch(t *testing.T) {
 	}
 }
 
+func TestTxSearchCursor(t *testing.T) {
+	c := getHTTPClient()
+
+	// first we broadcast a few txs
+	for i := 0; i < 5; i++ {
+		_, _, tx := MakeTxKV()
+		_, err := c.BroadcastTxCommit(context.Background(), tx)
+		require.NoError(t, err)
+	}
+
+	perPage := 100
+	result, err := c.TxSearch(context.Background(), "app.creator='Cosmoshi Netowoko'", false, nil, &perPage, "desc")
+	require.NoError(t, err)
+	require.Greater(t, len(result.Txs), 2)
+
+	for _, c := range GetClients() {
+		// iterate over the txs by pages of 2
+		var (
+			hashes  []string
+			cursor  string
+			perPage = 2
+		)
+		for {
+			page, err := c.TxSearchCursor(context.Background(), "app.creator='Cosmoshi Netowoko'", false, cursor, &perPage, "desc")
+			require.NoError(t, err)
+			require.LessOrEqual(t, len(page.Txs), perPage)
+			for _, tx := range page.Txs {
+				hashes = append(hashes, tx.Hash.String())
+			}
+			if page.NextCursor == "" {
+				break
+			}
+			cursor = page.NextCursor
+		}
+		require.Len(t, hashes, len(result.Txs))
+		for i, tx := range result.Txs {
+			require.Equal(t, tx.Hash.String(), hashes[i])
+		}
+
+		// the cursor is bound to the query
+		_, err = c.TxSearchCursor(context.Background(), "app.creator='other'", false, cursor, &perPage, "desc")
+		require.Error(t, err)
+
+		blocks, err := c.BlockSearchCursor(context.Background(), "block.height <= 3", "", &perPage, "asc")
+		require.NoError(t, err)
+		require.Len(t, blocks.Blocks, 2)
+		require.EqualValues(t, 1, blocks.Blocks[0].Block.Height)
+		require.NotEmpty(t, blocks.NextCursor)
+		blocks, err = c.BlockSearchCursor(context.Background(), "block.height <= 3", blocks.NextCursor, &perPage, "asc")
+		require.NoError(t, err)
+		require.Len(t, blocks.Blocks, 1)
+		require.EqualValues(t, 3, blocks.Blocks[0].Block.Height)
+		require.Empty(t, blocks.NextCursor)
+	}
+}
+
 func TestDataCommitment(t *testing.T) {
 	c := getHTTPClient()
 
@@ -1,18 +1,18 @@
 package core
 
 import (
-	"encoding/hex"
 	"errors"
 	"fmt"
 	"sort"
-	"strconv"
 
 	"github.com/tendermint/tendermint/crypto/merkle"
 	"github.com/tendermint/tendermint/libs/bytes"
 	cmtmath "github.com/tendermint/tendermint/libs/math"
 	cmtquery "github.com/tendermint/tendermint/libs/pubsub/query"
+	"github.com/tendermint/tendermint/pkg/da"
 	ctypes "github.com/tendermint/tendermint/rpc/core/types"
 	rpctypes "github.com/tendermint/tendermint/rpc/jsonrpc/types"
+	"github.com/tendermint/tendermint/state/indexer"
 	blockidxnull "github.com/tendermint/tendermint/state/indexer/block/null"
 	"github.com/tendermint/tendermint/types"
 )
@@ -250,44 +250,10 @@ func DataRootInclusionProof(
 	return &ctypes.ResultDataRootInclusionProof{Proof: *proof}, nil
 }
 
-// padBytes Pad bytes to given length
-func padBytes(byt []byte, length int) ([]byte, error) {
-	l := len(byt)
-	if l > length {
-		return nil, fmt.Errorf(
-			"cannot pad bytes because length of bytes array: %d is greater than given length: %d",
-			l,
-			length,
-		)
-	}
-	if l == length {
-		return byt, nil
-	}
-	tmp := make([]byte, length)
-	copy(tmp[length-l:], byt)
-	return tmp, nil
-}
-
 // To32PaddedHexBytes takes a number and returns its hex representation padded to 32 bytes.
-// Used to mimic the result of `abi.encode(number)` in Ethereum.
+// Deprecated: use da.To32PaddedHexBytes instead.
 func To32PaddedHexBytes(number uint64) ([]byte, error) {
-	hexRepresentation := strconv.FormatUint(number, 16)
-	// Make sure hex representation has even length.
-	// The `strconv.FormatUint` can return odd length hex encodings.
-	// For example, `strconv.FormatUint(10, 16)` returns `a`.
-	// Thus, we need to pad it.
-	if len(hexRepresentation)%2 == 1 {
-		hexRepresentation = "0" + hexRepresentation
-	}
-	hexBytes, hexErr := hex.DecodeString(hexRepresentation)
-	if hexErr != nil {
-		return nil, hexErr
-	}
-	paddedBytes, padErr := padBytes(hexBytes, 32)
-	if padErr != nil {
-		return nil, padErr
-	}
-	return paddedBytes, nil
+	return da.To32PaddedHexBytes(number)
 }
 
 // DataRootTuple contains the data that will be used to create the QGB commitments.
@@ -300,43 +266,11 @@ type DataRootTuple struct {
 
 // EncodeDataRootTuple takes a height and a data root, and returns the equivalent of
 // `abi.encode(...)` in Ethereum.
-// The encoded type is a DataRootTuple, which has the following ABI:
-//
-//	{
-//	  "components":[
-//	     {
-//	        "internalType":"uint256",
-//	        "name":"height",
-//	        "type":"uint256"
-//	     },
-//	     {
-//	        "internalType":"bytes32",
-//	        "name":"dataRoot",
-//	        "type":"bytes32"
-//	     },
-//	     {
-//	        "internalType":"structDataRootTuple",
-//	        "name":"_tuple",
-//	        "type":"tuple"
-//	     }
-//	  ]
-//	}
-//
-// padding the hex representation of the height padded to 32 bytes concatenated to the data root.
-// For more information, refer to:
-// https://github.com/celestiaorg/quantum-gravity-bridge/blob/master/src/DataRootTuple.sol
+// Deprecated: use da.EncodeDataRootTuple instead.
 func EncodeDataRootTuple(height uint64, dataRoot [32]byte) ([]byte, error) {
-	paddedHeight, err := To32PaddedHexBytes(height)
-	if err != nil {
-		return nil, err
-	}
-	return append(paddedHeight, dataRoot[:]...), nil
+	return da.EncodeDataRootTuple(height, dataRoot)
 }
 
-// dataCommitmentBlocksLimit The maximum number of blocks to be used to create a data commitment.
-// It's a local parameter to protect the API from creating unnecessarily large commitments.
-const dataCommitmentBlocksLimit = 10_000 // ~33 hours of blocks assuming 12-second blocks.
-
 // validateDataCommitmentRange runs basic checks on the asc sorted list of
 // heights that will be used subsequently in generating data commitments over
 // the defined set of heights.
@@ -346,8 +280,8 @@ func validateDataCommitmentRange(start uint64, end uint64) error {
 	}
 	env := GetEnvironment()
 	heightsRange := end - start
-	if heightsRange > uint64(dataCommitmentBlocksLimit) {
-		return fmt.Errorf("the query exceeds the limit of allowed blocks %d", dataCommitmentBlocksLimit)
+	if heightsRange > uint64(da.DataCommitmentBlocksLimit) {
+		return fmt.Errorf("the query exceeds the limit of allowed blocks %d", da.DataCommitmentBlocksLimit)
 	}
 	if heightsRange == 0 {
 		return fmt.Errorf("cannot create the data commitments for an empty set of blocks")
@@ -372,7 +306,7 @@ func validateDataCommitmentRange(start uint64, end uint64) error {
 func hashDataRootTuples(tuples []DataRootTuple) ([]byte, error) {
 	dataRootEncodedTuples := make([][]byte, 0, len(tuples))
 	for _, tuple := range tuples {
-		encodedTuple, err := EncodeDataRootTuple(
+		encodedTuple, err := da.EncodeDataRootTuple(
 			tuple.height,
 			tuple.dataRoot,
 		)
@@ -407,7 +341,7 @@ func validateDataRootInclusionProofRequest(height uint64, start uint64, end uint
 func proveDataRootTuples(tuples []DataRootTuple, height int64) (*merkle.Proof, error) {
 	dataRootEncodedTuples := make([][]byte, 0, len(tuples))
 	for _, tuple := range tuples {
-		encodedTuple, err := EncodeDataRootTuple(
+		encodedTuple, err := da.EncodeDataRootTuple(
 			tuple.height,
 			tuple.dataRoot,
 		)
@@ -484,32 +418,59 @@ func BlockSearch(
 		return nil, err
 	}
 
-	results, err := GetEnvironment().BlockIndexer.Search(ctx.Context(), q)
-	if err != nil {
-		return nil, err
-	}
+	perPage := validatePerPage(perPagePtr)
+	var (
+		results    []int64
+		totalCount int
+	)
+	if pager, ok := GetEnvironment().BlockIndexer.(indexer.BlockSearchPager); ok {
+		// the indexer sorts and paginates the results itself
+		var desc bool
+		switch orderBy {
+		case "desc", "":
+			desc = true
+		case "asc":
+		default:
+			return nil, errors.New("expected order_by to be either `asc` or `desc` or empty")
+		}
+		page := 1
+		if pagePtr != nil {
+			page = *pagePtr
+		}
+		results, totalCount, err = pager.SearchPage(ctx.Context(), q, desc, validateSkipCount(page, perPage), perPage)
+		if err != nil {
+			return nil, err
+		}
+		if _, err := validatePage(pagePtr, perPage, totalCount); err != nil {
+			return nil, err
+		}
+	} else {
+		results, err = GetEnvironment().BlockIndexer.Search(ctx.Context(), q)
+		if err != nil {
+			return nil, err
+		}
 
-	// sort results (must be done before pagination)
-	err = sortBlocks(results, orderBy)
-	if err != nil {
-		return nil, err
-	}
+		// sort results (must be done before pagination)
+		err = sortBlocks(results, orderBy)
+		if err != nil {
+			return nil, err
+		}
 
-	// paginate results
-	totalCount := len(results)
-	perPage := validatePerPage(perPagePtr)
+		// paginate results
+		totalCount = len(results)
+		page, err := validatePage(pagePtr, perPage, totalCount)
+		if err != nil {
+			return nil, err
+		}
 
-	page, err := validatePage(pagePtr, perPage, totalCount)
-	if err != nil {
-		return nil, err
+		skipCount := validateSkipCount(page, perPage)
+		pageSize := cmtmath.MinInt(perPage, totalCount-skipCount)
+		results = results[skipCount : skipCount+pageSize]
 	}
 
-	skipCount := validateSkipCount(page, perPage)
-	pageSize := cmtmath.MinInt(perPage, totalCount-skipCount)
-
-	apiResults := make([]*ctypes.ResultBlock, 0, pageSize)
-	for i := skipCount; i < skipCount+pageSize; i++ {
-		block := GetEnvironment().BlockStore.LoadBlock(results[i])
+	apiResults := make([]*ctypes.ResultBlock, 0, len(results))
+	for _, height := range results {
+		block := GetEnvironment().BlockStore.LoadBlock(height)
 		if block != nil {
 			blockMeta := GetEnvironment().BlockStore.LoadBlockMeta(block.Height)
 			if blockMeta != nil {
@@ -540,6 +501,144 @@ func sortBlocks(results []int64, orderBy string) error {
 	return nil
 }
 
+// BlockSearchCursor allows you to iterate over the blocks matching BeginBlock
+// and EndBlock event search criteria. It returns at most ?per_page of them, in
+// the order of their heights, along with the cursor to pass to get the next
+// ones. The heights are iterated lazily by the indexer, and the index entries
+// examined per request are capped by the search_scan_limit RPC config.
+// More: https://docs.cometbft.com/v0.34/rpc/#/Info/block_search_cursor
+func BlockSearchCursor(
+	ctx *rpctypes.Context,
+	query string,
+	cursor string,
+	perPagePtr *int,
+	orderBy string,
+) (*ctypes.ResultBlockSearchCursor, error) {
+	searcher, q, s, err := newBlockCursorSearch(query, cursor, perPagePtr, orderBy)
+	if err != nil {
+		return nil, err
+	}
+	s.MaxScan, _ = remainingScan(0)
+	page, err := searcher.SearchCursor(ctx.Context(), q, s)
+	if err != nil {
+		return nil, err
+	}
+	return blockCursorResult(page, query, s.Desc), nil
+}
+
+// BlockSearchStream streams the blocks matching BeginBlock and EndBlock event
+// search criteria over a websocket, in chunks of at most ?per_page of them,
+// each sent as a response to the request with more set, in the order of their
+// heights. The last chunk is the result of the request, with the cursor to
+// resume the search from if it reached the search_scan_limit RPC config.
+// More: https://docs.cometbft.com/v0.34/rpc/#/Websocket/block_search_stream
+func BlockSearchStream(
+	ctx *rpctypes.Context,
+	query string,
+	cursor string,
+	perPagePtr *int,
+	orderBy string,
+) (*ctypes.ResultBlockSearchCursor, error) {
+	searcher, q, s, err := newBlockCursorSearch(query, cursor, perPagePtr, orderBy)
+	if err != nil {
+		return nil, err
+	}
+
+	var scanned int
+	for {
+		s.MaxScan, _ = remainingScan(scanned)
+		page, err := searcher.SearchCursor(ctx.Context(), q, s)
+		if err != nil {
+			return nil, err
+		}
+		if err := ctx.Context().Err(); err != nil {
+			return nil, err
+		}
+		scanned += page.Scanned
+		res := blockCursorResult(page, query, s.Desc)
+		if _, done := remainingScan(scanned); done || page.Next == 0 {
+			return res, nil
+		}
+
+		res.More = true
+		if err := ctx.WSConn.WriteRPCResponse(ctx.Context(), rpctypes.NewRPCSuccessResponse(ctx.JSONReq.ID, res)); err != nil {
+			return nil, err
+		}
+		s.From = page.Next
+	}
+}
+
+// newBlockCursorSearch returns the indexer and the parameters of a search by
+// cursor of the blocks.
+func newBlockCursorSearch(
+	query string,
+	cursor string,
+	perPagePtr *int,
+	orderBy string,
+) (indexer.BlockCursorSearcher, *cmtquery.Query, indexer.BlockCursorSearch, error) {
+	env := GetEnvironment()
+	var s indexer.BlockCursorSearch
+	if _, ok := env.BlockIndexer.(*blockidxnull.BlockerIndexer); ok {
+		return nil, nil, s, errors.New("block indexing is disabled")
+	} else if len(query) > maxQueryLength {
+		return nil, nil, s, errors.New("maximum query length exceeded")
+	}
+	searcher, ok := env.BlockIndexer.(indexer.BlockCursorSearcher)
+	if !ok {
+		return nil, nil, s, errors.New("the block indexer doesn't support searches by cursor")
+	}
+
+	q, err := cmtquery.New(query)
+	if err != nil {
+		return nil, nil, s, err
+	}
+	if s.Desc, err = parseOrder(orderBy, true); err != nil {
+		return nil, nil, s, err
+	}
+	if cursor != "" {
+		c, err := decodeCursor(cursor, blockCursorKind, query, s.Desc)
+		if err != nil {
+			return nil, nil, s, err
+		}
+		if c.height <= 0 {
+			return nil, nil, s, errors.New("invalid cursor height")
+		}
+		s.From = c.height
+	}
+	s.MinHeight, s.MaxHeight = env.BlockStore.Base(), env.BlockStore.Height()
+	s.Limit = validatePerPage(perPagePtr)
+	return searcher, q, s, nil
+}
+
+// blockCursorResult returns the result of a page of a search by cursor of the
+// blocks.
+func blockCursorResult(page *indexer.BlockCursorPage, query string, desc bool) *ctypes.ResultBlockSearchCursor {
+	res := &ctypes.ResultBlockSearchCursor{Blocks: make([]*ctypes.ResultBlock, 0, len(page.Heights))}
+	for _, height := range page.Heights {
+		block := GetEnvironment().BlockStore.LoadBlock(height)
+		if block == nil {
+			continue
+		}
+		blockMeta := GetEnvironment().BlockStore.LoadBlockMeta(height)
+		if blockMeta == nil {
+			continue
+		}
+		res.Blocks = append(res.Blocks, &ctypes.ResultBlock{
+			Block:   block,
+			BlockID: blockMeta.BlockID,
+		})
+	}
+	if page.Next != 0 {
+		res.NextCursor = searchCursor{
+			kind:   blockCursorKind,
+			desc:   desc,
+			query:  query,
+			height: page.Next,
+		}.encode()
+	}
+	return res
+}
+
 // fetchDataRootTuples takes an end exclusive range of heights and fetches its
 // corresponding data root tuples.
 func fetchDataRootTuples(start, end uint64) ([]DataRootTuple, error) {
@@ -4,7 +4,6 @@ import (
 	"bytes"
 	"context"
 	"encoding/binary"
-	"encoding/hex"
 	"fmt"
 	"testing"
 
@@ -15,6 +14,7 @@ import (
 	"github.com/tendermint/tendermint/crypto/merkle"
 	"github.com/tendermint/tendermint/libs/pubsub/query"
 	cmtrand "github.com/tendermint/tendermint/libs/rand"
+	"github.com/tendermint/tendermint/pkg/da"
 	"github.com/tendermint/tendermint/types"
 
 	abci "github.com/tendermint/tendermint/abci/types"
@@ -126,29 +126,6 @@ func TestBlockResults(t *testing.T) {
 	}
 }
 
-func TestEncodeDataRootTuple(t *testing.T) {
-	height := uint64(2)
-	dataRoot, err := hex.DecodeString("82dc1607d84557d3579ce602a45f5872e821c36dbda7ec926dfa17ebc8d5c013")
-	require.NoError(t, err)
-
-	expectedEncoding, err := hex.DecodeString(
-		// hex representation of height padded to 32 bytes
-		"0000000000000000000000000000000000000000000000000000000000000002" +
-			// data root
-			"82dc1607d84557d3579ce602a45f5872e821c36dbda7ec926dfa17ebc8d5c013",
-	)
-	require.NoError(t, err)
-	require.NotNil(t, expectedEncoding)
-
-	actualEncoding, err := EncodeDataRootTuple(height, *(*[32]byte)(dataRoot))
-	require.NoError(t, err)
-	require.NotNil(t, actualEncoding)
-
-	// Check that the length of packed data is correct
-	assert.Equal(t, len(actualEncoding), 64)
-	assert.Equal(t, expectedEncoding, actualEncoding)
-}
-
 func TestDataCommitmentResults(t *testing.T) {
 	env := &Environment{}
 	height := int64(2826)
@@ -193,7 +170,7 @@ func TestDataCommitmentResults(t *testing.T) {
 			size := tc.endQuery - tc.beginQuery
 			dataRootEncodedTuples := make([][]byte, size)
 			for i := 0; i < size; i++ {
-				encodedTuple, err := EncodeDataRootTuple(
+				encodedTuple, err := da.EncodeDataRootTuple(
 					uint64(blocks[tc.beginQuery+i].Height),
 					*(*[32]byte)(blocks[tc.beginQuery+i].DataHash),
 				)
@@ -267,7 +244,7 @@ func TestDataRootInclusionProofResults(t *testing.T) {
 			size := tc.lastQuery - tc.firstQuery
 			dataRootEncodedTuples := make([][]byte, size)
 			for i := 0; i < size; i++ {
-				encodedTuple, err := EncodeDataRootTuple(
+				encodedTuple, err := da.EncodeDataRootTuple(
 					uint64(blocks[tc.firstQuery+i].Height),
 					*(*[32]byte)(blocks[tc.firstQuery+i].DataHash),
 				)
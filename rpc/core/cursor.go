@@ -0,0 +1,103 @@
+package core
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/binary"
+	"errors"
+	"fmt"
+)
+
+const (
+	// cursorVersion is the version of the encoding of the cursors.
+	cursorVersion = 1
+
+	txCursorKind    = 't'
+	blockCursorKind = 'b'
+)
+
+// searchCursor is the position a search by cursor resumes from, which is
+// handed to the clients as an opaque string. It is bound to the query and
+// order of the search.
+type searchCursor struct {
+	kind   byte
+	desc   bool
+	query  string
+	height int64
+	index  uint32
+}
+
+// encode returns the opaque string of the cursor.
+func (c searchCursor) encode() string {
+	var desc byte
+	if c.desc {
+		desc = 1
+	}
+	bz := []byte{cursorVersion, c.kind, desc}
+	bz = append(bz, queryChecksum(c.query)...)
+	bz = binary.AppendVarint(bz, c.height)
+	bz = binary.AppendUvarint(bz, uint64(c.index))
+	return base64.RawURLEncoding.EncodeToString(bz)
+}
+
+// decodeCursor decodes the cursor of a search of kind, by query in the
+// descending order if desc, from its opaque string.
+func decodeCursor(s string, kind byte, query string, desc bool) (searchCursor, error) {
+	c := searchCursor{kind: kind, desc: desc, query: query}
+	bz, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil || len(bz) < 3+sha256.Size/8 {
+		return c, errors.New("invalid cursor")
+	}
+	if bz[0] != cursorVersion || bz[1] != kind {
+		return c, errors.New("invalid cursor: not a cursor of this search")
+	}
+	if (bz[2] == 1) != desc || !bytes.Equal(bz[3:3+sha256.Size/8], queryChecksum(query)) {
+		return c, errors.New("invalid cursor: the query or the order differs from the search of the cursor")
+	}
+
+	r := bytes.NewReader(bz[3+sha256.Size/8:])
+	if c.height, err = binary.ReadVarint(r); err != nil {
+		return c, fmt.Errorf("invalid cursor height: %w", err)
+	}
+	index, err := binary.ReadUvarint(r)
+	if err != nil || index > uint64(^uint32(0)) {
+		return c, errors.New("invalid cursor index")
+	}
+	c.index = uint32(index)
+	if r.Len() != 0 {
+		return c, errors.New("invalid cursor")
+	}
+	return c, nil
+}
+
+// queryChecksum returns the checksum of the query binding a cursor to it.
+func queryChecksum(query string) []byte {
+	sum := sha256.Sum256([]byte(query))
+	return sum[:sha256.Size/8]
+}
+
+// parseOrder returns whether orderBy is the descending order, empty meaning
+// the descending order if defaultDesc.
+func parseOrder(orderBy string, defaultDesc bool) (bool, error) {
+	switch orderBy {
+	case "desc":
+		return true, nil
+	case "asc":
+		return false, nil
+	case "":
+		return defaultDesc, nil
+	default:
+		return false, errors.New("expected order_by to be either `asc` or `desc` or empty")
+	}
+}
+
+// remainingScan returns the scan limit of a search that examined scanned index
+// entries already, or 0 if unlimited, and whether it reached it.
+func remainingScan(scanned int) (int, bool) {
+	limit := GetEnvironment().Config.SearchScanLimit
+	if limit <= 0 {
+		return 0, false
+	}
+	return limit - scanned, scanned >= limit
+}
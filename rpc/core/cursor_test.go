@@ -0,0 +1,36 @@
+package core
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSearchCursor(t *testing.T) {
+	const query = "transfer.sender = 'alice'"
+	c := searchCursor{kind: txCursorKind, desc: true, query: query, height: 1234, index: 5}
+
+	decoded, err := decodeCursor(c.encode(), txCursorKind, query, true)
+	require.NoError(t, err)
+	assert.Equal(t, c, decoded)
+
+	cases := []struct {
+		name   string
+		cursor string
+		kind   byte
+		query  string
+		desc   bool
+	}{
+		{"other kind", c.encode(), blockCursorKind, query, true},
+		{"other query", c.encode(), txCursorKind, "transfer.sender = 'bob'", true},
+		{"other order", c.encode(), txCursorKind, query, false},
+		{"not base64", "!", txCursorKind, query, true},
+		{"truncated", c.encode()[:4], txCursorKind, query, true},
+		{"trailing bytes", c.encode() + "AA", txCursorKind, query, true},
+	}
+	for _, tc := range cases {
+		_, err := decodeCursor(tc.cursor, tc.kind, tc.query, tc.desc)
+		assert.Error(t, err, tc.name)
+	}
+}
@@ -1,6 +1,9 @@
 package core
 
 import (
+	"fmt"
+
+	mempl "github.com/tendermint/tendermint/mempool"
 	ctypes "github.com/tendermint/tendermint/rpc/core/types"
 	rpctypes "github.com/tendermint/tendermint/rpc/jsonrpc/types"
 )
@@ -10,3 +13,23 @@ func UnsafeFlushMempool(ctx *rpctypes.Context) (*ctypes.ResultUnsafeFlushMempool
 	GetEnvironment().Mempool.Flush()
 	return &ctypes.ResultUnsafeFlushMempool{}, nil
 }
+
+// UnsafeExportMempool writes the transactions in the mempool, in order of
+// arrival, to the export dir of the node. They are checked into the mempool
+// on the next startup, whatever its version, and the export removed. The
+// mempool is left as is.
+func UnsafeExportMempool(ctx *rpctypes.Context) (*ctypes.ResultUnsafeExportMempool, error) {
+	env := GetEnvironment()
+	if env.MempoolExportDir == "" {
+		return nil, fmt.Errorf("mempool export is not configured")
+	}
+
+	txs := mempl.ExportTxs(env.Mempool)
+	if err := mempl.WriteWAL(env.MempoolExportDir, txs); err != nil {
+		return nil, fmt.Errorf("failed to export mempool: %w", err)
+	}
+	return &ctypes.ResultUnsafeExportMempool{
+		Txs: len(txs),
+		Dir: env.MempoolExportDir,
+	}, nil
+}
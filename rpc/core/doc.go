@@ -19,17 +19,20 @@ Available endpoints:
 /abci_info
 /dump_consensus_state
 /genesis
+/mempool_snapshot
 /net_info
 /num_unconfirmed_txs
 /status
 /health
 /unconfirmed_txs
+/unsafe_export_mempool
 /unsafe_flush_mempool
 /validators
 
 Endpoints that require arguments:
 /abci_query?path=_&data=_&prove=_
 /block?height=_
+/block_search_cursor?query=_&cursor=_&per_page=_&order_by=_
 /blockchain?minHeight=_&maxHeight=_
 /broadcast_tx_async?tx=_
 /broadcast_tx_commit?tx=_
@@ -39,6 +42,7 @@ Endpoints that require arguments:
 /dial_persistent_peers?persistent_peers=_
 /subscribe?event=_
 /tx?hash=_&prove=_
+/tx_search_cursor?query=_&prove=_&cursor=_&per_page=_&order_by=_
 /unsubscribe?event=_
 ```
 */
@@ -78,6 +78,7 @@ type peers interface {
 	AddPrivatePeerIDs([]string) error
 	DialPeersAsync([]string) error
 	Peers() p2p.IPeerSet
+	PeerTrustScore(p2p.ID) (int, bool)
 }
 
 // ----------------------------------------------
@@ -105,6 +106,10 @@ type Environment struct {
 	EventBus         *types.EventBus // thread safe
 	Mempool          mempl.Mempool
 
+	// MempoolExportDir is the dir the mempool is exported to by
+	// UnsafeExportMempool, to be imported on the next startup.
+	MempoolExportDir string
+
 	Logger log.Logger
 
 	Config cfg.RPCConfig
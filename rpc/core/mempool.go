@@ -7,6 +7,7 @@ import (
 	"time"
 
 	abci "github.com/tendermint/tendermint/abci/types"
+	cmtbytes "github.com/tendermint/tendermint/libs/bytes"
 	mempl "github.com/tendermint/tendermint/mempool"
 	ctypes "github.com/tendermint/tendermint/rpc/core/types"
 	rpctypes "github.com/tendermint/tendermint/rpc/jsonrpc/types"
@@ -24,6 +25,9 @@ var ErrTimedOutWaitingForTx = errors.New("timed out waiting for tx to be include
 func BroadcastTxAsync(ctx *rpctypes.Context, tx types.Tx) (*ctypes.ResultBroadcastTx, error) {
 	err := GetEnvironment().Mempool.CheckTx(tx, nil, mempl.TxInfo{})
 
+	if res, ok := senderQuotaExceededResult(tx, err); ok {
+		return res, nil
+	}
 	if err != nil {
 		return nil, err
 	}
@@ -42,6 +46,9 @@ func BroadcastTxSync(ctx *rpctypes.Context, tx types.Tx) (*ctypes.ResultBroadcas
 		}
 
 	}, mempl.TxInfo{})
+	if res, ok := senderQuotaExceededResult(tx, err); ok {
+		return res, nil
+	}
 	if err != nil {
 		return nil, err
 	}
@@ -51,16 +58,37 @@ func BroadcastTxSync(ctx *rpctypes.Context, tx types.Tx) (*ctypes.ResultBroadcas
 		return nil, fmt.Errorf("broadcast confirmation not received: %w", ctx.Context().Err())
 	case res := <-resCh:
 		r := res.GetCheckTx()
+		log := r.Log
+		if r.Codespace == mempl.Codespace {
+			// the mempool rejected the tx accepted by the application
+			log = r.MempoolError
+		}
 		return &ctypes.ResultBroadcastTx{
 			Code:      r.Code,
 			Data:      r.Data,
-			Log:       r.Log,
+			Log:       log,
 			Codespace: r.Codespace,
 			Hash:      tx.Hash(),
 		}, nil
 	}
 }
 
+// senderQuotaExceededResult returns the result of a tx rejected by the
+// mempool because its sender exceeded its share of the mempool, if err is
+// such, so that clients get a rejection code rather than an error.
+func senderQuotaExceededResult(tx types.Tx, err error) (*ctypes.ResultBroadcastTx, bool) {
+	var quotaErr mempl.ErrSenderQuotaExceeded
+	if !errors.As(err, &quotaErr) {
+		return nil, false
+	}
+	return &ctypes.ResultBroadcastTx{
+		Code:      mempl.CodeTypeSenderQuotaExceeded,
+		Log:       quotaErr.Error(),
+		Codespace: mempl.Codespace,
+		Hash:      tx.Hash(),
+	}, true
+}
+
 // DEPRECATED: Use BroadcastTxSync or BroadcastTxAsync instead.
 // BroadcastTxCommit returns with the responses from CheckTx and DeliverTx.
 // More: https://docs.cometbft.com/v0.34/rpc/#/Tx/broadcast_tx_commit
@@ -98,6 +126,16 @@ func BroadcastTxCommit(ctx *rpctypes.Context, tx types.Tx) (*ctypes.ResultBroadc
 		case checkTxResCh <- res:
 		}
 	}, mempl.TxInfo{})
+	if res, ok := senderQuotaExceededResult(tx, err); ok {
+		return &ctypes.ResultBroadcastTxCommit{
+			CheckTx: abci.ResponseCheckTx{
+				Code:      res.Code,
+				Log:       res.Log,
+				Codespace: res.Codespace,
+			},
+			Hash: tx.Hash(),
+		}, nil
+	}
 	if err != nil {
 		env.Logger.Error("Error on broadcastTxCommit", "err", err)
 		return nil, fmt.Errorf("error on broadcastTxCommit: %v", err)
@@ -177,6 +215,47 @@ func NumUnconfirmedTxs(ctx *rpctypes.Context) (*ctypes.ResultUnconfirmedTxs, err
 		TotalBytes: env.Mempool.SizeBytes()}, nil
 }
 
+// MempoolSnapshot returns the txs in the mempool, in the order they are
+// reaped for the next block, along with the version of the last change to
+// them. Subscribing to the MempoolTx event before taking the snapshot, and
+// applying the changes of greater versions to it, follows the content of the
+// mempool.
+// More: https://docs.cometbft.com/v0.34/rpc/#/Info/mempool_snapshot
+func MempoolSnapshot(ctx *rpctypes.Context) (*ctypes.ResultMempoolSnapshot, error) {
+	snapshot := GetEnvironment().Mempool.Snapshot()
+
+	var (
+		now        = time.Now()
+		totalBytes int64
+		txs        = make([]ctypes.ResultMempoolTx, len(snapshot.Txs))
+	)
+	for i, tx := range snapshot.Txs {
+		totalBytes += tx.Size
+		namespaces := make([]cmtbytes.HexBytes, len(tx.Blobs.Namespaces))
+		for j, ns := range tx.Blobs.Namespaces {
+			namespaces[j] = ns
+		}
+		txs[i] = ctypes.ResultMempoolTx{
+			Hash:       tx.Key[:],
+			Size:       tx.Size,
+			Priority:   tx.Priority,
+			GasWanted:  tx.GasWanted,
+			Sender:     tx.Sender,
+			Blobs:      tx.Blobs.Count,
+			BlobBytes:  tx.Blobs.Bytes,
+			Namespaces: namespaces,
+			Height:     tx.Height,
+			Age:        now.Sub(tx.Timestamp),
+		}
+	}
+	return &ctypes.ResultMempoolSnapshot{
+		Version:    snapshot.Version,
+		Total:      len(txs),
+		TotalBytes: totalBytes,
+		Txs:        txs,
+	}, nil
+}
+
 // CheckTx checks the transaction without executing it. The transaction won't
 // be added to the mempool either.
 // More: https://docs.cometbft.com/v0.34/rpc/#/Tx/check_tx
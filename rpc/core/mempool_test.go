@@ -0,0 +1,81 @@
+package core
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+
+	mempl "github.com/tendermint/tendermint/mempool"
+	mock "github.com/tendermint/tendermint/rpc/core/mocks"
+	rpctypes "github.com/tendermint/tendermint/rpc/jsonrpc/types"
+	"github.com/tendermint/tendermint/types"
+)
+
+func TestMempoolSnapshot(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mempool := mock.NewMockMempool(ctrl)
+	SetEnvironment(&Environment{Mempool: mempool})
+
+	tx := types.Tx("tx")
+	mempool.EXPECT().Snapshot().Return(&mempl.Snapshot{
+		Version: 7,
+		Txs: []mempl.TxMeta{{
+			Key:       tx.Key(),
+			Size:      int64(len(tx)),
+			Priority:  10,
+			GasWanted: 100,
+			Sender:    "alice",
+			Height:    3,
+			Timestamp: time.Now().Add(-time.Minute),
+		}},
+	})
+
+	res, err := MempoolSnapshot(&rpctypes.Context{})
+	require.NoError(t, err)
+	require.EqualValues(t, 7, res.Version)
+	require.Equal(t, 1, res.Total)
+	require.EqualValues(t, len(tx), res.TotalBytes)
+	require.Len(t, res.Txs, 1)
+	require.EqualValues(t, tx.Hash(), res.Txs[0].Hash)
+	require.EqualValues(t, 10, res.Txs[0].Priority)
+	require.Equal(t, "alice", res.Txs[0].Sender)
+	require.GreaterOrEqual(t, res.Txs[0].Age, time.Minute)
+}
+
+func TestUnsafeExportMempool(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mempool := mock.NewMockMempool(ctrl)
+	dir := filepath.Join(t.TempDir(), "mempool.export")
+	SetEnvironment(&Environment{Mempool: mempool, MempoolExportDir: dir})
+
+	older, newer := types.Tx("older"), types.Tx("newer")
+	now := time.Now()
+	mempool.EXPECT().Snapshot().Return(&mempl.Snapshot{
+		Txs: []mempl.TxMeta{
+			{Key: newer.Key(), Priority: 10, Timestamp: now},
+			{Key: older.Key(), Priority: 1, Timestamp: now.Add(-time.Minute)},
+		},
+	})
+	mempool.EXPECT().GetTxByKey(newer.Key()).Return(newer, true)
+	mempool.EXPECT().GetTxByKey(older.Key()).Return(older, true)
+
+	res, err := UnsafeExportMempool(&rpctypes.Context{})
+	require.NoError(t, err)
+	require.Equal(t, 2, res.Txs)
+	require.Equal(t, dir, res.Dir)
+
+	// the txs are exported in order of arrival
+	txs, err := mempl.ReadWAL(dir)
+	require.NoError(t, err)
+	require.Len(t, txs, 2)
+	require.Equal(t, older, txs[0].Tx)
+	require.Equal(t, newer, txs[1].Tx)
+	require.EqualValues(t, 10, txs[1].Priority)
+}
@@ -88,6 +88,36 @@ func (mr *MockMempoolMockRecorder) FlushAppConn() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FlushAppConn", reflect.TypeOf((*MockMempool)(nil).FlushAppConn))
 }
 
+// GetPendingTx mocks base method.
+func (m *MockMempool) GetPendingTx(key types0.TxKey) (*mempool.PendingTx, bool) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPendingTx", key)
+	ret0, _ := ret[0].(*mempool.PendingTx)
+	ret1, _ := ret[1].(bool)
+	return ret0, ret1
+}
+
+// GetPendingTx indicates an expected call of GetPendingTx.
+func (mr *MockMempoolMockRecorder) GetPendingTx(key interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPendingTx", reflect.TypeOf((*MockMempool)(nil).GetPendingTx), key)
+}
+
+// GetRemovalReason mocks base method.
+func (m *MockMempool) GetRemovalReason(key types0.TxKey) (mempool.RemovalReason, bool) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetRemovalReason", key)
+	ret0, _ := ret[0].(mempool.RemovalReason)
+	ret1, _ := ret[1].(bool)
+	return ret0, ret1
+}
+
+// GetRemovalReason indicates an expected call of GetRemovalReason.
+func (mr *MockMempoolMockRecorder) GetRemovalReason(key interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRemovalReason", reflect.TypeOf((*MockMempool)(nil).GetRemovalReason), key)
+}
+
 // GetTxByKey mocks base method.
 func (m *MockMempool) GetTxByKey(key types0.TxKey) (types0.Tx, bool) {
 	m.ctrl.T.Helper()
@@ -157,6 +187,18 @@ func (mr *MockMempoolMockRecorder) RemoveTxByKey(txKey interface{}) *gomock.Call
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveTxByKey", reflect.TypeOf((*MockMempool)(nil).RemoveTxByKey), txKey)
 }
 
+// SetTxListener mocks base method.
+func (m *MockMempool) SetTxListener(l mempool.TxListener) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "SetTxListener", l)
+}
+
+// SetTxListener indicates an expected call of SetTxListener.
+func (mr *MockMempoolMockRecorder) SetTxListener(l interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetTxListener", reflect.TypeOf((*MockMempool)(nil).SetTxListener), l)
+}
+
 // Size mocks base method.
 func (m *MockMempool) Size() int {
 	m.ctrl.T.Helper()
@@ -185,6 +227,20 @@ func (mr *MockMempoolMockRecorder) SizeBytes() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SizeBytes", reflect.TypeOf((*MockMempool)(nil).SizeBytes))
 }
 
+// Snapshot mocks base method.
+func (m *MockMempool) Snapshot() *mempool.Snapshot {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Snapshot")
+	ret0, _ := ret[0].(*mempool.Snapshot)
+	return ret0
+}
+
+// Snapshot indicates an expected call of Snapshot.
+func (mr *MockMempoolMockRecorder) Snapshot() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Snapshot", reflect.TypeOf((*MockMempool)(nil).Snapshot))
+}
+
 // TxsAvailable mocks base method.
 func (m *MockMempool) TxsAvailable() <-chan struct{} {
 	m.ctrl.T.Helper()
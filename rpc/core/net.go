@@ -21,12 +21,16 @@ func NetInfo(ctx *rpctypes.Context) (*ctypes.ResultNetInfo, error) {
 		if !ok {
 			return nil, fmt.Errorf("peer.NodeInfo() is not DefaultNodeInfo")
 		}
-		peers = append(peers, ctypes.Peer{
+		p := ctypes.Peer{
 			NodeInfo:         nodeInfo,
 			IsOutbound:       peer.IsOutbound(),
 			ConnectionStatus: peer.Status(),
 			RemoteIP:         peer.RemoteIP().String(),
-		})
+		}
+		if score, ok := env.P2PPeers.PeerTrustScore(peer.ID()); ok {
+			p.TrustScore = &score
+		}
+		peers = append(peers, p)
 	}
 	// TODO: Should we include PersistentPeers and Seeds in here?
 	// PRO: useful info
@@ -13,6 +13,10 @@ var Routes = map[string]*rpc.RPCFunc{
 	"unsubscribe":     rpc.NewWSRPCFunc(Unsubscribe, "query"),
 	"unsubscribe_all": rpc.NewWSRPCFunc(UnsubscribeAll, ""),
 
+	// tx_search_stream/block_search_stream stream their results over websockets.
+	"tx_search_stream":    rpc.NewWSRPCFunc(TxSearchStream, "query,prove,cursor,per_page,order_by"),
+	"block_search_stream": rpc.NewWSRPCFunc(BlockSearchStream, "query,cursor,per_page,order_by"),
+
 	// info API
 	"health":                    rpc.NewRPCFunc(Health, ""),
 	"status":                    rpc.NewRPCFunc(Status, ""),
@@ -35,12 +39,15 @@ var Routes = map[string]*rpc.RPCFunc{
 	"data_root_inclusion_proof": rpc.NewRPCFunc(DataRootInclusionProof, "height,start,end"),
 	"tx_search":                 rpc.NewRPCFunc(TxSearchMatchEvents, "query,prove,page,per_page,order_by,match_events"),
 	"block_search":              rpc.NewRPCFunc(BlockSearchMatchEvents, "query,page,per_page,order_by,match_events"),
+	"tx_search_cursor":          rpc.NewRPCFunc(TxSearchCursor, "query,prove,cursor,per_page,order_by"),
+	"block_search_cursor":       rpc.NewRPCFunc(BlockSearchCursor, "query,cursor,per_page,order_by"),
 	"validators":                rpc.NewRPCFunc(Validators, "height,page,per_page", rpc.Cacheable("height")),
 	"dump_consensus_state":      rpc.NewRPCFunc(DumpConsensusState, ""),
 	"consensus_state":           rpc.NewRPCFunc(ConsensusState, ""),
 	"consensus_params":          rpc.NewRPCFunc(ConsensusParams, "height", rpc.Cacheable("height")),
 	"unconfirmed_txs":           rpc.NewRPCFunc(UnconfirmedTxs, "limit"),
 	"num_unconfirmed_txs":       rpc.NewRPCFunc(NumUnconfirmedTxs, ""),
+	"mempool_snapshot":          rpc.NewRPCFunc(MempoolSnapshot, ""),
 	"tx_status":                 rpc.NewRPCFunc(TxStatus, "hash"),
 
 	// tx broadcast API
@@ -62,4 +69,5 @@ func AddUnsafeRoutes() {
 	Routes["dial_seeds"] = rpc.NewRPCFunc(UnsafeDialSeeds, "seeds")
 	Routes["dial_peers"] = rpc.NewRPCFunc(UnsafeDialPeers, "peers,persistent,unconditional,private")
 	Routes["unsafe_flush_mempool"] = rpc.NewRPCFunc(UnsafeFlushMempool, "")
+	Routes["unsafe_export_mempool"] = rpc.NewRPCFunc(UnsafeExportMempool, "")
 }
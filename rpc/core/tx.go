@@ -4,24 +4,29 @@ import (
 	"errors"
 	"fmt"
 	"sort"
+	"time"
 
 	abcitypes "github.com/tendermint/tendermint/abci/types"
 	cmtmath "github.com/tendermint/tendermint/libs/math"
 	cmtquery "github.com/tendermint/tendermint/libs/pubsub/query"
+	"github.com/tendermint/tendermint/mempool"
 	"github.com/tendermint/tendermint/pkg/consts"
 	cmtproto "github.com/tendermint/tendermint/proto/tendermint/types"
 	ctypes "github.com/tendermint/tendermint/rpc/core/types"
 	rpctypes "github.com/tendermint/tendermint/rpc/jsonrpc/types"
 	"github.com/tendermint/tendermint/state"
+	"github.com/tendermint/tendermint/state/txindex"
 	"github.com/tendermint/tendermint/state/txindex/null"
 	"github.com/tendermint/tendermint/types"
 )
 
+// Deprecated: use the tx statuses of rpc/core/types instead.
 const (
-	TxStatusUnknown   string = "UNKNOWN"
-	TxStatusPending   string = "PENDING"
-	TxStatusEvicted   string = "EVICTED"
-	TxStatusCommitted string = "COMMITTED"
+	TxStatusUnknown   = ctypes.TxStatusUnknown
+	TxStatusPending   = ctypes.TxStatusPending
+	TxStatusEvicted   = ctypes.TxStatusEvicted
+	TxStatusRejected  = ctypes.TxStatusRejected
+	TxStatusCommitted = ctypes.TxStatusCommitted
 )
 
 // Tx allows you to query the transaction results. `nil` could mean the
@@ -89,56 +94,221 @@ func TxSearch(
 		return nil, err
 	}
 
-	results, err := env.TxIndexer.Search(ctx.Context(), q)
-	if err != nil {
-		return nil, err
+	perPage := validatePerPage(perPagePtr)
+	var (
+		results    []*abcitypes.TxResult
+		totalCount int
+	)
+	if pager, ok := env.TxIndexer.(txindex.SearchPager); ok {
+		// the indexer sorts and paginates the results itself
+		var desc bool
+		switch orderBy {
+		case "desc":
+			desc = true
+		case "asc", "":
+		default:
+			return nil, errors.New("expected order_by to be either `asc` or `desc` or empty")
+		}
+		page := 1
+		if pagePtr != nil {
+			page = *pagePtr
+		}
+		results, totalCount, err = pager.SearchPage(ctx.Context(), q, desc, validateSkipCount(page, perPage), perPage)
+		if err != nil {
+			return nil, err
+		}
+		if _, err := validatePage(pagePtr, perPage, totalCount); err != nil {
+			return nil, err
+		}
+	} else {
+		results, err = env.TxIndexer.Search(ctx.Context(), q)
+		if err != nil {
+			return nil, err
+		}
+
+		// sort results (must be done before pagination)
+		switch orderBy {
+		case "desc":
+			sort.Slice(results, func(i, j int) bool {
+				if results[i].Height == results[j].Height {
+					return results[i].Index > results[j].Index
+				}
+				return results[i].Height > results[j].Height
+			})
+		case "asc", "":
+			sort.Slice(results, func(i, j int) bool {
+				if results[i].Height == results[j].Height {
+					return results[i].Index < results[j].Index
+				}
+				return results[i].Height < results[j].Height
+			})
+		default:
+			return nil, errors.New("expected order_by to be either `asc` or `desc` or empty")
+		}
+
+		// paginate results
+		totalCount = len(results)
+		page, err := validatePage(pagePtr, perPage, totalCount)
+		if err != nil {
+			return nil, err
+		}
+
+		skipCount := validateSkipCount(page, perPage)
+		pageSize := cmtmath.MinInt(perPage, totalCount-skipCount)
+		results = results[skipCount : skipCount+pageSize]
 	}
 
-	// sort results (must be done before pagination)
-	switch orderBy {
-	case "desc":
-		sort.Slice(results, func(i, j int) bool {
-			if results[i].Height == results[j].Height {
-				return results[i].Index > results[j].Index
-			}
-			return results[i].Height > results[j].Height
-		})
-	case "asc", "":
-		sort.Slice(results, func(i, j int) bool {
-			if results[i].Height == results[j].Height {
-				return results[i].Index < results[j].Index
+	apiResults := make([]*ctypes.ResultTx, 0, len(results))
+	for _, r := range results {
+		var shareProof types.ShareProof
+		if prove {
+			shareProof, err = proveTx(r.Height, r.Index)
+			if err != nil {
+				return nil, err
 			}
-			return results[i].Height < results[j].Height
+		}
+
+		apiResults = append(apiResults, &ctypes.ResultTx{
+			Hash:     types.Tx(r.Tx).Hash(),
+			Height:   r.Height,
+			Index:    r.Index,
+			TxResult: r.Result,
+			Tx:       r.Tx,
+			Proof:    shareProof,
 		})
-	default:
-		return nil, errors.New("expected order_by to be either `asc` or `desc` or empty")
 	}
 
-	// paginate results
-	totalCount := len(results)
-	perPage := validatePerPage(perPagePtr)
+	return &ctypes.ResultTxSearch{Txs: apiResults, TotalCount: totalCount}, nil
+}
 
-	page, err := validatePage(pagePtr, perPage, totalCount)
+// TxSearchCursor allows you to iterate over the transactions results matching
+// a query. It returns at most ?per_page of them, in the order of their heights
+// and indexes, along with the cursor to pass to get the next ones. The
+// transactions are iterated lazily by the indexer, and the index entries
+// examined per request are capped by the search_scan_limit RPC config.
+// More: https://docs.cometbft.com/v0.34/rpc/#/Info/tx_search_cursor
+func TxSearchCursor(
+	ctx *rpctypes.Context,
+	query string,
+	prove bool,
+	cursor string,
+	perPagePtr *int,
+	orderBy string,
+) (*ctypes.ResultTxSearchCursor, error) {
+	searcher, q, s, err := newTxCursorSearch(query, cursor, perPagePtr, orderBy)
+	if err != nil {
+		return nil, err
+	}
+	s.MaxScan, _ = remainingScan(0)
+	page, err := searcher.SearchCursor(ctx.Context(), q, s)
+	if err != nil {
+		return nil, err
+	}
+	return txCursorResult(page, query, s.Desc, prove)
+}
+
+// TxSearchStream streams the transactions results matching a query over a
+// websocket, in chunks of at most ?per_page of them, each sent as a response
+// to the request with more set, in the order of their heights and indexes.
+// The last chunk is the result of the request, with the cursor to resume the
+// search from if it reached the search_scan_limit RPC config.
+// More: https://docs.cometbft.com/v0.34/rpc/#/Websocket/tx_search_stream
+func TxSearchStream(
+	ctx *rpctypes.Context,
+	query string,
+	prove bool,
+	cursor string,
+	perPagePtr *int,
+	orderBy string,
+) (*ctypes.ResultTxSearchCursor, error) {
+	searcher, q, s, err := newTxCursorSearch(query, cursor, perPagePtr, orderBy)
 	if err != nil {
 		return nil, err
 	}
 
-	skipCount := validateSkipCount(page, perPage)
-	pageSize := cmtmath.MinInt(perPage, totalCount-skipCount)
+	var scanned int
+	for {
+		s.MaxScan, _ = remainingScan(scanned)
+		page, err := searcher.SearchCursor(ctx.Context(), q, s)
+		if err != nil {
+			return nil, err
+		}
+		if err := ctx.Context().Err(); err != nil {
+			return nil, err
+		}
+		scanned += page.Scanned
+		res, err := txCursorResult(page, query, s.Desc, prove)
+		if err != nil {
+			return nil, err
+		}
+		if _, done := remainingScan(scanned); done || page.Next == nil {
+			return res, nil
+		}
 
-	apiResults := make([]*ctypes.ResultTx, 0, pageSize)
-	for i := skipCount; i < skipCount+pageSize; i++ {
-		r := results[i]
+		res.More = true
+		if err := ctx.WSConn.WriteRPCResponse(ctx.Context(), rpctypes.NewRPCSuccessResponse(ctx.JSONReq.ID, res)); err != nil {
+			return nil, err
+		}
+		s.From = page.Next
+	}
+}
 
-		var shareProof types.ShareProof
+// newTxCursorSearch returns the indexer and the parameters of a search by
+// cursor of the transactions.
+func newTxCursorSearch(
+	query string,
+	cursor string,
+	perPagePtr *int,
+	orderBy string,
+) (txindex.CursorSearcher, *cmtquery.Query, txindex.CursorSearch, error) {
+	env := GetEnvironment()
+	var s txindex.CursorSearch
+	if _, ok := env.TxIndexer.(*null.TxIndex); ok {
+		return nil, nil, s, errors.New("transaction indexing is disabled")
+	} else if len(query) > maxQueryLength {
+		return nil, nil, s, errors.New("maximum query length exceeded")
+	}
+	searcher, ok := env.TxIndexer.(txindex.CursorSearcher)
+	if !ok {
+		return nil, nil, s, errors.New("the transaction indexer doesn't support searches by cursor")
+	}
+
+	q, err := cmtquery.New(query)
+	if err != nil {
+		return nil, nil, s, err
+	}
+	if s.Desc, err = parseOrder(orderBy, false); err != nil {
+		return nil, nil, s, err
+	}
+	if cursor != "" {
+		c, err := decodeCursor(cursor, txCursorKind, query, s.Desc)
+		if err != nil {
+			return nil, nil, s, err
+		}
+		s.From = &txindex.Cursor{Height: c.height, Index: c.index}
+	}
+	// the txs of the blocks pruned are still indexed
+	s.MinHeight, s.MaxHeight = 1, env.BlockStore.Height()
+	s.Limit = validatePerPage(perPagePtr)
+	return searcher, q, s, nil
+}
+
+// txCursorResult returns the result of a page of a search by cursor of the
+// transactions.
+func txCursorResult(page *txindex.CursorPage, query string, desc, prove bool) (*ctypes.ResultTxSearchCursor, error) {
+	res := &ctypes.ResultTxSearchCursor{Txs: make([]*ctypes.ResultTx, 0, len(page.Results))}
+	for _, r := range page.Results {
+		var (
+			shareProof types.ShareProof
+			err        error
+		)
 		if prove {
 			shareProof, err = proveTx(r.Height, r.Index)
 			if err != nil {
 				return nil, err
 			}
 		}
-
-		apiResults = append(apiResults, &ctypes.ResultTx{
+		res.Txs = append(res.Txs, &ctypes.ResultTx{
 			Hash:     types.Tx(r.Tx).Hash(),
 			Height:   r.Height,
 			Index:    r.Index,
@@ -147,8 +317,16 @@ func TxSearch(
 			Proof:    shareProof,
 		})
 	}
-
-	return &ctypes.ResultTxSearch{Txs: apiResults, TotalCount: totalCount}, nil
+	if page.Next != nil {
+		res.NextCursor = searchCursor{
+			kind:   txCursorKind,
+			desc:   desc,
+			query:  query,
+			height: page.Next.Height,
+			index:  page.Next.Index,
+		}.encode()
+	}
+	return res, nil
 }
 
 func proveTx(height int64, index uint32) (types.ShareProof, error) {
@@ -221,8 +399,9 @@ func ProveShares(
 }
 
 // TxStatus retrieves the status of a transaction by its hash. It returns a ResultTxStatus
-// with the transaction's height and index if committed, or its pending, evicted, or unknown status.
-// It also includes the execution code and log for failed txs.
+// with the transaction's height and index if committed, or its pending, evicted, rejected or unknown status.
+// It also includes the execution code and log for failed txs, the priority, gas wanted, age and
+// estimated queue position of pending txs, and the reason evicted or rejected txs were removed.
 func TxStatus(ctx *rpctypes.Context, hash []byte) (*ctypes.ResultTxStatus, error) {
 	env := GetEnvironment()
 
@@ -239,15 +418,26 @@ func TxStatus(ctx *rpctypes.Context, hash []byte) (*ctypes.ResultTxStatus, error
 	}
 
 	// Check if the tx is in the mempool
-	txInMempool, ok := env.Mempool.GetTxByKey(txKey)
-	if txInMempool != nil && ok {
-		return &ctypes.ResultTxStatus{Status: TxStatusPending}, nil
+	if pending, ok := env.Mempool.GetPendingTx(txKey); ok {
+		return &ctypes.ResultTxStatus{
+			Status: TxStatusPending,
+			Pending: &ctypes.ResultPendingTx{
+				Priority:   pending.Priority,
+				GasWanted:  pending.GasWanted,
+				Height:     pending.Height,
+				TimeInPool: time.Since(pending.Timestamp),
+				Position:   pending.Position,
+			},
+		}, nil
 	}
 
-	// Check if the tx is evicted
-	isEvicted := env.Mempool.WasRecentlyEvicted(txKey)
-	if isEvicted {
-		return &ctypes.ResultTxStatus{Status: TxStatusEvicted}, nil
+	// Check if the tx was evicted or rejected
+	if reason, ok := env.Mempool.GetRemovalReason(txKey); ok {
+		status := TxStatusEvicted
+		if reason == mempool.RemovalReasonCheckTxFailed {
+			status = TxStatusRejected
+		}
+		return &ctypes.ResultTxStatus{Status: status, Reason: string(reason)}, nil
 	}
 
 	// If the tx is not in the mempool, evicted, or committed, return unknown
@@ -2,9 +2,12 @@ package core
 
 import (
 	"testing"
+	"time"
 
 	"github.com/golang/mock/gomock"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	mempl "github.com/tendermint/tendermint/mempool"
 	mock "github.com/tendermint/tendermint/rpc/core/mocks"
 	rpctypes "github.com/tendermint/tendermint/rpc/jsonrpc/types"
 	types "github.com/tendermint/tendermint/types"
@@ -56,10 +59,10 @@ func TestTxStatus(t *testing.T) {
 					blocks: nil,
 				}
 				for _, tx := range txs {
-					// Set GetTxByKey to return nil and false for all transactions
-					mempool.EXPECT().GetTxByKey(tx.Key()).Return(nil, false).AnyTimes()
-					// Set WasRecentlyEvicted to return false for all transactions
-					mempool.EXPECT().WasRecentlyEvicted(tx.Key()).Return(false).AnyTimes()
+					// Set GetPendingTx to return nil and false for all transactions
+					mempool.EXPECT().GetPendingTx(tx.Key()).Return(nil, false).AnyTimes()
+					// Set GetRemovalReason to return false for all transactions
+					mempool.EXPECT().GetRemovalReason(tx.Key()).Return(mempl.RemovalReason(""), false).AnyTimes()
 				}
 			},
 
@@ -76,9 +79,15 @@ func TestTxStatus(t *testing.T) {
 				mempool = mock.NewMockMempool(ctrl)
 				env.Mempool = mempool
 
-				for _, tx := range txs {
-					// Set GetTxByKey to return the transaction and true for all transactions
-					mempool.EXPECT().GetTxByKey(tx.Key()).Return(tx, true).AnyTimes()
+				for i, tx := range txs {
+					// Set GetPendingTx to return the transaction details and true for all transactions
+					mempool.EXPECT().GetPendingTx(tx.Key()).Return(&mempl.PendingTx{
+						Priority:  10,
+						GasWanted: 100,
+						Height:    1,
+						Timestamp: time.Now().Add(-time.Minute),
+						Position:  i,
+					}, true).AnyTimes()
 				}
 			},
 			expectedStatus: "PENDING",
@@ -95,14 +104,34 @@ func TestTxStatus(t *testing.T) {
 				env.Mempool = mempool
 
 				for _, tx := range txs {
-					// Set GetTxByKey to return nil and false for all transactions
-					mempool.EXPECT().GetTxByKey(tx.Key()).Return(nil, false).AnyTimes()
-					// Set WasRecentlyEvicted to return true for all transactions
-					mempool.EXPECT().WasRecentlyEvicted(tx.Key()).Return(true).AnyTimes()
+					// Set GetPendingTx to return nil and false for all transactions
+					mempool.EXPECT().GetPendingTx(tx.Key()).Return(nil, false).AnyTimes()
+					// Set GetRemovalReason to return an eviction for all transactions
+					mempool.EXPECT().GetRemovalReason(tx.Key()).Return(mempl.RemovalReasonExpired, true).AnyTimes()
 				}
 			},
 			expectedStatus: "EVICTED",
 		},
+		{
+			name: "Rejected",
+			setup: func(env *Environment, txs []types.Tx) {
+				env.BlockStore = mockBlockStore{
+					height: 0,
+					blocks: nil,
+				}
+				// Reset the mempool
+				mempool = mock.NewMockMempool(ctrl)
+				env.Mempool = mempool
+
+				for _, tx := range txs {
+					// Set GetPendingTx to return nil and false for all transactions
+					mempool.EXPECT().GetPendingTx(tx.Key()).Return(nil, false).AnyTimes()
+					// Set GetRemovalReason to return a rejection for all transactions
+					mempool.EXPECT().GetRemovalReason(tx.Key()).Return(mempl.RemovalReasonCheckTxFailed, true).AnyTimes()
+				}
+			},
+			expectedStatus: "REJECTED",
+		},
 	}
 
 	for _, tt := range tests {
@@ -128,6 +157,23 @@ func TestTxStatus(t *testing.T) {
 					assert.Equal(t, uint32(0), txStatus.ExecutionCode)
 					assert.Equal(t, "", txStatus.Error)
 				}
+
+				switch tt.expectedStatus {
+				case "PENDING":
+					require.NotNil(t, txStatus.Pending)
+					assert.Equal(t, int64(10), txStatus.Pending.Priority)
+					assert.Equal(t, int64(100), txStatus.Pending.GasWanted)
+					assert.Equal(t, int64(1), txStatus.Pending.Height)
+					assert.GreaterOrEqual(t, txStatus.Pending.TimeInPool, time.Minute)
+					assert.Equal(t, i, txStatus.Pending.Position)
+				case "EVICTED":
+					assert.Equal(t, "ttl_expired", txStatus.Reason)
+				case "REJECTED":
+					assert.Equal(t, "check_tx_failed", txStatus.Reason)
+				default:
+					assert.Nil(t, txStatus.Pending)
+					assert.Empty(t, txStatus.Reason)
+				}
 			}
 
 		})
@@ -60,6 +60,15 @@ type ResultCommit struct {
 	CanonicalCommit    bool `json:"canonical"`
 }
 
+// Statuses of a transaction reported by ResultTxStatus.
+const (
+	TxStatusUnknown   string = "UNKNOWN"
+	TxStatusPending   string = "PENDING"
+	TxStatusEvicted   string = "EVICTED"
+	TxStatusRejected  string = "REJECTED"
+	TxStatusCommitted string = "COMMITTED"
+)
+
 // ResultTxStatus represents the status of a transaction during its life cycle.
 // It contains info to locate a tx in a committed block as well as its execution code, log if it fails and status.
 type ResultTxStatus struct {
@@ -68,6 +77,27 @@ type ResultTxStatus struct {
 	ExecutionCode uint32 `json:"execution_code"`
 	Error         string `json:"error"`
 	Status        string `json:"status"`
+	// Verified is set by light clients once the tx's inclusion at Height and
+	// Index has been proven against a trusted header. The index of PayForBlobs
+	// txs can't be proven, so they are never verified.
+	Verified bool `json:"verified,omitempty"`
+	// Pending is set for txs still in the mempool.
+	Pending *ResultPendingTx `json:"pending,omitempty"`
+	// Reason is set for txs recently evicted from, or rejected by, the
+	// mempool, e.g. "ttl_expired" or "mempool_full".
+	Reason string `json:"reason,omitempty"`
+}
+
+// ResultPendingTx describes a tx pending in the mempool.
+type ResultPendingTx struct {
+	Priority  int64 `json:"priority"`
+	GasWanted int64 `json:"gas_wanted"`
+	// Height is the height at which the tx was added to the mempool.
+	Height     int64         `json:"height"`
+	TimeInPool time.Duration `json:"time_in_pool"`
+	// Position is the estimated number of txs ahead of the tx in the queue
+	// for the next blocks.
+	Position int `json:"position"`
 }
 
 // ABCI results from a block
@@ -163,6 +193,8 @@ type Peer struct {
 	IsOutbound       bool                 `json:"is_outbound"`
 	ConnectionStatus p2p.ConnectionStatus `json:"connection_status"`
 	RemoteIP         string               `json:"remote_ip"`
+	// TrustScore is nil when the node doesn't keep a trust metric for the peer
+	TrustScore *int `json:"trust_score,omitempty"`
 }
 
 // Validators for a height.
@@ -244,6 +276,28 @@ type ResultBlockSearch struct {
 	TotalCount int            `json:"total_count"`
 }
 
+// ResultTxSearchCursor defines the RPC response type for a tx search by
+// cursor, or a chunk of a streamed tx search.
+type ResultTxSearchCursor struct {
+	Txs []*ResultTx `json:"txs"`
+	// NextCursor is the cursor to resume the search from, empty once all the
+	// txs were searched.
+	NextCursor string `json:"next_cursor"`
+	// More reports whether more chunks of a streamed search follow.
+	More bool `json:"more,omitempty"`
+}
+
+// ResultBlockSearchCursor defines the RPC response type for a block search by
+// cursor, or a chunk of a streamed block search.
+type ResultBlockSearchCursor struct {
+	Blocks []*ResultBlock `json:"blocks"`
+	// NextCursor is the cursor to resume the search from, empty once all the
+	// blocks were searched.
+	NextCursor string `json:"next_cursor"`
+	// More reports whether more chunks of a streamed search follow.
+	More bool `json:"more,omitempty"`
+}
+
 // List of mempool txs
 type ResultUnconfirmedTxs struct {
 	Count      int        `json:"n_txs"`
@@ -252,6 +306,31 @@ type ResultUnconfirmedTxs struct {
 	Txs        []types.Tx `json:"txs"`
 }
 
+// ResultMempoolSnapshot is a view of the mempool as of a version of the
+// changes streamed to the MempoolTx subscriptions.
+type ResultMempoolSnapshot struct {
+	Version    uint64            `json:"version"`
+	Total      int               `json:"total"`
+	TotalBytes int64             `json:"total_bytes"`
+	Txs        []ResultMempoolTx `json:"txs"`
+}
+
+// ResultMempoolTx describes a tx in a snapshot of the mempool.
+type ResultMempoolTx struct {
+	Hash      bytes.HexBytes `json:"hash"`
+	Size      int64          `json:"size"`
+	Priority  int64          `json:"priority"`
+	GasWanted int64          `json:"gas_wanted"`
+	Sender    string         `json:"sender"`
+	// Blobs, BlobBytes and Namespaces describe the blobs of a BlobTx.
+	Blobs      int              `json:"blobs"`
+	BlobBytes  int64            `json:"blob_bytes"`
+	Namespaces []bytes.HexBytes `json:"namespaces"`
+	// Height is the height at which the tx was added to the mempool.
+	Height int64         `json:"height"`
+	Age    time.Duration `json:"age"`
+}
+
 // Info abci msg
 type ResultABCIInfo struct {
 	Response abci.ResponseInfo `json:"response"`
@@ -267,6 +346,14 @@ type ResultBroadcastEvidence struct {
 	Hash []byte `json:"hash"`
 }
 
+// Result of exporting the mempool
+type ResultUnsafeExportMempool struct {
+	// Txs is the number of transactions exported.
+	Txs int `json:"txs"`
+	// Dir is the dir of the node the transactions were written to.
+	Dir string `json:"dir"`
+}
+
 // empty results
 type (
 	ResultUnsafeFlushMempool struct{}
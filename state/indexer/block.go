@@ -22,3 +22,48 @@ type BlockIndexer interface {
 	// and Endblock event search criteria.
 	Search(ctx context.Context, q *query.Query) ([]int64, error)
 }
+
+// BlockSearchPager is implemented by the BlockIndexers that sort and paginate
+// the results of a search in their store, rather than returning all of them.
+type BlockSearchPager interface {
+	// SearchPage returns the page of the heights of the blocks matching q,
+	// descending if desc, that starts after skip of them and holds at most
+	// limit of them, along with the count of the blocks matching q.
+	SearchPage(ctx context.Context, q *query.Query, desc bool, skip, limit int) ([]int64, int, error)
+}
+
+// BlockCursorSearch are the parameters of a search iterating the heights of
+// the blocks matching a query in order.
+type BlockCursorSearch struct {
+	// From is the height to start the search from, included, or 0 to start
+	// from MinHeight, or MaxHeight if Desc.
+	From int64
+	// Desc iterates the heights in descending order.
+	Desc bool
+	// MinHeight and MaxHeight are the heights searched, included.
+	MinHeight, MaxHeight int64
+	// Limit is the maximum number of heights returned.
+	Limit int
+	// MaxScan is the maximum number of index entries examined, if positive.
+	MaxScan int
+}
+
+// BlockCursorPage is the result of a BlockCursorSearch.
+type BlockCursorPage struct {
+	// Heights are the heights of the blocks matching the query, in order.
+	Heights []int64
+	// Next is the height to resume the search from, or 0 if all the blocks
+	// were searched.
+	Next int64
+	// Scanned is the number of index entries examined.
+	Scanned int
+}
+
+// BlockCursorSearcher is implemented by the BlockIndexers that iterate the
+// heights of the blocks matching a query lazily, in order, rather than
+// collecting all of them.
+type BlockCursorSearcher interface {
+	// SearchCursor returns the heights of the blocks matching q within the
+	// bounds of s.
+	SearchCursor(ctx context.Context, q *query.Query, s BlockCursorSearch) (*BlockCursorPage, error)
+}
@@ -0,0 +1,346 @@
+package kv
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+
+	dbm "github.com/cometbft/cometbft-db"
+	"github.com/google/orderedcode"
+
+	"github.com/tendermint/tendermint/libs/pubsub/query"
+	"github.com/tendermint/tendermint/state/indexer"
+	"github.com/tendermint/tendermint/types"
+)
+
+var _ indexer.BlockCursorSearcher = (*BlockerIndexer)(nil)
+
+// SearchCursor implements indexer.BlockCursorSearcher. It iterates in order
+// the heights of the blocks holding the attribute of the first equality
+// condition of the query if any, or else the heights satisfying the other
+// attribute conditions if any, or else the heights of all the blocks, and
+// matches the attribute conditions at each height.
+//
+// As their values precede the heights in the index, the heights satisfying
+// the attribute conditions other than equalities are collected from their
+// index before iterating. It is an error if this exceeds s.MaxScan.
+func (idx *BlockerIndexer) SearchCursor(
+	ctx context.Context,
+	q *query.Query,
+	s indexer.BlockCursorSearch,
+) (*indexer.BlockCursorPage, error) {
+	conditions, err := q.Conditions()
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse query conditions: %w", err)
+	}
+	cc := indexer.SplitCursorConditions(conditions, types.BlockHeightKey, s.MinHeight, s.MaxHeight)
+	if s.From > 0 {
+		if s.Desc && s.From < cc.MaxHeight {
+			cc.MaxHeight = s.From
+		} else if !s.Desc && s.From > cc.MinHeight {
+			cc.MinHeight = s.From
+		}
+	}
+
+	page := new(indexer.BlockCursorPage)
+	if cc.MinHeight > cc.MaxHeight {
+		return page, nil
+	}
+
+	var (
+		driver    = -1
+		collected []map[int64][]int64
+	)
+	for i, c := range cc.Conditions {
+		if c.Op == query.OpEqual {
+			if driver < 0 {
+				driver = i
+			}
+			continue
+		}
+		c := c
+		events, err := idx.collectEvents(page, s.MaxScan, c.CompositeKey, cc, func(value string) bool {
+			switch c.Op {
+			case query.OpContains:
+				return strings.Contains(value, fmt.Sprintf("%v", c.Operand))
+			case query.OpExists:
+				return true
+			default:
+				return false
+			}
+		})
+		if err != nil {
+			return nil, err
+		}
+		collected = append(collected, events)
+	}
+	// collect the ranges in a stable order
+	keys := make([]string, 0, len(cc.Ranges))
+	for key := range cc.Ranges {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		qr := cc.Ranges[key]
+		events, err := idx.collectEvents(page, s.MaxScan, key, cc, func(value string) bool {
+			return indexer.IntegerBounds(qr) && indexer.InRange(qr, value)
+		})
+		if err != nil {
+			return nil, err
+		}
+		collected = append(collected, events)
+	}
+
+	// visit matches the block at height, and reports whether the search is
+	// over.
+	scanning := false
+	visit := func(height int64) (bool, error) {
+		select {
+		case <-ctx.Done():
+			page.Next = height
+			return true, nil
+		default:
+		}
+		if scanning && s.MaxScan > 0 && page.Scanned >= s.MaxScan {
+			page.Next = height
+			return true, nil
+		}
+		scanning = true
+
+		ok, err := idx.matchHeight(page, height, cc, collected)
+		if err != nil || !ok {
+			return false, err
+		}
+		if len(page.Heights) == s.Limit {
+			page.Next = height
+			return true, nil
+		}
+		page.Heights = append(page.Heights, height)
+		return false, nil
+	}
+
+	if driver < 0 && len(collected) > 0 {
+		heights := make([]int64, 0, len(collected[0]))
+		for height := range collected[0] {
+			heights = append(heights, height)
+		}
+		sort.Slice(heights, func(i, j int) bool {
+			if s.Desc {
+				return heights[i] > heights[j]
+			}
+			return heights[i] < heights[j]
+		})
+		for _, height := range heights {
+			if done, err := visit(height); done || err != nil {
+				return page, err
+			}
+		}
+		return page, nil
+	}
+
+	var prefix []byte
+	if driver >= 0 {
+		c := cc.Conditions[driver]
+		prefix, err = orderedcode.Append(nil, c.CompositeKey, fmt.Sprintf("%v", c.Operand))
+	} else {
+		prefix, err = orderedcode.Append(nil, types.BlockHeightKey)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to create prefix key: %w", err)
+	}
+	it, err := heightIterator(idx.store, prefix, cc.MinHeight, cc.MaxHeight, s.Desc)
+	if err != nil {
+		return nil, err
+	}
+	defer it.Close()
+
+	var (
+		seen bool
+		prev int64
+	)
+	for ; it.Valid(); it.Next() {
+		page.Scanned++
+		var height int64
+		if driver >= 0 {
+			height, err = parseHeightFromEventKey(it.Key())
+		} else {
+			var compositeKey string
+			_, err = orderedcode.Parse(string(it.Key()), &compositeKey, &height)
+		}
+		if err != nil {
+			return nil, err
+		}
+		if seen && height == prev {
+			continue
+		}
+		seen, prev = true, height
+
+		if done, err := visit(height); done || err != nil {
+			return page, err
+		}
+	}
+	return page, it.Error()
+}
+
+// collectEvents returns the sequences of the events, by height within the
+// heights of cc, holding an attribute of composite key compositeKey whose
+// value satisfies match.
+func (idx *BlockerIndexer) collectEvents(
+	page *indexer.BlockCursorPage,
+	maxScan int,
+	compositeKey string,
+	cc indexer.CursorConditions,
+	match func(value string) bool,
+) (map[int64][]int64, error) {
+	prefix, err := orderedcode.Append(nil, compositeKey)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create prefix key: %w", err)
+	}
+	it, err := dbm.IteratePrefix(idx.store, prefix)
+	if err != nil {
+		return nil, err
+	}
+	defer it.Close()
+
+	events := make(map[int64][]int64)
+	for ; it.Valid(); it.Next() {
+		page.Scanned++
+		if maxScan > 0 && page.Scanned > maxScan {
+			return nil, fmt.Errorf("the %q conditions of the query examine more than %d index entries; "+
+				"narrow the query with an equality condition", compositeKey, maxScan)
+		}
+
+		height, err := parseHeightFromEventKey(it.Key())
+		if err != nil {
+			return nil, err
+		}
+		if height < cc.MinHeight || height > cc.MaxHeight {
+			continue
+		}
+		value, err := parseValueFromEventKey(it.Key())
+		if err != nil {
+			return nil, err
+		}
+		if !match(value) {
+			continue
+		}
+		eventSeq, err := parseEventSeqFromEventKey(it.Key())
+		if err != nil {
+			return nil, err
+		}
+		events[height] = append(events[height], eventSeq)
+	}
+	return events, it.Error()
+}
+
+// matchHeight reports whether the events of the block at height satisfy the
+// attribute conditions of cc, the events satisfying the conditions other than
+// equalities being collected.
+func (idx *BlockerIndexer) matchHeight(
+	page *indexer.BlockCursorPage,
+	height int64,
+	cc indexer.CursorConditions,
+	collected []map[int64][]int64,
+) (bool, error) {
+	var eventSeqs [][]int64
+	for _, c := range cc.Conditions {
+		if c.Op != query.OpEqual {
+			continue
+		}
+		prefix, err := orderedcode.Append(nil, c.CompositeKey, fmt.Sprintf("%v", c.Operand), height)
+		if err != nil {
+			return false, fmt.Errorf("failed to create prefix key: %w", err)
+		}
+		seqs, err := idx.eventSeqs(page, prefix)
+		if err != nil {
+			return false, err
+		}
+		eventSeqs = append(eventSeqs, seqs)
+	}
+	for _, events := range collected {
+		eventSeqs = append(eventSeqs, events[height])
+	}
+
+	for _, seqs := range eventSeqs {
+		if len(seqs) == 0 {
+			return false, nil
+		}
+	}
+	if !cc.MatchEvents || len(eventSeqs) == 0 {
+		return true, nil
+	}
+	// the conditions must be satisfied by a single event
+	common := make(map[int64]int)
+	for _, seqs := range eventSeqs {
+		counted := make(map[int64]bool)
+		for _, seq := range seqs {
+			if !counted[seq] {
+				counted[seq] = true
+				common[seq]++
+			}
+		}
+	}
+	for _, n := range common {
+		if n == len(eventSeqs) {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// eventSeqs returns the sequences of the events of the entries under prefix.
+func (idx *BlockerIndexer) eventSeqs(page *indexer.BlockCursorPage, prefix []byte) ([]int64, error) {
+	it, err := dbm.IteratePrefix(idx.store, prefix)
+	if err != nil {
+		return nil, err
+	}
+	defer it.Close()
+
+	var seqs []int64
+	for ; it.Valid(); it.Next() {
+		page.Scanned++
+		eventSeq, err := parseEventSeqFromEventKey(it.Key())
+		if err != nil {
+			return nil, err
+		}
+		seqs = append(seqs, eventSeq)
+	}
+	return seqs, it.Error()
+}
+
+// heightIterator returns an iterator over the entries under prefix followed
+// by a height within [minHeight, maxHeight], in the order of the heights.
+func heightIterator(store dbm.DB, prefix []byte, minHeight, maxHeight int64, desc bool) (dbm.Iterator, error) {
+	start, err := orderedcode.Append(append([]byte{}, prefix...), minHeight)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create start key: %w", err)
+	}
+	var end []byte
+	if maxHeight < math.MaxInt64 {
+		end, err = orderedcode.Append(append([]byte{}, prefix...), maxHeight+1)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create end key: %w", err)
+		}
+	} else {
+		end = prefixEnd(prefix)
+	}
+	if desc {
+		return store.ReverseIterator(start, end)
+	}
+	return store.Iterator(start, end)
+}
+
+// prefixEnd returns the first key after all the keys with prefix, or nil if
+// there is none.
+func prefixEnd(prefix []byte) []byte {
+	end := append([]byte{}, prefix...)
+	for i := len(end) - 1; i >= 0; i-- {
+		if end[i] < 0xFF {
+			end[i]++
+			return end[:i+1]
+		}
+	}
+	return nil
+}
@@ -30,12 +30,35 @@ type BlockerIndexer struct {
 	// Add unique event identifier to use when querying
 	// Matching will be done both on height AND eventSeq
 	eventSeq int64
+
+	// filter of the event attributes indexed
+	filter *indexer.EventFilter
+	// delete the attributes of the blocks indexed the filter excludes
+	prune bool
+}
+
+// BlockerIndexerOption sets an optional parameter on the BlockerIndexer.
+type BlockerIndexerOption func(*BlockerIndexer)
+
+// WithEventFilter indexes only the event attributes selected by f.
+func WithEventFilter(f *indexer.EventFilter) BlockerIndexerOption {
+	return func(idx *BlockerIndexer) { idx.filter = f }
 }
 
-func New(store dbm.DB) *BlockerIndexer {
-	return &BlockerIndexer{
+// WithPruning deletes the event attributes excluded by the event filter
+// indexed previously for the blocks indexed, as when reindexing.
+func WithPruning() BlockerIndexerOption {
+	return func(idx *BlockerIndexer) { idx.prune = true }
+}
+
+func New(store dbm.DB, options ...BlockerIndexerOption) *BlockerIndexer {
+	idx := &BlockerIndexer{
 		store: store,
 	}
+	for _, option := range options {
+		option(idx)
+	}
+	return idx
 }
 
 // Has returns true if the given height has been indexed. An error is returned
@@ -578,18 +601,50 @@ func (idx *BlockerIndexer) indexEvents(batch dbm.Batch, events []abci.Event, typ
 				return fmt.Errorf("event type and attribute key \"%s\" is reserved; please use a different key", compositeKey)
 			}
 
-			if attr.GetIndex() {
-				key, err := eventKey(compositeKey, typ, string(attr.Value), height, idx.eventSeq)
-				if err != nil {
-					return fmt.Errorf("failed to create block index key: %w", err)
+			if !attr.GetIndex() {
+				continue
+			}
+			if !idx.filter.Indexed(compositeKey) {
+				if idx.prune {
+					if err := idx.deleteEvent(batch, compositeKey, typ, string(attr.Value), height); err != nil {
+						return fmt.Errorf("failed to delete filtered block event: %w", err)
+					}
 				}
+				continue
+			}
 
-				if err := batch.Set(key, heightBz); err != nil {
-					return err
-				}
+			key, err := eventKey(compositeKey, typ, string(attr.Value), height, idx.eventSeq)
+			if err != nil {
+				return fmt.Errorf("failed to create block index key: %w", err)
+			}
+
+			if err := batch.Set(key, heightBz); err != nil {
+				return err
 			}
 		}
 	}
 
 	return nil
 }
+
+// deleteEvent deletes the keys of the attribute of the block indexed
+// previously, whatever the sequence of its event.
+func (idx *BlockerIndexer) deleteEvent(batch dbm.Batch, compositeKey, typ, eventValue string, height int64) error {
+	prefix, err := orderedcode.Append(nil, compositeKey, eventValue, height, typ)
+	if err != nil {
+		return fmt.Errorf("failed to create block index key prefix: %w", err)
+	}
+
+	it, err := dbm.IteratePrefix(idx.store, prefix)
+	if err != nil {
+		return err
+	}
+	defer it.Close()
+
+	for ; it.Valid(); it.Next() {
+		if err := batch.Delete(it.Key()); err != nil {
+			return err
+		}
+	}
+	return it.Error()
+}
@@ -10,6 +10,7 @@ import (
 
 	abci "github.com/tendermint/tendermint/abci/types"
 	"github.com/tendermint/tendermint/libs/pubsub/query"
+	"github.com/tendermint/tendermint/state/indexer"
 	blockidxkv "github.com/tendermint/tendermint/state/indexer/block/kv"
 	"github.com/tendermint/tendermint/types"
 )
@@ -359,6 +360,129 @@ func TestBlockIndexerMulti(t *testing.T) {
 	}
 }
 
+func TestBlockIndexerEventFilter(t *testing.T) {
+	store := db.NewPrefixDB(db.NewMemDB(), []byte("block_events"))
+	header := func(height int64) types.EventDataNewBlockHeader {
+		return types.EventDataNewBlockHeader{
+			Header: types.Header{Height: height},
+			ResultEndBlock: abci.ResponseEndBlock{
+				Events: []abci.Event{
+					{
+						Type: "end_event",
+						Attributes: []abci.EventAttribute{
+							{Key: []byte("foo"), Value: []byte("100"), Index: true},
+							{Key: []byte("bar"), Value: []byte("baz"), Index: true},
+						},
+					},
+				},
+			},
+		}
+	}
+	search := func(idx *blockidxkv.BlockerIndexer, q string) []int64 {
+		results, err := idx.Search(context.Background(), query.MustParse(q))
+		require.NoError(t, err)
+		return results
+	}
+
+	idx := blockidxkv.New(store)
+	require.NoError(t, idx.Index(header(1)))
+	require.NoError(t, idx.Index(header(2)))
+	require.Equal(t, []int64{1, 2}, search(idx, "end_event.bar = 'baz'"))
+
+	filter, err := indexer.NewEventFilter([]string{"end_event.foo"}, nil)
+	require.NoError(t, err)
+
+	// only the attributes allowed are indexed
+	filtered := blockidxkv.New(db.NewMemDB(), blockidxkv.WithEventFilter(filter))
+	require.NoError(t, filtered.Index(header(1)))
+	require.Empty(t, search(filtered, "end_event.bar = 'baz'"))
+	require.Equal(t, []int64{1}, search(filtered, "end_event.foo = 100"))
+	require.Equal(t, []int64{1}, search(filtered, "block.height = 1"))
+
+	// reindexing prunes the attributes not allowed of the blocks reindexed only
+	pruning := blockidxkv.New(store, blockidxkv.WithEventFilter(filter), blockidxkv.WithPruning())
+	require.NoError(t, pruning.Index(header(1)))
+	require.Equal(t, []int64{2}, search(pruning, "end_event.bar = 'baz'"))
+	require.Equal(t, []int64{1, 2}, search(pruning, "end_event.foo = 100"))
+}
+
+func TestBlockIndexerSearchCursor(t *testing.T) {
+	idx := blockidxkv.New(db.NewPrefixDB(db.NewMemDB(), []byte("block_events")))
+	for height := int64(1); height <= 6; height++ {
+		proposer := "FCAA001"
+		if height%2 == 0 {
+			proposer = "FCAA002"
+		}
+		require.NoError(t, idx.Index(types.EventDataNewBlockHeader{
+			Header: types.Header{Height: height},
+			ResultBeginBlock: abci.ResponseBeginBlock{
+				Events: []abci.Event{
+					{
+						Type: "begin_event",
+						Attributes: []abci.EventAttribute{
+							{Key: []byte("proposer"), Value: []byte(proposer), Index: true},
+						},
+					},
+				},
+			},
+			ResultEndBlock: abci.ResponseEndBlock{
+				Events: []abci.Event{
+					{
+						Type: "end_event",
+						Attributes: []abci.EventAttribute{
+							{Key: []byte("foo"), Value: []byte(fmt.Sprint(height * 100)), Index: true},
+						},
+					},
+					{
+						Type: "end_event",
+						Attributes: []abci.EventAttribute{
+							{Key: []byte("bar"), Value: []byte(proposer), Index: true},
+						},
+					},
+				},
+			},
+		}))
+	}
+
+	search := func(q string, s indexer.BlockCursorSearch) *indexer.BlockCursorPage {
+		s.MinHeight, s.MaxHeight = 1, 6
+		page, err := idx.SearchCursor(context.Background(), query.MustParse(q), s)
+		require.NoError(t, err)
+		return page
+	}
+
+	// the pages resume from the height of the next result
+	page := search("begin_event.proposer = 'FCAA002'", indexer.BlockCursorSearch{Limit: 2})
+	require.Equal(t, []int64{2, 4}, page.Heights)
+	require.Equal(t, int64(6), page.Next)
+	page = search("begin_event.proposer = 'FCAA002'", indexer.BlockCursorSearch{From: page.Next, Limit: 2})
+	require.Equal(t, []int64{6}, page.Heights)
+	require.Zero(t, page.Next)
+
+	page = search("block.height <= 5", indexer.BlockCursorSearch{Desc: true, Limit: 3})
+	require.Equal(t, []int64{5, 4, 3}, page.Heights)
+	require.Equal(t, int64(2), page.Next)
+
+	page = search("end_event.foo >= 300 AND begin_event.proposer CONTAINS '1'", indexer.BlockCursorSearch{Limit: 10})
+	require.Equal(t, []int64{3, 5}, page.Heights)
+	require.Zero(t, page.Next)
+
+	// the attributes of distinct events satisfy the query unless matching events
+	q := "end_event.foo = 200 AND end_event.bar = 'FCAA002'"
+	require.Equal(t, []int64{2}, search(q, indexer.BlockCursorSearch{Limit: 10}).Heights)
+	require.Empty(t, search("match.events = 1 AND "+q, indexer.BlockCursorSearch{Limit: 10}).Heights)
+
+	// the search stops at the next height once the scan limit is reached
+	page = search("block.height > 0", indexer.BlockCursorSearch{Limit: 10, MaxScan: 1})
+	require.Equal(t, []int64{1}, page.Heights)
+	require.Equal(t, int64(2), page.Next)
+
+	// collecting the heights of the conditions other than equalities is bounded
+	_, err := idx.SearchCursor(context.Background(), query.MustParse("end_event.foo > 0"),
+		indexer.BlockCursorSearch{MinHeight: 1, MaxHeight: 6, Limit: 10, MaxScan: 3})
+	require.Error(t, err)
+}
+
 func TestBigInt(t *testing.T) {
 
 	bigInt := "10000000000000000000"
@@ -0,0 +1,139 @@
+package indexer
+
+import (
+	"math"
+	"math/big"
+
+	"github.com/tendermint/tendermint/libs/pubsub/query"
+	"github.com/tendermint/tendermint/types"
+)
+
+// CursorConditions are the conditions of a query, as split by the indexers
+// iterating the heights in order for a cursor search.
+type CursorConditions struct {
+	// MatchEvents reports whether the attribute conditions must be satisfied
+	// by the attributes of a single event, i.e. whether the first condition
+	// was "match.events = 1".
+	MatchEvents bool
+	// MinHeight and MaxHeight bound the heights satisfying the height
+	// conditions, included. MinHeight > MaxHeight if none does.
+	MinHeight, MaxHeight int64
+	// Conditions are the attribute conditions other than the ranges.
+	Conditions []query.Condition
+	// Ranges are the range conditions on the attributes, by composite key.
+	// The range conditions on a key are satisfied by a single integer value.
+	Ranges QueryRanges
+}
+
+// SplitCursorConditions splits the conditions of a query whose heights are
+// searched within [minHeight, maxHeight], the conditions on heightKey
+// narrowing the heights.
+func SplitCursorConditions(conditions []query.Condition, heightKey string, minHeight, maxHeight int64) CursorConditions {
+	cc := CursorConditions{MinHeight: minHeight, MaxHeight: maxHeight}
+
+	filtered := make([]query.Condition, 0, len(conditions))
+	for i, c := range conditions {
+		if c.CompositeKey != types.MatchEventKey {
+			filtered = append(filtered, c)
+			continue
+		}
+		if n, ok := c.Operand.(*big.Int); ok && i == 0 && c.Op == query.OpEqual && n.Int64() == 1 {
+			cc.MatchEvents = true
+		}
+	}
+
+	ranges, rangeIndexes, _ := LookForRangesWithHeight(filtered)
+	for i, c := range filtered {
+		if intInSlice(i, rangeIndexes) {
+			continue
+		}
+		if c.CompositeKey == heightKey && c.Op == query.OpEqual {
+			height, ok := c.Operand.(*big.Int)
+			if !ok || !height.IsInt64() {
+				cc.MinHeight, cc.MaxHeight = 1, 0
+				continue
+			}
+			cc.narrow(height, height)
+			continue
+		}
+		cc.Conditions = append(cc.Conditions, c)
+	}
+
+	if qr, ok := ranges[heightKey]; ok {
+		delete(ranges, heightKey)
+		if !IntegerBounds(qr) {
+			cc.MinHeight, cc.MaxHeight = 1, 0
+		} else {
+			var lower, upper *big.Int
+			if v := qr.LowerBoundValue(); v != nil {
+				lower = v.(*big.Int)
+			}
+			if v := qr.UpperBoundValue(); v != nil {
+				upper = v.(*big.Int)
+			}
+			cc.narrow(lower, upper)
+		}
+	}
+	cc.Ranges = ranges
+	return cc
+}
+
+// narrow narrows the heights to the ones within [lower, upper], the nil
+// bounds being unbounded.
+func (cc *CursorConditions) narrow(lower, upper *big.Int) {
+	if lower != nil {
+		if !lower.IsInt64() {
+			if lower.Sign() > 0 {
+				cc.MinHeight = math.MaxInt64
+			}
+		} else if lower.Int64() > cc.MinHeight {
+			cc.MinHeight = lower.Int64()
+		}
+	}
+	if upper != nil {
+		if !upper.IsInt64() {
+			if upper.Sign() < 0 {
+				cc.MaxHeight = math.MinInt64
+			}
+		} else if upper.Int64() < cc.MaxHeight {
+			cc.MaxHeight = upper.Int64()
+		}
+	}
+}
+
+// IntegerBounds reports whether the bounds of qr are integers, the indexers
+// matching the other ranges with no value.
+func IntegerBounds(qr QueryRange) bool {
+	for _, bound := range []interface{}{qr.LowerBound, qr.UpperBound} {
+		if _, ok := bound.(*big.Int); bound != nil && !ok {
+			return false
+		}
+	}
+	return true
+}
+
+// InRange reports whether value is an integer within the bounds of qr, which
+// must be integers.
+func InRange(qr QueryRange, value string) bool {
+	v, ok := new(big.Int).SetString(value, 10)
+	if !ok {
+		return false
+	}
+	if lower := qr.LowerBoundValue(); lower != nil && v.Cmp(lower.(*big.Int)) < 0 {
+		return false
+	}
+	if upper := qr.UpperBoundValue(); upper != nil && v.Cmp(upper.(*big.Int)) > 0 {
+		return false
+	}
+	return true
+}
+
+// intInSlice reports whether a is found in list.
+func intInSlice(a int, list []int) bool {
+	for _, b := range list {
+		if b == a {
+			return true
+		}
+	}
+	return false
+}
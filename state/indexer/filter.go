@@ -0,0 +1,68 @@
+package indexer
+
+import (
+	"fmt"
+	"path"
+)
+
+// EventFilter selects the event attributes to index among the ones the
+// application marks to index, by patterns of their composite key
+// "<event type>.<attribute key>" as matched by path.Match, e.g. "transfer.*".
+// An attribute is indexed if it matches one of the allow patterns, or there
+// are none, and matches none of the deny patterns.
+//
+// A nil EventFilter indexes all the attributes.
+type EventFilter struct {
+	allow []string
+	deny  []string
+}
+
+// NewEventFilter returns the filter of the allow and deny patterns, or nil if
+// there are none. It returns an error if a pattern is malformed.
+func NewEventFilter(allow, deny []string) (*EventFilter, error) {
+	if len(allow) == 0 && len(deny) == 0 {
+		return nil, nil
+	}
+	for _, pattern := range append(append([]string{}, allow...), deny...) {
+		if err := ValidateEventPattern(pattern); err != nil {
+			return nil, err
+		}
+	}
+	return &EventFilter{allow: allow, deny: deny}, nil
+}
+
+// ValidateEventPattern returns an error if pattern is malformed.
+func ValidateEventPattern(pattern string) error {
+	if _, err := path.Match(pattern, ""); err != nil {
+		return fmt.Errorf("invalid event pattern %q: %w", pattern, err)
+	}
+	return nil
+}
+
+// Indexed reports whether the attributes of composite key compositeKey are
+// indexed.
+func (f *EventFilter) Indexed(compositeKey string) bool {
+	if f == nil {
+		return true
+	}
+	for _, pattern := range f.deny {
+		if match(pattern, compositeKey) {
+			return false
+		}
+	}
+	if len(f.allow) == 0 {
+		return true
+	}
+	for _, pattern := range f.allow {
+		if match(pattern, compositeKey) {
+			return true
+		}
+	}
+	return false
+}
+
+// match reports whether compositeKey matches pattern, which was validated.
+func match(pattern, compositeKey string) bool {
+	ok, _ := path.Match(pattern, compositeKey)
+	return ok
+}
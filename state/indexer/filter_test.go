@@ -0,0 +1,39 @@
+package indexer
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEventFilter(t *testing.T) {
+	f, err := NewEventFilter(nil, nil)
+	require.NoError(t, err)
+	require.Nil(t, f)
+	assert.True(t, f.Indexed("transfer.sender"))
+
+	_, err = NewEventFilter([]string{"transfer.[a"}, nil)
+	require.Error(t, err)
+
+	testCases := []struct {
+		allow, deny  []string
+		compositeKey string
+		indexed      bool
+	}{
+		{[]string{"transfer.*"}, nil, "transfer.sender", true},
+		{[]string{"transfer.*"}, nil, "message.action", false},
+		{nil, []string{"*.sender"}, "transfer.sender", false},
+		{nil, []string{"*.sender"}, "transfer.recipient", true},
+		{[]string{"transfer.*"}, []string{"transfer.memo"}, "transfer.memo", false},
+		{[]string{"transfer.*"}, []string{"transfer.memo"}, "transfer.amount", true},
+		{[]string{"message.action", "transfer.amount"}, nil, "transfer.amount", true},
+		{[]string{"message.*"}, nil, "message", false},
+	}
+	for _, tc := range testCases {
+		f, err := NewEventFilter(tc.allow, tc.deny)
+		require.NoError(t, err)
+		assert.Equal(t, tc.indexed, f.Indexed(tc.compositeKey),
+			"allow %v deny %v: %s", tc.allow, tc.deny, tc.compositeKey)
+	}
+}
@@ -15,10 +15,10 @@ package psql
 
 import (
 	"context"
-	"errors"
 
 	abci "github.com/tendermint/tendermint/abci/types"
 	"github.com/tendermint/tendermint/libs/pubsub/query"
+	"github.com/tendermint/tendermint/state/indexer"
 	"github.com/tendermint/tendermint/state/txindex"
 	"github.com/tendermint/tendermint/types"
 )
@@ -47,16 +47,37 @@ func (b BackportTxIndexer) Index(txr *abci.TxResult) error {
 	return b.psql.IndexTxEvents([]*abci.TxResult{txr})
 }
 
-// Get is implemented to satisfy the TxIndexer interface, but is not supported
-// by the psql event sink and reports an error for all inputs.
-func (BackportTxIndexer) Get([]byte) (*abci.TxResult, error) {
-	return nil, errors.New("the TxIndexer.Get method is not supported")
+// Get returns the transaction result with the given hash from Postgres, or
+// nil if it isn't indexed, as part of TxIndexer.
+func (b BackportTxIndexer) Get(hash []byte) (*abci.TxResult, error) {
+	return b.psql.GetTxByHash(hash)
 }
 
-// Search is implemented to satisfy the TxIndexer interface, but it is not
-// supported by the psql event sink and reports an error for all inputs.
-func (BackportTxIndexer) Search(context.Context, *query.Query) ([]*abci.TxResult, error) {
-	return nil, errors.New("the TxIndexer.Search method is not supported")
+// Search returns the transaction results matching q from Postgres, as part of
+// TxIndexer.
+func (b BackportTxIndexer) Search(ctx context.Context, q *query.Query) ([]*abci.TxResult, error) {
+	return b.psql.SearchTxEvents(ctx, q)
+}
+
+// SearchPage returns a page of the transaction results matching q, sorted and
+// paginated by Postgres, as part of txindex.SearchPager.
+func (b BackportTxIndexer) SearchPage(
+	ctx context.Context,
+	q *query.Query,
+	desc bool,
+	skip, limit int,
+) ([]*abci.TxResult, int, error) {
+	return b.psql.SearchTxEventsPage(ctx, q, desc, skip, limit)
+}
+
+// SearchCursor returns the transaction results matching q within the bounds
+// of s, iterated by Postgres, as part of txindex.CursorSearcher.
+func (b BackportTxIndexer) SearchCursor(
+	ctx context.Context,
+	q *query.Query,
+	s txindex.CursorSearch,
+) (*txindex.CursorPage, error) {
+	return b.psql.SearchTxEventsCursor(ctx, q, s)
 }
 
 // BlockIndexer returns a bridge that implements the CometBFT v0.34 block
@@ -69,10 +90,10 @@ func (es *EventSink) BlockIndexer() BackportBlockIndexer {
 // delegating indexing operations to an underlying PostgreSQL event sink.
 type BackportBlockIndexer struct{ psql *EventSink }
 
-// Has is implemented to satisfy the BlockIndexer interface, but it is not
-// supported by the psql event sink and reports an error for all inputs.
-func (BackportBlockIndexer) Has(height int64) (bool, error) {
-	return false, errors.New("the BlockIndexer.Has method is not supported")
+// Has reports whether the block at height was indexed in Postgres, as part of
+// the BlockIndexer interface.
+func (b BackportBlockIndexer) Has(height int64) (bool, error) {
+	return b.psql.HasBlock(height)
 }
 
 // Index indexes block begin and end events for the specified block.  It is
@@ -81,8 +102,29 @@ func (b BackportBlockIndexer) Index(block types.EventDataNewBlockHeader) error {
 	return b.psql.IndexBlockEvents(block)
 }
 
-// Search is implemented to satisfy the BlockIndexer interface, but it is not
-// supported by the psql event sink and reports an error for all inputs.
-func (BackportBlockIndexer) Search(context.Context, *query.Query) ([]int64, error) {
-	return nil, errors.New("the BlockIndexer.Search method is not supported")
+// Search returns the heights of the blocks matching q from Postgres, as part
+// of the BlockIndexer interface.
+func (b BackportBlockIndexer) Search(ctx context.Context, q *query.Query) ([]int64, error) {
+	return b.psql.SearchBlockEvents(ctx, q)
+}
+
+// SearchPage returns a page of the heights of the blocks matching q, sorted
+// and paginated by Postgres, as part of indexer.BlockSearchPager.
+func (b BackportBlockIndexer) SearchPage(
+	ctx context.Context,
+	q *query.Query,
+	desc bool,
+	skip, limit int,
+) ([]int64, int, error) {
+	return b.psql.SearchBlockEventsPage(ctx, q, desc, skip, limit)
+}
+
+// SearchCursor returns the heights of the blocks matching q within the bounds
+// of s, iterated by Postgres, as part of indexer.BlockCursorSearcher.
+func (b BackportBlockIndexer) SearchCursor(
+	ctx context.Context,
+	q *query.Query,
+	s indexer.BlockCursorSearch,
+) (*indexer.BlockCursorPage, error) {
+	return b.psql.SearchBlockEventsCursor(ctx, q, s)
 }
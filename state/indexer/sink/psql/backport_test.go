@@ -6,6 +6,10 @@ import (
 )
 
 var (
-	_ indexer.BlockIndexer = BackportBlockIndexer{}
-	_ txindex.TxIndexer    = BackportTxIndexer{}
+	_ indexer.BlockIndexer        = BackportBlockIndexer{}
+	_ indexer.BlockSearchPager    = BackportBlockIndexer{}
+	_ indexer.BlockCursorSearcher = BackportBlockIndexer{}
+	_ txindex.TxIndexer           = BackportTxIndexer{}
+	_ txindex.SearchPager         = BackportTxIndexer{}
+	_ txindex.CursorSearcher      = BackportTxIndexer{}
 )
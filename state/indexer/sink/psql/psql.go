@@ -4,7 +4,6 @@ package psql
 import (
 	"context"
 	"database/sql"
-	"errors"
 	"fmt"
 	"strings"
 	"time"
@@ -13,6 +12,8 @@ import (
 
 	abci "github.com/tendermint/tendermint/abci/types"
 	"github.com/tendermint/tendermint/libs/pubsub/query"
+	"github.com/tendermint/tendermint/state/indexer"
+	"github.com/tendermint/tendermint/state/txindex"
 	"github.com/tendermint/tendermint/types"
 )
 
@@ -30,21 +31,46 @@ const (
 type EventSink struct {
 	store   *sql.DB
 	chainID string
+
+	// filter of the event attributes indexed
+	filter *indexer.EventFilter
+	// delete the attributes of the blocks and txs indexed already the filter
+	// excludes
+	prune bool
+}
+
+// EventSinkOption sets an optional parameter on the EventSink.
+type EventSinkOption func(*EventSink)
+
+// WithEventFilter indexes only the event attributes selected by f.
+func WithEventFilter(f *indexer.EventFilter) EventSinkOption {
+	return func(es *EventSink) { es.filter = f }
+}
+
+// WithPruning deletes the event attributes excluded by the event filter of
+// the blocks and txs indexed already when they are indexed again, as when
+// reindexing.
+func WithPruning() EventSinkOption {
+	return func(es *EventSink) { es.prune = true }
 }
 
 // NewEventSink constructs an event sink associated with the PostgreSQL
 // database specified by connStr. Events written to the sink are attributed to
 // the specified chainID.
-func NewEventSink(connStr, chainID string) (*EventSink, error) {
+func NewEventSink(connStr, chainID string, options ...EventSinkOption) (*EventSink, error) {
 	db, err := sql.Open(driverName, connStr)
 	if err != nil {
 		return nil, err
 	}
 
-	return &EventSink{
+	es := &EventSink{
 		store:   db,
 		chainID: chainID,
-	}, nil
+	}
+	for _, option := range options {
+		option(es)
+	}
+	return es, nil
 }
 
 // DB returns the underlying Postgres connection used by the sink.
@@ -82,8 +108,9 @@ func queryWithID(tx *sql.Tx, query string, args ...interface{}) (uint32, error)
 // events into the database associated with dbtx.
 //
 // If txID > 0, the event is attributed to the transaction with that
-// ID; otherwise it is recorded as a block event.
-func insertEvents(dbtx *sql.Tx, blockID, txID uint32, evts []abci.Event) error {
+// ID; otherwise it is recorded as a block event. Only the attributes selected
+// by filter are inserted.
+func insertEvents(dbtx *sql.Tx, blockID, txID uint32, evts []abci.Event, filter *indexer.EventFilter) error {
 	// Populate the transaction ID field iff one is defined (> 0).
 	var txIDArg interface{}
 	if txID > 0 {
@@ -121,6 +148,9 @@ func insertEvents(dbtx *sql.Tx, blockID, txID uint32, evts []abci.Event) error {
 				continue
 			}
 			compositeKey := evt.Type + "." + string(attr.Key)
+			if !filter.Indexed(compositeKey) {
+				continue
+			}
 			if _, err := dbtx.Exec(insertAttributeQuery, eid, attr.Key, compositeKey, attr.Value); err != nil {
 				return err
 			}
@@ -129,6 +159,55 @@ func insertEvents(dbtx *sql.Tx, blockID, txID uint32, evts []abci.Event) error {
 	return nil
 }
 
+// pruneEvents deletes the attributes of the events of the block, or of the tx
+// if txID > 0, filter excludes. The attributes of the meta-events are kept.
+func pruneEvents(dbtx *sql.Tx, blockID, txID uint32, filter *indexer.EventFilter) error {
+	var txIDArg interface{}
+	if txID > 0 {
+		txIDArg = txID
+	}
+
+	rows, err := dbtx.Query(`
+SELECT DISTINCT attributes.composite_key FROM `+tableAttributes+`
+  JOIN `+tableEvents+` ON attributes.event_id = events.rowid
+  WHERE events.block_id = $1 AND events.tx_id IS NOT DISTINCT FROM $2;
+`, blockID, txIDArg)
+	if err != nil {
+		return err
+	}
+	var pruned []string
+	for rows.Next() {
+		var compositeKey string
+		if err := rows.Scan(&compositeKey); err != nil {
+			rows.Close()
+			return err
+		}
+		switch compositeKey {
+		case types.BlockHeightKey, types.TxHashKey, types.TxHeightKey:
+			continue
+		}
+		if !filter.Indexed(compositeKey) {
+			pruned = append(pruned, compositeKey)
+		}
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return err
+	}
+
+	for _, compositeKey := range pruned {
+		if _, err := dbtx.Exec(`
+DELETE FROM `+tableAttributes+` USING `+tableEvents+`
+  WHERE attributes.event_id = events.rowid
+    AND events.block_id = $1 AND events.tx_id IS NOT DISTINCT FROM $2
+    AND attributes.composite_key = $3;
+`, blockID, txIDArg, compositeKey); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 // makeIndexedEvent constructs an event from the specified composite key and
 // value. If the key has the form "type.name", the event will have a single
 // attribute with that name and the value; otherwise the event will have only
@@ -158,7 +237,20 @@ INSERT INTO `+tableBlocks+` (height, chain_id, created_at)
   RETURNING rowid;
 `, h.Header.Height, es.chainID, ts)
 		if err == sql.ErrNoRows {
-			return nil // we already saw this block; quietly succeed
+			// we already saw this block; quietly succeed
+			if !es.prune {
+				return nil
+			}
+			blockID, err := queryWithID(dbtx, `
+SELECT rowid FROM `+tableBlocks+` WHERE height = $1 AND chain_id = $2;
+`, h.Header.Height, es.chainID)
+			if err != nil {
+				return fmt.Errorf("finding block ID: %w", err)
+			}
+			if err := pruneEvents(dbtx, blockID, 0, es.filter); err != nil {
+				return fmt.Errorf("pruning block events: %w", err)
+			}
+			return nil
 		} else if err != nil {
 			return fmt.Errorf("indexing block header: %w", err)
 		}
@@ -166,14 +258,14 @@ INSERT INTO `+tableBlocks+` (height, chain_id, created_at)
 		// Insert the special block meta-event for height.
 		if err := insertEvents(dbtx, blockID, 0, []abci.Event{
 			makeIndexedEvent(types.BlockHeightKey, fmt.Sprint(h.Header.Height)),
-		}); err != nil {
+		}, nil); err != nil {
 			return fmt.Errorf("block meta-events: %w", err)
 		}
 		// Insert all the block events. Order is important here,
-		if err := insertEvents(dbtx, blockID, 0, h.ResultBeginBlock.Events); err != nil {
+		if err := insertEvents(dbtx, blockID, 0, h.ResultBeginBlock.Events, es.filter); err != nil {
 			return fmt.Errorf("begin-block events: %w", err)
 		}
-		if err := insertEvents(dbtx, blockID, 0, h.ResultEndBlock.Events); err != nil {
+		if err := insertEvents(dbtx, blockID, 0, h.ResultEndBlock.Events, es.filter); err != nil {
 			return fmt.Errorf("end-block events: %w", err)
 		}
 		return nil
@@ -211,7 +303,20 @@ INSERT INTO `+tableTxResults+` (block_id, index, created_at, tx_hash, tx_result)
   RETURNING rowid;
 `, blockID, txr.Index, ts, txHash, resultData)
 			if err == sql.ErrNoRows {
-				return nil // we already saw this transaction; quietly succeed
+				// we already saw this transaction; quietly succeed
+				if !es.prune {
+					return nil
+				}
+				txID, err := queryWithID(dbtx, `
+SELECT rowid FROM `+tableTxResults+` WHERE block_id = $1 AND index = $2;
+`, blockID, txr.Index)
+				if err != nil {
+					return fmt.Errorf("finding tx_result ID: %w", err)
+				}
+				if err := pruneEvents(dbtx, blockID, txID, es.filter); err != nil {
+					return fmt.Errorf("pruning transaction events: %w", err)
+				}
+				return nil
 			} else if err != nil {
 				return fmt.Errorf("indexing tx_result: %w", err)
 			}
@@ -220,11 +325,11 @@ INSERT INTO `+tableTxResults+` (block_id, index, created_at, tx_hash, tx_result)
 			if err := insertEvents(dbtx, blockID, txID, []abci.Event{
 				makeIndexedEvent(types.TxHashKey, txHash),
 				makeIndexedEvent(types.TxHeightKey, fmt.Sprint(txr.Height)),
-			}); err != nil {
+			}, nil); err != nil {
 				return fmt.Errorf("indexing transaction meta-events: %w", err)
 			}
 			// Index any events packaged with the transaction.
-			if err := insertEvents(dbtx, blockID, txID, txr.Result.Events); err != nil {
+			if err := insertEvents(dbtx, blockID, txID, txr.Result.Events, es.filter); err != nil {
 				return fmt.Errorf("indexing transaction events: %w", err)
 			}
 			return nil
@@ -236,24 +341,392 @@ INSERT INTO `+tableTxResults+` (block_id, index, created_at, tx_hash, tx_result)
 	return nil
 }
 
-// SearchBlockEvents is not implemented by this sink, and reports an error for all queries.
+const (
+	// txEventScope selects the events of the tx of the tx_results row.
+	txEventScope = "events.tx_id = tx_results.rowid"
+	// blockEventScope selects the events of the block of the blocks row.
+	blockEventScope = "events.block_id = blocks.rowid AND events.tx_id IS NULL"
+)
+
+// SearchBlockEvents returns the heights of the blocks matching q, in
+// ascending order.
 func (es *EventSink) SearchBlockEvents(ctx context.Context, q *query.Query) ([]int64, error) {
-	return nil, errors.New("block search is not supported via the postgres event sink")
+	heights, _, err := es.searchBlocks(ctx, q, false, 0, -1)
+	return heights, err
+}
+
+// SearchBlockEventsPage returns the page of the heights of the blocks
+// matching q, in descending order if desc, starting after skip of them and
+// holding at most limit of them, along with the count of the blocks matching
+// q. The blocks are sorted and paginated by the database.
+func (es *EventSink) SearchBlockEventsPage(
+	ctx context.Context,
+	q *query.Query,
+	desc bool,
+	skip, limit int,
+) ([]int64, int, error) {
+	return es.searchBlocks(ctx, q, desc, skip, limit)
+}
+
+// searchBlocks returns the page of the heights of the blocks matching q, or
+// all of them if limit is negative, along with their count.
+func (es *EventSink) searchBlocks(
+	ctx context.Context,
+	q *query.Query,
+	desc bool,
+	skip, limit int,
+) ([]int64, int, error) {
+	f, err := makeQueryFilter(q, types.BlockHeightKey, blockEventScope)
+	if err != nil {
+		return nil, 0, err
+	}
+	from := `
+  FROM ` + tableBlocks + `
+  WHERE blocks.chain_id = $1
+  AND ` + f.where
+
+	total := -1
+	if limit >= 0 {
+		if total, err = es.count(ctx, from, f.args); err != nil {
+			return nil, 0, fmt.Errorf("counting blocks: %w", err)
+		}
+	}
+
+	rows, err := es.store.QueryContext(ctx,
+		"SELECT blocks.height"+from+"\nORDER BY blocks.height"+orderPage(desc, skip, limit),
+		append([]interface{}{es.chainID}, f.args...)...)
+	if err != nil {
+		return nil, 0, fmt.Errorf("searching blocks: %w", err)
+	}
+	defer rows.Close()
+
+	heights := make([]int64, 0)
+	for rows.Next() {
+		var height int64
+		if err := rows.Scan(&height); err != nil {
+			return nil, 0, fmt.Errorf("scanning block height: %w", err)
+		}
+		heights = append(heights, height)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, 0, fmt.Errorf("searching blocks: %w", err)
+	}
+	if total < 0 {
+		total = len(heights)
+	}
+	return heights, total, nil
 }
 
-// SearchTxEvents is not implemented by this sink, and reports an error for all queries.
+// SearchTxEvents returns the results of the transactions matching q, in
+// ascending order of height and index. If q has a "tx.hash" condition, only
+// the transaction with that hash is returned, if indexed.
 func (es *EventSink) SearchTxEvents(ctx context.Context, q *query.Query) ([]*abci.TxResult, error) {
-	return nil, errors.New("tx search is not supported via the postgres event sink")
+	txrs, _, err := es.searchTxs(ctx, q, false, 0, -1)
+	return txrs, err
+}
+
+// SearchTxEventsPage returns the page of the results of the transactions
+// matching q, in descending order of height and index if desc, starting after
+// skip of them and holding at most limit of them, along with the count of the
+// transactions matching q. The transactions are sorted and paginated by the
+// database.
+func (es *EventSink) SearchTxEventsPage(
+	ctx context.Context,
+	q *query.Query,
+	desc bool,
+	skip, limit int,
+) ([]*abci.TxResult, int, error) {
+	return es.searchTxs(ctx, q, desc, skip, limit)
+}
+
+// searchTxs returns the page of the results of the transactions matching q,
+// or all of them if limit is negative, along with their count.
+func (es *EventSink) searchTxs(
+	ctx context.Context,
+	q *query.Query,
+	desc bool,
+	skip, limit int,
+) ([]*abci.TxResult, int, error) {
+	conditions, err := q.Conditions()
+	if err != nil {
+		return nil, 0, fmt.Errorf("parsing query conditions: %w", err)
+	}
+	// if there is a hash condition, return the result alone
+	hash, ok, err := lookForHash(conditions)
+	if err != nil {
+		return nil, 0, fmt.Errorf("error during searching for a hash in the query: %w", err)
+	} else if ok {
+		txr, err := es.GetTxByHash(hash)
+		if err != nil || txr == nil {
+			return []*abci.TxResult{}, 0, err
+		}
+		if skip > 0 || limit == 0 {
+			return []*abci.TxResult{}, 1, nil
+		}
+		return []*abci.TxResult{txr}, 1, nil
+	}
+
+	f, err := makeQueryFilter(q, types.TxHeightKey, txEventScope)
+	if err != nil {
+		return nil, 0, err
+	}
+	from := `
+  FROM ` + tableTxResults + ` JOIN ` + tableBlocks + ` ON blocks.rowid = tx_results.block_id
+  WHERE blocks.chain_id = $1
+  AND ` + f.where
+
+	total := -1
+	if limit >= 0 {
+		if total, err = es.count(ctx, from, f.args); err != nil {
+			return nil, 0, fmt.Errorf("counting transactions: %w", err)
+		}
+	}
+
+	order := "\nORDER BY blocks.height" + orderDirection(desc) + ", tx_results.index"
+	rows, err := es.store.QueryContext(ctx,
+		"SELECT tx_results.tx_result"+from+order+orderPage(desc, skip, limit),
+		append([]interface{}{es.chainID}, f.args...)...)
+	if err != nil {
+		return nil, 0, fmt.Errorf("searching transactions: %w", err)
+	}
+	defer rows.Close()
+
+	txrs := make([]*abci.TxResult, 0)
+	for rows.Next() {
+		var resultData []byte
+		if err := rows.Scan(&resultData); err != nil {
+			return nil, 0, fmt.Errorf("scanning tx_result: %w", err)
+		}
+		txr := new(abci.TxResult)
+		if err := proto.Unmarshal(resultData, txr); err != nil {
+			return nil, 0, fmt.Errorf("unmarshaling tx_result: %w", err)
+		}
+		txrs = append(txrs, txr)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, 0, fmt.Errorf("searching transactions: %w", err)
+	}
+	if total < 0 {
+		total = len(txrs)
+	}
+	return txrs, total, nil
 }
 
-// GetTxByHash is not implemented by this sink, and reports an error for all queries.
+// SearchBlockEventsCursor returns the heights of the blocks matching q within
+// the bounds of s, iterated by the database from s.From. The work of the
+// database is not bounded by s.MaxScan.
+func (es *EventSink) SearchBlockEventsCursor(
+	ctx context.Context,
+	q *query.Query,
+	s indexer.BlockCursorSearch,
+) (*indexer.BlockCursorPage, error) {
+	f, err := makeQueryFilter(q, types.BlockHeightKey, blockEventScope)
+	if err != nil {
+		return nil, err
+	}
+	minHeight, maxHeight := s.MinHeight, s.MaxHeight
+	if s.From > 0 {
+		if s.Desc && s.From < maxHeight {
+			maxHeight = s.From
+		} else if !s.Desc && s.From > minHeight {
+			minHeight = s.From
+		}
+	}
+	where := f.where + "\n  AND blocks.height BETWEEN " + f.arg(minHeight) + " AND " + f.arg(maxHeight)
+
+	// the block after the page, if any, is the one to resume from
+	rows, err := es.store.QueryContext(ctx, `
+SELECT blocks.height
+  FROM `+tableBlocks+`
+  WHERE blocks.chain_id = $1
+  AND `+where+`
+ORDER BY blocks.height`+orderPage(s.Desc, 0, s.Limit+1),
+		append([]interface{}{es.chainID}, f.args...)...)
+	if err != nil {
+		return nil, fmt.Errorf("searching blocks: %w", err)
+	}
+	defer rows.Close()
+
+	page := new(indexer.BlockCursorPage)
+	for rows.Next() {
+		var height int64
+		if err := rows.Scan(&height); err != nil {
+			return nil, fmt.Errorf("scanning block height: %w", err)
+		}
+		page.Scanned++
+		if len(page.Heights) == s.Limit {
+			page.Next = height
+			break
+		}
+		page.Heights = append(page.Heights, height)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("searching blocks: %w", err)
+	}
+	return page, nil
+}
+
+// SearchTxEventsCursor returns the results of the transactions matching q
+// within the bounds of s, iterated by the database from s.From. The work of
+// the database is not bounded by s.MaxScan. If q has a "tx.hash" condition,
+// only the transaction with that hash is returned, if indexed.
+func (es *EventSink) SearchTxEventsCursor(
+	ctx context.Context,
+	q *query.Query,
+	s txindex.CursorSearch,
+) (*txindex.CursorPage, error) {
+	conditions, err := q.Conditions()
+	if err != nil {
+		return nil, fmt.Errorf("parsing query conditions: %w", err)
+	}
+	page := new(txindex.CursorPage)
+	hash, ok, err := lookForHash(conditions)
+	if err != nil {
+		return nil, fmt.Errorf("error during searching for a hash in the query: %w", err)
+	} else if ok {
+		txr, err := es.GetTxByHash(hash)
+		if err != nil {
+			return nil, err
+		}
+		page.Scanned = 1
+		if txr != nil && cursorWithin(txindex.Cursor{Height: txr.Height, Index: txr.Index}, s) {
+			page.Results = append(page.Results, txr)
+		}
+		return page, nil
+	}
+
+	f, err := makeQueryFilter(q, types.TxHeightKey, txEventScope)
+	if err != nil {
+		return nil, err
+	}
+	where := f.where + "\n  AND blocks.height BETWEEN " + f.arg(s.MinHeight) + " AND " + f.arg(s.MaxHeight)
+	if s.From != nil {
+		op := " >= "
+		if s.Desc {
+			op = " <= "
+		}
+		where += "\n  AND (blocks.height, tx_results.index)" + op +
+			"(" + f.arg(s.From.Height) + "::bigint, " + f.arg(int64(s.From.Index)) + "::integer)"
+	}
+
+	// the transaction after the page, if any, is the one to resume from
+	order := "\nORDER BY blocks.height" + orderDirection(s.Desc) + ", tx_results.index"
+	rows, err := es.store.QueryContext(ctx, `
+SELECT tx_results.tx_result
+  FROM `+tableTxResults+` JOIN `+tableBlocks+` ON blocks.rowid = tx_results.block_id
+  WHERE blocks.chain_id = $1
+  AND `+where+order+orderPage(s.Desc, 0, s.Limit+1),
+		append([]interface{}{es.chainID}, f.args...)...)
+	if err != nil {
+		return nil, fmt.Errorf("searching transactions: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var resultData []byte
+		if err := rows.Scan(&resultData); err != nil {
+			return nil, fmt.Errorf("scanning tx_result: %w", err)
+		}
+		txr := new(abci.TxResult)
+		if err := proto.Unmarshal(resultData, txr); err != nil {
+			return nil, fmt.Errorf("unmarshaling tx_result: %w", err)
+		}
+		page.Scanned++
+		if len(page.Results) == s.Limit {
+			page.Next = &txindex.Cursor{Height: txr.Height, Index: txr.Index}
+			break
+		}
+		page.Results = append(page.Results, txr)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("searching transactions: %w", err)
+	}
+	return page, nil
+}
+
+// cursorWithin reports whether the position pos is within the bounds of s.
+func cursorWithin(pos txindex.Cursor, s txindex.CursorSearch) bool {
+	if pos.Height < s.MinHeight || pos.Height > s.MaxHeight {
+		return false
+	}
+	if s.From == nil {
+		return true
+	}
+	if s.Desc {
+		return pos.Height < s.From.Height || (pos.Height == s.From.Height && pos.Index <= s.From.Index)
+	}
+	return pos.Height > s.From.Height || (pos.Height == s.From.Height && pos.Index >= s.From.Index)
+}
+
+// count returns the count of the rows of from, the FROM and WHERE clauses of
+// a search.
+func (es *EventSink) count(ctx context.Context, from string, args []interface{}) (int, error) {
+	var total int
+	err := es.store.QueryRowContext(ctx, "SELECT count(*)"+from,
+		append([]interface{}{es.chainID}, args...)...).Scan(&total)
+	return total, err
+}
+
+// orderDirection returns the direction of an ORDER BY term.
+func orderDirection(desc bool) string {
+	if desc {
+		return " DESC"
+	}
+	return " ASC"
+}
+
+// orderPage returns the direction of the last ORDER BY term of a search,
+// followed by the clauses selecting its page; all of its rows if limit is
+// negative.
+func orderPage(desc bool, skip, limit int) string {
+	clause := orderDirection(desc)
+	if limit >= 0 {
+		clause += fmt.Sprintf("\nLIMIT %d", limit)
+	}
+	if skip > 0 {
+		clause += fmt.Sprintf(" OFFSET %d", skip)
+	}
+	return clause + ";"
+}
+
+// GetTxByHash returns the result of the transaction with the given hash, or
+// nil if it isn't indexed. If the transaction was indexed at several heights,
+// the result of the highest one is returned.
 func (es *EventSink) GetTxByHash(hash []byte) (*abci.TxResult, error) {
-	return nil, errors.New("getTxByHash is not supported via the postgres event sink")
+	if len(hash) == 0 {
+		return nil, txindex.ErrorEmptyHash
+	}
+
+	var resultData []byte
+	err := es.store.QueryRow(`
+SELECT tx_results.tx_result
+  FROM `+tableTxResults+` JOIN `+tableBlocks+` ON blocks.rowid = tx_results.block_id
+  WHERE tx_results.tx_hash = $1 AND blocks.chain_id = $2
+  ORDER BY blocks.height DESC
+  LIMIT 1;
+`, fmt.Sprintf("%X", hash), es.chainID).Scan(&resultData)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	} else if err != nil {
+		return nil, fmt.Errorf("getting tx_result: %w", err)
+	}
+
+	txr := new(abci.TxResult)
+	if err := proto.Unmarshal(resultData, txr); err != nil {
+		return nil, fmt.Errorf("unmarshaling tx_result: %w", err)
+	}
+	return txr, nil
 }
 
-// HasBlock is not implemented by this sink, and reports an error for all queries.
+// HasBlock reports whether the block at height h was indexed.
 func (es *EventSink) HasBlock(h int64) (bool, error) {
-	return false, errors.New("hasBlock is not supported via the postgres event sink")
+	var ok bool
+	if err := es.store.QueryRow(`
+SELECT EXISTS (SELECT 1 FROM `+tableBlocks+` WHERE height = $1 AND chain_id = $2);
+`, h, es.chainID).Scan(&ok); err != nil {
+		return false, fmt.Errorf("looking up block: %w", err)
+	}
+	return ok, nil
 }
 
 // Stop closes the underlying PostgreSQL database.
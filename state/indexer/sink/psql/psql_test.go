@@ -19,6 +19,7 @@ import (
 	"github.com/stretchr/testify/require"
 
 	abci "github.com/tendermint/tendermint/abci/types"
+	"github.com/tendermint/tendermint/libs/pubsub/query"
 	"github.com/tendermint/tendermint/state/txindex"
 	"github.com/tendermint/tendermint/types"
 
@@ -145,13 +146,17 @@ func TestIndexing(t *testing.T) {
 		verifyBlock(t, 1)
 		verifyBlock(t, 2)
 
-		verifyNotImplemented(t, "hasBlock", func() (bool, error) { return indexer.HasBlock(1) })
-		verifyNotImplemented(t, "hasBlock", func() (bool, error) { return indexer.HasBlock(2) })
+		ok, err := indexer.HasBlock(1)
+		require.NoError(t, err)
+		assert.True(t, ok)
+		ok, err = indexer.HasBlock(2)
+		require.NoError(t, err)
+		assert.False(t, ok)
 
-		verifyNotImplemented(t, "block search", func() (bool, error) {
-			v, err := indexer.SearchBlockEvents(context.Background(), nil)
-			return v != nil, err
-		})
+		heights, err := indexer.SearchBlockEvents(context.Background(),
+			query.MustParse("end_event.foo = 100"))
+		require.NoError(t, err)
+		assert.Equal(t, []int64{1}, heights)
 
 		require.NoError(t, verifyTimeStamp(tableBlocks))
 
@@ -184,14 +189,14 @@ func TestIndexing(t *testing.T) {
 		require.NoError(t, verifyTimeStamp(tableTxResults))
 		require.NoError(t, verifyTimeStamp(viewTxEvents))
 
-		verifyNotImplemented(t, "getTxByHash", func() (bool, error) {
-			txr, err := indexer.GetTxByHash(types.Tx(txResult.Tx).Hash())
-			return txr != nil, err
-		})
-		verifyNotImplemented(t, "tx search", func() (bool, error) {
-			txr, err := indexer.SearchTxEvents(context.Background(), nil)
-			return txr != nil, err
-		})
+		txr, err = indexer.GetTxByHash(types.Tx(txResult.Tx).Hash())
+		require.NoError(t, err)
+		assert.Equal(t, txResult, txr)
+
+		txrs, err := indexer.SearchTxEvents(context.Background(),
+			query.MustParse("account.owner = 'Yulieta'"))
+		require.NoError(t, err)
+		assert.Equal(t, []*abci.TxResult{txResult}, txrs)
 
 		// try to insert the duplicate tx events.
 		err = indexer.IndexTxEvents([]*abci.TxResult{txResult})
@@ -371,20 +376,6 @@ SELECT type, height, chain_id FROM `+viewBlockEvents+`
 	}
 }
 
-// verifyNotImplemented calls f and verifies that it returns both a
-// false-valued flag and a non-nil error whose string matching the expected
-// "not supported" message with label prefixed.
-func verifyNotImplemented(t *testing.T, label string, f func() (bool, error)) {
-	t.Helper()
-	t.Logf("Verifying that %q reports it is not implemented", label)
-
-	want := label + " is not supported via the postgres event sink"
-	ok, err := f()
-	assert.False(t, ok)
-	require.NotNil(t, err)
-	assert.Equal(t, want, err.Error())
-}
-
 // waitForInterrupt blocks until a SIGINT is received by the process.
 func waitForInterrupt() {
 	ch := make(chan os.Signal, 1)
@@ -0,0 +1,199 @@
+package psql
+
+import (
+	"encoding/hex"
+	"fmt"
+	"math/big"
+	"sort"
+	"strings"
+
+	"github.com/tendermint/tendermint/libs/pubsub/query"
+	"github.com/tendermint/tendermint/state/indexer"
+	"github.com/tendermint/tendermint/types"
+)
+
+// integerPattern matches the attribute values compared by range conditions;
+// as with the kv indexers, the other values never match a range.
+const integerPattern = `'^[-+]?[0-9]+$'`
+
+// queryFilter is the translation of a query into an SQL boolean expression
+// over a row of the blocks table, joined with tx_results for a tx search.
+// The placeholders of the expression are bound to args, starting at $2, $1
+// being bound to the chain ID.
+type queryFilter struct {
+	where string
+	args  []interface{}
+}
+
+// arg binds v to the next placeholder of the filter and returns it.
+func (f *queryFilter) arg(v interface{}) string {
+	f.args = append(f.args, v)
+	return fmt.Sprintf("$%d", len(f.args)+1)
+}
+
+// makeQueryFilter translates the conditions of q into a filter, following the
+// semantics of the kv indexers:
+//
+//   - The conditions are ANDed. Conditions on heightKey filter the height of
+//     the block, the others require an indexed event attribute of the block
+//     or tx, as selected by eventScope, satisfying them.
+//   - The range conditions on a key are satisfied by the integer value of a
+//     single attribute.
+//   - If the first condition is "match.events = 1", the attribute conditions
+//     must all be satisfied by the attributes of a single event.
+func makeQueryFilter(q *query.Query, heightKey, eventScope string) (*queryFilter, error) {
+	conditions, err := q.Conditions()
+	if err != nil {
+		return nil, fmt.Errorf("parsing query conditions: %w", err)
+	}
+	conditions, matchEvents := lookForMatchEvents(conditions)
+
+	var (
+		f         = new(queryFilter)
+		terms     []string
+		attrTerms []string
+	)
+	ranges, rangeIndexes, _ := indexer.LookForRangesWithHeight(conditions)
+	// translate the ranges in a stable order
+	keys := make([]string, 0, len(ranges))
+	for key := range ranges {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		if key == heightKey {
+			terms = append(terms, f.heightRange(ranges[key]))
+		} else {
+			attrTerms = append(attrTerms, f.attributeRange(ranges[key]))
+		}
+	}
+
+	for i, c := range conditions {
+		if intInSlice(i, rangeIndexes) {
+			continue
+		}
+		if c.CompositeKey == heightKey && c.Op == query.OpEqual {
+			height, ok := c.Operand.(*big.Int)
+			if !ok {
+				terms = append(terms, "FALSE")
+				continue
+			}
+			terms = append(terms, "blocks.height = "+f.arg(height.Int64()))
+			continue
+		}
+		attrTerms = append(attrTerms, f.attribute(c))
+	}
+
+	if len(attrTerms) > 0 {
+		if matchEvents {
+			terms = append(terms, fmt.Sprintf(`EXISTS (
+  SELECT 1 FROM `+tableEvents+` WHERE %s AND EXISTS (
+    SELECT 1 FROM `+tableAttributes+` WHERE attributes.event_id = events.rowid AND %s))`,
+				eventScope, strings.Join(attrTerms, `)
+  AND EXISTS (
+    SELECT 1 FROM `+tableAttributes+` WHERE attributes.event_id = events.rowid AND `)))
+		} else {
+			for _, term := range attrTerms {
+				terms = append(terms, fmt.Sprintf(`EXISTS (
+  SELECT 1 FROM `+tableEvents+` JOIN `+tableAttributes+` ON attributes.event_id = events.rowid
+  WHERE %s AND %s)`, eventScope, term))
+			}
+		}
+	}
+
+	if len(terms) == 0 {
+		f.where = "TRUE"
+	} else {
+		f.where = strings.Join(terms, "\n  AND ")
+	}
+	return f, nil
+}
+
+// attribute returns the expression of an attribute satisfying the condition
+// c, which isn't a range condition.
+func (f *queryFilter) attribute(c query.Condition) string {
+	key := "attributes.composite_key = " + f.arg(c.CompositeKey)
+	switch c.Op {
+	case query.OpEqual:
+		return key + " AND attributes.value = " + f.arg(fmt.Sprintf("%v", c.Operand))
+	case query.OpContains:
+		return key + " AND strpos(attributes.value, " + f.arg(fmt.Sprintf("%v", c.Operand)) + ") > 0"
+	case query.OpExists:
+		return key
+	default:
+		return "FALSE"
+	}
+}
+
+// attributeRange returns the expression of an attribute whose integer value
+// is within qr.
+func (f *queryFilter) attributeRange(qr indexer.QueryRange) string {
+	if !indexer.IntegerBounds(qr) {
+		return "FALSE"
+	}
+	key := "attributes.composite_key = " + f.arg(qr.Key)
+	return key + " AND " + f.bounds("(CASE WHEN attributes.value ~ "+integerPattern+
+		" THEN attributes.value::numeric END)", qr, "::numeric")
+}
+
+// heightRange returns the expression of a block whose height is within qr.
+func (f *queryFilter) heightRange(qr indexer.QueryRange) string {
+	if !indexer.IntegerBounds(qr) {
+		return "FALSE"
+	}
+	return f.bounds("blocks.height", qr, "::bigint")
+}
+
+// bounds returns the expression of value being within qr, whose bounds must
+// be integers, casting the bounds with cast.
+func (f *queryFilter) bounds(value string, qr indexer.QueryRange, cast string) string {
+	var terms []string
+	if lower := qr.LowerBoundValue(); lower != nil {
+		terms = append(terms, value+" >= "+f.arg(lower.(*big.Int).String())+cast)
+	}
+	if upper := qr.UpperBoundValue(); upper != nil {
+		terms = append(terms, value+" <= "+f.arg(upper.(*big.Int).String())+cast)
+	}
+	return strings.Join(terms, " AND ")
+}
+
+// lookForMatchEvents drops the match.events conditions of a query, and reports
+// whether the events must be matched, i.e. whether the first condition was
+// "match.events = 1".
+func lookForMatchEvents(conditions []query.Condition) ([]query.Condition, bool) {
+	var (
+		filtered    = make([]query.Condition, 0, len(conditions))
+		matchEvents bool
+	)
+	for i, c := range conditions {
+		if c.CompositeKey != types.MatchEventKey {
+			filtered = append(filtered, c)
+			continue
+		}
+		if n, ok := c.Operand.(*big.Int); ok && i == 0 && c.Op == query.OpEqual && n.Int64() == 1 {
+			matchEvents = true
+		}
+	}
+	return filtered, matchEvents
+}
+
+// lookForHash returns the hash of a "tx.hash" condition, if any.
+func lookForHash(conditions []query.Condition) (hash []byte, ok bool, err error) {
+	for _, c := range conditions {
+		if c.CompositeKey == types.TxHashKey {
+			decoded, err := hex.DecodeString(fmt.Sprintf("%v", c.Operand))
+			return decoded, true, err
+		}
+	}
+	return nil, false, nil
+}
+
+// intInSlice reports whether a is found in list.
+func intInSlice(a int, list []int) bool {
+	for _, b := range list {
+		if b == a {
+			return true
+		}
+	}
+	return false
+}
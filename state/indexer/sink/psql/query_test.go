@@ -0,0 +1,182 @@
+package psql
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"testing"
+
+	db "github.com/cometbft/cometbft-db"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	abci "github.com/tendermint/tendermint/abci/types"
+	"github.com/tendermint/tendermint/libs/pubsub/query"
+	blockidxkv "github.com/tendermint/tendermint/state/indexer/block/kv"
+	"github.com/tendermint/tendermint/state/txindex/kv"
+	"github.com/tendermint/tendermint/types"
+)
+
+func TestMakeQueryFilter(t *testing.T) {
+	testCases := []struct {
+		q     string
+		where string
+		args  []interface{}
+	}{
+		{
+			q:     "tx.height = 5",
+			where: "blocks.height = $2",
+			args:  []interface{}{int64(5)},
+		},
+		{
+			q:     "tx.height > 5 AND tx.height <= 10",
+			where: "blocks.height >= $2::bigint AND blocks.height <= $3::bigint",
+			args:  []interface{}{"6", "10"},
+		},
+		{
+			q:     "tx.height < 5.5",
+			where: "FALSE",
+		},
+		{
+			q: "account.owner = 'Ivan' AND account.name CONTAINS 'an' AND account.id EXISTS",
+			where: `EXISTS (
+  SELECT 1 FROM events JOIN attributes ON attributes.event_id = events.rowid
+  WHERE events.tx_id = tx_results.rowid AND attributes.composite_key = $2 AND attributes.value = $3)
+  AND EXISTS (
+  SELECT 1 FROM events JOIN attributes ON attributes.event_id = events.rowid
+  WHERE events.tx_id = tx_results.rowid AND attributes.composite_key = $4 AND strpos(attributes.value, $5) > 0)
+  AND EXISTS (
+  SELECT 1 FROM events JOIN attributes ON attributes.event_id = events.rowid
+  WHERE events.tx_id = tx_results.rowid AND attributes.composite_key = $6)`,
+			args: []interface{}{"account.owner", "Ivan", "account.name", "an", "account.id"},
+		},
+		{
+			q: "match.events = 1 AND account.number >= 1 AND account.owner = 'Ivan' AND tx.height = 2",
+			where: `blocks.height = $6
+  AND EXISTS (
+  SELECT 1 FROM events WHERE events.tx_id = tx_results.rowid AND EXISTS (
+    SELECT 1 FROM attributes WHERE attributes.event_id = events.rowid AND attributes.composite_key = $2 AND ` +
+				`(CASE WHEN attributes.value ~ '^[-+]?[0-9]+$' THEN attributes.value::numeric END) >= $3::numeric)
+  AND EXISTS (
+    SELECT 1 FROM attributes WHERE attributes.event_id = events.rowid AND attributes.composite_key = $4 AND ` +
+				`attributes.value = $5))`,
+			args: []interface{}{"account.number", "1", "account.owner", "Ivan", int64(2)},
+		},
+	}
+
+	for _, tc := range testCases {
+		f, err := makeQueryFilter(query.MustParse(tc.q), types.TxHeightKey, txEventScope)
+		require.NoError(t, err, tc.q)
+		assert.Equal(t, tc.where, f.where, tc.q)
+		assert.Equal(t, tc.args, f.args, tc.q)
+	}
+}
+
+// The searches of the sink match the ones of the kv indexers on the same
+// blocks and txs.
+func TestSearchMatchesKV(t *testing.T) {
+	ctx := context.Background()
+	sink := &EventSink{store: testDB(), chainID: "search-chainID"}
+	txIndex := kv.NewTxIndex(db.NewMemDB())
+	blockIndex := blockidxkv.New(db.NewMemDB())
+
+	for height := int64(1); height <= 6; height++ {
+		header := types.EventDataNewBlockHeader{
+			Header: types.Header{Height: height},
+			ResultEndBlock: abci.ResponseEndBlock{Events: []abci.Event{
+				{Type: "end_event", Attributes: []abci.EventAttribute{
+					{Key: []byte("foo"), Value: []byte(fmt.Sprint(height * 100)), Index: true},
+					{Key: []byte("bar"), Value: []byte(fmt.Sprint(height % 2)), Index: true},
+				}},
+				makeIndexedEvent("end_event.foo", "100"),
+			}},
+		}
+		require.NoError(t, sink.IndexBlockEvents(header))
+		require.NoError(t, blockIndex.Index(header))
+
+		for index := uint32(0); index < 3; index++ {
+			txr := &abci.TxResult{
+				Height: height,
+				Index:  index,
+				Tx:     types.Tx(fmt.Sprintf("tx-%d-%d", height, index)),
+				Result: abci.ResponseDeliverTx{Events: []abci.Event{
+					{Type: "account", Attributes: []abci.EventAttribute{
+						{Key: []byte("number"), Value: []byte(fmt.Sprint(index)), Index: true},
+						{Key: []byte("owner"), Value: []byte([]string{"Ivan", "Yulieta", "Vlad"}[index]), Index: true},
+					}},
+					makeIndexedEvent("account.number", fmt.Sprint(height)),
+					makeIndexedEvent("transfer.amount", "not a number"),
+				}},
+			}
+			require.NoError(t, sink.IndexTxEvents([]*abci.TxResult{txr}))
+			require.NoError(t, txIndex.Index(txr))
+		}
+	}
+
+	for _, q := range []string{
+		"tx.height = 3",
+		"tx.height > 2 AND tx.height <= 4",
+		"account.owner = 'Ivan'",
+		"account.owner CONTAINS 'li'",
+		"account.owner EXISTS AND tx.height < 3",
+		"account.number = 2",
+		"account.number >= 1 AND account.number < 2",
+		"account.number > 4",
+		"account.number = 2 AND account.owner = 'Vlad'",
+		"match.events = 1 AND account.number = 2 AND account.owner = 'Vlad'",
+		"match.events = 1 AND account.number = 2 AND account.owner = 'Ivan'",
+		"match.events = 1 AND account.number = 0 AND tx.height = 2",
+		"match.events = 1 AND account.number > 0 AND account.owner = 'Yulieta' AND tx.height >= 5",
+		"match.events = 0 AND account.number = 2 AND account.owner = 'Ivan'",
+		"transfer.amount > 1",
+		"account.owner = 'Nobody'",
+		fmt.Sprintf("tx.hash = '%X'", types.Tx("tx-2-1").Hash()),
+	} {
+		want, err := txIndex.Search(ctx, query.MustParse(q))
+		require.NoError(t, err, q)
+		sort.Slice(want, func(i, j int) bool {
+			if want[i].Height == want[j].Height {
+				return want[i].Index < want[j].Index
+			}
+			return want[i].Height < want[j].Height
+		})
+		got, err := sink.SearchTxEvents(ctx, query.MustParse(q))
+		require.NoError(t, err, q)
+		assert.Equal(t, len(want), len(got), q)
+		for i := range want {
+			assert.Equal(t, want[i], got[i], q)
+		}
+	}
+
+	for _, q := range []string{
+		"block.height = 3",
+		"block.height > 2 AND block.height < 5",
+		"end_event.foo = 100",
+		"end_event.foo > 200 AND end_event.foo <= 500",
+		"end_event.bar = 1 AND block.height > 2",
+		"match.events = 1 AND end_event.foo = 100 AND end_event.bar = 1",
+		"match.events = 1 AND end_event.foo = 300 AND end_event.bar = 1",
+		"match.events = 1 AND end_event.foo > 100 AND end_event.bar = 0 AND block.height <= 4",
+		"end_event.baz EXISTS",
+	} {
+		want, err := blockIndex.Search(ctx, query.MustParse(q))
+		require.NoError(t, err, q)
+		got, err := sink.SearchBlockEvents(ctx, query.MustParse(q))
+		require.NoError(t, err, q)
+		assert.Equal(t, want, got, q)
+	}
+
+	// the pages are sorted and paginated by the database
+	q := query.MustParse("account.owner EXISTS")
+	txrs, total, err := sink.SearchTxEventsPage(ctx, q, true, 2, 4)
+	require.NoError(t, err)
+	assert.Equal(t, 18, total)
+	require.Len(t, txrs, 4)
+	assert.Equal(t, []int64{6, 5, 5, 5}, []int64{txrs[0].Height, txrs[1].Height, txrs[2].Height, txrs[3].Height})
+	assert.Equal(t, []uint32{0, 2, 1, 0}, []uint32{txrs[0].Index, txrs[1].Index, txrs[2].Index, txrs[3].Index})
+
+	heights, total, err := sink.SearchBlockEventsPage(ctx, query.MustParse("block.height > 1"), false, 4, 2)
+	require.NoError(t, err)
+	assert.Equal(t, 5, total)
+	assert.Equal(t, []int64{6}, heights)
+}
@@ -0,0 +1,11 @@
+package txindex
+
+import "time"
+
+// SetSinkRetryInterval sets the interval the sinks of a FanOut retry after a
+// failure, exclusively and explicitly for testing.
+func SetSinkRetryInterval(d time.Duration) (restore func()) {
+	prev := sinkRetryInterval
+	sinkRetryInterval = d
+	return func() { sinkRetryInterval = prev }
+}
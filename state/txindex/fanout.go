@@ -0,0 +1,286 @@
+package txindex
+
+import (
+	"encoding/binary"
+	"fmt"
+	"sync/atomic"
+	"time"
+
+	dbm "github.com/cometbft/cometbft-db"
+
+	abci "github.com/tendermint/tendermint/abci/types"
+	"github.com/tendermint/tendermint/libs/service"
+	sm "github.com/tendermint/tendermint/state"
+	"github.com/tendermint/tendermint/state/indexer"
+	"github.com/tendermint/tendermint/types"
+)
+
+// sinkQueueSize is the number of blocks received from the event bus a sink can
+// lag behind by. The blocks received once its queue is full are indexed from
+// the stores once the sink caught up.
+const sinkQueueSize = 100
+
+// sinkRetryInterval is how long a sink waits before indexing a block again
+// after failing to.
+var sinkRetryInterval = 5 * time.Second
+
+// Sink is one of the backends a FanOut indexes the txs and blocks into.
+type Sink struct {
+	// Name identifies the sink, e.g. "kv" or "psql", and the last height it
+	// indexed across restarts.
+	Name         string
+	TxIndexer    TxIndexer
+	BlockIndexer indexer.BlockIndexer
+}
+
+// FanOut indexes the txs and blocks into several sinks. Each sink indexes the
+// blocks in order and at its own pace, and records the height of the last
+// block it indexed. A sink that falls behind, because it failed or was slower
+// than the blocks, or was added after the others, is backfilled with the
+// blocks it missed from the block store and the ABCI responses, as by
+// reindex-event, without holding back the other sinks.
+type FanOut struct {
+	service.BaseService
+
+	sinks      []*fanOutSink
+	heights    dbm.DB
+	blockStore sm.BlockStore
+	stateStore sm.Store
+
+	// height of the last block received from the event bus, or executed
+	// before starting
+	latest atomic.Int64
+}
+
+type fanOutSink struct {
+	Sink
+	height int64 // height of the last block indexed
+	queue  chan blockEvents
+}
+
+// blockEvents are the events of a block to index.
+type blockEvents struct {
+	header types.EventDataNewBlockHeader
+	batch  *Batch
+}
+
+// NewFanOut returns a FanOut indexing into sinks, recording the heights they
+// indexed in heights. The sinks that didn't index any height yet, except the
+// first one, are backfilled from the base of blockStore.
+func NewFanOut(sinks []Sink, heights dbm.DB, blockStore sm.BlockStore, stateStore sm.Store) *FanOut {
+	fo := &FanOut{
+		heights:    heights,
+		blockStore: blockStore,
+		stateStore: stateStore,
+	}
+	for _, sink := range sinks {
+		fo.sinks = append(fo.sinks, &fanOutSink{Sink: sink, queue: make(chan blockEvents, sinkQueueSize)})
+	}
+	fo.BaseService = *service.NewBaseService(nil, "FanOut", fo)
+	return fo
+}
+
+// OnStart implements service.Service by starting to index into the sinks.
+func (fo *FanOut) OnStart() error {
+	state, err := fo.stateStore.Load()
+	if err != nil {
+		return fmt.Errorf("loading state: %w", err)
+	}
+	fo.latest.Store(state.LastBlockHeight)
+
+	// the blocks below the base of the block store can't be backfilled
+	minHeight := fo.blockStore.Base() - 1
+	if minHeight < 0 {
+		minHeight = 0
+	}
+	for i, s := range fo.sinks {
+		height, ok, err := fo.loadHeight(s.Name)
+		if err != nil {
+			return err
+		}
+		switch {
+		case !ok && i == 0:
+			// the first sink is the one indexing before the others were added
+			height = state.LastBlockHeight
+		case height < minHeight:
+			if ok {
+				fo.Logger.Error("blocks missed by sink were pruned; skipping them",
+					"sink", s.Name, "height", height, "base", minHeight+1)
+			}
+			height = minHeight
+		}
+		s.height = height
+		if err := fo.saveHeight(s); err != nil {
+			return err
+		}
+		fo.Logger.Info("indexing into sink", "sink", s.Name, "height", s.height)
+	}
+
+	for _, s := range fo.sinks {
+		go fo.sinkRoutine(s)
+	}
+	return nil
+}
+
+// Index queues the block and its txs to be indexed into the sinks. It doesn't
+// block: the block is indexed from the stores by the sinks lagging behind.
+func (fo *FanOut) Index(header types.EventDataNewBlockHeader, batch *Batch) {
+	if height := header.Header.Height; height > fo.latest.Load() {
+		fo.latest.Store(height)
+	}
+	for _, s := range fo.sinks {
+		select {
+		case s.queue <- blockEvents{header: header, batch: batch}:
+		default:
+			fo.Logger.Debug("sink is lagging behind; the block will be indexed from the stores",
+				"sink", s.Name, "height", header.Header.Height)
+		}
+	}
+}
+
+// sinkRoutine indexes the blocks received into s in order, indexing the ones
+// missed from the stores, and retrying the ones it failed to index.
+func (fo *FanOut) sinkRoutine(s *fanOutSink) {
+	var received *blockEvents
+	for {
+		select {
+		case <-fo.Quit():
+			return
+		default:
+		}
+		if received == nil {
+			select {
+			case events := <-s.queue:
+				received = &events
+			default:
+			}
+		}
+
+		var err error
+		switch {
+		case received != nil && received.header.Header.Height <= s.height:
+			// indexed from the stores already
+			received = nil
+			continue
+		case received != nil && received.header.Header.Height == s.height+1:
+			if err = fo.index(s, received.header, received.batch); err == nil {
+				received = nil
+			}
+		case received != nil || s.height < fo.latest.Load():
+			// catch up with the blocks missed
+			err = fo.indexStored(s, s.height+1)
+		default:
+			select {
+			case events := <-s.queue:
+				received = &events
+			case <-fo.Quit():
+				return
+			}
+			continue
+		}
+
+		if err != nil {
+			fo.Logger.Error("failed to index block into sink; retrying",
+				"sink", s.Name, "height", s.height+1, "err", err)
+			select {
+			case <-time.After(sinkRetryInterval):
+			case <-fo.Quit():
+				return
+			}
+		}
+	}
+}
+
+// index indexes the block and its txs into s, and records its height.
+func (fo *FanOut) index(s *fanOutSink, header types.EventDataNewBlockHeader, batch *Batch) error {
+	if err := s.BlockIndexer.Index(header); err != nil {
+		return fmt.Errorf("indexing block: %w", err)
+	}
+	if err := s.TxIndexer.AddBatch(batch); err != nil {
+		return fmt.Errorf("indexing block txs: %w", err)
+	}
+	s.height = header.Header.Height
+	fo.Logger.Debug("indexed block into sink", "sink", s.Name, "height", s.height, "num_txs", header.NumTxs)
+	return fo.saveHeight(s)
+}
+
+// indexStored indexes the block at height and its txs into s from the stores.
+func (fo *FanOut) indexStored(s *fanOutSink, height int64) error {
+	header, batch, err := LoadBlockEvents(fo.blockStore, fo.stateStore, height)
+	if err != nil {
+		return err
+	}
+	return fo.index(s, header, batch)
+}
+
+func (fo *FanOut) loadHeight(name string) (int64, bool, error) {
+	bz, err := fo.heights.Get(sinkHeightKey(name))
+	if err != nil {
+		return 0, false, fmt.Errorf("loading height of sink %s: %w", name, err)
+	}
+	if len(bz) != 8 {
+		return 0, false, nil
+	}
+	//nolint:gosec
+	return int64(binary.BigEndian.Uint64(bz)), true, nil
+}
+
+func (fo *FanOut) saveHeight(s *fanOutSink) error {
+	bz := make([]byte, 8)
+	//nolint:gosec
+	binary.BigEndian.PutUint64(bz, uint64(s.height))
+	if err := fo.heights.Set(sinkHeightKey(s.Name), bz); err != nil {
+		return fmt.Errorf("saving height of sink %s: %w", s.Name, err)
+	}
+	return nil
+}
+
+func sinkHeightKey(name string) []byte {
+	return []byte("sinkHeight:" + name)
+}
+
+// LoadBlockEvents loads the events of the block at height and of its txs from
+// the block store and the ABCI responses of the state store.
+func LoadBlockEvents(
+	blockStore sm.BlockStore,
+	stateStore sm.Store,
+	height int64,
+) (types.EventDataNewBlockHeader, *Batch, error) {
+	b := blockStore.LoadBlock(height)
+	if b == nil {
+		return types.EventDataNewBlockHeader{}, nil,
+			fmt.Errorf("not able to load block at height %d from the blockstore", height)
+	}
+
+	r, err := stateStore.LoadABCIResponses(height)
+	if err != nil {
+		return types.EventDataNewBlockHeader{}, nil,
+			fmt.Errorf("not able to load ABCI Response at height %d from the statestore: %w", height, err)
+	}
+	if len(r.DeliverTxs) != len(b.Data.Txs) {
+		return types.EventDataNewBlockHeader{}, nil,
+			fmt.Errorf("ABCI Response at height %d holds %d tx results for %d txs",
+				height, len(r.DeliverTxs), len(b.Data.Txs))
+	}
+
+	e := types.EventDataNewBlockHeader{
+		Header:           b.Header,
+		NumTxs:           int64(len(b.Txs)),
+		ResultBeginBlock: *r.BeginBlock,
+		ResultEndBlock:   *r.EndBlock,
+	}
+	batch := NewBatch(e.NumTxs)
+	for i := range b.Data.Txs {
+		tr := abci.TxResult{
+			Height: b.Height,
+			//nolint:gosec
+			Index:  uint32(i),
+			Tx:     b.Data.Txs[i],
+			Result: *(r.DeliverTxs[i]),
+		}
+		if err := batch.Add(&tr); err != nil {
+			return types.EventDataNewBlockHeader{}, nil, fmt.Errorf("adding tx to batch: %w", err)
+		}
+	}
+	return e, batch, nil
+}
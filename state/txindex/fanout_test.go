@@ -0,0 +1,168 @@
+package txindex_test
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	db "github.com/cometbft/cometbft-db"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	abci "github.com/tendermint/tendermint/abci/types"
+	"github.com/tendermint/tendermint/libs/log"
+	cmtstate "github.com/tendermint/tendermint/proto/tendermint/state"
+	sm "github.com/tendermint/tendermint/state"
+	blockidxkv "github.com/tendermint/tendermint/state/indexer/block/kv"
+	blockmocks "github.com/tendermint/tendermint/state/indexer/mocks"
+	"github.com/tendermint/tendermint/state/mocks"
+	"github.com/tendermint/tendermint/state/txindex"
+	"github.com/tendermint/tendermint/state/txindex/kv"
+	"github.com/tendermint/tendermint/types"
+)
+
+// blockTx returns the tx of the test block at height.
+func blockTx(height int64) types.Tx {
+	return types.Tx(fmt.Sprintf("tx-%d", height))
+}
+
+// blockEvents returns the events of the test block at height.
+func blockEvents(height int64) (types.EventDataNewBlockHeader, *txindex.Batch) {
+	batch := txindex.NewBatch(1)
+	_ = batch.Add(&abci.TxResult{Height: height, Tx: blockTx(height)})
+	return types.EventDataNewBlockHeader{Header: types.Header{Height: height}, NumTxs: 1}, batch
+}
+
+// mockStores returns stores holding the test blocks from base to height.
+func mockStores(base, height int64) (*mocks.BlockStore, *mocks.Store) {
+	blockStore := &mocks.BlockStore{}
+	stateStore := &mocks.Store{}
+	blockStore.On("Base").Return(base)
+	stateStore.On("Load").Return(sm.State{LastBlockHeight: height}, nil)
+	for h := base; h <= height; h++ {
+		blockStore.On("LoadBlock", h).Return(&types.Block{
+			Header: types.Header{Height: h},
+			Data:   types.Data{Txs: types.Txs{blockTx(h)}},
+		})
+		stateStore.On("LoadABCIResponses", h).Return(&cmtstate.ABCIResponses{
+			DeliverTxs: []*abci.ResponseDeliverTx{{}},
+			BeginBlock: &abci.ResponseBeginBlock{},
+			EndBlock:   &abci.ResponseEndBlock{},
+		}, nil)
+	}
+	return blockStore, stateStore
+}
+
+func newKVSink(name string) txindex.Sink {
+	store := db.NewMemDB()
+	return txindex.Sink{
+		Name:         name,
+		TxIndexer:    kv.NewTxIndex(store),
+		BlockIndexer: blockidxkv.New(db.NewPrefixDB(store, []byte("block_events"))),
+	}
+}
+
+func startFanOut(t *testing.T, fanOut *txindex.FanOut) {
+	fanOut.SetLogger(log.TestingLogger())
+	require.NoError(t, fanOut.Start())
+	t.Cleanup(func() {
+		if !fanOut.IsRunning() {
+			return
+		}
+		if err := fanOut.Stop(); err != nil {
+			t.Error(err)
+		}
+	})
+}
+
+func requireIndexed(t *testing.T, sink txindex.Sink, height int64) {
+	t.Helper()
+	require.Eventually(t, func() bool {
+		ok, err := sink.BlockIndexer.Has(height)
+		require.NoError(t, err)
+		txr, err := sink.TxIndexer.Get(blockTx(height).Hash())
+		require.NoError(t, err)
+		return ok && txr != nil
+	}, 5*time.Second, 10*time.Millisecond, "sink %s at height %d", sink.Name, height)
+}
+
+func TestFanOutBackfillsAddedSink(t *testing.T) {
+	blockStore, stateStore := mockStores(2, 4)
+	heights := db.NewMemDB()
+	kvSink, addedSink := newKVSink("kv"), newKVSink("psql")
+
+	// the first sink indexed up to the height executed, the added one is
+	// backfilled from the base of the block store
+	fanOut := txindex.NewFanOut([]txindex.Sink{kvSink, addedSink}, heights, blockStore, stateStore)
+	startFanOut(t, fanOut)
+	for h := int64(2); h <= 4; h++ {
+		requireIndexed(t, addedSink, h)
+		ok, err := kvSink.BlockIndexer.Has(h)
+		require.NoError(t, err)
+		require.False(t, ok)
+	}
+
+	// the blocks received are indexed into both
+	fanOut.Index(blockEvents(5))
+	requireIndexed(t, kvSink, 5)
+	requireIndexed(t, addedSink, 5)
+}
+
+func TestFanOutCatchesUpFailingSink(t *testing.T) {
+	defer txindex.SetSinkRetryInterval(10 * time.Millisecond)()
+
+	blockStore, stateStore := mockStores(1, 3)
+	heights := db.NewMemDB()
+	kvSink := newKVSink("kv")
+
+	// the failing sink fails to index the blocks received until it recovers
+	failingSink := newKVSink("psql")
+	blockIndexer := &blockmocks.BlockIndexer{}
+	recovered := make(chan struct{})
+	blockIndexer.On("Index", mock.Anything).Return(func(h types.EventDataNewBlockHeader) error {
+		select {
+		case <-recovered:
+			return failingSink.BlockIndexer.Index(h)
+		default:
+			return errors.New("failing")
+		}
+	})
+	failing := txindex.Sink{Name: "psql", TxIndexer: failingSink.TxIndexer, BlockIndexer: blockIndexer}
+
+	fanOut := txindex.NewFanOut([]txindex.Sink{kvSink, failing}, heights, blockStore, stateStore)
+	startFanOut(t, fanOut)
+
+	// the healthy sink isn't held back by the failing one
+	for h := int64(4); h <= 6; h++ {
+		blockStore.On("LoadBlock", h).Return(&types.Block{
+			Header: types.Header{Height: h},
+			Data:   types.Data{Txs: types.Txs{blockTx(h)}},
+		})
+		stateStore.On("LoadABCIResponses", h).Return(&cmtstate.ABCIResponses{
+			DeliverTxs: []*abci.ResponseDeliverTx{{}},
+			BeginBlock: &abci.ResponseBeginBlock{},
+			EndBlock:   &abci.ResponseEndBlock{},
+		}, nil)
+		fanOut.Index(blockEvents(h))
+		requireIndexed(t, kvSink, h)
+	}
+
+	// once recovered, the failing sink catches up with the blocks it missed
+	close(recovered)
+	for h := int64(1); h <= 6; h++ {
+		requireIndexed(t, failingSink, h)
+	}
+
+	// the heights indexed are recorded, and the sinks resume from them
+	require.NoError(t, fanOut.Stop())
+	kvSink2, failingSink2 := newKVSink("kv"), newKVSink("psql")
+	fanOut = txindex.NewFanOut([]txindex.Sink{kvSink2, failingSink2}, heights, blockStore, stateStore)
+	startFanOut(t, fanOut)
+	fanOut.Index(blockEvents(7))
+	requireIndexed(t, kvSink2, 7)
+	requireIndexed(t, failingSink2, 7)
+	ok, err := failingSink2.BlockIndexer.Has(6)
+	require.NoError(t, err)
+	require.False(t, ok)
+}
@@ -28,6 +28,58 @@ type TxIndexer interface {
 	Search(ctx context.Context, q *query.Query) ([]*abci.TxResult, error)
 }
 
+// SearchPager is implemented by the TxIndexers that sort and paginate the
+// results of a search in their store, rather than returning all of them.
+type SearchPager interface {
+	// SearchPage returns the page of the transactions matching q, ordered by
+	// height and index, descending if desc, that starts after skip of them and
+	// holds at most limit of them, along with the count of the transactions
+	// matching q.
+	SearchPage(ctx context.Context, q *query.Query, desc bool, skip, limit int) ([]*abci.TxResult, int, error)
+}
+
+// Cursor is the position of a transaction among the transactions indexed,
+// ordered by height and index.
+type Cursor struct {
+	Height int64
+	Index  uint32
+}
+
+// CursorSearch are the parameters of a search iterating the transactions
+// matching a query in order.
+type CursorSearch struct {
+	// From is the position to start the search from, included, or nil to
+	// start from MinHeight, or MaxHeight if Desc.
+	From *Cursor
+	// Desc iterates the transactions in descending order.
+	Desc bool
+	// MinHeight and MaxHeight are the heights searched, included.
+	MinHeight, MaxHeight int64
+	// Limit is the maximum number of transactions returned.
+	Limit int
+	// MaxScan is the maximum number of index entries examined, if positive.
+	MaxScan int
+}
+
+// CursorPage is the result of a CursorSearch.
+type CursorPage struct {
+	// Results are the transactions matching the query, in order.
+	Results []*abci.TxResult
+	// Next is the position to resume the search from, or nil if all the
+	// transactions were searched.
+	Next *Cursor
+	// Scanned is the number of index entries examined.
+	Scanned int
+}
+
+// CursorSearcher is implemented by the TxIndexers that iterate the
+// transactions matching a query lazily, in the order of their heights and
+// indexes, rather than collecting all of them.
+type CursorSearcher interface {
+	// SearchCursor returns the transactions matching q within the bounds of s.
+	SearchCursor(ctx context.Context, q *query.Query, s CursorSearch) (*CursorPage, error)
+}
+
 // Batch groups together multiple Index operations to be performed at the same time.
 // NOTE: Batch is NOT thread-safe and must not be modified after starting its execution.
 type Batch struct {
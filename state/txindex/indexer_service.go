@@ -3,6 +3,7 @@ package txindex
 import (
 	"context"
 
+	"github.com/tendermint/tendermint/libs/log"
 	"github.com/tendermint/tendermint/libs/service"
 	"github.com/tendermint/tendermint/state/indexer"
 	"github.com/tendermint/tendermint/types"
@@ -21,6 +22,7 @@ type IndexerService struct {
 
 	txIdxr           TxIndexer
 	blockIdxr        indexer.BlockIndexer
+	fanOut           *FanOut
 	eventBus         *types.EventBus
 	terminateOnError bool
 }
@@ -38,9 +40,32 @@ func NewIndexerService(
 	return is
 }
 
+// NewFanOutIndexerService returns a new service instance indexing into the
+// sinks of fanOut, which it starts and stops.
+func NewFanOutIndexerService(fanOut *FanOut, eventBus *types.EventBus) *IndexerService {
+	is := &IndexerService{fanOut: fanOut, eventBus: eventBus}
+	is.BaseService = *service.NewBaseService(nil, "IndexerService", is)
+	return is
+}
+
+// SetLogger implements service.Service by setting the logger of the service
+// and of its FanOut, if any.
+func (is *IndexerService) SetLogger(l log.Logger) {
+	is.BaseService.SetLogger(l)
+	if is.fanOut != nil {
+		is.fanOut.SetLogger(l)
+	}
+}
+
 // OnStart implements service.Service by subscribing for all transactions
 // and indexing them by events.
 func (is *IndexerService) OnStart() error {
+	if is.fanOut != nil {
+		if err := is.fanOut.Start(); err != nil {
+			return err
+		}
+	}
+
 	// Use SubscribeUnbuffered here to ensure both subscriptions does not get
 	// canceled due to not pulling messages fast enough. Cause this might
 	// sometimes happen when there are no other subscribers.
@@ -85,6 +110,11 @@ func (is *IndexerService) OnStart() error {
 				}
 			}
 
+			if is.fanOut != nil {
+				is.fanOut.Index(eventDataHeader, batch)
+				continue
+			}
+
 			if err := is.blockIdxr.Index(eventDataHeader); err != nil {
 				is.Logger.Error("failed to index block", "height", height, "err", err)
 				if is.terminateOnError {
@@ -118,4 +148,9 @@ func (is *IndexerService) OnStop() {
 	if is.eventBus.IsRunning() {
 		_ = is.eventBus.UnsubscribeAll(context.Background(), subscriber)
 	}
+	if is.fanOut != nil {
+		if err := is.fanOut.Stop(); err != nil {
+			is.Logger.Error("failed to stop fan-out", "err", err)
+		}
+	}
 }
@@ -0,0 +1,254 @@
+package kv
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+
+	dbm "github.com/cometbft/cometbft-db"
+
+	abci "github.com/tendermint/tendermint/abci/types"
+	"github.com/tendermint/tendermint/libs/pubsub/query"
+	"github.com/tendermint/tendermint/state/indexer"
+	"github.com/tendermint/tendermint/state/txindex"
+	"github.com/tendermint/tendermint/types"
+)
+
+var _ txindex.CursorSearcher = (*TxIndex)(nil)
+
+// SearchCursor implements txindex.CursorSearcher. It iterates the heights in
+// order and, at each height, examines the txs holding the attribute of the
+// first equality condition of the query, if any, or all the txs otherwise,
+// matching the indexed attributes of their results against the query. As
+// with Search, a "tx.hash" condition returns the tx of the hash alone.
+//
+// The examination of a height is not interrupted by s.MaxScan, so that the
+// search is resumed at the next height.
+func (txi *TxIndex) SearchCursor(ctx context.Context, q *query.Query, s txindex.CursorSearch) (*txindex.CursorPage, error) {
+	conditions, err := q.Conditions()
+	if err != nil {
+		return nil, fmt.Errorf("error during parsing conditions from query: %w", err)
+	}
+
+	page := new(txindex.CursorPage)
+	hash, ok, err := lookForHash(conditions)
+	if err != nil {
+		return nil, fmt.Errorf("error during searching for a hash in the query: %w", err)
+	} else if ok {
+		res, err := txi.Get(hash)
+		if err != nil {
+			return nil, fmt.Errorf("error while retrieving the result: %w", err)
+		}
+		page.Scanned = 1
+		if res != nil && cursorWithin(txindex.Cursor{Height: res.Height, Index: res.Index}, s) {
+			page.Results = append(page.Results, res)
+		}
+		return page, nil
+	}
+
+	cc := indexer.SplitCursorConditions(conditions, types.TxHeightKey, s.MinHeight, s.MaxHeight)
+	driver := -1
+	for i, c := range cc.Conditions {
+		if c.Op == query.OpEqual {
+			driver = i
+			break
+		}
+	}
+
+	height, last, step := cc.MinHeight, cc.MaxHeight, int64(1)
+	if s.Desc {
+		height, last, step = cc.MaxHeight, cc.MinHeight, -1
+	}
+	var from *txindex.Cursor
+	if s.From != nil {
+		if (!s.Desc && s.From.Height > height) || (s.Desc && s.From.Height < height) {
+			height = s.From.Height
+		}
+		from = s.From
+	}
+
+	if height < cc.MinHeight || height > cc.MaxHeight {
+		return page, nil
+	}
+	for ; ; height += step {
+		first := txindex.Cursor{Height: height}
+		if s.Desc {
+			first.Index = math.MaxUint32
+		}
+		select {
+		case <-ctx.Done():
+			page.Next = &first
+			return page, nil
+		default:
+		}
+		if s.MaxScan > 0 && page.Scanned >= s.MaxScan {
+			page.Next = &first
+			return page, nil
+		}
+
+		prefix := startKey(types.TxHeightKey, height, height)
+		if driver >= 0 {
+			c := cc.Conditions[driver]
+			prefix = startKey(c.CompositeKey, c.Operand, height)
+		}
+		results, scanned, err := txi.resultsAt(prefix, height, s.Desc)
+		page.Scanned += scanned
+		if err != nil {
+			return nil, err
+		}
+
+		for _, res := range results {
+			pos := txindex.Cursor{Height: res.Height, Index: res.Index}
+			if from != nil && pos.Height == from.Height &&
+				((!s.Desc && pos.Index < from.Index) || (s.Desc && pos.Index > from.Index)) {
+				continue
+			}
+			if !txi.matchResult(res, cc) {
+				continue
+			}
+			if len(page.Results) == s.Limit {
+				page.Next = &pos
+				return page, nil
+			}
+			page.Results = append(page.Results, res)
+		}
+		if height == last {
+			return page, nil
+		}
+	}
+}
+
+// resultsAt returns the results of the txs at height indexed under prefix,
+// ordered by index, and the number of index entries examined.
+func (txi *TxIndex) resultsAt(prefix []byte, height int64, desc bool) ([]*abci.TxResult, int, error) {
+	it, err := dbm.IteratePrefix(txi.store, prefix)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer it.Close()
+
+	var (
+		hashes  = make(map[string]struct{})
+		scanned = 1
+	)
+	for ; it.Valid(); it.Next() {
+		hashes[string(it.Value())] = struct{}{}
+		scanned++
+	}
+	if err := it.Error(); err != nil {
+		return nil, scanned, err
+	}
+
+	results := make([]*abci.TxResult, 0, len(hashes))
+	for hash := range hashes {
+		res, err := txi.Get([]byte(hash))
+		if err != nil {
+			return nil, scanned, fmt.Errorf("failed to get Tx{%X}: %w", hash, err)
+		}
+		// the tx is searched at the height it was indexed at last
+		if res == nil || res.Height != height {
+			continue
+		}
+		results = append(results, res)
+	}
+	sort.Slice(results, func(i, j int) bool {
+		if desc {
+			return results[i].Index > results[j].Index
+		}
+		return results[i].Index < results[j].Index
+	})
+	return results, scanned, nil
+}
+
+// indexedAttribute is an attribute of an event indexed.
+type indexedAttribute struct {
+	compositeKey string
+	value        string
+}
+
+// matchResult reports whether the indexed attributes of the events of res
+// satisfy the attribute conditions of cc, following the semantics of Search.
+func (txi *TxIndex) matchResult(res *abci.TxResult, cc indexer.CursorConditions) bool {
+	if len(cc.Conditions) == 0 && len(cc.Ranges) == 0 {
+		return true
+	}
+
+	var all []indexedAttribute
+	for _, event := range res.Result.Events {
+		if len(event.Type) == 0 {
+			continue
+		}
+		var attrs []indexedAttribute
+		for _, attr := range event.Attributes {
+			if len(attr.Key) == 0 || !attr.GetIndex() {
+				continue
+			}
+			compositeKey := fmt.Sprintf("%s.%s", event.Type, string(attr.Key))
+			if !txi.filter.Indexed(compositeKey) {
+				continue
+			}
+			attrs = append(attrs, indexedAttribute{compositeKey: compositeKey, value: string(attr.Value)})
+		}
+		if cc.MatchEvents && matchAttributes(attrs, cc) {
+			return true
+		}
+		all = append(all, attrs...)
+	}
+	return !cc.MatchEvents && matchAttributes(all, cc)
+}
+
+// matchAttributes reports whether attrs satisfy the attribute conditions of
+// cc.
+func matchAttributes(attrs []indexedAttribute, cc indexer.CursorConditions) bool {
+	for _, c := range cc.Conditions {
+		if !anyAttribute(attrs, c.CompositeKey, func(value string) bool {
+			switch c.Op {
+			case query.OpEqual:
+				return value == fmt.Sprintf("%v", c.Operand)
+			case query.OpContains:
+				return strings.Contains(value, fmt.Sprintf("%v", c.Operand))
+			case query.OpExists:
+				return true
+			default:
+				return false
+			}
+		}) {
+			return false
+		}
+	}
+	for _, qr := range cc.Ranges {
+		if !indexer.IntegerBounds(qr) || !anyAttribute(attrs, qr.Key, func(value string) bool {
+			return indexer.InRange(qr, value)
+		}) {
+			return false
+		}
+	}
+	return true
+}
+
+// anyAttribute reports whether an attribute of attrs of composite key
+// compositeKey has a value satisfying match.
+func anyAttribute(attrs []indexedAttribute, compositeKey string, match func(string) bool) bool {
+	for _, attr := range attrs {
+		if attr.compositeKey == compositeKey && match(attr.value) {
+			return true
+		}
+	}
+	return false
+}
+
+// cursorWithin reports whether the position pos is within the bounds of s.
+func cursorWithin(pos txindex.Cursor, s txindex.CursorSearch) bool {
+	if pos.Height < s.MinHeight || pos.Height > s.MaxHeight {
+		return false
+	}
+	if s.From == nil {
+		return true
+	}
+	if s.Desc {
+		return pos.Height < s.From.Height || (pos.Height == s.From.Height && pos.Index <= s.From.Index)
+	}
+	return pos.Height > s.From.Height || (pos.Height == s.From.Height && pos.Index >= s.From.Index)
+}
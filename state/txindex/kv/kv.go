@@ -31,13 +31,36 @@ type TxIndex struct {
 	store dbm.DB
 	// Number the events in the event list
 	eventSeq int64
+
+	// filter of the event attributes indexed
+	filter *indexer.EventFilter
+	// delete the attributes of the txs indexed the filter excludes
+	prune bool
+}
+
+// TxIndexOption sets an optional parameter on the TxIndex.
+type TxIndexOption func(*TxIndex)
+
+// WithEventFilter indexes only the event attributes selected by f.
+func WithEventFilter(f *indexer.EventFilter) TxIndexOption {
+	return func(txi *TxIndex) { txi.filter = f }
+}
+
+// WithPruning deletes the event attributes excluded by the event filter
+// indexed previously for the txs indexed, as when reindexing.
+func WithPruning() TxIndexOption {
+	return func(txi *TxIndex) { txi.prune = true }
 }
 
 // NewTxIndex creates new KV indexer.
-func NewTxIndex(store dbm.DB) *TxIndex {
-	return &TxIndex{
+func NewTxIndex(store dbm.DB, options ...TxIndexOption) *TxIndex {
+	txi := &TxIndex{
 		store: store,
 	}
+	for _, option := range options {
+		option(txi)
+	}
+	return txi
 }
 
 // Get gets transaction from the TxIndex storage and returns it or nil if the
@@ -148,13 +171,22 @@ func (txi *TxIndex) indexEvents(result *abci.TxResult, hash []byte, store dbm.Ba
 				continue
 			}
 
-			// index if `index: true` is set
+			// index if `index: true` is set and the filter selects it
 			compositeTag := fmt.Sprintf("%s.%s", event.Type, string(attr.Key))
-			if attr.GetIndex() {
-				err := store.Set(keyForEvent(compositeTag, attr.Value, result, txi.eventSeq), hash)
-				if err != nil {
-					return err
+			if !attr.GetIndex() {
+				continue
+			}
+			if !txi.filter.Indexed(compositeTag) {
+				if txi.prune {
+					if err := txi.deleteEvent(compositeTag, attr.Value, result, store); err != nil {
+						return err
+					}
 				}
+				continue
+			}
+			err := store.Set(keyForEvent(compositeTag, attr.Value, result, txi.eventSeq), hash)
+			if err != nil {
+				return err
 			}
 		}
 	}
@@ -162,6 +194,29 @@ func (txi *TxIndex) indexEvents(result *abci.TxResult, hash []byte, store dbm.Ba
 	return nil
 }
 
+// deleteEvent deletes the keys of the attribute of the tx result indexed
+// previously, whatever the sequence of its event.
+func (txi *TxIndex) deleteEvent(compositeTag string, value []byte, result *abci.TxResult, store dbm.Batch) error {
+	prefix := []byte(fmt.Sprintf("%s/%s/%d/%d", compositeTag, value, result.Height, result.Index))
+	it, err := dbm.IteratePrefix(txi.store, prefix)
+	if err != nil {
+		return err
+	}
+	defer it.Close()
+
+	for ; it.Valid(); it.Next() {
+		// skip the keys of other txs sharing the prefix, e.g. of index 10 for 1
+		suffix := it.Key()[len(prefix):]
+		if len(suffix) > 0 && !bytes.HasPrefix(suffix, []byte(eventSeqSeparator)) {
+			continue
+		}
+		if err := store.Delete(it.Key()); err != nil {
+			return err
+		}
+	}
+	return it.Error()
+}
+
 func (txi *TxIndex) indexResult(batch dbm.Batch, result *abci.TxResult) error {
 	hash := types.Tx(result.Tx).Hash()
 
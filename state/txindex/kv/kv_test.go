@@ -15,6 +15,7 @@ import (
 	abci "github.com/tendermint/tendermint/abci/types"
 	"github.com/tendermint/tendermint/libs/pubsub/query"
 	cmtrand "github.com/tendermint/tendermint/libs/rand"
+	"github.com/tendermint/tendermint/state/indexer"
 	"github.com/tendermint/tendermint/state/txindex"
 	"github.com/tendermint/tendermint/types"
 )
@@ -706,6 +707,125 @@ func TestTxSearchMultipleTxs(t *testing.T) {
 
 }
 
+func TestTxIndexEventFilter(t *testing.T) {
+	store := db.NewMemDB()
+	events := []abci.Event{
+		{Type: "transfer", Attributes: []abci.EventAttribute{
+			{Key: []byte("sender"), Value: []byte("alice"), Index: true},
+			{Key: []byte("amount"), Value: []byte("5"), Index: true},
+		}},
+	}
+	txResult := txResultWithEvents(events)
+	txResult.Index = 1
+	// shares the key prefix of the events of txResult
+	txResult10 := txResultWithEvents(events)
+	txResult10.Tx = types.Tx("HELLO WORLD 10")
+	txResult10.Index = 10
+
+	txIndexer := NewTxIndex(store)
+	require.NoError(t, txIndexer.Index(txResult))
+	require.NoError(t, txIndexer.Index(txResult10))
+
+	search := func(txIndexer *TxIndex, q string) int {
+		results, err := txIndexer.Search(context.Background(), query.MustParse(q))
+		require.NoError(t, err)
+		return len(results)
+	}
+	require.Equal(t, 2, search(txIndexer, "transfer.sender = 'alice'"))
+
+	filter, err := indexer.NewEventFilter(nil, []string{"*.sender"})
+	require.NoError(t, err)
+
+	// the attributes denied are not indexed
+	filtered := NewTxIndex(db.NewMemDB(), WithEventFilter(filter))
+	require.NoError(t, filtered.Index(txResult))
+	assert.Equal(t, 0, search(filtered, "transfer.sender = 'alice'"))
+	assert.Equal(t, 1, search(filtered, "transfer.amount = 5"))
+	assert.Equal(t, 1, search(filtered, "tx.height = 1"))
+
+	// reindexing prunes the attributes denied of the txs reindexed only
+	pruning := NewTxIndex(store, WithEventFilter(filter), WithPruning())
+	require.NoError(t, pruning.Index(txResult))
+	results, err := pruning.Search(context.Background(), query.MustParse("transfer.sender = 'alice'"))
+	require.NoError(t, err)
+	require.Len(t, results, 1)
+	assert.True(t, proto.Equal(txResult10, results[0]))
+	assert.Equal(t, 2, search(pruning, "transfer.amount = 5"))
+}
+
+func TestTxSearchCursor(t *testing.T) {
+	txIndexer := NewTxIndex(db.NewMemDB())
+	for height := int64(1); height <= 5; height++ {
+		for index, owner := range []string{"alice", "bob"} {
+			txResult := txResultWithEvents([]abci.Event{
+				{Type: "account", Attributes: []abci.EventAttribute{
+					{Key: []byte("owner"), Value: []byte(owner), Index: true},
+				}},
+				{Type: "account", Attributes: []abci.EventAttribute{
+					{Key: []byte("number"), Value: []byte(fmt.Sprint(height*10 + int64(index))), Index: true},
+				}},
+			})
+			txResult.Tx = types.Tx(fmt.Sprintf("HELLO WORLD %d %d", height, index))
+			txResult.Height = height
+			txResult.Index = uint32(index)
+			require.NoError(t, txIndexer.Index(txResult))
+		}
+	}
+
+	positions := func(page *txindex.CursorPage) []txindex.Cursor {
+		cursors := make([]txindex.Cursor, 0, len(page.Results))
+		for _, res := range page.Results {
+			cursors = append(cursors, txindex.Cursor{Height: res.Height, Index: res.Index})
+		}
+		return cursors
+	}
+	search := func(q string, s txindex.CursorSearch) *txindex.CursorPage {
+		s.MinHeight, s.MaxHeight = 1, 5
+		page, err := txIndexer.SearchCursor(context.Background(), query.MustParse(q), s)
+		require.NoError(t, err)
+		return page
+	}
+
+	// the pages resume from the position of the next result
+	page := search("account.owner = 'alice'", txindex.CursorSearch{Limit: 2})
+	assert.Equal(t, []txindex.Cursor{{Height: 1}, {Height: 2}}, positions(page))
+	require.Equal(t, &txindex.Cursor{Height: 3}, page.Next)
+	page = search("account.owner = 'alice'", txindex.CursorSearch{From: page.Next, Limit: 2})
+	assert.Equal(t, []txindex.Cursor{{Height: 3}, {Height: 4}}, positions(page))
+	page = search("account.owner = 'alice'", txindex.CursorSearch{From: page.Next, Limit: 2})
+	assert.Equal(t, []txindex.Cursor{{Height: 5}}, positions(page))
+	assert.Nil(t, page.Next)
+
+	page = search("account.owner = 'bob'", txindex.CursorSearch{Desc: true, Limit: 2})
+	assert.Equal(t, []txindex.Cursor{{Height: 5, Index: 1}, {Height: 4, Index: 1}}, positions(page))
+	require.Equal(t, &txindex.Cursor{Height: 3, Index: 1}, page.Next)
+
+	page = search("tx.height >= 2 AND tx.height <= 3", txindex.CursorSearch{
+		From:  &txindex.Cursor{Height: 2, Index: 1},
+		Limit: 10,
+	})
+	assert.Equal(t, []txindex.Cursor{{Height: 2, Index: 1}, {Height: 3}, {Height: 3, Index: 1}}, positions(page))
+	assert.Nil(t, page.Next)
+
+	page = search("account.number > 30", txindex.CursorSearch{Limit: 10})
+	assert.Equal(t, []txindex.Cursor{{Height: 3, Index: 1}, {Height: 4}, {Height: 4, Index: 1}, {Height: 5}, {Height: 5, Index: 1}},
+		positions(page))
+
+	// the attributes of distinct events satisfy the query unless matching events
+	assert.Len(t, search("account.owner = 'alice' AND account.number = 10", txindex.CursorSearch{Limit: 10}).Results, 1)
+	assert.Empty(t, search("match.events = 1 AND account.owner = 'alice' AND account.number = 10",
+		txindex.CursorSearch{Limit: 10}).Results)
+
+	// the search stops at the next height once the scan limit is reached
+	page = search("account.owner = 'alice'", txindex.CursorSearch{Limit: 10, MaxScan: 1})
+	assert.Equal(t, []txindex.Cursor{{Height: 1}}, positions(page))
+	assert.Equal(t, &txindex.Cursor{Height: 2}, page.Next)
+
+	page = search("tx.hash = '"+fmt.Sprintf("%X", types.Tx("HELLO WORLD 4 1").Hash())+"'",
+		txindex.CursorSearch{From: &txindex.Cursor{Height: 4}, Limit: 10})
+	assert.Equal(t, []txindex.Cursor{{Height: 4, Index: 1}}, positions(page))
+}
+
 func txResultWithEvents(events []abci.Event) *abci.TxResult {
 	tx := types.Tx("HELLO WORLD")
 	return &abci.TxResult{
@@ -8,9 +8,12 @@ import (
 	"github.com/gogo/protobuf/proto"
 
 	abci "github.com/tendermint/tendermint/abci/types"
+	"github.com/tendermint/tendermint/behaviour"
 	"github.com/tendermint/tendermint/config"
 	cmtsync "github.com/tendermint/tendermint/libs/sync"
 	"github.com/tendermint/tendermint/p2p"
+	"github.com/tendermint/tendermint/pkg/trace"
+	"github.com/tendermint/tendermint/pkg/trace/schema"
 	ssproto "github.com/tendermint/tendermint/proto/tendermint/statesync"
 	"github.com/tendermint/tendermint/proxy"
 	sm "github.com/tendermint/tendermint/state"
@@ -40,6 +43,17 @@ type Reactor struct {
 	// snapshots and chunks into the sync.
 	mtx    cmtsync.RWMutex
 	syncer *syncer
+
+	traceClient trace.Tracer
+}
+
+// ReactorOption sets an optional parameter on the Reactor.
+type ReactorOption func(*Reactor)
+
+// WithTraceClient sets the tracer used to trace the fetching and applying of
+// snapshot chunks.
+func WithTraceClient(traceClient trace.Tracer) ReactorOption {
+	return func(r *Reactor) { r.traceClient = traceClient }
 }
 
 // NewReactor creates a new state sync reactor.
@@ -48,12 +62,17 @@ func NewReactor(
 	conn proxy.AppConnSnapshot,
 	connQuery proxy.AppConnQuery,
 	tempDir string,
+	options ...ReactorOption,
 ) *Reactor {
 
 	r := &Reactor{
-		cfg:       cfg,
-		conn:      conn,
-		connQuery: connQuery,
+		cfg:         cfg,
+		conn:        conn,
+		connQuery:   connQuery,
+		traceClient: trace.NoOpTracer(),
+	}
+	for _, option := range options {
+		option(r)
 	}
 	r.BaseReactor = *p2p.NewBaseReactor("StateSync", r)
 
@@ -171,6 +190,8 @@ func (r *Reactor) ReceiveEnvelope(e p2p.Envelope) {
 		case *ssproto.ChunkRequest:
 			r.Logger.Debug("Received chunk request", "height", msg.Height, "format", msg.Format,
 				"chunk", msg.Index, "peer", e.Src.ID())
+			schema.WriteSnapshotChunk(r.traceClient, msg.Height, msg.Format, msg.Index, string(e.Src.ID()),
+				schema.SnapshotChunkRequest, 0, "", schema.Download)
 			resp, err := r.conn.LoadSnapshotChunkSync(abci.RequestLoadSnapshotChunk{
 				Height: msg.Height,
 				Format: msg.Format,
@@ -183,7 +204,7 @@ func (r *Reactor) ReceiveEnvelope(e p2p.Envelope) {
 			}
 			r.Logger.Debug("Sending chunk", "height", msg.Height, "format", msg.Format,
 				"chunk", msg.Index, "peer", e.Src.ID())
-			p2p.SendEnvelopeShim(e.Src, p2p.Envelope{ //nolint: staticcheck
+			sent := p2p.SendEnvelopeShim(e.Src, p2p.Envelope{ //nolint: staticcheck
 				ChannelID: ChunkChannel,
 				Message: &ssproto.ChunkResponse{
 					Height:  msg.Height,
@@ -193,8 +214,14 @@ func (r *Reactor) ReceiveEnvelope(e p2p.Envelope) {
 					Missing: resp.Chunk == nil,
 				},
 			}, r.Logger)
+			if sent {
+				schema.WriteSnapshotChunk(r.traceClient, msg.Height, msg.Format, msg.Index, string(e.Src.ID()),
+					schema.SnapshotChunkResponse, len(resp.Chunk), chunkResult(resp.Chunk == nil), schema.Upload)
+			}
 
 		case *ssproto.ChunkResponse:
+			schema.WriteSnapshotChunk(r.traceClient, msg.Height, msg.Format, msg.Index, string(e.Src.ID()),
+				schema.SnapshotChunkResponse, len(msg.Chunk), chunkResult(msg.Missing), schema.Download)
 			r.mtx.RLock()
 			defer r.mtx.RUnlock()
 			if r.syncer == nil {
@@ -225,6 +252,14 @@ func (r *Reactor) ReceiveEnvelope(e p2p.Envelope) {
 	}
 }
 
+// chunkResult is the result traced for a chunk response.
+func chunkResult(missing bool) string {
+	if missing {
+		return "missing"
+	}
+	return ""
+}
+
 func (r *Reactor) Receive(chID byte, peer p2p.Peer, msgBytes []byte) {
 	msg := &ssproto.Message{}
 	err := proto.Unmarshal(msgBytes, msg)
@@ -285,7 +320,8 @@ func (r *Reactor) Sync(stateProvider StateProvider, discoveryTime time.Duration)
 		r.mtx.Unlock()
 		return sm.State{}, nil, errors.New("a state sync is already in progress")
 	}
-	r.syncer = newSyncer(r.cfg, r.Logger, r.conn, r.connQuery, stateProvider, r.tempDir)
+	r.syncer = newSyncer(r.cfg, r.Logger, r.conn, r.connQuery, stateProvider, r.tempDir, r.traceClient)
+	r.syncer.reporter = behaviour.NewSwitchReporter(r.Switch)
 	r.mtx.Unlock()
 
 	hook := func() {
@@ -8,11 +8,14 @@ import (
 	"time"
 
 	abci "github.com/tendermint/tendermint/abci/types"
+	"github.com/tendermint/tendermint/behaviour"
 	"github.com/tendermint/tendermint/config"
 	"github.com/tendermint/tendermint/libs/log"
 	cmtsync "github.com/tendermint/tendermint/libs/sync"
 	"github.com/tendermint/tendermint/light"
 	"github.com/tendermint/tendermint/p2p"
+	"github.com/tendermint/tendermint/pkg/trace"
+	"github.com/tendermint/tendermint/pkg/trace/schema"
 	ssproto "github.com/tendermint/tendermint/proto/tendermint/statesync"
 	"github.com/tendermint/tendermint/proxy"
 	sm "github.com/tendermint/tendermint/state"
@@ -62,6 +65,11 @@ type syncer struct {
 
 	mtx    cmtsync.RWMutex
 	chunks *chunkQueue
+
+	// reports the peers responding slowly, nil if not reported
+	reporter behaviour.Reporter
+
+	traceClient trace.Tracer
 }
 
 // newSyncer creates a new syncer.
@@ -72,6 +80,7 @@ func newSyncer(
 	connQuery proxy.AppConnQuery,
 	stateProvider StateProvider,
 	tempDir string,
+	traceClient trace.Tracer,
 ) *syncer {
 
 	return &syncer{
@@ -83,6 +92,7 @@ func newSyncer(
 		tempDir:       tempDir,
 		chunkFetchers: cfg.ChunkFetchers,
 		retryTimeout:  cfg.ChunkRequestTimeout,
+		traceClient:   traceClient,
 	}
 }
 
@@ -370,14 +380,20 @@ func (s *syncer) applyChunks(chunks *chunkQueue) error {
 			return fmt.Errorf("failed to fetch chunk: %w", err)
 		}
 
+		schema.WriteSnapshotChunk(s.traceClient, chunk.Height, chunk.Format, chunk.Index, string(chunk.Sender),
+			schema.SnapshotChunkApplyStart, len(chunk.Chunk), "", schema.Download)
 		resp, err := s.conn.ApplySnapshotChunkSync(abci.RequestApplySnapshotChunk{
 			Index:  chunk.Index,
 			Chunk:  chunk.Chunk,
 			Sender: string(chunk.Sender),
 		})
 		if err != nil {
+			schema.WriteSnapshotChunk(s.traceClient, chunk.Height, chunk.Format, chunk.Index, string(chunk.Sender),
+				schema.SnapshotChunkApplyEnd, len(chunk.Chunk), err.Error(), schema.Download)
 			return fmt.Errorf("failed to apply chunk %v: %w", chunk.Index, err)
 		}
+		schema.WriteSnapshotChunk(s.traceClient, chunk.Height, chunk.Format, chunk.Index, string(chunk.Sender),
+			schema.SnapshotChunkApplyEnd, len(chunk.Chunk), resp.Result.String(), schema.Download)
 		s.logger.Info("Applied snapshot chunk to ABCI app", "height", chunk.Height,
 			"format", chunk.Format, "chunk", chunk.Index, "total", chunks.Size())
 
@@ -450,7 +466,7 @@ func (s *syncer) fetchChunks(ctx context.Context, snapshot *snapshot, chunks *ch
 		ticker := time.NewTicker(s.retryTimeout)
 		defer ticker.Stop()
 
-		s.requestChunk(snapshot, index)
+		peer := s.requestChunk(snapshot, index)
 
 		select {
 		case <-chunks.WaitFor(index):
@@ -458,6 +474,13 @@ func (s *syncer) fetchChunks(ctx context.Context, snapshot *snapshot, chunks *ch
 
 		case <-ticker.C:
 			next = false
+			if peer != nil && s.reporter != nil {
+				err := s.reporter.Report(behaviour.SlowResponse(peer.ID(),
+					fmt.Sprintf("timed out sending snapshot chunk %d", index)))
+				if err != nil {
+					s.logger.Debug("Failed to report slow peer", "peer", peer.ID(), "err", err)
+				}
+			}
 
 		case <-ctx.Done():
 			return
@@ -467,17 +490,17 @@ func (s *syncer) fetchChunks(ctx context.Context, snapshot *snapshot, chunks *ch
 	}
 }
 
-// requestChunk requests a chunk from a peer.
-func (s *syncer) requestChunk(snapshot *snapshot, chunk uint32) {
+// requestChunk requests a chunk from a peer, and returns the peer if any.
+func (s *syncer) requestChunk(snapshot *snapshot, chunk uint32) p2p.Peer {
 	peer := s.snapshots.GetPeer(snapshot)
 	if peer == nil {
 		s.logger.Error("No valid peers found for snapshot", "height", snapshot.Height,
 			"format", snapshot.Format, "hash", snapshot.Hash)
-		return
+		return nil
 	}
 	s.logger.Debug("Requesting snapshot chunk", "height", snapshot.Height,
 		"format", snapshot.Format, "chunk", chunk, "peer", peer.ID())
-	p2p.SendEnvelopeShim(peer, p2p.Envelope{ //nolint: staticcheck
+	sent := p2p.SendEnvelopeShim(peer, p2p.Envelope{ //nolint: staticcheck
 		ChannelID: ChunkChannel,
 		Message: &ssproto.ChunkRequest{
 			Height: snapshot.Height,
@@ -485,6 +508,11 @@ func (s *syncer) requestChunk(snapshot *snapshot, chunk uint32) {
 			Index:  chunk,
 		},
 	}, s.logger)
+	if sent {
+		schema.WriteSnapshotChunk(s.traceClient, snapshot.Height, snapshot.Format, chunk, string(peer.ID()),
+			schema.SnapshotChunkRequest, 0, "", schema.Upload)
+	}
+	return peer
 }
 
 // verifyApp verifies the sync, checking the app hash, last block height and app version
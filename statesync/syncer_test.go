@@ -15,6 +15,7 @@ import (
 	cmtsync "github.com/tendermint/tendermint/libs/sync"
 	"github.com/tendermint/tendermint/p2p"
 	p2pmocks "github.com/tendermint/tendermint/p2p/mocks"
+	"github.com/tendermint/tendermint/pkg/trace"
 	cmtstate "github.com/tendermint/tendermint/proto/tendermint/state"
 	ssproto "github.com/tendermint/tendermint/proto/tendermint/statesync"
 	cmtversion "github.com/tendermint/tendermint/proto/tendermint/version"
@@ -39,7 +40,7 @@ func setupOfferSyncer(t *testing.T) (*syncer, *proxymocks.AppConnSnapshot) {
 	stateProvider.On("State", mock.AnythingOfType("*context.timerCtx"), uint64(4)).Return(sm.State{}, nil)
 
 	cfg := config.DefaultStateSyncConfig()
-	syncer := newSyncer(*cfg, log.NewNopLogger(), connSnapshot, connQuery, stateProvider, "")
+	syncer := newSyncer(*cfg, log.NewNopLogger(), connSnapshot, connQuery, stateProvider, "", trace.NoOpTracer())
 
 	return syncer, connSnapshot
 }
@@ -95,7 +96,7 @@ func TestSyncer_SyncAny(t *testing.T) {
 	connQuery := &proxymocks.AppConnQuery{}
 
 	cfg := config.DefaultStateSyncConfig()
-	syncer := newSyncer(*cfg, log.NewNopLogger(), connSnapshot, connQuery, stateProvider, "")
+	syncer := newSyncer(*cfg, log.NewNopLogger(), connSnapshot, connQuery, stateProvider, "", trace.NoOpTracer())
 
 	// Adding a chunk should error when no sync is in progress
 	_, err := syncer.AddChunk(&chunk{Height: 1, Format: 1, Index: 0, Chunk: []byte{1}})
@@ -438,7 +439,7 @@ func TestSyncer_applyChunks_Results(t *testing.T) {
 			stateProvider.On("AppHash", mock.Anything, mock.Anything).Return([]byte("app_hash"), nil)
 
 			cfg := config.DefaultStateSyncConfig()
-			syncer := newSyncer(*cfg, log.NewNopLogger(), connSnapshot, connQuery, stateProvider, "")
+			syncer := newSyncer(*cfg, log.NewNopLogger(), connSnapshot, connQuery, stateProvider, "", trace.NoOpTracer())
 
 			body := []byte{1, 2, 3}
 			chunks, err := newChunkQueue(&snapshot{Height: 1, Format: 1, Chunks: 1}, "")
@@ -491,7 +492,7 @@ func TestSyncer_applyChunks_RefetchChunks(t *testing.T) {
 			stateProvider.On("AppHash", mock.Anything, mock.Anything).Return([]byte("app_hash"), nil)
 
 			cfg := config.DefaultStateSyncConfig()
-			syncer := newSyncer(*cfg, log.NewNopLogger(), connSnapshot, connQuery, stateProvider, "")
+			syncer := newSyncer(*cfg, log.NewNopLogger(), connSnapshot, connQuery, stateProvider, "", trace.NoOpTracer())
 
 			chunks, err := newChunkQueue(&snapshot{Height: 1, Format: 1, Chunks: 3}, "")
 			require.NoError(t, err)
@@ -556,7 +557,7 @@ func TestSyncer_applyChunks_RejectSenders(t *testing.T) {
 			stateProvider.On("AppHash", mock.Anything, mock.Anything).Return([]byte("app_hash"), nil)
 
 			cfg := config.DefaultStateSyncConfig()
-			syncer := newSyncer(*cfg, log.NewNopLogger(), connSnapshot, connQuery, stateProvider, "")
+			syncer := newSyncer(*cfg, log.NewNopLogger(), connSnapshot, connQuery, stateProvider, "", trace.NoOpTracer())
 
 			// Set up three peers across two snapshots, and ask for one of them to be banned.
 			// It should be banned from all snapshots.
@@ -678,7 +679,7 @@ func TestSyncer_verifyApp(t *testing.T) {
 			stateProvider := &mocks.StateProvider{}
 
 			cfg := config.DefaultStateSyncConfig()
-			syncer := newSyncer(*cfg, log.NewNopLogger(), connSnapshot, connQuery, stateProvider, "")
+			syncer := newSyncer(*cfg, log.NewNopLogger(), connSnapshot, connQuery, stateProvider, "", trace.NoOpTracer())
 
 			connQuery.On("InfoSync", proxy.RequestInfo).Return(tc.response, tc.err)
 			_, err := syncer.verifyApp(s, appVersion)
@@ -0,0 +1,67 @@
+package store
+
+import (
+	"time"
+
+	"github.com/tendermint/tendermint/libs/service"
+)
+
+// TxInfoPruner is a service pruning the TxInfo index of a BlockStore in the
+// background, keeping the TxInfo of the txs committed in the last
+// retainBlocks heights regardless of the blocks being retained.
+type TxInfoPruner struct {
+	service.BaseService
+
+	blockStore   *BlockStore
+	retainBlocks int64
+	interval     time.Duration
+}
+
+// NewTxInfoPruner returns a new pruner keeping the TxInfo of the last
+// retainBlocks heights of blockStore, pruning older ones every interval.
+func NewTxInfoPruner(blockStore *BlockStore, retainBlocks int64, interval time.Duration) *TxInfoPruner {
+	p := &TxInfoPruner{
+		blockStore:   blockStore,
+		retainBlocks: retainBlocks,
+		interval:     interval,
+	}
+	p.BaseService = *service.NewBaseService(nil, "TxInfoPruner", p)
+	return p
+}
+
+// OnStart implements service.Service by starting the pruning routine.
+func (p *TxInfoPruner) OnStart() error {
+	go p.pruneRoutine()
+	return nil
+}
+
+func (p *TxInfoPruner) pruneRoutine() {
+	ticker := time.NewTicker(p.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			p.prune()
+		case <-p.Quit():
+			return
+		}
+	}
+}
+
+// prune prunes the TxInfo committed below the retain height, if any.
+func (p *TxInfoPruner) prune() {
+	retainHeight := p.blockStore.Height() - p.retainBlocks + 1
+	if retainHeight <= 1 {
+		return
+	}
+
+	pruned, err := p.blockStore.PruneTxInfo(retainHeight)
+	if err != nil {
+		p.Logger.Error("Failed to prune tx info", "retain_height", retainHeight, "err", err)
+		return
+	}
+	if pruned > 0 {
+		p.Logger.Debug("Pruned tx info", "pruned", pruned, "retain_height", retainHeight)
+	}
+}
@@ -42,17 +42,34 @@ type BlockStore struct {
 	mtx    cmtsync.RWMutex
 	base   int64
 	height int64
+
+	// keepTxInfo is set if the TxInfo index is pruned separately from blocks.
+	keepTxInfo bool
+}
+
+// BlockStoreOption sets an optional parameter on the BlockStore.
+type BlockStoreOption func(*BlockStore)
+
+// WithSeparateTxInfoPruning makes PruneBlocks leave the TxInfo index
+// untouched, for it to be pruned with PruneTxInfo under its own retention
+// policy.
+func WithSeparateTxInfoPruning() BlockStoreOption {
+	return func(bs *BlockStore) { bs.keepTxInfo = true }
 }
 
 // NewBlockStore returns a new BlockStore with the given DB,
 // initialized to the last height that was committed to the DB.
-func NewBlockStore(db dbm.DB) *BlockStore {
-	bs := LoadBlockStoreState(db)
-	return &BlockStore{
-		base:   bs.Base,
-		height: bs.Height,
+func NewBlockStore(db dbm.DB, options ...BlockStoreOption) *BlockStore {
+	bss := LoadBlockStoreState(db)
+	bs := &BlockStore{
+		base:   bss.Base,
+		height: bss.Height,
 		db:     db,
 	}
+	for _, option := range options {
+		option(bs)
+	}
+	return bs
 }
 
 // Base returns the first known contiguous block height, or 0 for empty block stores.
@@ -265,6 +282,8 @@ func (bs *BlockStore) LoadSeenCommit(height int64) *types.Commit {
 }
 
 // PruneBlocks removes block up to (but not including) a height. It returns number of blocks pruned.
+// The TxInfo of the txs of the pruned blocks are removed as well, unless the store was created
+// WithSeparateTxInfoPruning.
 func (bs *BlockStore) PruneBlocks(height int64) (uint64, error) {
 	if height <= 0 {
 		return 0, fmt.Errorf("height must be greater than 0")
@@ -304,10 +323,15 @@ func (bs *BlockStore) PruneBlocks(height int64) (uint64, error) {
 		if meta == nil { // assume already deleted
 			continue
 		}
-		block := bs.LoadBlock(h)
-		for _, tx := range block.Txs {
-			if err := batch.Delete(calcTxHashKey(tx.Hash())); err != nil {
-				return 0, err
+		if !bs.keepTxInfo {
+			block := bs.LoadBlock(h)
+			for i, tx := range block.Txs {
+				if err := batch.Delete(calcTxHashKey(tx.Hash())); err != nil {
+					return 0, err
+				}
+				if err := batch.Delete(calcTxInfoHeightKey(h, i)); err != nil {
+					return 0, err
+				}
 			}
 		}
 		if err := batch.Delete(calcBlockMetaKey(h)); err != nil {
@@ -482,12 +506,78 @@ func (bs *BlockStore) SaveTxInfo(block *types.Block, txResponseCodes []uint32, l
 		if err := batch.Set(calcTxHashKey(tx.Hash()), txInfoBytes); err != nil {
 			return err
 		}
+		if err := batch.Set(calcTxInfoHeightKey(block.Height, i), tx.Hash()); err != nil {
+			return err
+		}
 	}
 
 	// Write the batch to the db
 	return batch.WriteSync()
 }
 
+// PruneTxInfo removes the TxInfo of the txs committed up to (but not including) a height. It
+// returns the number of TxInfo pruned. The TxInfo are found through their height index, so
+// TxInfo saved before the index existed are not pruned until they are saved again, e.g. by
+// rebuilding them with the rebuild-tx-info command.
+func (bs *BlockStore) PruneTxInfo(height int64) (uint64, error) {
+	if height <= 0 {
+		return 0, fmt.Errorf("height must be greater than 0")
+	}
+
+	// batches are written between iterations, as some backends don't
+	// support writes while iterating
+	const batchSize = 1000
+	end := calcTxInfoHeightPrefix(height)
+	pruned := uint64(0)
+	for {
+		keys, hashes, err := bs.loadTxInfoHeightKeys(end, batchSize)
+		if err != nil {
+			return pruned, err
+		}
+
+		batch := bs.db.NewBatch()
+		for i, key := range keys {
+			// the tx may have been committed again at a retained height
+			if txi := bs.LoadTxInfo(hashes[i]); txi != nil && txi.Height < height {
+				if err := batch.Delete(calcTxHashKey(hashes[i])); err != nil {
+					batch.Close()
+					return pruned, err
+				}
+				pruned++
+			}
+			if err := batch.Delete(key); err != nil {
+				batch.Close()
+				return pruned, err
+			}
+		}
+		err = batch.WriteSync()
+		batch.Close()
+		if err != nil {
+			return pruned, fmt.Errorf("failed to prune tx info up to height %v: %w", height, err)
+		}
+
+		if len(keys) < batchSize {
+			return pruned, nil
+		}
+	}
+}
+
+// loadTxInfoHeightKeys returns up to limit keys of the TxInfo height index
+// below end, along with the hashes of their txs.
+func (bs *BlockStore) loadTxInfoHeightKeys(end []byte, limit int) (keys, hashes [][]byte, err error) {
+	it, err := bs.db.Iterator([]byte(txInfoHeightKeyPrefix), end)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer it.Close()
+
+	for ; it.Valid() && len(keys) < limit; it.Next() {
+		keys = append(keys, append([]byte(nil), it.Key()...))
+		hashes = append(hashes, append([]byte(nil), it.Value()...))
+	}
+	return keys, hashes, it.Error()
+}
+
 func (bs *BlockStore) Close() error {
 	return bs.db.Close()
 }
@@ -518,6 +608,21 @@ func calcTxHashKey(hash []byte) []byte {
 	return []byte(fmt.Sprintf("TH:%x", hash))
 }
 
+const txInfoHeightKeyPrefix = "TIH:"
+
+// calcTxInfoHeightKey returns the key indexing the TxInfo of the tx at the
+// given index of the block at the given height. Heights are zero padded for
+// keys to be ordered by height.
+func calcTxInfoHeightKey(height int64, index int) []byte {
+	return []byte(fmt.Sprintf("%s%020d:%d", txInfoHeightKeyPrefix, height, index))
+}
+
+// calcTxInfoHeightPrefix returns the prefix of the TxInfo height index keys
+// at the given height.
+func calcTxInfoHeightPrefix(height int64) []byte {
+	return []byte(fmt.Sprintf("%s%020d:", txInfoHeightKeyPrefix, height))
+}
+
 //-----------------------------------------------------------------------------
 
 var blockStoreKey = []byte("blockStore")
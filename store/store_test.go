@@ -661,6 +661,73 @@ func TestPruneBlocksPrunesTxs(t *testing.T) {
 	}
 }
 
+func TestPruneTxInfo(t *testing.T) {
+	config := cfg.ResetTestRoot("blockchain_reactor_test")
+	defer os.RemoveAll(config.RootDir)
+
+	stateStore := sm.NewStore(dbm.NewMemDB(), sm.StoreOptions{DiscardABCIResponses: false})
+	state, err := stateStore.LoadFromDBOrGenesisFile(config.GenesisFile())
+	require.NoError(t, err)
+
+	blockStore := NewBlockStore(dbm.NewMemDB(), WithSeparateTxInfoPruning())
+	maxHeight := int64(15)
+
+	var indexedTxHashes [][]byte
+	for height := int64(1); height <= maxHeight; height++ {
+		block := makeUniqueBlock(height, state, new(types.Commit))
+		partSet := block.MakePartSet(types.BlockPartSizeBytes)
+		seenCommit := makeTestCommit(height, cmttime.Now())
+		blockStore.SaveBlock(block, partSet, seenCommit)
+		err := blockStore.SaveTxInfo(block, make([]uint32, len(block.Txs)), make([]string, len(block.Txs)))
+		require.NoError(t, err)
+		for _, tx := range block.Txs {
+			indexedTxHashes = append(indexedTxHashes, tx.Hash())
+		}
+	}
+	require.Len(t, indexedTxHashes, 15)
+
+	// Pruning blocks leaves the tx info untouched.
+	pruned, err := blockStore.PruneBlocks(12)
+	require.NoError(t, err)
+	assert.EqualValues(t, 11, pruned)
+	for _, hash := range indexedTxHashes {
+		require.NotNil(t, blockStore.LoadTxInfo(hash))
+	}
+
+	_, err = blockStore.PruneTxInfo(0)
+	require.Error(t, err)
+
+	pruned, err = blockStore.PruneTxInfo(6) // prune tx info of heights 1 to 5.
+	require.NoError(t, err)
+	assert.EqualValues(t, 5, pruned)
+	for i, hash := range indexedTxHashes {
+		txInfo := blockStore.LoadTxInfo(hash)
+		if int64(i) < 5 {
+			require.Nil(t, txInfo)
+		} else {
+			require.NotNil(t, txInfo)
+			require.EqualValues(t, i+1, txInfo.Height)
+		}
+	}
+
+	// Pruning again at the same height is a no-op.
+	pruned, err = blockStore.PruneTxInfo(6)
+	require.NoError(t, err)
+	assert.EqualValues(t, 0, pruned)
+
+	// A tx committed again at a retained height keeps its tx info.
+	block := blockStore.LoadBlock(14)
+	block.Height = maxHeight + 1
+	require.NoError(t, blockStore.SaveTxInfo(block, []uint32{0}, []string{""}))
+	pruned, err = blockStore.PruneTxInfo(maxHeight)
+	require.NoError(t, err)
+	assert.EqualValues(t, 8, pruned) // heights 6 to 13, as 14 was committed again.
+	txInfo := blockStore.LoadTxInfo(indexedTxHashes[13])
+	require.NotNil(t, txInfo)
+	require.Equal(t, maxHeight+1, txInfo.Height)
+	require.NotNil(t, blockStore.LoadTxInfo(indexedTxHashes[14]))
+}
+
 func TestLoadBlockMeta(t *testing.T) {
 	bs, db := freshBlockStore()
 	height := int64(10)
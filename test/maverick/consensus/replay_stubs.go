@@ -40,8 +40,14 @@ func (emptyMempool) TxsAvailable() <-chan struct{} { return make(chan struct{})
 func (emptyMempool) EnableTxsAvailable()           {}
 func (emptyMempool) TxsBytes() int64               { return 0 }
 
-func (emptyMempool) GetTxByKey(txKey types.TxKey) (types.Tx, bool) { return nil, false }
-func (emptyMempool) WasRecentlyEvicted(txKey types.TxKey) bool     { return false }
+func (emptyMempool) GetTxByKey(txKey types.TxKey) (types.Tx, bool)           { return nil, false }
+func (emptyMempool) WasRecentlyEvicted(txKey types.TxKey) bool               { return false }
+func (emptyMempool) GetPendingTx(txKey types.TxKey) (*mempl.PendingTx, bool) { return nil, false }
+func (emptyMempool) GetRemovalReason(txKey types.TxKey) (mempl.RemovalReason, bool) {
+	return "", false
+}
+func (emptyMempool) SetTxListener(l mempl.TxListener) {}
+func (emptyMempool) Snapshot() *mempl.Snapshot        { return &mempl.Snapshot{} }
 
 func (emptyMempool) TxsFront() *clist.CElement    { return nil }
 func (emptyMempool) TxsWaitChan() <-chan struct{} { return nil }
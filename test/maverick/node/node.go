@@ -399,9 +399,12 @@ func createMempoolAndMempoolReactor(config *cfg.Config, proxyApp proxy.AppConns,
 		reactor, err := mempoolv2.NewReactor(
 			mp,
 			&mempoolv2.ReactorOptions{
-				ListenOnly:     !config.Mempool.Broadcast,
-				MaxTxSize:      config.Mempool.MaxTxBytes,
-				MaxGossipDelay: config.Mempool.MaxGossipDelay,
+				ListenOnly:                    !config.Mempool.Broadcast,
+				MaxTxSize:                     config.Mempool.MaxTxBytes,
+				MaxGossipDelay:                config.Mempool.MaxGossipDelay,
+				PullOnly:                      config.Mempool.PullOnly,
+				MaxOutboundBytesPerSecond:     config.Mempool.MaxOutboundBytesPerSecond,
+				MaxPeerOutboundBytesPerSecond: config.Mempool.MaxPeerOutboundBytesPerSecond,
 			},
 		)
 		if err != nil {
@@ -191,6 +191,23 @@ func (b *EventBus) PublishEventTx(data EventDataTx) error {
 	return b.pubsub.PublishWithEvents(ctx, data, events)
 }
 
+// PublishEventMempoolTx publishes a change to the mempool. Note it will add
+// predefined keys (EventTypeKey, MempoolTxTypeKey, MempoolTxSenderKey).
+func (b *EventBus) PublishEventMempoolTx(data EventDataMempoolTx) error {
+	// no explicit deadline for publishing events
+	ctx := context.Background()
+
+	events := map[string][]string{
+		EventTypeKey:     {EventMempoolTx},
+		MempoolTxTypeKey: {data.Type},
+	}
+	if data.Sender != "" {
+		events[MempoolTxSenderKey] = []string{data.Sender}
+	}
+
+	return b.pubsub.PublishWithEvents(ctx, data, events)
+}
+
 func (b *EventBus) PublishEventNewRoundStep(data EventDataRoundState) error {
 	return b.Publish(EventNewRoundStep, data)
 }
@@ -275,6 +292,10 @@ func (NopEventBus) PublishEventTx(data EventDataTx) error {
 	return nil
 }
 
+func (NopEventBus) PublishEventMempoolTx(data EventDataMempoolTx) error {
+	return nil
+}
+
 func (NopEventBus) PublishEventNewRoundStep(data EventDataRoundState) error {
 	return nil
 }
@@ -358,6 +358,45 @@ func TestEventBusPublishEventNewEvidence(t *testing.T) {
 	}
 }
 
+func TestEventBusPublishEventMempoolTx(t *testing.T) {
+	eventBus := NewEventBus()
+	err := eventBus.Start()
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		if err := eventBus.Stop(); err != nil {
+			t.Error(err)
+		}
+	})
+
+	// PublishEventMempoolTx adds the type and sender keys
+	query := "tm.event='MempoolTx' AND mempool_tx.type='evict' AND mempool_tx.sender='alice'"
+	sub, err := eventBus.Subscribe(context.Background(), "test", cmtquery.MustParse(query))
+	require.NoError(t, err)
+
+	done := make(chan struct{})
+	go func() {
+		msg := <-sub.Out()
+		edt := msg.Data().(EventDataMempoolTx)
+		assert.EqualValues(t, 2, edt.Version)
+		assert.Equal(t, "ttl_expired", edt.Reason)
+		close(done)
+	}()
+
+	for _, data := range []EventDataMempoolTx{
+		{Version: 1, Type: "add", Sender: "alice"},
+		{Version: 2, Type: "evict", Reason: "ttl_expired", Sender: "alice"},
+	} {
+		err = eventBus.PublishEventMempoolTx(data)
+		assert.NoError(t, err)
+	}
+
+	select {
+	case <-done:
+	case <-time.After(1 * time.Second):
+		t.Fatal("did not receive a mempool tx after 1 sec.")
+	}
+}
+
 func TestEventBusPublish(t *testing.T) {
 	eventBus := NewEventBus()
 	err := eventBus.Start()
@@ -2,8 +2,10 @@ package types
 
 import (
 	"fmt"
+	"time"
 
 	abci "github.com/tendermint/tendermint/abci/types"
+	cmtbytes "github.com/tendermint/tendermint/libs/bytes"
 	cmtjson "github.com/tendermint/tendermint/libs/json"
 	cmtpubsub "github.com/tendermint/tendermint/libs/pubsub"
 	cmtquery "github.com/tendermint/tendermint/libs/pubsub/query"
@@ -37,6 +39,11 @@ const (
 	EventUnlock           = "Unlock"
 	EventValidBlock       = "ValidBlock"
 	EventVote             = "Vote"
+
+	// Mempool events.
+	// These are triggered from the mempool as txs are added, evicted and
+	// removed, for external block builders to follow its content.
+	EventMempoolTx = "MempoolTx"
 )
 
 // ENCODING / DECODING
@@ -58,6 +65,7 @@ func init() {
 	cmtjson.RegisterType(EventDataVote{}, "tendermint/event/Vote")
 	cmtjson.RegisterType(EventDataValidatorSetUpdates{}, "tendermint/event/ValidatorSetUpdates")
 	cmtjson.RegisterType(EventDataString(""), "tendermint/event/ProposalString")
+	cmtjson.RegisterType(EventDataMempoolTx{}, "tendermint/event/MempoolTx")
 }
 
 // Most event messages are basic types (a block, a transaction)
@@ -136,6 +144,23 @@ type EventDataValidatorSetUpdates struct {
 	ValidatorUpdates []*Validator `json:"validator_updates"`
 }
 
+// EventDataMempoolTx is a change to the txs in the mempool, of the given
+// version of its snapshots. Type is one of "add", "evict" and "remove", and
+// Reason is the reason of an eviction.
+type EventDataMempoolTx struct {
+	Version uint64 `json:"version"`
+	Type    string `json:"type"`
+	Reason  string `json:"reason,omitempty"`
+
+	Hash      cmtbytes.HexBytes `json:"hash"`
+	Size      int64             `json:"size"`
+	Priority  int64             `json:"priority"`
+	GasWanted int64             `json:"gas_wanted"`
+	Sender    string            `json:"sender"`
+	Height    int64             `json:"height"`
+	Timestamp time.Time         `json:"timestamp"`
+}
+
 // PUBSUB
 
 const (
@@ -156,11 +181,21 @@ const (
 	// conditions in the query have to have occurred both on the same height
 	// as well as in the same event
 	MatchEventKey = "match.events"
+
+	// MempoolTxTypeKey is a reserved key, used to specify the type of a change
+	// to the mempool.
+	// see EventBus#PublishEventMempoolTx
+	MempoolTxTypeKey = "mempool_tx.type"
+	// MempoolTxSenderKey is a reserved key, used to specify the sender of a tx
+	// added to, or removed from, the mempool.
+	// see EventBus#PublishEventMempoolTx
+	MempoolTxSenderKey = "mempool_tx.sender"
 )
 
 var (
 	EventQueryCompleteProposal    = QueryForEvent(EventCompleteProposal)
 	EventQueryLock                = QueryForEvent(EventLock)
+	EventQueryMempoolTx           = QueryForEvent(EventMempoolTx)
 	EventQueryNewBlock            = QueryForEvent(EventNewBlock)
 	EventQueryNewBlockHeader      = QueryForEvent(EventNewBlockHeader)
 	EventQueryNewEvidence         = QueryForEvent(EventNewEvidence)
@@ -118,6 +118,22 @@ func (psh PartSetHeader) Equals(other PartSetHeader) bool {
 	return psh.Total == other.Total && bytes.Equal(psh.Hash, other.Hash)
 }
 
+// VerifyPart returns ErrPartSetUnexpectedIndex or ErrPartSetInvalidProof if
+// the part is not one of the parts of the set with this header.
+func (psh PartSetHeader) VerifyPart(part *Part) error {
+	if part.Index >= psh.Total {
+		return ErrPartSetUnexpectedIndex
+	}
+	// The proof should be compatible with the number of parts.
+	if part.Proof.Total != int64(psh.Total) {
+		return ErrPartSetInvalidProof
+	}
+	if part.Proof.Verify(psh.Hash, part.Bytes) != nil {
+		return ErrPartSetInvalidProof
+	}
+	return nil
+}
+
 // ValidateBasic performs basic validation.
 func (psh PartSetHeader) ValidateBasic() error {
 	// Hash can be empty in case of POLBlockID.PartSetHeader in Proposal.
@@ -271,6 +287,16 @@ func (ps *PartSet) Total() uint32 {
 }
 
 func (ps *PartSet) AddPart(part *Part) (bool, error) {
+	return ps.addPart(part, true)
+}
+
+// AddVerifiedPart is AddPart for a part already verified against the header
+// of the set with PartSetHeader.VerifyPart, which proof isn't verified again.
+func (ps *PartSet) AddVerifiedPart(part *Part) (bool, error) {
+	return ps.addPart(part, false)
+}
+
+func (ps *PartSet) addPart(part *Part, verify bool) (bool, error) {
 	if ps == nil {
 		return false, nil
 	}
@@ -287,14 +313,10 @@ func (ps *PartSet) AddPart(part *Part) (bool, error) {
 		return false, nil
 	}
 
-	// The proof should be compatible with the number of parts.
-	if part.Proof.Total != int64(ps.total) {
-		return false, ErrPartSetInvalidProof
-	}
-
-	// Check hash proof
-	if part.Proof.Verify(ps.Hash(), part.Bytes) != nil {
-		return false, ErrPartSetInvalidProof
+	if verify {
+		if err := ps.Header().VerifyPart(part); err != nil {
+			return false, err
+		}
 	}
 
 	// Add part
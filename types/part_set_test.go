@@ -104,6 +104,32 @@ func TestWrongProof(t *testing.T) {
 	}
 }
 
+func TestVerifyPart(t *testing.T) {
+	partSet := NewPartSetFromData(cmtrand.Bytes(testPartSize*10), testPartSize)
+	header := partSet.Header()
+
+	for i := 0; i < int(partSet.Total()); i++ {
+		require.NoError(t, header.VerifyPart(partSet.GetPart(i)))
+	}
+
+	part := partSet.GetPart(0)
+	part.Bytes[0] += byte(0x01)
+	require.ErrorIs(t, header.VerifyPart(part), ErrPartSetInvalidProof)
+
+	other := NewPartSetFromData(cmtrand.Bytes(testPartSize*20), testPartSize)
+	require.ErrorIs(t, header.VerifyPart(other.GetPart(15)), ErrPartSetUnexpectedIndex)
+	require.ErrorIs(t, header.VerifyPart(other.GetPart(1)), ErrPartSetInvalidProof)
+
+	// a verified part is added without verifying its proof again
+	partSet2 := NewPartSetFromHeader(header)
+	added, err := partSet2.AddVerifiedPart(partSet.GetPart(1))
+	require.NoError(t, err)
+	require.True(t, added)
+	added, err = partSet2.AddVerifiedPart(partSet.GetPart(1))
+	require.NoError(t, err)
+	require.False(t, added)
+}
+
 func TestPartSetHeaderValidateBasic(t *testing.T) {
 	testCases := []struct {
 		testName              string